@@ -0,0 +1,105 @@
+package in_memory
+
+import (
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/kiramishima/receipt-processor/domain"
+	appErrors "github.com/kiramishima/receipt-processor/pkg/errors"
+	"github.com/samber/lo"
+	"sync"
+	"time"
+)
+
+func NewAPIKeyRepository() *APIKeyRepository {
+	return &APIKeyRepository{
+		records: make([]*domain.APIKey, 0),
+	}
+}
+
+type APIKeyRepository struct {
+	mu      sync.RWMutex
+	records []*domain.APIKey
+}
+
+// SaveAPIKey stores the key with a new id, unless it has one
+func (repo *APIKeyRepository) SaveAPIKey(key *domain.APIKey) (string, error) {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	var item = copyAPIKey(key)
+	if item.ID == "" {
+		item.ID = uuid.New().String()
+	} else if lo.ContainsBy(repo.records, func(i *domain.APIKey) bool { return i.ID == item.ID }) {
+		return "", fmt.Errorf("%w: API key with id: %s already exists", appErrors.BadRequest, item.ID)
+	}
+	repo.records = append(repo.records, item)
+	return item.ID, nil
+}
+
+func (repo *APIKeyRepository) FindAPIKeyById(id string) (*domain.APIKey, error) {
+	return repo.find(func(i *domain.APIKey) bool {
+		return i.ID == id
+	}, fmt.Errorf("API key with id: %s %w", id, appErrors.NotFound))
+}
+
+func (repo *APIKeyRepository) FindAPIKeyByHash(hash string) (*domain.APIKey, error) {
+	return repo.find(func(i *domain.APIKey) bool {
+		return i.Hash == hash
+	}, fmt.Errorf("API key %w", appErrors.NotFound))
+}
+
+func (repo *APIKeyRepository) find(predicate func(i *domain.APIKey) bool, notFound error) (*domain.APIKey, error) {
+	repo.mu.RLock()
+	defer repo.mu.RUnlock()
+
+	item, ok := lo.Find(repo.records, predicate)
+	if !ok {
+		return nil, notFound
+	}
+	return copyAPIKey(item), nil
+}
+
+func (repo *APIKeyRepository) ListAPIKeys() ([]*domain.APIKey, error) {
+	repo.mu.RLock()
+	defer repo.mu.RUnlock()
+
+	return lo.Map(repo.records, func(k *domain.APIKey, _ int) *domain.APIKey {
+		return copyAPIKey(k)
+	}), nil
+}
+
+func (repo *APIKeyRepository) UpdateAPIKey(key *domain.APIKey) error {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	_, index, ok := lo.FindIndexOf(repo.records, func(i *domain.APIKey) bool {
+		return i.ID == key.ID
+	})
+	if !ok {
+		return fmt.Errorf("API key with id: %s %w", key.ID, appErrors.NotFound)
+	}
+	repo.records[index] = copyAPIKey(key)
+	return nil
+}
+
+// TouchAPIKey records the last use of the key without replacing the rest of it, so it can't undo a revocation
+func (repo *APIKeyRepository) TouchAPIKey(id string, usedAt time.Time) error {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	item, ok := lo.Find(repo.records, func(i *domain.APIKey) bool {
+		return i.ID == id
+	})
+	if !ok {
+		return fmt.Errorf("API key with id: %s %w", id, appErrors.NotFound)
+	}
+	item.LastUsedAt = &usedAt
+	return nil
+}
+
+// copyAPIKey copies the key with its scopes, so the stored keys are not modified through the returned ones
+func copyAPIKey(key *domain.APIKey) *domain.APIKey {
+	var item = *key
+	item.Scopes = append([]string(nil), key.Scopes...)
+	return &item
+}
@@ -0,0 +1,50 @@
+package in_memory
+
+import (
+	"github.com/kiramishima/receipt-processor/domain"
+	appErrors "github.com/kiramishima/receipt-processor/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestAPIKeyRepository(t *testing.T) {
+	repo := NewAPIKeyRepository()
+
+	id, err := repo.SaveAPIKey(&domain.APIKey{Name: "pos", Hash: "abc", Scopes: []string{domain.ScopeReceiptsWrite}})
+	assert.NoError(t, err)
+	assert.NotEmpty(t, id)
+
+	t.Run("Find by hash", func(t *testing.T) {
+		item, err := repo.FindAPIKeyByHash("abc")
+		assert.NoError(t, err)
+		assert.Equal(t, id, item.ID)
+		_, err = repo.FindAPIKeyByHash("def")
+		assert.ErrorIs(t, err, appErrors.NotFound)
+	})
+
+	t.Run("Duplicated id", func(t *testing.T) {
+		_, err := repo.SaveAPIKey(&domain.APIKey{ID: id})
+		assert.ErrorIs(t, err, appErrors.BadRequest)
+	})
+
+	t.Run("Touch", func(t *testing.T) {
+		var usedAt = time.Date(2022, 3, 20, 10, 0, 0, 0, time.UTC)
+		assert.NoError(t, repo.TouchAPIKey(id, usedAt))
+		item, _ := repo.FindAPIKeyById(id)
+		assert.Equal(t, usedAt, *item.LastUsedAt)
+		assert.ErrorIs(t, repo.TouchAPIKey("unknown", usedAt), appErrors.NotFound)
+	})
+
+	t.Run("Update", func(t *testing.T) {
+		item, _ := repo.FindAPIKeyById(id)
+		var revokedAt = time.Now()
+		item.RevokedAt = &revokedAt
+		assert.NoError(t, repo.UpdateAPIKey(item))
+		items, _ := repo.ListAPIKeys()
+		assert.Len(t, items, 1)
+		assert.NotNil(t, items[0].RevokedAt)
+		assert.NotNil(t, items[0].LastUsedAt)
+		assert.ErrorIs(t, repo.UpdateAPIKey(&domain.APIKey{ID: "unknown"}), appErrors.NotFound)
+	})
+}
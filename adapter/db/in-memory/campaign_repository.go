@@ -0,0 +1,85 @@
+package in_memory
+
+import (
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/kiramishima/receipt-processor/domain"
+	appErrors "github.com/kiramishima/receipt-processor/pkg/errors"
+	"github.com/samber/lo"
+	"sync"
+)
+
+func NewCampaignRepository() *CampaignRepository {
+	return &CampaignRepository{
+		records: make([]*domain.Campaign, 0),
+	}
+}
+
+type CampaignRepository struct {
+	mu      sync.RWMutex
+	records []*domain.Campaign
+}
+
+func (repo *CampaignRepository) SaveCampaign(campaign *domain.Campaign) (string, error) {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+	// Generate ID
+	var item = *campaign
+	item.ID = uuid.New().String()
+	// Insert record
+	repo.records = append(repo.records, &item)
+	return item.ID, nil
+}
+
+func (repo *CampaignRepository) FindCampaignById(id string) (*domain.Campaign, error) {
+	repo.mu.RLock()
+	defer repo.mu.RUnlock()
+
+	_, index, ok := lo.FindIndexOf(repo.records, func(i *domain.Campaign) bool {
+		return i.ID == id
+	})
+	if !ok {
+		return nil, fmt.Errorf("campaign with id: %s %w", id, appErrors.NotFound)
+	}
+	var item = *repo.records[index]
+	return &item, nil
+}
+
+func (repo *CampaignRepository) ListCampaigns() ([]*domain.Campaign, error) {
+	repo.mu.RLock()
+	defer repo.mu.RUnlock()
+
+	return lo.Map(repo.records, func(c *domain.Campaign, _ int) *domain.Campaign {
+		var item = *c
+		return &item
+	}), nil
+}
+
+func (repo *CampaignRepository) UpdateCampaign(campaign *domain.Campaign) error {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	_, index, ok := lo.FindIndexOf(repo.records, func(i *domain.Campaign) bool {
+		return i.ID == campaign.ID
+	})
+	if !ok {
+		return fmt.Errorf("campaign with id: %s %w", campaign.ID, appErrors.NotFound)
+	}
+	var item = *campaign
+	repo.records[index] = &item
+	return nil
+}
+
+func (repo *CampaignRepository) DeleteCampaign(id string) error {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	_, index, ok := lo.FindIndexOf(repo.records, func(i *domain.Campaign) bool {
+		return i.ID == id
+	})
+	if !ok {
+		return fmt.Errorf("campaign with id: %s %w", id, appErrors.NotFound)
+	}
+	repo.records = append(repo.records[:index], repo.records[index+1:]...)
+	return nil
+}
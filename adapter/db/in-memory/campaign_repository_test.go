@@ -0,0 +1,43 @@
+package in_memory
+
+import (
+	"github.com/google/uuid"
+	"github.com/kiramishima/receipt-processor/domain"
+	appErrors "github.com/kiramishima/receipt-processor/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestCampaignRepository_CRUD(t *testing.T) {
+	repo := NewCampaignRepository()
+
+	id, err := repo.SaveCampaign(&domain.Campaign{Name: "Double points", Multiplier: 2})
+	assert.NoError(t, err)
+	assert.NotEmpty(t, id)
+
+	t.Run("Find", func(t *testing.T) {
+		item, err := repo.FindCampaignById(id)
+		assert.NoError(t, err)
+		assert.Equal(t, "Double points", item.Name)
+	})
+
+	t.Run("Update", func(t *testing.T) {
+		err := repo.UpdateCampaign(&domain.Campaign{ID: id, Name: "Triple points", Multiplier: 3})
+		assert.NoError(t, err)
+		items, _ := repo.ListCampaigns()
+		assert.Len(t, items, 1)
+		assert.Equal(t, "Triple points", items[0].Name)
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		assert.NoError(t, repo.DeleteCampaign(id))
+		_, err := repo.FindCampaignById(id)
+		assert.ErrorIs(t, err, appErrors.NotFound)
+	})
+
+	t.Run("Not exists", func(t *testing.T) {
+		var uid = uuid.New().String()
+		assert.ErrorIs(t, repo.UpdateCampaign(&domain.Campaign{ID: uid}), appErrors.NotFound)
+		assert.ErrorIs(t, repo.DeleteCampaign(uid), appErrors.NotFound)
+	})
+}
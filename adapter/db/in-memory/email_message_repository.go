@@ -0,0 +1,67 @@
+package in_memory
+
+import (
+	"fmt"
+	"github.com/kiramishima/receipt-processor/domain"
+	appErrors "github.com/kiramishima/receipt-processor/pkg/errors"
+	"sync"
+	"time"
+)
+
+func NewEmailMessageRepository() *EmailMessageRepository {
+	return &EmailMessageRepository{
+		records: make(map[string]*domain.EmailMessage),
+	}
+}
+
+// EmailMessageRepository keeps the Message-IDs of the ingested messages
+type EmailMessageRepository struct {
+	mu      sync.Mutex
+	records map[string]*domain.EmailMessage
+}
+
+func (repo *EmailMessageRepository) ReserveEmailMessage(messageID string, receivedAt time.Time) error {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	if message, ok := repo.records[messageID]; ok {
+		if message.ReceiptID == "" {
+			return fmt.Errorf("%w: message %s is being ingested", appErrors.Conflict, messageID)
+		}
+		return fmt.Errorf("%w: message %s was ingested as receipt %s", appErrors.Conflict, messageID, message.ReceiptID)
+	}
+	repo.records[messageID] = &domain.EmailMessage{MessageID: messageID, ReceivedAt: receivedAt}
+	return nil
+}
+
+func (repo *EmailMessageRepository) CompleteEmailMessage(messageID string, receiptID string) error {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	message, ok := repo.records[messageID]
+	if !ok {
+		return fmt.Errorf("email message with id: %s %w", messageID, appErrors.NotFound)
+	}
+	message.ReceiptID = receiptID
+	return nil
+}
+
+func (repo *EmailMessageRepository) ReleaseEmailMessage(messageID string) error {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	delete(repo.records, messageID)
+	return nil
+}
+
+func (repo *EmailMessageRepository) FindEmailMessage(messageID string) (*domain.EmailMessage, error) {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	message, ok := repo.records[messageID]
+	if !ok {
+		return nil, fmt.Errorf("email message with id: %s %w", messageID, appErrors.NotFound)
+	}
+	var item = *message
+	return &item, nil
+}
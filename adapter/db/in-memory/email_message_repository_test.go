@@ -0,0 +1,35 @@
+package in_memory
+
+import (
+	appErrors "github.com/kiramishima/receipt-processor/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestEmailMessageRepository(t *testing.T) {
+	repo := NewEmailMessageRepository()
+	var now = time.Date(2022, 3, 18, 14, 35, 0, 0, time.UTC)
+
+	assert.NoError(t, repo.ReserveEmailMessage("1234@target.com", now))
+	assert.ErrorContains(t, repo.ReserveEmailMessage("1234@target.com", now), "is being ingested")
+	assert.NoError(t, repo.CompleteEmailMessage("1234@target.com", "receipt-1"))
+	err := repo.ReserveEmailMessage("1234@target.com", now)
+	assert.ErrorIs(t, err, appErrors.Conflict)
+	assert.ErrorContains(t, err, "was ingested as receipt receipt-1")
+
+	message, err := repo.FindEmailMessage("1234@target.com")
+	if assert.NoError(t, err) {
+		assert.Equal(t, "receipt-1", message.ReceiptID)
+		assert.Equal(t, now, message.ReceivedAt)
+	}
+
+	// a released message can be ingested again
+	assert.NoError(t, repo.ReserveEmailMessage("5678@target.com", now))
+	assert.NoError(t, repo.ReleaseEmailMessage("5678@target.com"))
+	assert.NoError(t, repo.ReserveEmailMessage("5678@target.com", now))
+
+	_, err = repo.FindEmailMessage("unknown@target.com")
+	assert.ErrorIs(t, err, appErrors.NotFound)
+	assert.ErrorIs(t, repo.CompleteEmailMessage("unknown@target.com", "receipt-2"), appErrors.NotFound)
+}
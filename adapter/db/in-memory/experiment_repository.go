@@ -0,0 +1,85 @@
+package in_memory
+
+import (
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/kiramishima/receipt-processor/domain"
+	appErrors "github.com/kiramishima/receipt-processor/pkg/errors"
+	"github.com/samber/lo"
+	"sync"
+)
+
+func NewExperimentRepository() *ExperimentRepository {
+	return &ExperimentRepository{
+		records: make([]*domain.Experiment, 0),
+	}
+}
+
+type ExperimentRepository struct {
+	mu      sync.RWMutex
+	records []*domain.Experiment
+}
+
+func (repo *ExperimentRepository) SaveExperiment(experiment *domain.Experiment) (string, error) {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+	// Generate ID
+	var item = *experiment
+	item.ID = uuid.New().String()
+	// Insert record
+	repo.records = append(repo.records, &item)
+	return item.ID, nil
+}
+
+func (repo *ExperimentRepository) FindExperimentById(id string) (*domain.Experiment, error) {
+	repo.mu.RLock()
+	defer repo.mu.RUnlock()
+
+	_, index, ok := lo.FindIndexOf(repo.records, func(i *domain.Experiment) bool {
+		return i.ID == id
+	})
+	if !ok {
+		return nil, fmt.Errorf("experiment with id: %s %w", id, appErrors.NotFound)
+	}
+	var item = *repo.records[index]
+	return &item, nil
+}
+
+func (repo *ExperimentRepository) ListExperiments() ([]*domain.Experiment, error) {
+	repo.mu.RLock()
+	defer repo.mu.RUnlock()
+
+	return lo.Map(repo.records, func(c *domain.Experiment, _ int) *domain.Experiment {
+		var item = *c
+		return &item
+	}), nil
+}
+
+func (repo *ExperimentRepository) UpdateExperiment(experiment *domain.Experiment) error {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	_, index, ok := lo.FindIndexOf(repo.records, func(i *domain.Experiment) bool {
+		return i.ID == experiment.ID
+	})
+	if !ok {
+		return fmt.Errorf("experiment with id: %s %w", experiment.ID, appErrors.NotFound)
+	}
+	var item = *experiment
+	repo.records[index] = &item
+	return nil
+}
+
+func (repo *ExperimentRepository) DeleteExperiment(id string) error {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	_, index, ok := lo.FindIndexOf(repo.records, func(i *domain.Experiment) bool {
+		return i.ID == id
+	})
+	if !ok {
+		return fmt.Errorf("experiment with id: %s %w", id, appErrors.NotFound)
+	}
+	repo.records = append(repo.records[:index], repo.records[index+1:]...)
+	return nil
+}
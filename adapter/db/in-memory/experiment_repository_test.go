@@ -0,0 +1,43 @@
+package in_memory
+
+import (
+	"github.com/google/uuid"
+	"github.com/kiramishima/receipt-processor/domain"
+	appErrors "github.com/kiramishima/receipt-processor/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestExperimentRepository_CRUD(t *testing.T) {
+	repo := NewExperimentRepository()
+
+	id, err := repo.SaveExperiment(&domain.Experiment{Name: "Odd days", TreatmentPercent: 10})
+	assert.NoError(t, err)
+	assert.NotEmpty(t, id)
+
+	t.Run("Find", func(t *testing.T) {
+		item, err := repo.FindExperimentById(id)
+		assert.NoError(t, err)
+		assert.Equal(t, "Odd days", item.Name)
+	})
+
+	t.Run("Update", func(t *testing.T) {
+		err := repo.UpdateExperiment(&domain.Experiment{ID: id, Name: "Odd days 50/50", TreatmentPercent: 50})
+		assert.NoError(t, err)
+		items, _ := repo.ListExperiments()
+		assert.Len(t, items, 1)
+		assert.Equal(t, "Odd days 50/50", items[0].Name)
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		assert.NoError(t, repo.DeleteExperiment(id))
+		_, err := repo.FindExperimentById(id)
+		assert.ErrorIs(t, err, appErrors.NotFound)
+	})
+
+	t.Run("Not exists", func(t *testing.T) {
+		var uid = uuid.New().String()
+		assert.ErrorIs(t, repo.UpdateExperiment(&domain.Experiment{ID: uid}), appErrors.NotFound)
+		assert.ErrorIs(t, repo.DeleteExperiment(uid), appErrors.NotFound)
+	})
+}
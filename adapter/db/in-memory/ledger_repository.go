@@ -0,0 +1,43 @@
+package in_memory
+
+import (
+	"github.com/google/uuid"
+	"github.com/kiramishima/receipt-processor/domain"
+	"github.com/samber/lo"
+	"sync"
+	"time"
+)
+
+func NewLedgerRepository() *LedgerRepository {
+	return &LedgerRepository{
+		records: make([]*domain.LedgerEntry, 0),
+	}
+}
+
+type LedgerRepository struct {
+	mu      sync.RWMutex
+	records []*domain.LedgerEntry
+}
+
+func (repo *LedgerRepository) SaveLedgerEntry(entry *domain.LedgerEntry) (string, error) {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	var item = *entry
+	item.ID = uuid.New().String()
+	if item.CreatedAt.IsZero() {
+		item.CreatedAt = time.Now().UTC()
+	}
+	repo.records = append(repo.records, &item)
+	return item.ID, nil
+}
+
+func (repo *LedgerRepository) ListLedgerEntries(receiptID string) ([]*domain.LedgerEntry, error) {
+	repo.mu.RLock()
+	defer repo.mu.RUnlock()
+
+	return lo.FilterMap(repo.records, func(e *domain.LedgerEntry, _ int) (*domain.LedgerEntry, bool) {
+		var item = *e
+		return &item, e.ReceiptID == receiptID
+	}), nil
+}
@@ -0,0 +1,23 @@
+package in_memory
+
+import (
+	"github.com/kiramishima/receipt-processor/domain"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestLedgerRepository(t *testing.T) {
+	repo := NewLedgerRepository()
+
+	id, err := repo.SaveLedgerEntry(&domain.LedgerEntry{ReceiptID: "r1", Points: 10, Reason: "rescore"})
+	assert.NoError(t, err)
+	assert.NotEmpty(t, id)
+	_, _ = repo.SaveLedgerEntry(&domain.LedgerEntry{ReceiptID: "r2", Points: -5, Reason: "rescore"})
+
+	entries, err := repo.ListLedgerEntries("r1")
+	assert.NoError(t, err)
+	assert.Len(t, entries, 1)
+	assert.Equal(t, id, entries[0].ID)
+	assert.Equal(t, 10, entries[0].Points)
+	assert.False(t, entries[0].CreatedAt.IsZero())
+}
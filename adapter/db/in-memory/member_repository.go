@@ -0,0 +1,56 @@
+package in_memory
+
+import (
+	"fmt"
+	"github.com/kiramishima/receipt-processor/domain"
+	appErrors "github.com/kiramishima/receipt-processor/pkg/errors"
+	"github.com/samber/lo"
+	"sort"
+	"sync"
+)
+
+func NewMemberRepository() *MemberRepository {
+	return &MemberRepository{
+		records: make(map[string]*domain.Member),
+	}
+}
+
+type MemberRepository struct {
+	mu      sync.RWMutex
+	records map[string]*domain.Member
+}
+
+func (repo *MemberRepository) FindMemberById(id string) (*domain.Member, error) {
+	repo.mu.RLock()
+	defer repo.mu.RUnlock()
+
+	item, ok := repo.records[id]
+	if !ok {
+		return nil, fmt.Errorf("member with id: %s %w", id, appErrors.NotFound)
+	}
+	var member = *item
+	return &member, nil
+}
+
+func (repo *MemberRepository) SaveMember(member *domain.Member) error {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	var item = *member
+	repo.records[member.ID] = &item
+	return nil
+}
+
+func (repo *MemberRepository) ListMembers() ([]*domain.Member, error) {
+	repo.mu.RLock()
+	defer repo.mu.RUnlock()
+
+	members := lo.MapToSlice(repo.records, func(_ string, m *domain.Member) *domain.Member {
+		var member = *m
+		return &member
+	})
+	sort.Slice(members, func(i, j int) bool {
+		return members[i].ID < members[j].ID
+	})
+	return members, nil
+}
@@ -0,0 +1,59 @@
+package in_memory
+
+import (
+	"fmt"
+	"github.com/kiramishima/receipt-processor/domain"
+	appErrors "github.com/kiramishima/receipt-processor/pkg/errors"
+	"sync"
+)
+
+func NewQuotaRepository() *QuotaRepository {
+	return &QuotaRepository{
+		records: make(map[string]*domain.QuotaUsage),
+	}
+}
+
+// QuotaRepository keeps the usage of the current day of every client, the usage of a previous day is replaced
+type QuotaRepository struct {
+	mu      sync.Mutex
+	records map[string]*domain.QuotaUsage
+}
+
+func (repo *QuotaRepository) IncrementQuotaUsage(client string, day string, limit int) (*domain.QuotaUsage, error) {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	var usage, ok = repo.records[client]
+	if !ok || usage.Day != day {
+		usage = &domain.QuotaUsage{Client: client, Day: day}
+		repo.records[client] = usage
+	}
+	if usage.Used >= limit {
+		var item = *usage
+		return &item, fmt.Errorf("%w: client %s used %d of %d", appErrors.QuotaExceeded, client, usage.Used, limit)
+	}
+	usage.Used++
+	var item = *usage
+	return &item, nil
+}
+
+func (repo *QuotaRepository) DecrementQuotaUsage(client string, day string) error {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	if usage, ok := repo.records[client]; ok && usage.Day == day && usage.Used > 0 {
+		usage.Used--
+	}
+	return nil
+}
+
+func (repo *QuotaRepository) FindQuotaUsage(client string, day string) (*domain.QuotaUsage, error) {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	if usage, ok := repo.records[client]; ok && usage.Day == day {
+		var item = *usage
+		return &item, nil
+	}
+	return &domain.QuotaUsage{Client: client, Day: day}, nil
+}
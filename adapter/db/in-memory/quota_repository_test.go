@@ -0,0 +1,41 @@
+package in_memory
+
+import (
+	appErrors "github.com/kiramishima/receipt-processor/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestQuotaRepository(t *testing.T) {
+	repo := NewQuotaRepository()
+
+	for i := 1; i <= 2; i++ {
+		usage, err := repo.IncrementQuotaUsage("apikey:key-1", "2022-03-20", 2)
+		assert.NoError(t, err)
+		assert.Equal(t, i, usage.Used)
+	}
+	usage, err := repo.IncrementQuotaUsage("apikey:key-1", "2022-03-20", 2)
+	assert.ErrorIs(t, err, appErrors.QuotaExceeded)
+	assert.Equal(t, 2, usage.Used)
+
+	usage, _ = repo.FindQuotaUsage("apikey:key-1", "2022-03-20")
+	assert.Equal(t, 2, usage.Used)
+	usage, _ = repo.FindQuotaUsage("apikey:key-2", "2022-03-20")
+	assert.Equal(t, 0, usage.Used)
+
+	// a refund frees a use of the day
+	assert.NoError(t, repo.DecrementQuotaUsage("apikey:key-1", "2022-03-20"))
+	usage, err = repo.IncrementQuotaUsage("apikey:key-1", "2022-03-20", 2)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, usage.Used)
+	assert.NoError(t, repo.DecrementQuotaUsage("apikey:key-2", "2022-03-20"))
+	usage, _ = repo.FindQuotaUsage("apikey:key-2", "2022-03-20")
+	assert.Equal(t, 0, usage.Used)
+
+	// a new day starts a new usage
+	usage, err = repo.IncrementQuotaUsage("apikey:key-1", "2022-03-21", 2)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, usage.Used)
+	usage, _ = repo.FindQuotaUsage("apikey:key-1", "2022-03-20")
+	assert.Equal(t, 0, usage.Used)
+}
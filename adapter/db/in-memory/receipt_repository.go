@@ -1,11 +1,14 @@
 package in_memory
 
 import (
-	"errors"
+	"context"
 	"fmt"
 	"github.com/google/uuid"
 	"github.com/kiramishima/receipt-processor/domain"
+	appErrors "github.com/kiramishima/receipt-processor/pkg/errors"
 	"github.com/samber/lo"
+	"sync"
+	"time"
 )
 
 func NewReceiptRepository() *ReceiptRepository {
@@ -15,25 +18,136 @@ func NewReceiptRepository() *ReceiptRepository {
 }
 
 type ReceiptRepository struct {
+	mu      sync.RWMutex
 	records []*domain.Result
 }
 
-func (repo *ReceiptRepository) SaveReceiptPoints(points int16) (string, error) {
+// Ping fails when the records stay locked until the context ends
+func (repo *ReceiptRepository) Ping(ctx context.Context) error {
+	var done = make(chan struct{})
+	go func() {
+		repo.mu.RLock()
+		defer repo.mu.RUnlock()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("receipt repository locked: %w", ctx.Err())
+	}
+}
+
+func (repo *ReceiptRepository) SaveReceiptPoints(result *domain.Result) (string, error) {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+	return repo.insert(result), nil
+}
+
+// SaveCappedReceiptPoints limits the points of the receipt to what is left of the daily cap of its member and stores
+// it. The points the member earned after since are summed under the same lock as the insert, so concurrent receipts
+// of the member can't exceed the cap.
+func (repo *ReceiptRepository) SaveCappedReceiptPoints(result *domain.Result, dailyCap int, since time.Time) (string, error) {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	if result.Breakdown != nil {
+		result.Breakdown.ApplyDailyCap(dailyCap, repo.sumMemberPointsSince(result.MemberID, since))
+		result.Points = result.Breakdown.TotalPoints
+	}
+	return repo.insert(result), nil
+}
+
+func (repo *ReceiptRepository) insert(result *domain.Result) string {
 	// Generate ID
 	var uid = uuid.New()
+	result.ID = uid.String()
+	if result.CreatedAt.IsZero() {
+		result.CreatedAt = time.Now().UTC()
+	}
 	// Insert record
-	repo.records = append(repo.records, &domain.Result{ID: uid.String(), Points: points})
-	return uid.String(), nil
+	repo.records = append(repo.records, result)
+	return uid.String()
 }
 
 func (repo *ReceiptRepository) FindReceiptById(id string) (*domain.Result, error) {
+	repo.mu.RLock()
+	defer repo.mu.RUnlock()
 	// Find or return empty
 	item := lo.FindOrElse(repo.records, nil, func(i *domain.Result) bool {
 		return i.ID == id
 	})
 
 	if item == nil {
-		return nil, errors.New(fmt.Sprintf("element with id: %s don't found", id))
+		return nil, fmt.Errorf("receipt with id: %s %w", id, appErrors.NotFound)
+	}
+	// A copy, the stored receipt changes under the lock when it is re-scored
+	var result = *item
+	return &result, nil
+}
+
+// SumMemberPointsSince adds the points of the member receipts stored after since
+func (repo *ReceiptRepository) SumMemberPointsSince(memberID string, since time.Time) (int, error) {
+	repo.mu.RLock()
+	defer repo.mu.RUnlock()
+	return repo.sumMemberPointsSince(memberID, since), nil
+}
+
+func (repo *ReceiptRepository) sumMemberPointsSince(memberID string, since time.Time) int {
+	var points = 0
+	for _, item := range repo.records {
+		if item.MemberID == memberID && !item.CreatedAt.Before(since) {
+			points = domain.AddPoints(points, item.Points)
+		}
+	}
+	return points
+}
+
+// ListReceipts returns the stored receipts matching the filter in insertion order
+func (repo *ReceiptRepository) ListReceipts(filter *domain.ReceiptFilter) ([]*domain.Result, error) {
+	repo.mu.RLock()
+	defer repo.mu.RUnlock()
+
+	return lo.FilterMap(repo.records, func(i *domain.Result, _ int) (*domain.Result, bool) {
+		var item = *i
+		return &item, filter == nil || filter.Matches(i)
+	}), nil
+}
+
+// EachReceipt calls fn with a copy of every stored receipt matching the filter in insertion order, until fn returns an
+// error. The lock is only held while reading a receipt, so fn can write to slow consumers.
+func (repo *ReceiptRepository) EachReceipt(filter *domain.ReceiptFilter, fn func(result *domain.Result) error) error {
+	for i := 0; ; i++ {
+		repo.mu.RLock()
+		if i >= len(repo.records) {
+			repo.mu.RUnlock()
+			return nil
+		}
+		var item = *repo.records[i]
+		repo.mu.RUnlock()
+
+		if filter != nil && !filter.Matches(&item) {
+			continue
+		}
+		if err := fn(&item); err != nil {
+			return err
+		}
+	}
+}
+
+// UpdateReceiptPoints replaces the points and breakdown of a stored receipt
+func (repo *ReceiptRepository) UpdateReceiptPoints(id string, points int, breakdown *domain.Breakdown) error {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	item, ok := lo.Find(repo.records, func(i *domain.Result) bool {
+		return i.ID == id
+	})
+	if !ok {
+		return fmt.Errorf("receipt with id: %s %w", id, appErrors.NotFound)
 	}
-	return item, nil
+	item.Points = points
+	item.Breakdown = breakdown
+	return nil
 }
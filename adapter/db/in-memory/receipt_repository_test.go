@@ -1,25 +1,30 @@
 package in_memory
 
 import (
+	"context"
+	"errors"
 	"github.com/google/uuid"
+	"github.com/kiramishima/receipt-processor/domain"
 	"github.com/stretchr/testify/assert"
+	"sync"
 	"testing"
+	"time"
 )
 
 func TestReceiptRepository_SaveReceiptPoints(t *testing.T) {
 	repo := NewReceiptRepository()
 
 	t.Run("OK", func(t *testing.T) {
-		id, err := repo.SaveReceiptPoints(6)
+		id, err := repo.SaveReceiptPoints(&domain.Result{Points: 6})
 		assert.NoError(t, err)
 		assert.NotEmpty(t, id)
 	})
 
 	t.Run("Multiple Times", func(t *testing.T) {
 
-		var points = []int16{0, 10, 12, 20, 120}
+		var points = []int{0, 10, 12, 20, 120}
 		for _, point := range points {
-			id, err := repo.SaveReceiptPoints(point)
+			id, err := repo.SaveReceiptPoints(&domain.Result{Points: point})
 			assert.NoError(t, err)
 			assert.NotEmpty(t, id)
 		}
@@ -28,14 +33,47 @@ func TestReceiptRepository_SaveReceiptPoints(t *testing.T) {
 	})
 }
 
+func TestReceiptRepository_SaveCappedReceiptPoints(t *testing.T) {
+	repo := NewReceiptRepository()
+	var today = time.Now().UTC().Truncate(24 * time.Hour)
+	_, _ = repo.SaveReceiptPoints(&domain.Result{MemberID: "m1", Points: 500, CreatedAt: today.Add(-time.Hour)})
+	_, _ = repo.SaveReceiptPoints(&domain.Result{MemberID: "m1", Points: 50})
+
+	t.Run("Capped", func(t *testing.T) {
+		var result = &domain.Result{MemberID: "m1", Points: 100, Breakdown: &domain.Breakdown{TotalPoints: 100}}
+		id, err := repo.SaveCappedReceiptPoints(result, 120, today)
+		assert.NoError(t, err)
+		assert.NotEmpty(t, id)
+		assert.Equal(t, 70, result.Points)
+		assert.Equal(t, []*domain.AppliedCap{{Cap: domain.CapMemberDailyCap, Limit: 70, PointsBefore: 100}}, result.Breakdown.Caps)
+	})
+
+	t.Run("Concurrent receipts", func(t *testing.T) {
+		var wg sync.WaitGroup
+		for i := 0; i < 20; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				_, err := repo.SaveCappedReceiptPoints(&domain.Result{MemberID: "m2", Points: 30, Breakdown: &domain.Breakdown{TotalPoints: 30}}, 100, today)
+				assert.NoError(t, err)
+			}()
+		}
+		wg.Wait()
+
+		points, err := repo.SumMemberPointsSince("m2", today)
+		assert.NoError(t, err)
+		assert.Equal(t, 100, points)
+	})
+}
+
 func TestReceiptRepository_FindReceiptById(t *testing.T) {
 	repo := NewReceiptRepository()
 
 	// Generate some entries
-	var points = []int16{0, 10, 12, 20, 120}
+	var points = []int{0, 10, 12, 20, 120}
 	var id string
 	for _, point := range points {
-		id, _ = repo.SaveReceiptPoints(point)
+		id, _ = repo.SaveReceiptPoints(&domain.Result{Points: point})
 	}
 
 	t.Run("OK", func(t *testing.T) {
@@ -50,4 +88,122 @@ func TestReceiptRepository_FindReceiptById(t *testing.T) {
 		assert.Error(t, err)
 		assert.Nil(t, item)
 	})
+
+	t.Run("Copy", func(t *testing.T) {
+		item, _ := repo.FindReceiptById(id)
+		item.Points = 0
+		stored, _ := repo.FindReceiptById(id)
+		assert.Equal(t, 120, stored.Points)
+	})
+
+	t.Run("Concurrent update", func(t *testing.T) {
+		var wg sync.WaitGroup
+		for i := 0; i < 10; i++ {
+			wg.Add(2)
+			go func(points int) {
+				defer wg.Done()
+				assert.NoError(t, repo.UpdateReceiptPoints(id, points, &domain.Breakdown{TotalPoints: points}))
+			}(i)
+			go func() {
+				defer wg.Done()
+				item, err := repo.FindReceiptById(id)
+				if assert.NoError(t, err) {
+					assert.GreaterOrEqual(t, item.Points, 0)
+				}
+			}()
+		}
+		wg.Wait()
+	})
+}
+
+func TestReceiptRepository_SumMemberPointsSince(t *testing.T) {
+	repo := NewReceiptRepository()
+	var now = time.Now().UTC()
+
+	_, _ = repo.SaveReceiptPoints(&domain.Result{MemberID: "m1", Points: 10, CreatedAt: now.AddDate(-2, 0, 0)})
+	_, _ = repo.SaveReceiptPoints(&domain.Result{MemberID: "m1", Points: 20, CreatedAt: now.AddDate(0, -1, 0)})
+	_, _ = repo.SaveReceiptPoints(&domain.Result{MemberID: "m1", Points: 30})
+	_, _ = repo.SaveReceiptPoints(&domain.Result{MemberID: "m2", Points: 40})
+
+	t.Run("OK", func(t *testing.T) {
+		points, err := repo.SumMemberPointsSince("m1", now.AddDate(-1, 0, 0))
+		assert.NoError(t, err)
+		assert.Equal(t, 50, points)
+	})
+
+	t.Run("Unknown member", func(t *testing.T) {
+		points, err := repo.SumMemberPointsSince("m3", now.AddDate(-1, 0, 0))
+		assert.NoError(t, err)
+		assert.Equal(t, 0, points)
+	})
+}
+
+func TestReceiptRepository_ListReceipts(t *testing.T) {
+	repo := NewReceiptRepository()
+	var now = time.Now().UTC()
+
+	_, _ = repo.SaveReceiptPoints(&domain.Result{MemberID: "m1", RetailerID: "target", Points: 10, CreatedAt: now.AddDate(0, -2, 0)})
+	id, _ := repo.SaveReceiptPoints(&domain.Result{MemberID: "m1", RetailerID: "walgreens", Points: 20})
+	_, _ = repo.SaveReceiptPoints(&domain.Result{MemberID: "m2", RetailerID: "target", Points: 30})
+
+	t.Run("Without filter", func(t *testing.T) {
+		items, err := repo.ListReceipts(nil)
+		assert.NoError(t, err)
+		assert.Len(t, items, 3)
+	})
+
+	t.Run("Filter", func(t *testing.T) {
+		items, err := repo.ListReceipts(&domain.ReceiptFilter{RetailerID: "target", From: now.AddDate(0, -1, 0)})
+		assert.NoError(t, err)
+		assert.Len(t, items, 1)
+		assert.Equal(t, "m2", items[0].MemberID)
+	})
+
+	t.Run("Update points", func(t *testing.T) {
+		assert.NoError(t, repo.UpdateReceiptPoints(id, 25, &domain.Breakdown{RuleSetVersion: "v2", TotalPoints: 25}))
+		item, err := repo.FindReceiptById(id)
+		assert.NoError(t, err)
+		assert.Equal(t, 25, item.Points)
+		assert.Equal(t, "v2", item.Breakdown.RuleSetVersion)
+		assert.Error(t, repo.UpdateReceiptPoints(uuid.New().String(), 1, nil))
+	})
+}
+
+func TestReceiptRepository_EachReceipt(t *testing.T) {
+	repo := NewReceiptRepository()
+	_, _ = repo.SaveReceiptPoints(&domain.Result{MemberID: "m1", Points: 10})
+	_, _ = repo.SaveReceiptPoints(&domain.Result{MemberID: "m2", Points: 20})
+	_, _ = repo.SaveReceiptPoints(&domain.Result{MemberID: "m1", Points: 30})
+
+	var points []int
+	err := repo.EachReceipt(&domain.ReceiptFilter{MemberID: "m1"}, func(result *domain.Result) error {
+		points = append(points, result.Points)
+		result.Points = 0
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []int{10, 30}, points)
+
+	items, _ := repo.ListReceipts(nil)
+	assert.Equal(t, 10, items[0].Points)
+
+	var stop = errors.New("stop")
+	var count = 0
+	err = repo.EachReceipt(nil, func(result *domain.Result) error {
+		count++
+		return stop
+	})
+	assert.ErrorIs(t, err, stop)
+	assert.Equal(t, 1, count)
+}
+
+func TestReceiptRepository_Ping(t *testing.T) {
+	repo := NewReceiptRepository()
+	assert.NoError(t, repo.Ping(context.Background()))
+
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, repo.Ping(ctx), context.DeadlineExceeded)
 }
@@ -1,7 +1,22 @@
 package in_memory
 
-import "go.uber.org/fx"
+import (
+	"github.com/kiramishima/receipt-processor/domain"
+	"go.uber.org/fx"
+)
 
 var Module = fx.Module("db",
 	fx.Provide(NewReceiptRepository),
+	fx.Provide(NewMemberRepository),
+	fx.Provide(NewCampaignRepository),
+	fx.Provide(NewExperimentRepository),
+	fx.Provide(NewRetailerRepository),
+	fx.Provide(NewRuleSetRepository),
+	fx.Provide(NewLedgerRepository),
+	fx.Provide(NewAPIKeyRepository),
+	fx.Provide(NewQuotaRepository),
+	fx.Provide(NewEmailMessageRepository),
+	fx.Provide(fx.Annotate(func(repo *ReceiptRepository) domain.HealthCheck {
+		return domain.HealthCheck{Name: "receipt-repository", Kind: domain.HealthReadiness, Check: repo.Ping}
+	}, fx.ResultTags(`group:"health"`))),
 )
@@ -0,0 +1,128 @@
+package in_memory
+
+import (
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/kiramishima/receipt-processor/domain"
+	appErrors "github.com/kiramishima/receipt-processor/pkg/errors"
+	"github.com/samber/lo"
+	"sort"
+	"sync"
+)
+
+func NewRetailerRepository() *RetailerRepository {
+	return &RetailerRepository{
+		records:    make([]*domain.Retailer, 0),
+		categories: make(map[string]*domain.RetailerCategory),
+	}
+}
+
+type RetailerRepository struct {
+	mu         sync.RWMutex
+	records    []*domain.Retailer
+	categories map[string]*domain.RetailerCategory
+}
+
+func (repo *RetailerRepository) SaveRetailer(retailer *domain.Retailer) (string, error) {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+	// Generate ID when the catalog doesn't provide one
+	var item = *retailer
+	if item.ID == "" {
+		item.ID = uuid.New().String()
+	}
+	if lo.ContainsBy(repo.records, func(i *domain.Retailer) bool { return i.ID == item.ID }) {
+		return "", fmt.Errorf("%w: retailer with id: %s already exists", appErrors.BadRequest, item.ID)
+	}
+	// Insert record
+	repo.records = append(repo.records, &item)
+	return item.ID, nil
+}
+
+func (repo *RetailerRepository) FindRetailerById(id string) (*domain.Retailer, error) {
+	repo.mu.RLock()
+	defer repo.mu.RUnlock()
+
+	_, index, ok := lo.FindIndexOf(repo.records, func(i *domain.Retailer) bool {
+		return i.ID == id
+	})
+	if !ok {
+		return nil, fmt.Errorf("retailer with id: %s %w", id, appErrors.NotFound)
+	}
+	var item = *repo.records[index]
+	return &item, nil
+}
+
+func (repo *RetailerRepository) ListRetailers() ([]*domain.Retailer, error) {
+	repo.mu.RLock()
+	defer repo.mu.RUnlock()
+
+	return lo.Map(repo.records, func(r *domain.Retailer, _ int) *domain.Retailer {
+		var item = *r
+		return &item
+	}), nil
+}
+
+func (repo *RetailerRepository) UpdateRetailer(retailer *domain.Retailer) error {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	_, index, ok := lo.FindIndexOf(repo.records, func(i *domain.Retailer) bool {
+		return i.ID == retailer.ID
+	})
+	if !ok {
+		return fmt.Errorf("retailer with id: %s %w", retailer.ID, appErrors.NotFound)
+	}
+	var item = *retailer
+	repo.records[index] = &item
+	return nil
+}
+
+func (repo *RetailerRepository) DeleteRetailer(id string) error {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	_, index, ok := lo.FindIndexOf(repo.records, func(i *domain.Retailer) bool {
+		return i.ID == id
+	})
+	if !ok {
+		return fmt.Errorf("retailer with id: %s %w", id, appErrors.NotFound)
+	}
+	repo.records = append(repo.records[:index], repo.records[index+1:]...)
+	return nil
+}
+
+func (repo *RetailerRepository) SaveCategory(category *domain.RetailerCategory) error {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	var item = *category
+	repo.categories[category.Name] = &item
+	return nil
+}
+
+func (repo *RetailerRepository) FindCategoryByName(name string) (*domain.RetailerCategory, error) {
+	repo.mu.RLock()
+	defer repo.mu.RUnlock()
+
+	item, ok := repo.categories[name]
+	if !ok {
+		return nil, fmt.Errorf("category: %s %w", name, appErrors.NotFound)
+	}
+	var category = *item
+	return &category, nil
+}
+
+func (repo *RetailerRepository) ListCategories() ([]*domain.RetailerCategory, error) {
+	repo.mu.RLock()
+	defer repo.mu.RUnlock()
+
+	categories := lo.MapToSlice(repo.categories, func(_ string, c *domain.RetailerCategory) *domain.RetailerCategory {
+		var item = *c
+		return &item
+	})
+	sort.Slice(categories, func(i, j int) bool {
+		return categories[i].Name < categories[j].Name
+	})
+	return categories, nil
+}
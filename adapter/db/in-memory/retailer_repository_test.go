@@ -0,0 +1,60 @@
+package in_memory
+
+import (
+	"github.com/kiramishima/receipt-processor/domain"
+	appErrors "github.com/kiramishima/receipt-processor/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestRetailerRepository_Retailers(t *testing.T) {
+	repo := NewRetailerRepository()
+
+	id, err := repo.SaveRetailer(&domain.Retailer{ID: "target", Name: "Target"})
+	assert.NoError(t, err)
+	assert.Equal(t, "target", id)
+
+	t.Run("Duplicated id", func(t *testing.T) {
+		_, err := repo.SaveRetailer(&domain.Retailer{ID: "target", Name: "Target"})
+		assert.ErrorIs(t, err, appErrors.BadRequest)
+	})
+
+	t.Run("Generated id", func(t *testing.T) {
+		id, err := repo.SaveRetailer(&domain.Retailer{Name: "Walgreens"})
+		assert.NoError(t, err)
+		assert.NotEmpty(t, id)
+	})
+
+	t.Run("Update", func(t *testing.T) {
+		assert.NoError(t, repo.UpdateRetailer(&domain.Retailer{ID: "target", Name: "Target", Category: "general"}))
+		item, err := repo.FindRetailerById("target")
+		assert.NoError(t, err)
+		assert.Equal(t, "general", item.Category)
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		assert.NoError(t, repo.DeleteRetailer("target"))
+		_, err := repo.FindRetailerById("target")
+		assert.ErrorIs(t, err, appErrors.NotFound)
+		items, _ := repo.ListRetailers()
+		assert.Len(t, items, 1)
+	})
+}
+
+func TestRetailerRepository_Categories(t *testing.T) {
+	repo := NewRetailerRepository()
+
+	assert.NoError(t, repo.SaveCategory(&domain.RetailerCategory{Name: "pharmacy"}))
+	assert.NoError(t, repo.SaveCategory(&domain.RetailerCategory{Name: "grocery"}))
+
+	item, err := repo.FindCategoryByName("grocery")
+	assert.NoError(t, err)
+	assert.Equal(t, "grocery", item.Name)
+
+	_, err = repo.FindCategoryByName("general")
+	assert.ErrorIs(t, err, appErrors.NotFound)
+
+	items, _ := repo.ListCategories()
+	assert.Equal(t, "grocery", items[0].Name)
+	assert.Equal(t, "pharmacy", items[1].Name)
+}
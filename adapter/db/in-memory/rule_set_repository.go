@@ -0,0 +1,55 @@
+package in_memory
+
+import (
+	"fmt"
+	"github.com/kiramishima/receipt-processor/domain"
+	appErrors "github.com/kiramishima/receipt-processor/pkg/errors"
+	"github.com/samber/lo"
+	"sync"
+)
+
+// NewRuleSetRepository creates the repository with the built-in rule set stored
+func NewRuleSetRepository() *RuleSetRepository {
+	return &RuleSetRepository{
+		records: []*domain.RuleSet{domain.DefaultRuleSet()},
+	}
+}
+
+type RuleSetRepository struct {
+	mu      sync.RWMutex
+	records []*domain.RuleSet
+}
+
+// SaveRuleSet stores a new version, the stored versions are immutable
+func (repo *RuleSetRepository) SaveRuleSet(rs *domain.RuleSet) error {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	if lo.ContainsBy(repo.records, func(i *domain.RuleSet) bool { return i.Version == rs.Version }) {
+		return fmt.Errorf("%w: rule set version: %s already exists", appErrors.BadRequest, rs.Version)
+	}
+	repo.records = append(repo.records, rs.WithOverrides(nil))
+	return nil
+}
+
+func (repo *RuleSetRepository) FindRuleSetByVersion(version string) (*domain.RuleSet, error) {
+	repo.mu.RLock()
+	defer repo.mu.RUnlock()
+
+	item, ok := lo.Find(repo.records, func(i *domain.RuleSet) bool {
+		return i.Version == version
+	})
+	if !ok {
+		return nil, fmt.Errorf("rule set version: %s %w", version, appErrors.NotFound)
+	}
+	return item.WithOverrides(nil), nil
+}
+
+func (repo *RuleSetRepository) ListRuleSets() ([]*domain.RuleSet, error) {
+	repo.mu.RLock()
+	defer repo.mu.RUnlock()
+
+	return lo.Map(repo.records, func(rs *domain.RuleSet, _ int) *domain.RuleSet {
+		return rs.WithOverrides(nil)
+	}), nil
+}
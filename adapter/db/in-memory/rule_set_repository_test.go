@@ -0,0 +1,43 @@
+package in_memory
+
+import (
+	"github.com/kiramishima/receipt-processor/domain"
+	appErrors "github.com/kiramishima/receipt-processor/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestRuleSetRepository(t *testing.T) {
+	repo := NewRuleSetRepository()
+
+	t.Run("Default rule set", func(t *testing.T) {
+		rs, err := repo.FindRuleSetByVersion(domain.DefaultRuleSetVersion)
+		assert.NoError(t, err)
+		assert.Equal(t, domain.DefaultRuleSet(), rs)
+	})
+
+	var rs = domain.DefaultRuleSet()
+	rs.Version = "v2"
+	assert.NoError(t, repo.SaveRuleSet(rs))
+
+	t.Run("Immutable versions", func(t *testing.T) {
+		assert.ErrorIs(t, repo.SaveRuleSet(rs), appErrors.BadRequest)
+
+		item, err := repo.FindRuleSetByVersion("v2")
+		assert.NoError(t, err)
+		item.Rules[domain.RuleOddDay] = domain.RuleParams{}
+		stored, _ := repo.FindRuleSetByVersion("v2")
+		assert.True(t, stored.Rules[domain.RuleOddDay].Enabled)
+	})
+
+	t.Run("Not found", func(t *testing.T) {
+		_, err := repo.FindRuleSetByVersion("v3")
+		assert.ErrorIs(t, err, appErrors.NotFound)
+	})
+
+	t.Run("List", func(t *testing.T) {
+		items, err := repo.ListRuleSets()
+		assert.NoError(t, err)
+		assert.Len(t, items, 2)
+	})
+}
@@ -0,0 +1,46 @@
+package in_memory
+
+import (
+	"context"
+	"github.com/kiramishima/receipt-processor/domain"
+	"go.uber.org/zap"
+	"sync"
+)
+
+// Handler reacts to a published event
+type Handler func(ctx context.Context, event domain.Event) error
+
+func NewEventBus(logger *zap.SugaredLogger) *EventBus {
+	return &EventBus{
+		logger:   logger,
+		handlers: make(map[string][]Handler),
+	}
+}
+
+// EventBus dispatches the events synchronously to the handlers subscribed in the same process
+type EventBus struct {
+	mu       sync.RWMutex
+	logger   *zap.SugaredLogger
+	handlers map[string][]Handler
+}
+
+// Subscribe registers a handler for the events with the given name
+func (bus *EventBus) Subscribe(name string, handler Handler) {
+	bus.mu.Lock()
+	defer bus.mu.Unlock()
+	bus.handlers[name] = append(bus.handlers[name], handler)
+}
+
+func (bus *EventBus) Publish(ctx context.Context, event domain.Event) error {
+	bus.mu.RLock()
+	handlers := bus.handlers[event.EventName()]
+	bus.mu.RUnlock()
+
+	bus.logger.Infow("event published", "event", event.EventName(), "payload", event)
+	for _, handler := range handlers {
+		if err := handler(ctx, event); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,7 @@
+package in_memory
+
+import "go.uber.org/fx"
+
+var Module = fx.Module("events",
+	fx.Provide(NewEventBus),
+)
@@ -0,0 +1,34 @@
+package metrics
+
+import (
+	"github.com/kiramishima/receipt-processor/domain"
+	ports "github.com/kiramishima/receipt-processor/ports/metrics"
+	"github.com/kiramishima/receipt-processor/ports/repository"
+)
+
+// LedgerRepository records the latency of the operations of a ledger repository
+type LedgerRepository struct {
+	next     repository.ILedgerRepository
+	recorder ports.IMetricsRecorder
+}
+
+func NewLedgerRepository(next repository.ILedgerRepository, recorder ports.IMetricsRecorder) *LedgerRepository {
+	return &LedgerRepository{
+		next:     next,
+		recorder: recorder,
+	}
+}
+
+func (repo *LedgerRepository) SaveLedgerEntry(entry *domain.LedgerEntry) (string, error) {
+	var done = observe(repo.recorder, "ledger", "save")
+	id, err := repo.next.SaveLedgerEntry(entry)
+	done(err)
+	return id, err
+}
+
+func (repo *LedgerRepository) ListLedgerEntries(receiptID string) ([]*domain.LedgerEntry, error) {
+	var done = observe(repo.recorder, "ledger", "list")
+	entries, err := repo.next.ListLedgerEntries(receiptID)
+	done(err)
+	return entries, err
+}
@@ -0,0 +1,31 @@
+package metrics
+
+import (
+	in_memory "github.com/kiramishima/receipt-processor/adapter/db/in-memory"
+	ports "github.com/kiramishima/receipt-processor/ports/metrics"
+	"github.com/kiramishima/receipt-processor/ports/repository"
+	"go.uber.org/fx"
+	"time"
+)
+
+// Module records the metrics with Prometheus and instruments the repositories used by the services
+var Module = fx.Module("metrics",
+	fx.Provide(NewPrometheusRecorder),
+	fx.Provide(func(recorder *PrometheusRecorder) ports.IMetricsRecorder {
+		return recorder
+	}),
+	fx.Provide(func(repo *in_memory.ReceiptRepository, recorder ports.IMetricsRecorder) repository.IReceiptRepository {
+		return NewReceiptRepository(repo, recorder)
+	}),
+	fx.Provide(func(repo *in_memory.LedgerRepository, recorder ports.IMetricsRecorder) repository.ILedgerRepository {
+		return NewLedgerRepository(repo, recorder)
+	}),
+)
+
+// observe starts timing a repository operation, the returned function records it with its error
+func observe(recorder ports.IMetricsRecorder, repository string, operation string) func(err error) {
+	var started = time.Now()
+	return func(err error) {
+		recorder.ObserveRepository(repository, operation, time.Since(started), err)
+	}
+}
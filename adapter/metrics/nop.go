@@ -0,0 +1,17 @@
+package metrics
+
+import "time"
+
+// NopRecorder discards the metrics, for the commands and tests without a metrics endpoint
+type NopRecorder struct{}
+
+func (NopRecorder) ObserveRequest(method string, route string, status int, duration time.Duration) {}
+
+func (NopRecorder) ReceiptProcessed(points int) {}
+
+func (NopRecorder) ReceiptRejected(reason string) {}
+
+func (NopRecorder) RuleFired(rule string) {}
+
+func (NopRecorder) ObserveRepository(repository string, operation string, duration time.Duration, err error) {
+}
@@ -0,0 +1,113 @@
+package metrics
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "receipt_processor"
+
+// PrometheusRecorder records the metrics in a Prometheus registry of its own, served in the text exposition format
+type PrometheusRecorder struct {
+	registry           *prometheus.Registry
+	requests           *prometheus.CounterVec
+	requestDuration    *prometheus.HistogramVec
+	receiptsProcessed  prometheus.Counter
+	receiptsRejected   *prometheus.CounterVec
+	pointsAwarded      prometheus.Histogram
+	rulesFired         *prometheus.CounterVec
+	repositoryDuration *prometheus.HistogramVec
+}
+
+func NewPrometheusRecorder() *PrometheusRecorder {
+	var recorder = &PrometheusRecorder{
+		registry: prometheus.NewRegistry(),
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "http_requests_total",
+			Help:      "HTTP requests by method, route pattern and status code.",
+		}, []string{"method", "route", "status"}),
+		requestDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "http_request_duration_seconds",
+			Help:      "Latency of the HTTP requests by method and route pattern.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"method", "route"}),
+		receiptsProcessed: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "receipts_processed_total",
+			Help:      "Receipts scored and stored.",
+		}),
+		receiptsRejected: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "receipts_rejected_total",
+			Help:      "Receipts not stored by rejection reason.",
+		}, []string{"reason"}),
+		pointsAwarded: prometheus.NewHistogram(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "points_awarded",
+			Help:      "Points awarded to the stored receipts.",
+			Buckets:   []float64{0, 10, 25, 50, 100, 250, 500, 1000, 2500, 5000},
+		}),
+		rulesFired: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "rules_fired_total",
+			Help:      "Rules that awarded points to the stored receipts.",
+		}, []string{"rule"}),
+		repositoryDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "repository_operation_duration_seconds",
+			Help:      "Latency of the repository operations by outcome.",
+			Buckets:   prometheus.ExponentialBuckets(0.0001, 4, 8),
+		}, []string{"repository", "operation", "outcome"}),
+	}
+
+	recorder.registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		recorder.requests,
+		recorder.requestDuration,
+		recorder.receiptsProcessed,
+		recorder.receiptsRejected,
+		recorder.pointsAwarded,
+		recorder.rulesFired,
+		recorder.repositoryDuration,
+	)
+	return recorder
+}
+
+// Handler serves the metrics in the Prometheus text exposition format
+func (r *PrometheusRecorder) Handler() http.Handler {
+	return promhttp.HandlerFor(r.registry, promhttp.HandlerOpts{})
+}
+
+func (r *PrometheusRecorder) ObserveRequest(method string, route string, status int, duration time.Duration) {
+	r.requests.WithLabelValues(method, route, strconv.Itoa(status)).Inc()
+	r.requestDuration.WithLabelValues(method, route).Observe(duration.Seconds())
+}
+
+func (r *PrometheusRecorder) ReceiptProcessed(points int) {
+	r.receiptsProcessed.Inc()
+	r.pointsAwarded.Observe(float64(points))
+}
+
+func (r *PrometheusRecorder) ReceiptRejected(reason string) {
+	r.receiptsRejected.WithLabelValues(reason).Inc()
+}
+
+func (r *PrometheusRecorder) RuleFired(rule string) {
+	r.rulesFired.WithLabelValues(rule).Inc()
+}
+
+func (r *PrometheusRecorder) ObserveRepository(repository string, operation string, duration time.Duration, err error) {
+	var outcome = "ok"
+	if err != nil {
+		outcome = "error"
+	}
+	r.repositoryDuration.WithLabelValues(repository, operation, outcome).Observe(duration.Seconds())
+}
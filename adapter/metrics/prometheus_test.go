@@ -0,0 +1,43 @@
+package metrics
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPrometheusRecorder(t *testing.T) {
+	var recorder = NewPrometheusRecorder()
+	recorder.ObserveRequest(http.MethodPost, "/receipts/process", http.StatusOK, 20*time.Millisecond)
+	recorder.ObserveRequest(http.MethodPost, "/receipts/process", http.StatusOK, 30*time.Millisecond)
+	recorder.ReceiptProcessed(28)
+	recorder.ReceiptRejected("invalid")
+	recorder.RuleFired("oddDay")
+	recorder.ObserveRepository("receipts", "save", time.Millisecond, nil)
+	recorder.ObserveRepository("receipts", "find", time.Millisecond, errors.New("not found"))
+
+	assert.Equal(t, 2.0, testutil.ToFloat64(recorder.requests.WithLabelValues("POST", "/receipts/process", "200")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(recorder.receiptsProcessed))
+	assert.Equal(t, 1.0, testutil.ToFloat64(recorder.receiptsRejected.WithLabelValues("invalid")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(recorder.rulesFired.WithLabelValues("oddDay")))
+	assert.Equal(t, 2, testutil.CollectAndCount(recorder.repositoryDuration))
+
+	var response = httptest.NewRecorder()
+	recorder.Handler().ServeHTTP(response, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	assert.Equal(t, http.StatusOK, response.Code)
+	assert.True(t, strings.HasPrefix(response.Header().Get("Content-Type"), "text/plain"))
+	for _, line := range []string{
+		`receipt_processor_http_requests_total{method="POST",route="/receipts/process",status="200"} 2`,
+		`receipt_processor_points_awarded_sum 28`,
+		`receipt_processor_repository_operation_duration_seconds_count{operation="find",outcome="error",repository="receipts"} 1`,
+		`go_goroutines`,
+	} {
+		assert.Contains(t, response.Body.String(), line)
+	}
+}
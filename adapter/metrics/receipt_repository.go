@@ -0,0 +1,71 @@
+package metrics
+
+import (
+	"github.com/kiramishima/receipt-processor/domain"
+	ports "github.com/kiramishima/receipt-processor/ports/metrics"
+	"github.com/kiramishima/receipt-processor/ports/repository"
+	"time"
+)
+
+// ReceiptRepository records the latency of the operations of a receipt repository
+type ReceiptRepository struct {
+	next     repository.IReceiptRepository
+	recorder ports.IMetricsRecorder
+}
+
+func NewReceiptRepository(next repository.IReceiptRepository, recorder ports.IMetricsRecorder) *ReceiptRepository {
+	return &ReceiptRepository{
+		next:     next,
+		recorder: recorder,
+	}
+}
+
+func (repo *ReceiptRepository) SaveReceiptPoints(result *domain.Result) (string, error) {
+	var done = observe(repo.recorder, "receipts", "save")
+	id, err := repo.next.SaveReceiptPoints(result)
+	done(err)
+	return id, err
+}
+
+func (repo *ReceiptRepository) SaveCappedReceiptPoints(result *domain.Result, dailyCap int, since time.Time) (string, error) {
+	var done = observe(repo.recorder, "receipts", "save_capped")
+	id, err := repo.next.SaveCappedReceiptPoints(result, dailyCap, since)
+	done(err)
+	return id, err
+}
+
+func (repo *ReceiptRepository) FindReceiptById(id string) (*domain.Result, error) {
+	var done = observe(repo.recorder, "receipts", "find")
+	result, err := repo.next.FindReceiptById(id)
+	done(err)
+	return result, err
+}
+
+func (repo *ReceiptRepository) SumMemberPointsSince(memberID string, since time.Time) (int, error) {
+	var done = observe(repo.recorder, "receipts", "sum_member_points")
+	points, err := repo.next.SumMemberPointsSince(memberID, since)
+	done(err)
+	return points, err
+}
+
+func (repo *ReceiptRepository) ListReceipts(filter *domain.ReceiptFilter) ([]*domain.Result, error) {
+	var done = observe(repo.recorder, "receipts", "list")
+	results, err := repo.next.ListReceipts(filter)
+	done(err)
+	return results, err
+}
+
+// EachReceipt the latency includes the time spent in fn
+func (repo *ReceiptRepository) EachReceipt(filter *domain.ReceiptFilter, fn func(result *domain.Result) error) error {
+	var done = observe(repo.recorder, "receipts", "each")
+	err := repo.next.EachReceipt(filter, fn)
+	done(err)
+	return err
+}
+
+func (repo *ReceiptRepository) UpdateReceiptPoints(id string, points int, breakdown *domain.Breakdown) error {
+	var done = observe(repo.recorder, "receipts", "update_points")
+	err := repo.next.UpdateReceiptPoints(id, points, breakdown)
+	done(err)
+	return err
+}
@@ -0,0 +1,31 @@
+package metrics
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/kiramishima/receipt-processor/domain"
+	"github.com/kiramishima/receipt-processor/mocks"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestReceiptRepository(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	next := mocks.NewMockIReceiptRepository(ctrl)
+	recorder := mocks.NewMockIMetricsRecorder(ctrl)
+	repo := NewReceiptRepository(next, recorder)
+
+	next.EXPECT().SaveReceiptPoints(gomock.Any()).Times(1).Return("id-1", nil)
+	recorder.EXPECT().ObserveRepository("receipts", "save", gomock.Any(), nil).Times(1)
+	id, err := repo.SaveReceiptPoints(&domain.Result{})
+	assert.NoError(t, err)
+	assert.Equal(t, "id-1", id)
+
+	var notFound = errors.New("not found")
+	next.EXPECT().FindReceiptById("id-2").Times(1).Return(nil, notFound)
+	recorder.EXPECT().ObserveRepository("receipts", "find", gomock.Any(), notFound).Times(1)
+	_, err = repo.FindReceiptById("id-2")
+	assert.ErrorIs(t, err, notFound)
+}
@@ -0,0 +1,13 @@
+package in_memory
+
+import (
+	"github.com/kiramishima/receipt-processor/ports/ratelimit"
+	"go.uber.org/fx"
+)
+
+var Module = fx.Module("ratelimit",
+	fx.Provide(NewTokenBucketLimiter),
+	fx.Provide(func(limiter *TokenBucketLimiter) ratelimit.IRateLimiter {
+		return limiter
+	}),
+)
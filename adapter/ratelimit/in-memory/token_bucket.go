@@ -0,0 +1,91 @@
+package in_memory
+
+import (
+	"math"
+	"sync"
+	"time"
+
+	"github.com/kiramishima/receipt-processor/domain"
+)
+
+// sweepEvery the full buckets are removed every sweepEvery calls, so the idle clients don't use memory
+const sweepEvery = 1024
+
+// bucket tokens of a key at the time they were counted
+type bucket struct {
+	tokens  float64
+	updated time.Time
+	limit   domain.RateLimit
+}
+
+func NewTokenBucketLimiter() *TokenBucketLimiter {
+	return &TokenBucketLimiter{
+		buckets: make(map[string]*bucket),
+	}
+}
+
+// TokenBucketLimiter limits the requests of every key with a token bucket kept in memory. The buckets start full,
+// hold up to the requests of the limit and refill continuously over its period.
+type TokenBucketLimiter struct {
+	mu      sync.Mutex
+	buckets map[string]*bucket
+	calls   int
+}
+
+func (l *TokenBucketLimiter) Allow(key string, limit domain.RateLimit, now time.Time) domain.RateLimitDecision {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.calls++; l.calls%sweepEvery == 0 {
+		l.sweep(now)
+	}
+
+	var b, ok = l.buckets[key]
+	if !ok || b.limit != limit {
+		b = &bucket{tokens: float64(limit.Requests), updated: now, limit: limit}
+		l.buckets[key] = b
+	}
+	b.refill(now)
+
+	var decision = domain.RateLimitDecision{Limit: limit}
+	if b.tokens >= 1 {
+		b.tokens--
+		decision.Allowed = true
+	} else {
+		decision.RetryAfter = b.until(1)
+	}
+	decision.Remaining = int(math.Floor(b.tokens))
+	decision.Reset = b.until(float64(limit.Requests))
+	return decision
+}
+
+// refill adds the tokens of the time elapsed since the last count, up to the capacity
+func (b *bucket) refill(now time.Time) {
+	if elapsed := now.Sub(b.updated); elapsed > 0 {
+		b.tokens = math.Min(float64(b.limit.Requests), b.tokens+elapsed.Seconds()*b.rate())
+		b.updated = now
+	}
+}
+
+// rate tokens added per second
+func (b *bucket) rate() float64 {
+	return float64(b.limit.Requests) / b.limit.Period.Seconds()
+}
+
+// until returns the time until the bucket has the tokens
+func (b *bucket) until(tokens float64) time.Duration {
+	if b.tokens >= tokens {
+		return 0
+	}
+	return time.Duration((tokens - b.tokens) / b.rate() * float64(time.Second))
+}
+
+// sweep removes the buckets that are full at now, a new bucket starts full too
+func (l *TokenBucketLimiter) sweep(now time.Time) {
+	for key, b := range l.buckets {
+		b.refill(now)
+		if b.tokens >= float64(b.limit.Requests) {
+			delete(l.buckets, key)
+		}
+	}
+}
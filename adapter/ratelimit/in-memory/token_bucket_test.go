@@ -0,0 +1,53 @@
+package in_memory
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kiramishima/receipt-processor/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTokenBucketLimiter(t *testing.T) {
+	var limiter = NewTokenBucketLimiter()
+	var limit = domain.RateLimit{Requests: 2, Period: time.Second}
+	var now = time.Date(2022, 3, 20, 10, 0, 0, 0, time.UTC)
+
+	var decision = limiter.Allow("client-1", limit, now)
+	assert.True(t, decision.Allowed)
+	assert.Equal(t, 1, decision.Remaining)
+	assert.Equal(t, 500*time.Millisecond, decision.Reset)
+
+	assert.True(t, limiter.Allow("client-1", limit, now).Allowed)
+	decision = limiter.Allow("client-1", limit, now)
+	assert.False(t, decision.Allowed)
+	assert.Equal(t, 0, decision.Remaining)
+	assert.Equal(t, 500*time.Millisecond, decision.RetryAfter)
+	assert.Equal(t, time.Second, decision.Reset)
+
+	// other clients have their own bucket
+	assert.True(t, limiter.Allow("client-2", limit, now).Allowed)
+
+	// half a period refills one token
+	decision = limiter.Allow("client-1", limit, now.Add(500*time.Millisecond))
+	assert.True(t, decision.Allowed)
+	assert.False(t, limiter.Allow("client-1", limit, now.Add(500*time.Millisecond)).Allowed)
+
+	// the bucket never holds more than the requests of the limit
+	decision = limiter.Allow("client-1", limit, now.Add(time.Hour))
+	assert.True(t, decision.Allowed)
+	assert.Equal(t, 1, decision.Remaining)
+}
+
+func TestTokenBucketLimiter_Sweep(t *testing.T) {
+	var limiter = NewTokenBucketLimiter()
+	var limit = domain.RateLimit{Requests: 10, Period: time.Second}
+	var now = time.Date(2022, 3, 20, 10, 0, 0, 0, time.UTC)
+
+	limiter.Allow("idle", limit, now)
+	for i := 1; i < sweepEvery; i++ {
+		limiter.Allow("busy", domain.RateLimit{Requests: sweepEvery * 2, Period: time.Hour}, now.Add(2*time.Second))
+	}
+	assert.NotContains(t, limiter.buckets, "idle")
+	assert.Contains(t, limiter.buckets, "busy")
+}
@@ -0,0 +1,102 @@
+package telemetry
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/kiramishima/receipt-processor/domain"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+)
+
+// Module registers the tracer provider as the global provider of the handlers and services, and flushes the spans
+// when the application stops
+var Module = fx.Module("telemetry",
+	fx.Provide(func(cfg *domain.Configuration) (*sdktrace.TracerProvider, error) {
+		return NewTracerProvider(cfg.TracingConfig)
+	}),
+	fx.Invoke(func(lifecycle fx.Lifecycle, provider *sdktrace.TracerProvider, logger *zap.SugaredLogger) {
+		otel.SetTracerProvider(provider)
+		otel.SetTextMapPropagator(propagation.TraceContext{})
+		otel.SetErrorHandler(otel.ErrorHandlerFunc(func(err error) {
+			logger.Errorw("tracing error", "error", err)
+		}))
+		lifecycle.Append(fx.Hook{
+			OnStop: provider.Shutdown,
+		})
+	}),
+)
+
+// NewTracerProvider creates a tracer provider sending the spans to the configured exporter. The traces started by
+// the API are sampled with the sample ratio, the traces propagated by the callers keep their decision.
+func NewTracerProvider(cfg domain.TracingConfig) (*sdktrace.TracerProvider, error) {
+	if cfg.TracingSampleRatio < 0 || cfg.TracingSampleRatio > 1 {
+		return nil, fmt.Errorf("tracing sample ratio %g is not between 0 and 1", cfg.TracingSampleRatio)
+	}
+	var options = []sdktrace.TracerProviderOption{
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(cfg.TracingSampleRatio))),
+		sdktrace.WithResource(resource.NewWithAttributes(semconv.SchemaURL, semconv.ServiceName(cfg.TracingServiceName))),
+	}
+
+	exporter, err := newExporter(cfg)
+	if err != nil {
+		return nil, err
+	}
+	if exporter != nil {
+		options = append(options, sdktrace.WithBatcher(exporter))
+	}
+	return sdktrace.NewTracerProvider(options...), nil
+}
+
+// newExporter returns the exporter of the configuration, nil when the spans are not exported
+func newExporter(cfg domain.TracingConfig) (sdktrace.SpanExporter, error) {
+	switch cfg.TracingExporter {
+	case domain.TracingExporterNone, "":
+		return nil, nil
+	case domain.TracingExporterStdout:
+		return stdouttrace.New(stdouttrace.WithWriter(os.Stdout))
+	case domain.TracingExporterFile:
+		file, err := os.OpenFile(cfg.TracingFile, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+		if err != nil {
+			return nil, err
+		}
+		exporter, err := stdouttrace.New(stdouttrace.WithWriter(file))
+		if err != nil {
+			_ = file.Close()
+			return nil, err
+		}
+		return &fileExporter{SpanExporter: exporter, file: file}, nil
+	case domain.TracingExporterOTLP:
+		var options []otlptracehttp.Option
+		if cfg.TracingOTLPEndpoint != "" {
+			options = append(options, otlptracehttp.WithEndpoint(cfg.TracingOTLPEndpoint))
+		}
+		if cfg.TracingOTLPInsecure {
+			options = append(options, otlptracehttp.WithInsecure())
+		}
+		return otlptracehttp.New(context.Background(), options...)
+	default:
+		return nil, fmt.Errorf("unknown tracing exporter %q", cfg.TracingExporter)
+	}
+}
+
+// fileExporter writes the spans to a file as JSON, one span per line, and closes it on shutdown
+type fileExporter struct {
+	sdktrace.SpanExporter
+	file *os.File
+}
+
+func (e *fileExporter) Shutdown(ctx context.Context) error {
+	if err := e.SpanExporter.Shutdown(ctx); err != nil {
+		return err
+	}
+	return e.file.Close()
+}
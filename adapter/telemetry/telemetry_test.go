@@ -0,0 +1,77 @@
+package telemetry
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/kiramishima/receipt-processor/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewTracerProvider_File(t *testing.T) {
+	var cfg = domain.TracingConfig{
+		TracingExporter:    domain.TracingExporterFile,
+		TracingFile:        filepath.Join(t.TempDir(), "traces.json"),
+		TracingSampleRatio: 1,
+		TracingServiceName: "receipt-processor",
+	}
+	provider, err := NewTracerProvider(cfg)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	ctx, parent := provider.Tracer("test").Start(context.Background(), "request")
+	_, child := provider.Tracer("test").Start(ctx, "validate")
+	child.End()
+	parent.End()
+	assert.NoError(t, provider.Shutdown(context.Background()))
+
+	file, err := os.Open(cfg.TracingFile)
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer file.Close()
+	var names = make([]string, 0)
+	var scanner = bufio.NewScanner(file)
+	for scanner.Scan() {
+		var span struct {
+			Name        string
+			SpanContext struct{ TraceID string }
+		}
+		assert.NoError(t, json.Unmarshal(scanner.Bytes(), &span))
+		assert.Equal(t, parent.SpanContext().TraceID().String(), span.SpanContext.TraceID)
+		names = append(names, span.Name)
+	}
+	assert.Equal(t, []string{"validate", "request"}, names)
+}
+
+func TestNewTracerProvider(t *testing.T) {
+	testCases := map[string]struct {
+		cfg   domain.TracingConfig
+		error string
+	}{
+		"None":         {cfg: domain.TracingConfig{TracingExporter: domain.TracingExporterNone, TracingSampleRatio: 1}},
+		"Stdout":       {cfg: domain.TracingConfig{TracingExporter: domain.TracingExporterStdout, TracingSampleRatio: 0.5}},
+		"OTLP":         {cfg: domain.TracingConfig{TracingExporter: domain.TracingExporterOTLP, TracingOTLPEndpoint: "localhost:4318", TracingOTLPInsecure: true, TracingSampleRatio: 1}},
+		"Unknown":      {cfg: domain.TracingConfig{TracingExporter: "jaeger", TracingSampleRatio: 1}, error: `unknown tracing exporter "jaeger"`},
+		"Sample ratio": {cfg: domain.TracingConfig{TracingExporter: domain.TracingExporterNone, TracingSampleRatio: 2}, error: "tracing sample ratio 2 is not between 0 and 1"},
+		"Missing dir":  {cfg: domain.TracingConfig{TracingExporter: domain.TracingExporterFile, TracingFile: "/missing/dir/traces.json", TracingSampleRatio: 1}, error: "open /missing/dir/traces.json: no such file or directory"},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			provider, err := NewTracerProvider(tc.cfg)
+			if tc.error != "" {
+				assert.EqualError(t, err, tc.error)
+				return
+			}
+			if assert.NoError(t, err) {
+				assert.NoError(t, provider.Shutdown(context.Background()))
+			}
+		})
+	}
+}
@@ -3,7 +3,15 @@ package bootstrap
 import (
 	"context"
 	repository "github.com/kiramishima/receipt-processor/adapter/db/in-memory"
+	events "github.com/kiramishima/receipt-processor/adapter/events/in-memory"
+	"github.com/kiramishima/receipt-processor/adapter/metrics"
+	ratelimit "github.com/kiramishima/receipt-processor/adapter/ratelimit/in-memory"
+	"github.com/kiramishima/receipt-processor/adapter/telemetry"
+	"github.com/kiramishima/receipt-processor/domain"
 	"github.com/kiramishima/receipt-processor/handlers"
+	"github.com/kiramishima/receipt-processor/jobs"
+	metricsPorts "github.com/kiramishima/receipt-processor/ports/metrics"
+	ratelimitPorts "github.com/kiramishima/receipt-processor/ports/ratelimit"
 	"github.com/kiramishima/receipt-processor/services"
 	"time"
 
@@ -17,44 +25,95 @@ import (
 	"go.uber.org/zap"
 )
 
+// drainTimeout extra time to stop the background jobs after the server shutdown timeout
+const drainTimeout = 5 * time.Second
+
+// bootstrap starts the server last, so fx stops it first: the readiness fails as soon as the shutdown begins, the
+// in-flight requests complete while the jobs and repositories they use are still running, then the jobs are drained
+// and the logger is flushed at the end.
 func bootstrap(
 	lifecycle fx.Lifecycle,
 	logger *zap.SugaredLogger,
 	server *server.Server,
+	health *services.HealthService,
 ) {
 	lifecycle.Append(
 		fx.Hook{
 			OnStart: func(ctx context.Context) error {
 				logger.Info("Starting API")
-				_ = server.Run()
-
-				return nil
+				return server.Start()
 			},
 			OnStop: func(ctx context.Context) error {
-				return logger.Sync()
+				health.ShutdownStarted()
+				return server.Stop(ctx)
 			},
 		},
 	)
 }
 
+// StopTimeout time fx gives the application to stop, the server shutdown timeout plus the time to drain the jobs
+func StopTimeout(cfg *domain.Configuration) time.Duration {
+	if cfg == nil {
+		return fx.DefaultTimeout
+	}
+	return cfg.ShutdownTimeout + drainTimeout
+}
+
 var Module = fx.Options(
 	config.Module,
-	fx.Provide(func() *chi.Mux {
+	// Appended first, the logger is flushed after every other stop hook. Syncing a terminal fails, so it is ignored
+	fx.Module("logger", fx.Invoke(func(lifecycle fx.Lifecycle, logger *zap.SugaredLogger) {
+		lifecycle.Append(fx.Hook{
+			OnStop: func(ctx context.Context) error {
+				_ = logger.Sync()
+				return nil
+			},
+		})
+	})),
+	fx.Provide(func(cfg *domain.Configuration, recorder metricsPorts.IMetricsRecorder, apiKeyService *services.APIKeyService, tokenService *services.TokenService, limiter ratelimitPorts.IRateLimiter, render *render.Render, logger *zap.SugaredLogger) (*chi.Mux, error) {
+		policies, err := domain.ParseRateLimitPolicies(cfg.RateLimitDefault, cfg.RateLimitRoutes)
+		if err != nil {
+			return nil, err
+		}
+		var ipLimit domain.RateLimit
+		if cfg.RateLimitIP != "" {
+			if ipLimit, err = domain.ParseRateLimit(cfg.RateLimitIP); err != nil {
+				return nil, err
+			}
+		}
 		var r = chi.NewRouter()
+		r.Use(handlers.MetricsMiddleware(recorder))
+		r.Use(handlers.TracingMiddleware())
 		r.Use(middleware.Timeout(60 * time.Second))
 		r.Use(middleware.RequestID)
+		r.Use(handlers.RemoteIPMiddleware())
 		r.Use(middleware.RealIP)
+		r.Use(handlers.ClientIPMiddleware())
 		r.Use(middleware.Recoverer)
-		r.Use(middleware.Logger)
+		r.Use(handlers.LoggingMiddleware(logger))
+		if cfg.RateLimitEnabled && ipLimit.Requests > 0 {
+			r.Use(handlers.IPRateLimitMiddleware(ipLimit, limiter, render, logger))
+		}
+		if cfg.AuthEnabled {
+			r.Use(handlers.AuthMiddleware(apiKeyService, tokenService, render, logger))
+		}
+		if cfg.RateLimitEnabled {
+			r.Use(handlers.RateLimitMiddleware(r, policies, limiter, render, logger))
+		}
 		r.Use(middleware.Compress(5))
-		return r
+		return r, nil
 	}),
 	fx.Provide(func() *render.Render {
 		return render.New()
 	}),
 	server.Module,
+	telemetry.Module,
 	repository.Module,
+	ratelimit.Module,
+	metrics.Module,
+	events.Module,
 	services.Module,
 	handlers.Module,
+	jobs.Module,
 	fx.Invoke(bootstrap),
 )
@@ -2,10 +2,11 @@ package main
 
 import (
 	"github.com/kiramishima/receipt-processor/bootstrap"
+	"github.com/kiramishima/receipt-processor/config"
 
 	"go.uber.org/fx"
 )
 
 func main() {
-	fx.New(bootstrap.Module).Run()
+	fx.New(bootstrap.Module, fx.StopTimeout(bootstrap.StopTimeout(config.NewConfig()))).Run()
 }
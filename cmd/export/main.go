@@ -0,0 +1,83 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+
+	"github.com/kiramishima/receipt-processor/domain"
+)
+
+// Exports the stored receipts of a running API to a local file. The receipts are kept by the API, so the export is
+// streamed from the /receipts/export endpoint straight to the file.
+func main() {
+	var (
+		serverURL  = flag.String("url", "http://localhost:8080", "base URL of the API storing the receipts")
+		format     = flag.String("format", domain.ExportFormatCSV, "export format, csv or ndjson")
+		output     = flag.String("o", "", "output file, receipts.<format> by default and - for the standard output")
+		member     = flag.String("member", "", "only the receipts of the member")
+		retailer   = flag.String("retailer", "", "only the receipts of the catalog retailer")
+		experiment = flag.String("experiment", "", "only the receipts assigned to the experiment")
+		from       = flag.String("from", "", "only the receipts stored since the date, like 2022-01-31 or 2022-01-31T10:00:00Z")
+		to         = flag.String("to", "", "only the receipts stored before the date")
+		apiKey     = flag.String("key", os.Getenv("RECEIPT_API_KEY"), "API key with the receipts:read scope, $RECEIPT_API_KEY by default")
+	)
+	flag.Parse()
+	if flag.NArg() != 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	var query = url.Values{"format": {*format}}
+	for name, value := range map[string]string{"memberId": *member, "retailerId": *retailer, "experimentId": *experiment, "from": *from, "to": *to} {
+		if value != "" {
+			query.Set(name, value)
+		}
+	}
+	req, err := http.NewRequest(http.MethodGet, *serverURL+"/receipts/export?"+query.Encode(), nil)
+	if err != nil {
+		exit(err)
+	}
+	if *apiKey != "" {
+		req.Header.Set("X-API-Key", *apiKey)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		exit(err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		var body map[string]string
+		_ = json.NewDecoder(resp.Body).Decode(&body)
+		exit(fmt.Errorf("%s: %s", resp.Status, body["error"]))
+	}
+
+	var out io.Writer = os.Stdout
+	if *output != "-" {
+		if *output == "" {
+			*output = "receipts." + *format
+		}
+		file, err := os.Create(*output)
+		if err != nil {
+			exit(err)
+		}
+		defer file.Close()
+		out = file
+	}
+	n, err := io.Copy(out, resp.Body)
+	if err != nil {
+		exit(err)
+	}
+	if *output != "-" {
+		fmt.Fprintf(os.Stderr, "%d bytes written to %s\n", n, *output)
+	}
+}
+
+func exit(err error) {
+	fmt.Fprintln(os.Stderr, err)
+	os.Exit(1)
+}
@@ -0,0 +1,139 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+
+	repository "github.com/kiramishima/receipt-processor/adapter/db/in-memory"
+	events "github.com/kiramishima/receipt-processor/adapter/events/in-memory"
+	"github.com/kiramishima/receipt-processor/adapter/metrics"
+	"github.com/kiramishima/receipt-processor/config"
+	"github.com/kiramishima/receipt-processor/domain"
+	"github.com/kiramishima/receipt-processor/services"
+	"go.uber.org/fx"
+)
+
+// Imports a CSV file of receipts. With -url the file is posted to the /receipts/import endpoint of a running API,
+// otherwise the receipts are validated and scored locally with the importer of the API without storing them.
+func main() {
+	var (
+		serverURL = flag.String("url", "", "base URL of the API storing the receipts, like http://localhost:8080")
+		columns   = flag.String("columns", "", "column mapping like receipt:Order ID,total:Amount")
+		delimiter = flag.String("delimiter", ",", "field delimiter, one character or tab")
+		dryRun    = flag.Bool("dry-run", false, "score the receipts without storing them, always true without -url")
+		format    = flag.String("format", "json", "report format, json or csv")
+		apiKey    = flag.String("key", os.Getenv("RECEIPT_API_KEY"), "API key with the receipts:write scope, $RECEIPT_API_KEY by default")
+	)
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "Usage: %s [flags] file.csv\n", os.Args[0])
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	if flag.NArg() != 1 || (*format != "json" && *format != "csv") {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	file, err := os.Open(flag.Arg(0))
+	if err != nil {
+		exit(err)
+	}
+	defer file.Close()
+
+	var report *domain.ImportReport
+	if *serverURL != "" {
+		report, err = post(*serverURL, *apiKey, file, *columns, *delimiter, *dryRun)
+	} else {
+		report, err = importLocally(file, *columns, *delimiter)
+	}
+	if err != nil {
+		exit(err)
+	}
+
+	if *format == "csv" {
+		err = report.WriteCSV(os.Stdout)
+	} else {
+		var encoder = json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		err = encoder.Encode(report)
+	}
+	if err != nil {
+		exit(err)
+	}
+	if report.Failed > 0 {
+		os.Exit(1)
+	}
+}
+
+// importLocally scores the receipts with the services of the API
+func importLocally(file io.Reader, columns string, delimiter string) (*domain.ImportReport, error) {
+	mapping, err := domain.ParseImportColumns(columns)
+	if err != nil {
+		return nil, err
+	}
+	comma, err := domain.ParseImportDelimiter(delimiter)
+	if err != nil {
+		return nil, err
+	}
+
+	var report *domain.ImportReport
+	var app = fx.New(
+		fx.NopLogger,
+		config.Module,
+		repository.Module,
+		metrics.Module,
+		events.Module,
+		services.Module,
+		fx.Invoke(func(svc *services.ImportService) error {
+			report, err = svc.ImportReceipts(context.Background(), file, &domain.ImportOptions{Columns: mapping, Delimiter: comma, DryRun: true})
+			return err
+		}),
+	)
+	if err := app.Err(); err != nil {
+		return nil, err
+	}
+	return report, nil
+}
+
+// post imports the file with the API
+func post(serverURL string, apiKey string, file io.Reader, columns string, delimiter string, dryRun bool) (*domain.ImportReport, error) {
+	var query = url.Values{"columns": {columns}, "delimiter": {delimiter}}
+	if dryRun {
+		query.Set("dryRun", "true")
+	}
+	req, err := http.NewRequest(http.MethodPost, serverURL+"/receipts/import?"+query.Encode(), file)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "text/csv")
+	if apiKey != "" {
+		req.Header.Set("X-API-Key", apiKey)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		var body map[string]string
+		_ = json.NewDecoder(resp.Body).Decode(&body)
+		return nil, fmt.Errorf("%s: %s", resp.Status, body["error"])
+	}
+	var report = &domain.ImportReport{}
+	if err := json.NewDecoder(resp.Body).Decode(report); err != nil {
+		return nil, err
+	}
+	return report, nil
+}
+
+func exit(err error) {
+	fmt.Fprintln(os.Stderr, err)
+	os.Exit(1)
+}
@@ -1,13 +1,60 @@
 package config
 
 import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/kiramishima/receipt-processor/domain"
 	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
 )
 
-// NewLogger Creates new logger instance
-func NewLogger() *zap.SugaredLogger {
-	logger, _ := zap.NewProduction()
-	slogger := logger.Sugar()
+// NewLogger Creates new logger instance with the level and encoding of the configuration. The debug logs are sampled,
+// so the logs of every rule of every receipt don't flood the output, the info and higher logs are always written.
+func NewLogger(cfg *domain.Configuration) (*zap.SugaredLogger, error) {
+	var logging = domain.LoggingConfig{LogLevel: "info", LogEncoding: domain.LogEncodingJSON}
+	if cfg != nil {
+		logging = cfg.LoggingConfig
+	}
+	core, err := newLoggerCore(logging, zapcore.Lock(os.Stderr))
+	if err != nil {
+		return nil, err
+	}
+	var logger = zap.New(core, zap.AddCaller(), zap.AddStacktrace(zap.ErrorLevel), zap.ErrorOutput(zapcore.Lock(os.Stderr)))
+	return logger.Sugar(), nil
+}
+
+// newLoggerCore writes the logs of the configured level and encoding to out, the debug logs through a sampler
+func newLoggerCore(cfg domain.LoggingConfig, out zapcore.WriteSyncer) (zapcore.Core, error) {
+	level, err := zapcore.ParseLevel(cfg.LogLevel)
+	if err != nil {
+		return nil, err
+	}
+
+	var encoder zapcore.Encoder
+	switch cfg.LogEncoding {
+	case domain.LogEncodingJSON:
+		encoder = zapcore.NewJSONEncoder(zap.NewProductionEncoderConfig())
+	case domain.LogEncodingConsole:
+		var encoderConfig = zap.NewDevelopmentEncoderConfig()
+		encoderConfig.EncodeLevel = zapcore.CapitalLevelEncoder
+		encoder = zapcore.NewConsoleEncoder(encoderConfig)
+	default:
+		return nil, fmt.Errorf("unknown log encoding %q", cfg.LogEncoding)
+	}
 
-	return slogger
+	var core = zapcore.NewCore(encoder, out, zap.LevelEnablerFunc(func(l zapcore.Level) bool {
+		return l >= level && l >= zapcore.InfoLevel
+	}))
+	if level >= zapcore.InfoLevel {
+		return core, nil
+	}
+	var debug = zapcore.NewCore(encoder, out, zap.LevelEnablerFunc(func(l zapcore.Level) bool {
+		return l >= level && l < zapcore.InfoLevel
+	}))
+	if cfg.LogDebugSampleInitial > 0 {
+		debug = zapcore.NewSamplerWithOptions(debug, time.Second, cfg.LogDebugSampleInitial, cfg.LogDebugSampleThereafter)
+	}
+	return zapcore.NewTee(core, debug), nil
 }
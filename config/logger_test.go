@@ -0,0 +1,78 @@
+package config
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/kiramishima/receipt-processor/domain"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+func TestNewLoggerCore(t *testing.T) {
+	var out bytes.Buffer
+	core, err := newLoggerCore(domain.LoggingConfig{
+		LogLevel:                 "debug",
+		LogEncoding:              domain.LogEncodingJSON,
+		LogDebugSampleInitial:    2,
+		LogDebugSampleThereafter: 5,
+	}, zapcore.AddSync(&out))
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	var logger = zap.New(core).Sugar()
+	for i := 0; i < 10; i++ {
+		logger.Debugw("rule evaluated", "i", i)
+		logger.Infow("receipt stored", "i", i)
+	}
+
+	var lines = strings.Split(strings.TrimSpace(out.String()), "\n")
+	var debug, info = 0, 0
+	for _, line := range lines {
+		switch {
+		case strings.Contains(line, `"level":"debug"`):
+			debug++
+		case strings.Contains(line, `"level":"info"`):
+			info++
+		}
+	}
+	// The first 2 debug logs and one of every 5 after them
+	assert.Equal(t, 3, debug)
+	assert.Equal(t, 10, info)
+}
+
+func TestNewLoggerCore_Config(t *testing.T) {
+	testCases := map[string]struct {
+		cfg     domain.LoggingConfig
+		debug   bool
+		console bool
+		error   string
+	}{
+		"Info":             {cfg: domain.LoggingConfig{LogLevel: "info", LogEncoding: domain.LogEncodingJSON}},
+		"Debug console":    {cfg: domain.LoggingConfig{LogLevel: "DEBUG", LogEncoding: domain.LogEncodingConsole}, debug: true, console: true},
+		"Unknown level":    {cfg: domain.LoggingConfig{LogLevel: "verbose", LogEncoding: domain.LogEncodingJSON}, error: `unrecognized level: "verbose"`},
+		"Unknown encoding": {cfg: domain.LoggingConfig{LogLevel: "info", LogEncoding: "xml"}, error: `unknown log encoding "xml"`},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			var out bytes.Buffer
+			core, err := newLoggerCore(tc.cfg, zapcore.AddSync(&out))
+			if tc.error != "" {
+				assert.EqualError(t, err, tc.error)
+				return
+			}
+			if !assert.NoError(t, err) {
+				return
+			}
+			assert.Equal(t, tc.debug, core.Enabled(zapcore.DebugLevel))
+			assert.True(t, core.Enabled(zapcore.InfoLevel))
+
+			zap.New(core).Info("receipt stored")
+			assert.Equal(t, tc.console, !strings.HasPrefix(out.String(), "{"))
+		})
+	}
+}
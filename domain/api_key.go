@@ -0,0 +1,69 @@
+package domain
+
+import (
+	"github.com/samber/lo"
+	"time"
+)
+
+// Scopes of the API keys
+const (
+	// ScopeReceiptsWrite submits receipts to be stored, scored, parsed or imported
+	ScopeReceiptsWrite = "receipts:write"
+	// ScopeReceiptsRead reads the points of the receipts and exports them
+	ScopeReceiptsRead = "receipts:read"
+	// ScopeAdmin manages the rules, campaigns, retailers, experiments and API keys, it grants every other scope
+	ScopeAdmin = "admin"
+)
+
+// APIKeyPrefix starts every API key, to tell them from the JWTs and to recognize them in the leaked secrets scanners
+const APIKeyPrefix = "rp_"
+
+// APIKey key of a client of the API. Only the hash of the key is stored, the key itself is shown once when it is
+// issued.
+type APIKey struct {
+	ID string `json:"id"`
+	// Name of the client
+	Name string `json:"name"`
+	// Prefix first characters of the key, to recognize it
+	Prefix     string     `json:"prefix"`
+	Hash       string     `json:"-"`
+	Scopes     []string   `json:"scopes"`
+	CreatedAt  time.Time  `json:"createdAt"`
+	ExpiresAt  *time.Time `json:"expiresAt,omitempty"`
+	LastUsedAt *time.Time `json:"lastUsedAt,omitempty"`
+	RevokedAt  *time.Time `json:"revokedAt,omitempty"`
+	// RotatedTo id of the key that replaced it, the key works until it expires
+	RotatedTo string `json:"rotatedTo,omitempty"`
+	// DailyReceiptQuota receipts the client can store per day, the default quota when 0
+	DailyReceiptQuota int `json:"dailyReceiptQuota,omitempty"`
+}
+
+// IsActive returns true if the key is not revoked nor expired at t
+func (k *APIKey) IsActive(t time.Time) bool {
+	return k.RevokedAt == nil && (k.ExpiresAt == nil || t.Before(*k.ExpiresAt))
+}
+
+// HasScope returns true if the key has the scope or the admin scope
+func (k *APIKey) HasScope(scope string) bool {
+	return lo.Contains(k.Scopes, scope) || lo.Contains(k.Scopes, ScopeAdmin)
+}
+
+// Principal returns the principal of the requests authenticated with the key
+func (k *APIKey) Principal() *Principal {
+	return &Principal{Subject: k.ID, Scopes: k.Scopes, APIKeyID: k.ID, DailyReceiptQuota: k.DailyReceiptQuota}
+}
+
+// APIKeyRequest client and scopes of a new key
+type APIKeyRequest struct {
+	Name      string     `json:"name" validate:"required"`
+	Scopes    []string   `json:"scopes" validate:"required,min=1,dive,oneof=receipts:write receipts:read admin"`
+	ExpiresAt *time.Time `json:"expiresAt,omitempty"`
+	// DailyReceiptQuota receipts the client can store per day, the default quota when 0
+	DailyReceiptQuota int `json:"dailyReceiptQuota,omitempty" validate:"gte=0"`
+}
+
+// IssuedAPIKey key issued or rotated, with the key the client authenticates with
+type IssuedAPIKey struct {
+	*APIKey
+	Key string `json:"key"`
+}
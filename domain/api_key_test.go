@@ -0,0 +1,40 @@
+package domain
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestAPIKey_IsActive(t *testing.T) {
+	var now = time.Date(2022, 3, 20, 14, 33, 0, 0, time.UTC)
+	var past = now.Add(-time.Hour)
+	var future = now.Add(time.Hour)
+
+	var testCases = map[string]struct {
+		key    *APIKey
+		active bool
+	}{
+		"Without expiration": {key: &APIKey{}, active: true},
+		"Not expired":        {key: &APIKey{ExpiresAt: &future}, active: true},
+		"Expired":            {key: &APIKey{ExpiresAt: &past}, active: false},
+		"Revoked":            {key: &APIKey{ExpiresAt: &future, RevokedAt: &past}, active: false},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.active, tc.key.IsActive(now))
+		})
+	}
+}
+
+func TestAPIKey_HasScope(t *testing.T) {
+	var writer = &APIKey{Scopes: []string{ScopeReceiptsWrite}}
+	assert.True(t, writer.HasScope(ScopeReceiptsWrite))
+	assert.False(t, writer.HasScope(ScopeReceiptsRead))
+	assert.False(t, writer.HasScope(ScopeAdmin))
+
+	var admin = &APIKey{Scopes: []string{ScopeAdmin}}
+	assert.True(t, admin.HasScope(ScopeReceiptsRead))
+	assert.True(t, admin.HasScope(ScopeReceiptsWrite))
+}
@@ -0,0 +1,29 @@
+package domain
+
+import "time"
+
+type AuthConfig struct {
+	// AuthEnabled requires an API key with the scope of the route in every request, but the health probes and the
+	// metrics
+	AuthEnabled bool `envconfig:"AUTH_ENABLED" default:"true"`
+	// AuthBootstrapKey key with the admin scope registered at startup to issue the first keys, at least 32
+	// characters. Only its hash is kept
+	AuthBootstrapKey string `envconfig:"AUTH_BOOTSTRAP_KEY"`
+	// APIKeyRotationGrace time the previous key keeps working after a rotation, so the clients can switch
+	APIKeyRotationGrace time.Duration `envconfig:"API_KEY_ROTATION_GRACE" default:"24h"`
+	// JWTJWKSPath JSON Web Key Set file, or directory of .json JWKS files, with the keys verifying the bearer JWTs of
+	// the members. The JWTs are rejected when empty
+	JWTJWKSPath string `envconfig:"JWT_JWKS_PATH"`
+	// JWTIssuer iss the JWTs must have, any when empty
+	JWTIssuer string `envconfig:"JWT_ISSUER"`
+	// JWTAudience the JWTs must have in their aud, any when empty
+	JWTAudience string `envconfig:"JWT_AUDIENCE"`
+	// JWTClockSkew tolerance of the exp, nbf and iat claims to the clock differences with the issuer
+	JWTClockSkew time.Duration `envconfig:"JWT_CLOCK_SKEW" default:"1m"`
+	// JWTMemberClaim claim with the id of the member, the receipts of the token are attributed to it
+	JWTMemberClaim string `envconfig:"JWT_MEMBER_CLAIM" default:"sub"`
+	// JWTRolesClaim claim with the roles of the principal, a string or an array of strings
+	JWTRolesClaim string `envconfig:"JWT_ROLES_CLAIM" default:"roles"`
+	// JWTDefaultRole role of the tokens without roles
+	JWTDefaultRole string `envconfig:"JWT_DEFAULT_ROLE" default:"member"`
+}
@@ -0,0 +1,85 @@
+package domain
+
+import "math"
+
+// RulePoints points awarded by a single rule
+type RulePoints struct {
+	Rule   string `json:"rule"`
+	Points int    `json:"points"`
+	Reason string `json:"reason,omitempty"`
+}
+
+// CampaignPoints extra points awarded by a campaign
+type CampaignPoints struct {
+	CampaignID string `json:"campaignId"`
+	Name       string `json:"name"`
+	Points     int    `json:"points"`
+}
+
+const (
+	CapReceiptMax     = "receiptMax"
+	CapReceiptMin     = "receiptMin"
+	CapMemberDailyCap = "memberDailyCap"
+)
+
+// AppliedCap limit that changed the points of the receipt
+type AppliedCap struct {
+	Cap          string `json:"cap"`
+	Limit        int    `json:"limit"`
+	PointsBefore int    `json:"pointsBefore"`
+}
+
+// Breakdown detail of how the points of a receipt were computed
+type Breakdown struct {
+	RuleSetVersion string            `json:"ruleSetVersion,omitempty"`
+	Rules          []*RulePoints     `json:"rules"`
+	BasePoints     int               `json:"basePoints"`
+	Campaigns      []*CampaignPoints `json:"campaigns,omitempty"`
+	Tier           Tier              `json:"tier,omitempty"`
+	TierMultiplier float64           `json:"tierMultiplier,omitempty"`
+	Caps           []*AppliedCap     `json:"caps,omitempty"`
+	TotalPoints    int               `json:"totalPoints"`
+}
+
+// ApplyCampaign adds the extra points of the campaign computed over the base points
+func (b *Breakdown) ApplyCampaign(c *Campaign) {
+	var points = c.Points(b.BasePoints)
+	b.Campaigns = append(b.Campaigns, &CampaignPoints{CampaignID: c.ID, Name: c.Name, Points: points})
+	b.TotalPoints = AddPoints(b.TotalPoints, points)
+}
+
+// ApplyTierMultiplier multiplies the collected points by the tier multiplier, rounding to the nearest integer
+func (b *Breakdown) ApplyTierMultiplier(tier Tier, multiplier float64) {
+	b.Tier = tier
+	b.TierMultiplier = multiplier
+	b.TotalPoints = PointsFromFloat(math.Round(float64(b.TotalPoints) * multiplier))
+}
+
+// ApplyReceiptLimits keeps the points between the minimum and maximum per receipt, a 0 limit is not applied
+func (b *Breakdown) ApplyReceiptLimits(minPoints, maxPoints int) {
+	if maxPoints > 0 && b.TotalPoints > maxPoints {
+		b.applyCap(CapReceiptMax, maxPoints)
+	}
+	if minPoints > 0 && b.TotalPoints < minPoints {
+		b.applyCap(CapReceiptMin, minPoints)
+	}
+}
+
+// ApplyDailyCap limits the points to what the member can still earn today, a 0 cap is not applied
+func (b *Breakdown) ApplyDailyCap(dailyCap, earnedToday int) {
+	if dailyCap <= 0 {
+		return
+	}
+	var remaining = AddPoints(dailyCap, -earnedToday)
+	if remaining < 0 {
+		remaining = 0
+	}
+	if b.TotalPoints > remaining {
+		b.applyCap(CapMemberDailyCap, remaining)
+	}
+}
+
+func (b *Breakdown) applyCap(name string, limit int) {
+	b.Caps = append(b.Caps, &AppliedCap{Cap: name, Limit: limit, PointsBefore: b.TotalPoints})
+	b.TotalPoints = limit
+}
@@ -0,0 +1,58 @@
+package domain
+
+import (
+	"math"
+	"strings"
+	"time"
+)
+
+// Campaign time-boxed promotion evaluated after the base rules
+type Campaign struct {
+	ID              string    `json:"id"`
+	Name            string    `json:"name" validate:"required"`
+	StartsAt        time.Time `json:"startsAt" validate:"required"`
+	EndsAt          time.Time `json:"endsAt" validate:"required,gtfield=StartsAt"`
+	RetailerMatcher string    `json:"retailerMatcher,omitempty"`
+	ItemMatcher     string    `json:"itemMatcher,omitempty"`
+	Multiplier      float64   `json:"multiplier,omitempty" validate:"omitempty,gt=0"`
+	Bonus           int       `json:"bonus,omitempty" validate:"omitempty,gt=0"`
+}
+
+// IsActive returns true if t is between the start (inclusive) and the end (exclusive) of the campaign
+func (c *Campaign) IsActive(t time.Time) bool {
+	return !t.Before(c.StartsAt) && t.Before(c.EndsAt)
+}
+
+// Matches returns true if the receipt was purchased during the campaign and satisfies the retailer and item matchers.
+// The matchers are case-insensitive and an empty matcher matches any receipt. Discount and tax lines don't match the
+// item matcher.
+func (c *Campaign) Matches(r *Receipt) bool {
+	if !c.IsActive(r.PurchaseDT) {
+		return false
+	}
+	if c.RetailerMatcher != "" && !containsFold(r.Retailer, c.RetailerMatcher) {
+		return false
+	}
+	if c.ItemMatcher == "" {
+		return true
+	}
+	for _, item := range r.Items {
+		if item.IsItem() && containsFold(item.ShortDescription, c.ItemMatcher) {
+			return true
+		}
+	}
+	return false
+}
+
+// Points returns the extra points awarded by the campaign over the base points
+func (c *Campaign) Points(basePoints int) int {
+	var points = c.Bonus
+	if c.Multiplier > 0 {
+		points = AddPoints(points, PointsFromFloat(math.Round(float64(basePoints)*(c.Multiplier-1))))
+	}
+	return points
+}
+
+func containsFold(s, substr string) bool {
+	return strings.Contains(strings.ToLower(s), strings.ToLower(strings.TrimSpace(substr)))
+}
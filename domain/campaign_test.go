@@ -0,0 +1,43 @@
+package domain
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestCampaign_Matches(t *testing.T) {
+	var receipt = &Receipt{
+		Retailer:   "M&M Corner Market",
+		PurchaseDT: time.Date(2022, 3, 20, 14, 33, 0, 0, time.UTC),
+		Items: []*ReceiptItem{
+			{ShortDescription: "Gatorade", Price: 2.25},
+		},
+	}
+	var starts = time.Date(2022, 3, 19, 0, 0, 0, 0, time.UTC)
+	var ends = time.Date(2022, 3, 21, 0, 0, 0, 0, time.UTC)
+
+	var testCases = map[string]struct {
+		campaign *Campaign
+		matches  bool
+	}{
+		"No matchers":       {campaign: &Campaign{StartsAt: starts, EndsAt: ends}, matches: true},
+		"Retailer matches":  {campaign: &Campaign{StartsAt: starts, EndsAt: ends, RetailerMatcher: "corner market"}, matches: true},
+		"Retailer differs":  {campaign: &Campaign{StartsAt: starts, EndsAt: ends, RetailerMatcher: "Target"}, matches: false},
+		"Item matches":      {campaign: &Campaign{StartsAt: starts, EndsAt: ends, ItemMatcher: "GATORADE"}, matches: true},
+		"Item differs":      {campaign: &Campaign{StartsAt: starts, EndsAt: ends, ItemMatcher: "Pepsi"}, matches: false},
+		"Campaign finished": {campaign: &Campaign{StartsAt: starts.AddDate(0, 0, -7), EndsAt: starts}, matches: false},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.matches, tc.campaign.Matches(receipt))
+		})
+	}
+}
+
+func TestCampaign_Points(t *testing.T) {
+	assert.Equal(t, 100, (&Campaign{Bonus: 100}).Points(28))
+	assert.Equal(t, 28, (&Campaign{Multiplier: 2}).Points(28))
+	assert.Equal(t, 114, (&Campaign{Multiplier: 1.5, Bonus: 100}).Points(28))
+}
@@ -0,0 +1,7 @@
+package domain
+
+type CatalogConfig struct {
+	RetailerCatalogFile    string            `envconfig:"RETAILER_CATALOG_FILE"`
+	RetailerMatchThreshold float64           `envconfig:"RETAILER_MATCH_THRESHOLD" default:"0.85"`
+	RetailerAbbreviations  map[string]string `envconfig:"RETAILER_ABBREVIATIONS"`
+}
@@ -2,4 +2,17 @@ package domain
 
 type Configuration struct {
 	HTTPServer
+	TierConfig
+	CatalogConfig
+	PointsConfig
+	RulesConfig
+	CurrencyConfig
+	ParserConfig
+	EmailConfig
+	ImportConfig
+	HealthConfig
+	TracingConfig
+	LoggingConfig
+	AuthConfig
+	RateLimitConfig
 }
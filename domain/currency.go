@@ -0,0 +1,84 @@
+package domain
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+const (
+	RulesCurrencyReceipt = "receipt"
+	RulesCurrencyBase    = "base"
+)
+
+// currencyExponents ISO 4217 minor unit exponents different of 2
+var currencyExponents = map[string]int{
+	"BIF": 0, "CLP": 0, "DJF": 0, "GNF": 0, "ISK": 0, "JPY": 0, "KMF": 0, "KRW": 0, "PYG": 0, "RWF": 0, "UGX": 0,
+	"VND": 0, "VUV": 0, "XAF": 0, "XOF": 0, "XPF": 0,
+	"BHD": 3, "IQD": 3, "JOD": 3, "KWD": 3, "LYD": 3, "OMR": 3, "TND": 3,
+}
+
+// CurrencyExponent returns the number of decimals of the minor unit of the currency, 2 for most currencies
+func CurrencyExponent(currency string) int {
+	if exponent, ok := currencyExponents[currency]; ok {
+		return exponent
+	}
+	return 2
+}
+
+// ParseAmount parses an amount of the currency, amounts with more decimals than the minor unit of the currency are
+// rejected
+func ParseAmount(amount string, currency string) (float64, error) {
+	value, err := strconv.ParseFloat(amount, 64)
+	if err != nil || math.IsInf(value, 0) || math.IsNaN(value) {
+		return 0, fmt.Errorf("error parsing currency string: %s", amount)
+	}
+	if _, decimals, ok := strings.Cut(amount, "."); ok && len(decimals) > CurrencyExponent(currency) {
+		return 0, fmt.Errorf("error parsing currency string: %s has more than %d decimals for %s", amount, CurrencyExponent(currency), currency)
+	}
+	return value, nil
+}
+
+// RoundAmount rounds an amount to the minor unit of the currency
+func RoundAmount(amount float64, currency string) float64 {
+	var scale = math.Pow10(CurrencyExponent(currency))
+	return math.Round(amount*scale) / scale
+}
+
+// FormatAmount formats an amount with the decimals of the currency
+func FormatAmount(amount float64, currency string) string {
+	return fmt.Sprintf("%.*f", CurrencyExponent(currency), amount)
+}
+
+// ExchangeRates table of the units of the base currency worth one unit of every currency
+type ExchangeRates struct {
+	Base  string             `json:"base" validate:"required,iso4217"`
+	Rates map[string]float64 `json:"rates" validate:"dive,keys,iso4217,endkeys,gt=0"`
+}
+
+// Rate returns the units of the base currency worth one unit of the currency
+func (e *ExchangeRates) Rate(currency string) (float64, bool) {
+	if currency == e.Base {
+		return 1, true
+	}
+	rate, ok := e.Rates[currency]
+	return rate, ok
+}
+
+// InCurrency returns a copy of the receipt with the total and the item prices converted with the rate and rounded to
+// the minor unit of the currency
+func (r *Receipt) InCurrency(currency string, rate float64) *Receipt {
+	var converted = *r
+	converted.Currency = currency
+	converted.ExchangeRate = 1
+	converted.Total = float32(RoundAmount(float64(r.Total)*rate, currency))
+	converted.Items = make([]*ReceiptItem, 0, len(r.Items))
+	for _, item := range r.Items {
+		var copied = *item
+		copied.UnitPrice = float32(RoundAmount(float64(item.UnitPrice)*rate, currency))
+		copied.Price = float32(RoundAmount(float64(item.Price)*rate, currency))
+		converted.Items = append(converted.Items, &copied)
+	}
+	return &converted
+}
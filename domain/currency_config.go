@@ -0,0 +1,7 @@
+package domain
+
+type CurrencyConfig struct {
+	BaseCurrency      string `envconfig:"CURRENCY_BASE" default:"USD"`
+	ExchangeRatesFile string `envconfig:"CURRENCY_RATES_FILE"`
+	RulesCurrency     string `envconfig:"CURRENCY_RULES" default:"receipt"`
+}
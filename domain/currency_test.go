@@ -0,0 +1,79 @@
+package domain
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestParseAmount(t *testing.T) {
+	var valid = map[[2]string]float64{
+		{"35.35", "USD"}: 35.35,
+		{"100", "MXN"}:   100,
+		{"1200", "JPY"}:  1200,
+		{"1.250", "KWD"}: 1.25,
+		{"-0.50", "CAD"}: -0.5,
+	}
+	for input, expected := range valid {
+		amount, err := ParseAmount(input[0], input[1])
+		assert.NoError(t, err, input)
+		assert.Equal(t, expected, amount, input)
+	}
+
+	var invalid = map[[2]string]string{
+		{"12.5", "JPY"}:   "error parsing currency string: 12.5 has more than 0 decimals for JPY",
+		{"1.255", "USD"}:  "error parsing currency string: 1.255 has more than 2 decimals for USD",
+		{"1.2555", "KWD"}: "error parsing currency string: 1.2555 has more than 3 decimals for KWD",
+		{"12,50", "MXN"}:  "error parsing currency string: 12,50",
+		{"NaN", "USD"}:    "error parsing currency string: NaN",
+	}
+	for input, expected := range invalid {
+		_, err := ParseAmount(input[0], input[1])
+		assert.EqualError(t, err, expected, input)
+	}
+
+	assert.Equal(t, 1201.0, RoundAmount(1200.5, "JPY"))
+	assert.Equal(t, "1201", FormatAmount(1200.6, "JPY"))
+	assert.Equal(t, "5.43", FormatAmount(5.4321, "USD"))
+}
+
+func TestExchangeRates_Rate(t *testing.T) {
+	var rates = &ExchangeRates{Base: "USD", Rates: map[string]float64{"CAD": 0.74}}
+	rate, ok := rates.Rate("USD")
+	assert.True(t, ok)
+	assert.Equal(t, 1.0, rate)
+	rate, ok = rates.Rate("CAD")
+	assert.True(t, ok)
+	assert.Equal(t, 0.74, rate)
+	_, ok = rates.Rate("MXN")
+	assert.False(t, ok)
+}
+
+func TestReceipt_InCurrency(t *testing.T) {
+	var receipt = &Receipt{
+		Retailer:     "Oxxo",
+		PurchaseDT:   time.Date(2022, 1, 1, 13, 1, 0, 0, time.UTC),
+		Currency:     "MXN",
+		ExchangeRate: 0.05,
+		Total:        105,
+		Items: []*ReceiptItem{
+			{ShortDescription: "Coca-Cola 600ml", Quantity: 3, UnitPrice: 25, Price: 75},
+			{ShortDescription: "Sabritas", Price: 30},
+		},
+	}
+
+	var converted = receipt.InCurrency("USD", receipt.ExchangeRate)
+	assert.Equal(t, "USD", converted.Currency)
+	assert.Equal(t, 1.0, converted.ExchangeRate)
+	assert.Equal(t, float32(5.25), converted.Total)
+	assert.Equal(t, &ReceiptItem{ShortDescription: "Coca-Cola 600ml", Quantity: 3, UnitPrice: 1.25, Price: 3.75}, converted.Items[0])
+	assert.Equal(t, float32(1.5), converted.Items[1].Price)
+	// The receipt is not modified
+	assert.Equal(t, float32(105), receipt.Total)
+	assert.Equal(t, float32(75), receipt.Items[0].Price)
+
+	// 105 MXN is a round amount but 5.25 USD only a multiple of 0.25
+	assert.Equal(t, 50, receipt.GetPointsIfTotalRoundWithNoCents())
+	assert.Equal(t, 0, converted.GetPointsIfTotalRoundWithNoCents())
+	assert.Equal(t, 25, converted.GetPointsIfTotalIsMultipleOf25Cents())
+}
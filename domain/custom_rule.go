@@ -0,0 +1,164 @@
+package domain
+
+import (
+	"fmt"
+	"github.com/kiramishima/receipt-processor/pkg/expr"
+	"github.com/samber/lo"
+)
+
+var ruleItemType = expr.Object("item", map[string]*expr.Type{
+	"type":        expr.String,
+	"description": expr.String,
+	"quantity":    expr.Float,
+	"units":       expr.Int,
+	"unitPrice":   expr.Float,
+	"price":       expr.Float,
+	"sku":         expr.String,
+	"upc":         expr.String,
+	"category":    expr.String,
+})
+
+var rulePurchaseType = expr.Object("purchase", map[string]*expr.Type{
+	"year":    expr.Int,
+	"month":   expr.Int,
+	"day":     expr.Int,
+	"weekday": expr.Int,
+	"hour":    expr.Int,
+	"minute":  expr.Int,
+	"time":    expr.Int,
+})
+
+// RuleEnvironment variables of the receipt available to the custom rule expressions
+var RuleEnvironment = map[string]*expr.Type{
+	"retailer":     expr.String,
+	"retailerName": expr.String,
+	"retailerId":   expr.String,
+	"memberId":     expr.String,
+	"currency":     expr.String,
+	"total":        expr.Float,
+	"purchase":     rulePurchaseType,
+	"items":        expr.List(ruleItemType),
+}
+
+// CustomRule rule written in the rule expression language, the expression evaluates to the points awarded
+type CustomRule struct {
+	Name       string `json:"name" validate:"required"`
+	Expression string `json:"expression" validate:"required"`
+	Cap        int    `json:"cap,omitempty" validate:"gte=0"`
+	program    *expr.Program
+}
+
+// Compile type checks the expression, it must evaluate to int points
+func (c *CustomRule) Compile(opts ...expr.Option) error {
+	program, err := c.compile(opts...)
+	if err != nil {
+		return err
+	}
+	c.program = program
+	return nil
+}
+
+func (c *CustomRule) compile(opts ...expr.Option) (*expr.Program, error) {
+	program, err := expr.Compile(c.Expression, RuleEnvironment, opts...)
+	if err != nil {
+		return nil, err
+	}
+	if program.Type().Kind != expr.KindInt {
+		return nil, fmt.Errorf("expression must evaluate to int points, got %s", program.Type())
+	}
+	return program, nil
+}
+
+// Compile compiles every custom rule of the rule set, their names must be unique and different of the base rules
+func (rs *RuleSet) Compile(opts ...expr.Option) error {
+	var names = make(map[string]bool, len(rs.CustomRules))
+	for i := range rs.CustomRules {
+		var rule = &rs.CustomRules[i]
+		if lo.Contains(RuleNames, rule.Name) || names[rule.Name] {
+			return fmt.Errorf("custom rule %s: duplicated rule name", rule.Name)
+		}
+		names[rule.Name] = true
+		if err := rule.Compile(opts...); err != nil {
+			return fmt.Errorf("custom rule %s: %w", rule.Name, err)
+		}
+	}
+	return nil
+}
+
+// ExplainCustomRule returns the points of a custom rule and the reason. Rules that fail to compile or evaluate don't
+// award points.
+func (r *Receipt) ExplainCustomRule(rule *CustomRule) (int, string) {
+	// Rule sets are compiled when they are stored, the others are compiled with the default limits
+	var program = rule.program
+	if program == nil {
+		var err error
+		if program, err = rule.compile(); err != nil {
+			return 0, fmt.Sprintf("expression does not compile: %s", err)
+		}
+	}
+
+	value, err := program.Eval(r.ruleVariables())
+	if err != nil {
+		return 0, fmt.Sprintf("expression failed: %s", err)
+	}
+	var points = value.(int)
+	if points < 0 {
+		return 0, fmt.Sprintf("expression evaluated to %d, negative points are not awarded", points)
+	}
+
+	var reason = fmt.Sprintf("%s evaluated to %d", rule.Expression, points)
+	if rule.Cap > 0 && points > rule.Cap {
+		reason = fmt.Sprintf("%s, capped from %d to %d points", reason, points, rule.Cap)
+		points = rule.Cap
+	}
+	return points, reason
+}
+
+// ruleVariables returns the values of the RuleEnvironment for the receipt
+func (r *Receipt) ruleVariables() map[string]any {
+	var items = make([]any, 0, len(r.Items))
+	for _, item := range r.Items {
+		var lineType, quantity = item.Type, float64(item.Quantity)
+		if lineType == "" {
+			lineType = ItemLineItem
+		}
+		if quantity <= 0 {
+			quantity = 1
+		}
+		items = append(items, map[string]any{
+			"type":        lineType,
+			"description": item.ShortDescription,
+			"quantity":    quantity,
+			"units":       item.Units(),
+			"unitPrice":   float64(item.UnitPrice),
+			"price":       float64(item.Price),
+			"sku":         item.SKU,
+			"upc":         item.UPC,
+			"category":    item.Category,
+		})
+	}
+	var retailerName = r.Retailer
+	if r.RetailerName != "" {
+		retailerName = r.RetailerName
+	}
+	var dt = r.PurchaseDT
+
+	return map[string]any{
+		"retailer":     r.Retailer,
+		"retailerName": retailerName,
+		"retailerId":   r.RetailerID,
+		"memberId":     r.MemberID,
+		"currency":     r.Currency,
+		"total":        float64(r.Total),
+		"purchase": map[string]any{
+			"year":    dt.Year(),
+			"month":   int(dt.Month()),
+			"day":     dt.Day(),
+			"weekday": int(dt.Weekday()),
+			"hour":    dt.Hour(),
+			"minute":  dt.Minute(),
+			"time":    dt.Hour()*3600 + dt.Minute()*60 + dt.Second(),
+		},
+		"items": items,
+	}
+}
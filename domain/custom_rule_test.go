@@ -0,0 +1,139 @@
+package domain
+
+import (
+	"github.com/stretchr/testify/assert"
+	"math/rand"
+	"strings"
+	"testing"
+	"time"
+)
+
+// baseRuleExpressions the base rules of the default rule set written in the rule expression language
+var baseRuleExpressions = map[string]string{
+	RuleRetailerName:      `len(alnum(retailerName))`,
+	RuleRoundDollar:       `ceil(total) == total ? 50 : 0`,
+	RuleMultipleOf25Cents: `total % 0.25 == 0 ? 25 : 0`,
+	RuleEveryTwoItems:     `items.sum(i, i.units) / 2 * 5`,
+	RuleItemDescription:   `items.sum(i, i.type == "item" && len(trim(i.description)) % 3 == 0 ? ceil(i.price * 0.2) : 0)`,
+	RuleOddDay:            `purchase.day % 2 == 1 ? 6 : 0`,
+	RuleAfternoonPurchase: `purchase.time > clock("14:00") && purchase.time < clock("16:00") ? 10 : 0`,
+}
+
+func TestCustomRule_BaseRules(t *testing.T) {
+	var receipts = make([]*Receipt, 0)
+	for _, receipt := range testCases {
+		var receipt = receipt
+		receipts = append(receipts, &receipt)
+	}
+	// Random receipts with the cents, item descriptions and times the base rules are sensitive to
+	var random = rand.New(rand.NewSource(1))
+	for i := 0; i < 500; i++ {
+		var receipt = &Receipt{
+			Retailer:   strings.Repeat("M&M ", random.Intn(4)) + "Corner Market",
+			PurchaseDT: time.Date(2022, 3, 1+random.Intn(28), 12+random.Intn(5), random.Intn(60), 0, 0, time.UTC),
+		}
+		var cents = 0
+		for j := random.Intn(8); j > 0; j-- {
+			var price = random.Intn(3000)
+			if random.Intn(3) == 0 {
+				price = price / 100 * 100
+			}
+			var item = &ReceiptItem{
+				ShortDescription: strings.Repeat(" ", random.Intn(3)) + strings.Repeat("x", 1+random.Intn(20)),
+				Price:            float32(price) / 100,
+			}
+			switch random.Intn(6) {
+			case 0:
+				item.Quantity = float32(1 + random.Intn(4))
+			case 1:
+				item.Quantity = 0.5
+			case 2:
+				item.Type = ItemLineDiscount
+				item.Price = -item.Price
+				price = -price
+			}
+			cents += price
+			receipt.Items = append(receipt.Items, item)
+		}
+		receipt.Total = float32(cents) / 100
+		receipts = append(receipts, receipt)
+	}
+
+	var rs = DefaultRuleSet()
+	for _, name := range RuleNames {
+		var rule = &CustomRule{Name: name, Expression: baseRuleExpressions[name]}
+		assert.NoError(t, rule.Compile(), name)
+
+		for _, receipt := range receipts {
+			points, reason := receipt.ExplainCustomRule(rule)
+			assert.Equal(t, receipt.EvaluateRule(name, rs), points, "%s: %s %+v", name, reason, receipt)
+		}
+	}
+}
+
+func TestRuleSet_Compile(t *testing.T) {
+	var rs = &RuleSet{Version: "v2", CustomRules: []CustomRule{
+		{Name: "bigBasket", Expression: `retailer.matches("Target") && items.count >= 3 ? 15 : 0`},
+	}}
+	assert.NoError(t, rs.Compile())
+
+	var testCases = map[string]CustomRule{
+		"custom rule oddDay: duplicated rule name":                               {Name: RuleOddDay, Expression: `1`},
+		"custom rule bigBasket: duplicated rule name":                            {Name: "bigBasket", Expression: `1`},
+		"custom rule weekend: position 1: undefined variable weekend":            {Name: "weekend", Expression: `weekend ? 5 : 0`},
+		"custom rule average: expression must evaluate to int points, got float": {Name: "average", Expression: `total / 2.0`},
+		"custom rule named: expression must evaluate to int points, got string":  {Name: "named", Expression: `retailer`},
+	}
+	for expected, rule := range testCases {
+		var invalid = rs.WithOverrides(nil)
+		invalid.CustomRules = append(invalid.CustomRules, rule)
+		assert.EqualError(t, invalid.Compile(), expected)
+	}
+}
+
+func TestReceipt_GetBreakdownWithCustomRules(t *testing.T) {
+	var receipt = &Receipt{
+		Retailer:   "Target",
+		PurchaseDT: time.Date(2022, 1, 1, 13, 1, 0, 0, time.UTC),
+		Total:      35.35,
+		Items: []*ReceiptItem{
+			{ShortDescription: "Mountain Dew 12PK", Price: 6.49},
+			{ShortDescription: "Emils Cheese Pizza", Price: 12.25},
+			{ShortDescription: "Knorr Creamy Chicken", Price: 1.26},
+		},
+	}
+	var rs = DefaultRuleSet()
+	rs.CustomRules = []CustomRule{
+		{Name: "bigBasket", Expression: `retailer.matches("Target") && items.count >= 3 ? 15 : 0`},
+		{Name: "perItem", Expression: `items.count * 10`, Cap: 20},
+		{Name: "refund", Expression: `-5`},
+		{Name: "broken", Expression: `1 / (items.count - 3)`},
+	}
+	assert.NoError(t, rs.Compile())
+
+	var breakdown = receipt.GetBreakdownWithRules(rs)
+	var custom = breakdown.Rules[len(RuleNames):]
+	assert.Equal(t, []*RulePoints{
+		{Rule: "bigBasket", Points: 15, Reason: `retailer.matches("Target") && items.count >= 3 ? 15 : 0 evaluated to 15`},
+		{Rule: "perItem", Points: 20, Reason: "items.count * 10 evaluated to 30, capped from 30 to 20 points"},
+		{Rule: "refund", Points: 0, Reason: "expression evaluated to -5, negative points are not awarded"},
+		{Rule: "broken", Points: 0, Reason: "expression failed: division by zero"},
+	}, custom)
+	// 6 retailer name + 5 one pair + 3 pizza description + 6 odd day + 35 custom
+	assert.Equal(t, 55, breakdown.TotalPoints)
+
+	t.Run("Not compiled", func(t *testing.T) {
+		points, _ := receipt.ExplainCustomRule(&CustomRule{Name: "bigBasket", Expression: `items.count * 2`})
+		assert.Equal(t, 6, points)
+		points, reason := receipt.ExplainCustomRule(&CustomRule{Name: "invalid", Expression: `items.count *`})
+		assert.Equal(t, 0, points)
+		assert.Equal(t, "expression does not compile: position 14: unexpected end of expression", reason)
+	})
+
+	t.Run("Copies keep the compiled rules", func(t *testing.T) {
+		var copied = rs.WithOverrides(nil)
+		assert.Equal(t, rs.CustomRules, copied.CustomRules)
+		copied.CustomRules[0].Cap = 1
+		assert.Equal(t, 0, rs.CustomRules[0].Cap)
+	})
+}
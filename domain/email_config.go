@@ -0,0 +1,9 @@
+package domain
+
+import "time"
+
+type EmailConfig struct {
+	EmailWatchDir      string        `envconfig:"EMAIL_WATCH_DIR"`
+	EmailWatchInterval time.Duration `envconfig:"EMAIL_WATCH_INTERVAL" default:"10s"`
+	EmailMinConfidence float64       `envconfig:"EMAIL_MIN_CONFIDENCE" default:"0.5"`
+}
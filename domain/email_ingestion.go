@@ -0,0 +1,19 @@
+package domain
+
+import "time"
+
+// EmailIngestion receipt stored from an email message
+type EmailIngestion struct {
+	ID        string         `json:"id"`
+	MessageID string         `json:"messageId,omitempty"`
+	From      string         `json:"from,omitempty"`
+	Subject   string         `json:"subject,omitempty"`
+	Parsed    *ParsedReceipt `json:"parsed"`
+}
+
+// EmailMessage message ingested as a receipt, its Message-ID is recorded to skip the repeats of the message
+type EmailMessage struct {
+	MessageID  string    `json:"messageId"`
+	ReceiptID  string    `json:"receiptId,omitempty"`
+	ReceivedAt time.Time `json:"receivedAt"`
+}
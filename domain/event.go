@@ -0,0 +1,20 @@
+package domain
+
+import "time"
+
+// Event something that happened in the domain and other components may react to
+type Event interface {
+	EventName() string
+}
+
+// TierChangeEvent emitted when a member moves between tiers
+type TierChangeEvent struct {
+	MemberID   string    `json:"memberId"`
+	From       Tier      `json:"from"`
+	To         Tier      `json:"to"`
+	OccurredAt time.Time `json:"occurredAt"`
+}
+
+func (e *TierChangeEvent) EventName() string {
+	return "member.tier_changed"
+}
@@ -0,0 +1,61 @@
+package domain
+
+import (
+	"hash/fnv"
+	"time"
+)
+
+const (
+	ExperimentArmControl   = "control"
+	ExperimentArmTreatment = "treatment"
+
+	BucketByMember  = "member"
+	BucketByReceipt = "receipt"
+)
+
+// Experiment time-boxed A/B test scoring a share of the receipts with a treatment rule set instead of the control one
+type Experiment struct {
+	ID               string    `json:"id"`
+	Name             string    `json:"name" validate:"required"`
+	StartsAt         time.Time `json:"startsAt" validate:"required"`
+	EndsAt           time.Time `json:"endsAt" validate:"required,gtfield=StartsAt"`
+	ControlVersion   string    `json:"controlVersion" validate:"required"`
+	TreatmentVersion string    `json:"treatmentVersion" validate:"required,nefield=ControlVersion"`
+	TreatmentPercent int       `json:"treatmentPercent" validate:"gt=0,lt=100"`
+	BucketBy         string    `json:"bucketBy,omitempty" validate:"omitempty,oneof=member receipt"`
+}
+
+// ExperimentAssignment arm of an experiment a receipt was scored with
+type ExperimentAssignment struct {
+	ExperimentID   string `json:"experimentId"`
+	Arm            string `json:"arm"`
+	RuleSetVersion string `json:"ruleSetVersion"`
+	Bucket         int    `json:"bucket"`
+}
+
+// IsActive returns true if t is between the start (inclusive) and the end (exclusive) of the experiment
+func (e *Experiment) IsActive(t time.Time) bool {
+	return !t.Before(e.StartsAt) && t.Before(e.EndsAt)
+}
+
+// Overlaps returns true if both experiments run at the same time
+func (e *Experiment) Overlaps(other *Experiment) bool {
+	return e.StartsAt.Before(other.EndsAt) && other.StartsAt.Before(e.EndsAt)
+}
+
+// Assign buckets the receipt deterministically by its member, or by its fingerprint when bucketing by receipt or the
+// receipt has no member. The buckets below the treatment percent are in the treatment arm.
+func (e *Experiment) Assign(r *Receipt) *ExperimentAssignment {
+	var key = r.MemberID
+	if e.BucketBy == BucketByReceipt || key == "" {
+		key = r.Fingerprint()
+	}
+	var h = fnv.New32a()
+	_, _ = h.Write([]byte(e.ID + ":" + key))
+	var bucket = int(h.Sum32() % 100)
+
+	if bucket < e.TreatmentPercent {
+		return &ExperimentAssignment{ExperimentID: e.ID, Arm: ExperimentArmTreatment, RuleSetVersion: e.TreatmentVersion, Bucket: bucket}
+	}
+	return &ExperimentAssignment{ExperimentID: e.ID, Arm: ExperimentArmControl, RuleSetVersion: e.ControlVersion, Bucket: bucket}
+}
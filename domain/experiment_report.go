@@ -0,0 +1,86 @@
+package domain
+
+import (
+	"math"
+	"sort"
+)
+
+// ExperimentArmStats distribution of the points awarded in an experiment arm
+type ExperimentArmStats struct {
+	Arm            string  `json:"arm"`
+	RuleSetVersion string  `json:"ruleSetVersion"`
+	Receipts       int     `json:"receipts"`
+	TotalPoints    int     `json:"totalPoints"`
+	MeanPoints     float64 `json:"meanPoints"`
+	StdDevPoints   float64 `json:"stdDevPoints"`
+	MinPoints      int     `json:"minPoints"`
+	MedianPoints   int     `json:"medianPoints"`
+	P90Points      int     `json:"p90Points"`
+	MaxPoints      int     `json:"maxPoints"`
+}
+
+// ExperimentReport comparison of the points distributions of the experiment arms
+type ExperimentReport struct {
+	ExperimentID string              `json:"experimentId"`
+	Name         string              `json:"name"`
+	Control      *ExperimentArmStats `json:"control"`
+	Treatment    *ExperimentArmStats `json:"treatment"`
+	// MeanDelta treatment mean points minus control mean points
+	MeanDelta float64 `json:"meanDelta"`
+	// MeanLift MeanDelta relative to the control mean, 0 when the control has no points
+	MeanLift float64 `json:"meanLift"`
+}
+
+// NewExperimentReport aggregates the results scored in the experiment by arm, other results are ignored
+func NewExperimentReport(e *Experiment, results []*Result) *ExperimentReport {
+	var points = map[string][]int{}
+	for _, result := range results {
+		if result.Experiment == nil || result.Experiment.ExperimentID != e.ID {
+			continue
+		}
+		points[result.Experiment.Arm] = append(points[result.Experiment.Arm], result.Points)
+	}
+
+	var report = &ExperimentReport{
+		ExperimentID: e.ID,
+		Name:         e.Name,
+		Control:      newArmStats(ExperimentArmControl, e.ControlVersion, points[ExperimentArmControl]),
+		Treatment:    newArmStats(ExperimentArmTreatment, e.TreatmentVersion, points[ExperimentArmTreatment]),
+	}
+	report.MeanDelta = report.Treatment.MeanPoints - report.Control.MeanPoints
+	if report.Control.MeanPoints != 0 {
+		report.MeanLift = report.MeanDelta / report.Control.MeanPoints
+	}
+	return report
+}
+
+func newArmStats(arm, version string, points []int) *ExperimentArmStats {
+	var stats = &ExperimentArmStats{Arm: arm, RuleSetVersion: version, Receipts: len(points)}
+	if len(points) == 0 {
+		return stats
+	}
+
+	sort.Ints(points)
+	var sum = 0.0
+	for _, p := range points {
+		stats.TotalPoints = AddPoints(stats.TotalPoints, p)
+		sum += float64(p)
+	}
+	stats.MeanPoints = sum / float64(len(points))
+	var squares = 0.0
+	for _, p := range points {
+		squares += math.Pow(float64(p)-stats.MeanPoints, 2)
+	}
+	stats.StdDevPoints = math.Sqrt(squares / float64(len(points)))
+	stats.MinPoints = points[0]
+	stats.MedianPoints = percentile(points, 50)
+	stats.P90Points = percentile(points, 90)
+	stats.MaxPoints = points[len(points)-1]
+	return stats
+}
+
+// percentile nearest-rank percentile of sorted values
+func percentile(sorted []int, p float64) int {
+	var rank = int(math.Ceil(p / 100 * float64(len(sorted))))
+	return sorted[max(rank, 1)-1]
+}
@@ -0,0 +1,99 @@
+package domain
+
+import (
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestExperiment_Assign(t *testing.T) {
+	var experiment = &Experiment{
+		ID:               "exp-1",
+		ControlVersion:   "default",
+		TreatmentVersion: "v2",
+		TreatmentPercent: 30,
+	}
+	var receipt = &Receipt{
+		Retailer:   "Target",
+		PurchaseDT: time.Date(2022, 1, 1, 13, 1, 0, 0, time.UTC),
+		Total:      6.49,
+		Items:      []*ReceiptItem{{ShortDescription: "Mountain Dew 12PK", Price: 6.49}},
+	}
+
+	t.Run("Deterministic", func(t *testing.T) {
+		var first = experiment.Assign(receipt)
+		assert.Equal(t, first, experiment.Assign(receipt))
+		assert.Equal(t, "exp-1", first.ExperimentID)
+		assert.Equal(t, first.Arm == ExperimentArmTreatment, first.Bucket < 30)
+	})
+
+	t.Run("Same member same arm", func(t *testing.T) {
+		var other = *receipt
+		other.MemberID = "member-1"
+		other.Total = 12.25
+		var member = *receipt
+		member.MemberID = "member-1"
+		assert.Equal(t, experiment.Assign(&member), experiment.Assign(&other))
+	})
+
+	t.Run("Split", func(t *testing.T) {
+		var treatment = 0
+		for i := 0; i < 1000; i++ {
+			var assignment = experiment.Assign(&Receipt{MemberID: fmt.Sprintf("member-%d", i)})
+			if assignment.Arm == ExperimentArmTreatment {
+				assert.Equal(t, "v2", assignment.RuleSetVersion)
+				treatment++
+			}
+		}
+		assert.InDelta(t, 300, treatment, 60)
+	})
+}
+
+func TestReceipt_Fingerprint(t *testing.T) {
+	var receipt = &Receipt{Retailer: "Target", Total: 6.49, Items: []*ReceiptItem{{ShortDescription: "Pepsi", Price: 6.49}}}
+	var duplicated = &Receipt{Retailer: " target ", Total: 6.49, Items: []*ReceiptItem{{ShortDescription: "Pepsi ", Price: 6.49}}}
+	var other = &Receipt{Retailer: "Target", Total: 6.50, Items: []*ReceiptItem{{ShortDescription: "Pepsi", Price: 6.50}}}
+
+	assert.Equal(t, receipt.Fingerprint(), duplicated.Fingerprint())
+	assert.NotEqual(t, receipt.Fingerprint(), other.Fingerprint())
+}
+
+func TestNewExperimentReport(t *testing.T) {
+	var experiment = &Experiment{ID: "exp-1", Name: "Odd days", ControlVersion: "default", TreatmentVersion: "v2"}
+	var control = &ExperimentAssignment{ExperimentID: "exp-1", Arm: ExperimentArmControl}
+	var treatment = &ExperimentAssignment{ExperimentID: "exp-1", Arm: ExperimentArmTreatment}
+	var results = []*Result{
+		{Points: 10, Experiment: control},
+		{Points: 30, Experiment: control},
+		{Points: 20, Experiment: control},
+		{Points: 40, Experiment: treatment},
+		{Points: 20, Experiment: treatment},
+		{Points: 100},
+		{Points: 100, Experiment: &ExperimentAssignment{ExperimentID: "exp-2", Arm: ExperimentArmControl}},
+	}
+
+	var report = NewExperimentReport(experiment, results)
+	assert.Equal(t, &ExperimentArmStats{
+		Arm:            ExperimentArmControl,
+		RuleSetVersion: "default",
+		Receipts:       3,
+		TotalPoints:    60,
+		MeanPoints:     20,
+		StdDevPoints:   8.16496580927726,
+		MinPoints:      10,
+		MedianPoints:   20,
+		P90Points:      30,
+		MaxPoints:      30,
+	}, report.Control)
+	assert.Equal(t, 2, report.Treatment.Receipts)
+	assert.Equal(t, 30.0, report.Treatment.MeanPoints)
+	assert.Equal(t, 10.0, report.MeanDelta)
+	assert.Equal(t, 0.5, report.MeanLift)
+
+	t.Run("Without receipts", func(t *testing.T) {
+		var report = NewExperimentReport(experiment, nil)
+		assert.Equal(t, 0, report.Control.Receipts)
+		assert.Equal(t, 0.0, report.MeanLift)
+	})
+}
@@ -0,0 +1,49 @@
+package domain
+
+import "context"
+
+type HealthStatus string
+
+const (
+	HealthOK      HealthStatus = "ok"
+	HealthFailing HealthStatus = "failing"
+)
+
+type HealthCheckKind string
+
+const (
+	// HealthReadiness checks decide if the service can receive traffic
+	HealthReadiness HealthCheckKind = "readiness"
+	// HealthLiveness checks decide if the process has to be restarted
+	HealthLiveness HealthCheckKind = "liveness"
+)
+
+// HealthCheck check contributed by an adapter or a background worker, it returns an error when unhealthy
+type HealthCheck struct {
+	Name  string
+	Kind  HealthCheckKind
+	Check func(ctx context.Context) error
+}
+
+// HealthCheckResult outcome of a check
+type HealthCheckResult struct {
+	Name   string       `json:"name"`
+	Status HealthStatus `json:"status"`
+	Error  string       `json:"error,omitempty"`
+}
+
+// HealthReport outcome of the checks of a kind, failing if any check fails
+type HealthReport struct {
+	Status HealthStatus         `json:"status"`
+	Checks []*HealthCheckResult `json:"checks"`
+}
+
+// Add appends the result of a check
+func (r *HealthReport) Add(name string, err error) {
+	var result = &HealthCheckResult{Name: name, Status: HealthOK}
+	if err != nil {
+		result.Status, result.Error = HealthFailing, err.Error()
+		r.Status = HealthFailing
+	}
+	r.Checks = append(r.Checks, result)
+}
@@ -0,0 +1,8 @@
+package domain
+
+import "time"
+
+type HealthConfig struct {
+	// HealthCheckTimeout time every check has to complete before it fails
+	HealthCheckTimeout time.Duration `envconfig:"HEALTH_CHECK_TIMEOUT" default:"2s"`
+}
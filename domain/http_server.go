@@ -8,4 +8,14 @@ type HTTPServer struct {
 	Port          int           `envconfig:"PORT" default:"8080"`
 	ReadTimeout   time.Duration `envconfig:"HTTP_SERVER_READ_TIMEOUT" default:"1s"`
 	WriteTimeout  time.Duration `envconfig:"HTTP_SERVER_WRITE_TIMEOUT" default:"2s"`
+	// ShutdownTimeout time the in-flight requests have to complete when the server stops
+	ShutdownTimeout time.Duration `envconfig:"HTTP_SERVER_SHUTDOWN_TIMEOUT" default:"10s"`
+	// TLS serves HTTPS with the certificate and key files
+	TLS         bool   `envconfig:"HTTP_SERVER_TLS" default:"false"`
+	TLSCertFile string `envconfig:"HTTP_SERVER_TLS_CERT_FILE" default:"ssl/Server.crt"`
+	TLSKeyFile  string `envconfig:"HTTP_SERVER_TLS_KEY_FILE" default:"ssl/Server.pem"`
+	// TLSClientCAFile PEM bundle of the CAs of the client certificates, when set the clients must present a certificate
+	TLSClientCAFile string `envconfig:"HTTP_SERVER_TLS_CLIENT_CA_FILE"`
+	// TLSReloadInterval how often the certificate, key and CA files are checked for changes
+	TLSReloadInterval time.Duration `envconfig:"HTTP_SERVER_TLS_RELOAD_INTERVAL" default:"30s"`
 }
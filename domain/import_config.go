@@ -0,0 +1,6 @@
+package domain
+
+type ImportConfig struct {
+	ImportColumns map[string]string `envconfig:"IMPORT_COLUMNS"`
+	ImportMaxRows int               `envconfig:"IMPORT_MAX_ROWS" default:"10000"`
+}
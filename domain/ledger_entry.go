@@ -0,0 +1,14 @@
+package domain
+
+import "time"
+
+// LedgerEntry adjustment of the points awarded to a receipt
+type LedgerEntry struct {
+	ID             string    `json:"id"`
+	ReceiptID      string    `json:"receiptId"`
+	MemberID       string    `json:"memberId,omitempty"`
+	Points         int       `json:"points"`
+	Reason         string    `json:"reason"`
+	RuleSetVersion string    `json:"ruleSetVersion,omitempty"`
+	CreatedAt      time.Time `json:"createdAt"`
+}
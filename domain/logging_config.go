@@ -0,0 +1,19 @@
+package domain
+
+// Log encodings
+const (
+	LogEncodingJSON    = "json"
+	LogEncodingConsole = "console"
+)
+
+type LoggingConfig struct {
+	// LogLevel minimum level of the logs: debug, info, warn or error
+	LogLevel string `envconfig:"LOG_LEVEL" default:"info"`
+	// LogEncoding json for the log collectors or console for humans
+	LogEncoding string `envconfig:"LOG_ENCODING" default:"json"`
+	// LogDebugSampleInitial debug logs with the same message logged every second before the sampling begins
+	LogDebugSampleInitial int `envconfig:"LOG_DEBUG_SAMPLE_INITIAL" default:"100"`
+	// LogDebugSampleThereafter once sampling, one of every LogDebugSampleThereafter debug logs with the same message is
+	// logged for the rest of the second. The info and higher logs are never sampled
+	LogDebugSampleThereafter int `envconfig:"LOG_DEBUG_SAMPLE_THEREAFTER" default:"100"`
+}
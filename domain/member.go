@@ -0,0 +1,10 @@
+package domain
+
+import "time"
+
+type Member struct {
+	ID            string    `json:"id"`
+	Tier          Tier      `json:"tier"`
+	RollingPoints int       `json:"rollingPoints"`
+	UpdatedAt     time.Time `json:"updatedAt"`
+}
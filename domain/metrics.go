@@ -0,0 +1,10 @@
+package domain
+
+// Reasons of the rejected receipts in the metrics, few values so they can be labels
+const (
+	RejectionInvalid  = "invalid"
+	RejectionNotFound = "not_found"
+	RejectionTimeout  = "timeout"
+	RejectionStorage  = "storage"
+	RejectionQuota    = "quota"
+)
@@ -0,0 +1,26 @@
+package domain
+
+// ParseRequest text of a receipt to parse, the locale is the format of its dates, times and amounts when no template
+// of the retailer declares it
+type ParseRequest struct {
+	Text   string `json:"text" validate:"required,max=65536"`
+	Locale string `json:"locale,omitempty"`
+}
+
+// ParsedReceipt receipt extracted from a text with the confidence of every field, from 0 to 1. The receipt has the
+// canonical formats and can be submitted to /receipts/process once reviewed.
+type ParsedReceipt struct {
+	Receipt    *ReceiptBase     `json:"receipt"`
+	Confidence *ParseConfidence `json:"confidence"`
+	Template   string           `json:"template,omitempty"`
+	Warnings   []string         `json:"warnings,omitempty"`
+}
+
+// ParseConfidence confidence of the fields of a parsed receipt, the item confidences follow the order of the items
+type ParseConfidence struct {
+	Retailer     float64   `json:"retailer"`
+	PurchaseDate float64   `json:"purchaseDate"`
+	PurchaseTime float64   `json:"purchaseTime"`
+	Total        float64   `json:"total"`
+	Items        []float64 `json:"items"`
+}
@@ -0,0 +1,5 @@
+package domain
+
+type ParserConfig struct {
+	ParserTemplatesFile string `envconfig:"PARSER_TEMPLATES_FILE"`
+}
@@ -0,0 +1,43 @@
+package domain
+
+import "math"
+
+// AddPoints returns a + b saturating at the int limits instead of overflowing
+func AddPoints(a, b int) int {
+	if b > 0 && a > math.MaxInt-b {
+		return math.MaxInt
+	}
+	if b < 0 && a < math.MinInt-b {
+		return math.MinInt
+	}
+	return a + b
+}
+
+// MulPoints returns a * b saturating at the int limits instead of overflowing
+func MulPoints(a, b int) int {
+	if a == 0 || b == 0 {
+		return 0
+	}
+	var result = a * b
+	if result/b != a || (a == -1 && b == math.MinInt) || (b == -1 && a == math.MinInt) {
+		if (a > 0) == (b > 0) {
+			return math.MaxInt
+		}
+		return math.MinInt
+	}
+	return result
+}
+
+// PointsFromFloat converts a float amount of points to int saturating at the int limits, NaN is 0 points
+func PointsFromFloat(f float64) int {
+	switch {
+	case math.IsNaN(f):
+		return 0
+	case f >= math.MaxInt:
+		return math.MaxInt
+	case f <= math.MinInt:
+		return math.MinInt
+	default:
+		return int(f)
+	}
+}
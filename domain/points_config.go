@@ -0,0 +1,9 @@
+package domain
+
+// PointsConfig limits of the points. The receipt limits are applied first and the member daily cap last, so a receipt
+// of a member who reached the cap gets fewer points than POINTS_MIN_PER_RECEIPT, down to 0
+type PointsConfig struct {
+	MaxPerReceipt  int `envconfig:"POINTS_MAX_PER_RECEIPT" default:"0"`
+	MinPerReceipt  int `envconfig:"POINTS_MIN_PER_RECEIPT" default:"0"`
+	MemberDailyCap int `envconfig:"POINTS_MEMBER_DAILY_CAP" default:"0"`
+}
@@ -0,0 +1,97 @@
+package domain
+
+import (
+	"github.com/stretchr/testify/assert"
+	"math"
+	"testing"
+)
+
+func TestAddPoints(t *testing.T) {
+	assert.Equal(t, 30, AddPoints(10, 20))
+	assert.Equal(t, -10, AddPoints(10, -20))
+	assert.Equal(t, math.MaxInt, AddPoints(math.MaxInt-1, 20))
+	assert.Equal(t, math.MinInt, AddPoints(math.MinInt+1, -20))
+}
+
+func TestMulPoints(t *testing.T) {
+	assert.Equal(t, 50, MulPoints(10, 5))
+	assert.Equal(t, 0, MulPoints(0, math.MaxInt))
+	assert.Equal(t, math.MaxInt, MulPoints(math.MaxInt/2, 3))
+	assert.Equal(t, math.MinInt, MulPoints(math.MaxInt/2, -3))
+	assert.Equal(t, math.MaxInt, MulPoints(-1, math.MinInt))
+}
+
+func TestPointsFromFloat(t *testing.T) {
+	assert.Equal(t, 3, PointsFromFloat(3.9))
+	assert.Equal(t, 0, PointsFromFloat(math.NaN()))
+	assert.Equal(t, math.MaxInt, PointsFromFloat(math.Inf(1)))
+	assert.Equal(t, math.MinInt, PointsFromFloat(math.Inf(-1)))
+}
+
+func TestReceipt_GetTotalPointsDoesNotOverflow(t *testing.T) {
+	// Each item is worth 6.8e37 points, far above any integer type
+	var items = make([]*ReceiptItem, 0)
+	for i := 0; i < 1000; i++ {
+		items = append(items, &ReceiptItem{ShortDescription: "Abc", Price: math.MaxFloat32})
+	}
+	var receipt = &Receipt{Retailer: "Target", Items: items, Total: 1.5}
+
+	assert.Equal(t, math.MaxInt, receipt.GetTotalPoints())
+}
+
+func TestBreakdown_ApplyReceiptLimits(t *testing.T) {
+	t.Run("Maximum", func(t *testing.T) {
+		var breakdown = &Breakdown{TotalPoints: 1500}
+		breakdown.ApplyReceiptLimits(5, 1000)
+		assert.Equal(t, 1000, breakdown.TotalPoints)
+		assert.Equal(t, []*AppliedCap{{Cap: CapReceiptMax, Limit: 1000, PointsBefore: 1500}}, breakdown.Caps)
+	})
+
+	t.Run("Minimum", func(t *testing.T) {
+		var breakdown = &Breakdown{TotalPoints: 2}
+		breakdown.ApplyReceiptLimits(5, 1000)
+		assert.Equal(t, 5, breakdown.TotalPoints)
+		assert.Equal(t, []*AppliedCap{{Cap: CapReceiptMin, Limit: 5, PointsBefore: 2}}, breakdown.Caps)
+	})
+
+	t.Run("No limits", func(t *testing.T) {
+		var breakdown = &Breakdown{TotalPoints: 1500}
+		breakdown.ApplyReceiptLimits(0, 0)
+		assert.Equal(t, 1500, breakdown.TotalPoints)
+		assert.Empty(t, breakdown.Caps)
+	})
+}
+
+func TestBreakdown_ApplyDailyCap(t *testing.T) {
+	t.Run("Partially consumed", func(t *testing.T) {
+		var breakdown = &Breakdown{TotalPoints: 300}
+		breakdown.ApplyDailyCap(500, 400)
+		assert.Equal(t, 100, breakdown.TotalPoints)
+		assert.Equal(t, []*AppliedCap{{Cap: CapMemberDailyCap, Limit: 100, PointsBefore: 300}}, breakdown.Caps)
+	})
+
+	t.Run("Exhausted", func(t *testing.T) {
+		var breakdown = &Breakdown{TotalPoints: 300}
+		breakdown.ApplyDailyCap(500, 700)
+		assert.Equal(t, 0, breakdown.TotalPoints)
+	})
+
+	t.Run("Under the cap", func(t *testing.T) {
+		var breakdown = &Breakdown{TotalPoints: 300}
+		breakdown.ApplyDailyCap(500, 100)
+		assert.Equal(t, 300, breakdown.TotalPoints)
+		assert.Empty(t, breakdown.Caps)
+	})
+
+	t.Run("Below the receipt minimum", func(t *testing.T) {
+		// The daily cap goes after the receipt limits and wins over the minimum
+		var breakdown = &Breakdown{TotalPoints: 2}
+		breakdown.ApplyReceiptLimits(5, 1000)
+		breakdown.ApplyDailyCap(500, 497)
+		assert.Equal(t, 3, breakdown.TotalPoints)
+		assert.Equal(t, []*AppliedCap{
+			{Cap: CapReceiptMin, Limit: 5, PointsBefore: 2},
+			{Cap: CapMemberDailyCap, Limit: 3, PointsBefore: 5},
+		}, breakdown.Caps)
+	})
+}
@@ -0,0 +1,55 @@
+package domain
+
+import "github.com/samber/lo"
+
+// Roles of the JWT principals
+const (
+	// RoleMember submits its own receipts and reads their points
+	RoleMember = "member"
+	// RoleAdmin has the admin scope
+	RoleAdmin = "admin"
+)
+
+// RoleScopes scopes granted by every role
+var RoleScopes = map[string][]string{
+	RoleMember: {ScopeReceiptsWrite, ScopeReceiptsRead},
+	RoleAdmin:  {ScopeAdmin},
+}
+
+// Principal authenticated client of a request, an API key or the subject of a JWT
+type Principal struct {
+	// Subject id of the API key or sub of the JWT
+	Subject string `json:"subject"`
+	// MemberID member of the JWT, its receipts are attributed to it
+	MemberID string   `json:"memberId,omitempty"`
+	Roles    []string `json:"roles,omitempty"`
+	Scopes   []string `json:"scopes"`
+	// APIKeyID id of the API key, empty for the JWTs
+	APIKeyID string `json:"apiKeyId,omitempty"`
+	// DailyReceiptQuota receipts the principal can store per day, the default quota when 0
+	DailyReceiptQuota int `json:"dailyReceiptQuota,omitempty"`
+}
+
+// NewTokenPrincipal returns the principal of a JWT with the scopes of its roles, the unknown roles grant nothing
+func NewTokenPrincipal(subject string, memberID string, roles []string) *Principal {
+	var scopes = lo.Uniq(lo.FlatMap(roles, func(role string, _ int) []string { return RoleScopes[role] }))
+	return &Principal{Subject: subject, MemberID: memberID, Roles: roles, Scopes: scopes}
+}
+
+// HasScope returns true if the principal has the scope or the admin scope
+func (p *Principal) HasScope(scope string) bool {
+	return lo.Contains(p.Scopes, scope) || lo.Contains(p.Scopes, ScopeAdmin)
+}
+
+// ClientKey returns the client the rate limits and quotas of the principal apply to: its API key, or the member or
+// subject of its JWT
+func (p *Principal) ClientKey() string {
+	switch {
+	case p.APIKeyID != "":
+		return "apikey:" + p.APIKeyID
+	case p.MemberID != "":
+		return "member:" + p.MemberID
+	default:
+		return "subject:" + p.Subject
+	}
+}
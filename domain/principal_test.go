@@ -0,0 +1,21 @@
+package domain
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestNewTokenPrincipal(t *testing.T) {
+	var member = NewTokenPrincipal("user-1", "member-1", []string{RoleMember, "viewer"})
+	assert.Equal(t, []string{ScopeReceiptsWrite, ScopeReceiptsRead}, member.Scopes)
+	assert.True(t, member.HasScope(ScopeReceiptsRead))
+	assert.False(t, member.HasScope(ScopeAdmin))
+
+	var admin = NewTokenPrincipal("user-2", "", []string{RoleAdmin, RoleMember})
+	assert.True(t, admin.HasScope(ScopeAdmin))
+	assert.True(t, admin.HasScope(ScopeReceiptsWrite))
+
+	var unknown = NewTokenPrincipal("user-3", "", []string{"viewer"})
+	assert.Empty(t, unknown.Scopes)
+	assert.False(t, unknown.HasScope(ScopeReceiptsRead))
+}
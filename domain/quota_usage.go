@@ -0,0 +1,17 @@
+package domain
+
+import "time"
+
+// QuotaUsage receipts stored by a client in a day (UTC)
+type QuotaUsage struct {
+	Client string `json:"client"`
+	Day    string `json:"day"`
+	Used   int    `json:"used"`
+}
+
+// QuotaStatus daily receipt quota of a client, 0 is unlimited
+type QuotaStatus struct {
+	QuotaUsage
+	Quota    int       `json:"quota"`
+	ResetsAt time.Time `json:"resetsAt"`
+}
@@ -0,0 +1,111 @@
+package domain
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// rateLimitPeriods units of the periods of the limits
+var rateLimitPeriods = map[string]time.Duration{
+	"s": time.Second,
+	"m": time.Minute,
+	"h": time.Hour,
+}
+
+// RateLimit token bucket of Requests tokens, refilled at Requests per Period
+type RateLimit struct {
+	Requests int
+	Period   time.Duration
+}
+
+// ParseRateLimit parses a limit like 10/s, 600/m or 5/10s
+func ParseRateLimit(value string) (RateLimit, error) {
+	requests, period, found := strings.Cut(strings.TrimSpace(value), "/")
+	if !found {
+		return RateLimit{}, fmt.Errorf("invalid rate limit %q, expected requests/period like 10/s", value)
+	}
+	n, err := strconv.Atoi(requests)
+	if err != nil || n <= 0 {
+		return RateLimit{}, fmt.Errorf("invalid rate limit %q, the requests must be a positive integer", value)
+	}
+	d, ok := rateLimitPeriods[period]
+	if !ok {
+		if d, err = time.ParseDuration(period); err != nil || d <= 0 {
+			return RateLimit{}, fmt.Errorf("invalid rate limit %q, the period must be s, m, h or a duration", value)
+		}
+	}
+	// The headers have the period in seconds
+	if d%time.Second != 0 {
+		return RateLimit{}, fmt.Errorf("invalid rate limit %q, the period must be whole seconds", value)
+	}
+	return RateLimit{Requests: n, Period: d}, nil
+}
+
+// String returns the limit in the RateLimit-Policy header format, like 10;w=1
+func (l RateLimit) String() string {
+	return fmt.Sprintf("%d;w=%d", l.Requests, int(l.Period.Seconds()))
+}
+
+// RateLimitPolicies limits of the routes
+type RateLimitPolicies struct {
+	Default RateLimit
+	// Routes limits by "METHOD pattern" or by pattern for every method
+	Routes map[string]RateLimit
+}
+
+// ParseRateLimitPolicies parses the default limit and the comma separated route=limit limits of the routes
+func ParseRateLimitPolicies(defaultLimit string, routes string) (*RateLimitPolicies, error) {
+	limit, err := ParseRateLimit(defaultLimit)
+	if err != nil {
+		return nil, err
+	}
+	var policies = &RateLimitPolicies{Default: limit, Routes: map[string]RateLimit{}}
+	for _, entry := range strings.Split(routes, ",") {
+		if strings.TrimSpace(entry) == "" {
+			continue
+		}
+		route, value, found := strings.Cut(entry, "=")
+		if !found {
+			return nil, fmt.Errorf("invalid route rate limit %q, expected route=limit", entry)
+		}
+		limit, err := ParseRateLimit(value)
+		if err != nil {
+			return nil, err
+		}
+		policies.Routes[strings.Join(strings.Fields(route), " ")] = limit
+	}
+	return policies, nil
+}
+
+// For returns the name and limit of the policy of the route pattern: the limit of the method and pattern, of the
+// pattern or the default
+func (p *RateLimitPolicies) For(method string, pattern string) (string, RateLimit) {
+	var key = method + " " + pattern
+	if limit, ok := p.Routes[key]; ok {
+		return key, limit
+	}
+	if limit, ok := p.Routes[pattern]; ok {
+		return pattern, limit
+	}
+	return "default", p.Default
+}
+
+// RateLimitDecision outcome of a request to a rate limit
+type RateLimitDecision struct {
+	Allowed bool
+	Limit   RateLimit
+	// Remaining requests of the bucket
+	Remaining int
+	// Reset time until the bucket is full again
+	Reset time.Duration
+	// RetryAfter time until the next request is allowed, 0 when allowed
+	RetryAfter time.Duration
+}
+
+// QuotaDay returns the day (UTC) of the quotas at t and when its quotas reset
+func QuotaDay(t time.Time) (string, time.Time) {
+	var day = t.UTC().Truncate(24 * time.Hour)
+	return day.Format(time.DateOnly), day.AddDate(0, 0, 1)
+}
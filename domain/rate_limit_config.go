@@ -0,0 +1,17 @@
+package domain
+
+type RateLimitConfig struct {
+	// RateLimitEnabled limits the requests of every client, but the health probes and the metrics
+	RateLimitEnabled bool `envconfig:"RATE_LIMIT_ENABLED" default:"true"`
+	// RateLimitDefault requests per period of a client to the routes without their own limit, like 20/s or 600/m
+	RateLimitDefault string `envconfig:"RATE_LIMIT_DEFAULT" default:"20/s"`
+	// RateLimitRoutes limits of the routes, comma separated route=limit where the route is a chi route pattern with an
+	// optional method, like POST /receipts/process=5/s,/receipts/import=10/m
+	RateLimitRoutes string `envconfig:"RATE_LIMIT_ROUTES" default:"POST /receipts/process=10/s"`
+	// RateLimitIP requests per period of an IP to all the routes, checked before the authentication so the
+	// credentials can't be guessed without limit. Empty for no limit
+	RateLimitIP string `envconfig:"RATE_LIMIT_IP" default:"50/s"`
+	// QuotaDailyReceipts receipts a client can store per day (UTC), the API keys can have their own quota. 0 is
+	// unlimited
+	QuotaDailyReceipts int `envconfig:"QUOTA_DAILY_RECEIPTS" default:"0"`
+}
@@ -0,0 +1,68 @@
+package domain
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestParseRateLimit(t *testing.T) {
+	var testCases = map[string]struct {
+		value string
+		limit RateLimit
+		valid bool
+	}{
+		"Per second":   {value: "10/s", limit: RateLimit{Requests: 10, Period: time.Second}, valid: true},
+		"Per minute":   {value: " 600/m", limit: RateLimit{Requests: 600, Period: time.Minute}, valid: true},
+		"Duration":     {value: "5/10s", limit: RateLimit{Requests: 5, Period: 10 * time.Second}, valid: true},
+		"No period":    {value: "10", valid: false},
+		"Zero":         {value: "0/s", valid: false},
+		"Bad period":   {value: "10/day", valid: false},
+		"Zero period":  {value: "10/0s", valid: false},
+		"Sub-second":   {value: "10/500ms", valid: false},
+		"Fraction":     {value: "10/1500ms", valid: false},
+		"Not a number": {value: "ten/s", valid: false},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			limit, err := ParseRateLimit(tc.value)
+			if tc.valid {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.limit, limit)
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
+
+func TestRateLimitPolicies_For(t *testing.T) {
+	policies, err := ParseRateLimitPolicies("20/s", "POST  /receipts/process=5/s, /receipts/import=10/m,")
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	name, limit := policies.For("POST", "/receipts/process")
+	assert.Equal(t, "POST /receipts/process", name)
+	assert.Equal(t, RateLimit{Requests: 5, Period: time.Second}, limit)
+
+	name, limit = policies.For("POST", "/receipts/import")
+	assert.Equal(t, "/receipts/import", name)
+	assert.Equal(t, RateLimit{Requests: 10, Period: time.Minute}, limit)
+
+	name, limit = policies.For("GET", "/receipts/process")
+	assert.Equal(t, "default", name)
+	assert.Equal(t, RateLimit{Requests: 20, Period: time.Second}, limit)
+
+	_, err = ParseRateLimitPolicies("20/s", "POST /receipts/process")
+	assert.Error(t, err)
+	_, err = ParseRateLimitPolicies("fast", "")
+	assert.Error(t, err)
+}
+
+func TestQuotaDay(t *testing.T) {
+	day, resetsAt := QuotaDay(time.Date(2022, 3, 20, 23, 59, 0, 0, time.FixedZone("CST", -6*3600)))
+	assert.Equal(t, "2022-03-21", day)
+	assert.Equal(t, time.Date(2022, 3, 22, 0, 0, 0, 0, time.UTC), resetsAt)
+}
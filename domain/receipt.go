@@ -1,7 +1,9 @@
 package domain
 
 import (
-	"log"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
 	"math"
 	"regexp"
 	"strings"
@@ -9,103 +11,230 @@ import (
 )
 
 type Receipt struct {
-	Retailer   string         `json:"retailer,omitempty"`
-	PurchaseDT time.Time      `json:"purchaseTime,omitempty"`
-	Total      float32        `json:"total,omitempty"`
-	Items      []*ReceiptItem `json:"items,omitempty"`
+	MemberID     string         `json:"memberId,omitempty"`
+	Retailer     string         `json:"retailer,omitempty"`
+	RetailerID   string         `json:"retailerId,omitempty"`
+	RetailerName string         `json:"retailerName,omitempty"`
+	PurchaseDT   time.Time      `json:"purchaseTime,omitempty"`
+	Currency     string         `json:"currency,omitempty"`
+	ExchangeRate float64        `json:"exchangeRate,omitempty"`
+	Total        float32        `json:"total,omitempty"`
+	Items        []*ReceiptItem `json:"items,omitempty"`
+}
+
+var nonAlphanumericRegex = regexp.MustCompile(`[^a-zA-Z0-9 ]+`)
+
+// ruleEvaluators computes the points of every base rule with the given parameters and the reason of the result
+var ruleEvaluators = map[string]func(r *Receipt, params RuleParams) (int, string){
+	RuleRetailerName:      (*Receipt).pointsCountingRetailerName,
+	RuleRoundDollar:       (*Receipt).pointsIfTotalRoundWithNoCents,
+	RuleMultipleOf25Cents: (*Receipt).pointsIfTotalIsMultipleOf25Cents,
+	RuleEveryTwoItems:     (*Receipt).pointsForEvery2Items,
+	RuleItemDescription:   (*Receipt).pointsFromItemsDescription,
+	RuleOddDay:            (*Receipt).pointsDayIsOdd,
+	RuleAfternoonPurchase: (*Receipt).pointsBetweenTime,
 }
 
 // GetPointsCountingRetailerName returns 1 point by alphanumeric characters in the retailer name
 func (r *Receipt) GetPointsCountingRetailerName() int {
-	var nonAlphanumericRegex = regexp.MustCompile(`[^a-zA-Z0-9 ]+`)
-	var retailName = strings.ReplaceAll(nonAlphanumericRegex.ReplaceAllString(r.Retailer, ""), " ", "")
-	log.Println("GetPointsCountingRetailerName -> ", len(retailName)*1)
-	return len(retailName) * 1
+	return r.EvaluateRule(RuleRetailerName, DefaultRuleSet())
+}
+
+func (r *Receipt) pointsCountingRetailerName(params RuleParams) (int, string) {
+	// Receipts of catalog retailers count the canonical name, so every spelling earns the same points
+	var name = r.Retailer
+	if r.RetailerName != "" {
+		name = r.RetailerName
+	}
+	var retailName = strings.ReplaceAll(nonAlphanumericRegex.ReplaceAllString(name, ""), " ", "")
+	var points = MulPoints(len(retailName), params.Points)
+	return points, fmt.Sprintf("retailer name (%s) has %d alphanumeric characters", name, len(retailName))
 }
 
 // GetPointsIfTotalRoundWithNoCents returns 50 points if the total is rounded amount without cents
 func (r *Receipt) GetPointsIfTotalRoundWithNoCents() int {
+	return r.EvaluateRule(RuleRoundDollar, DefaultRuleSet())
+}
+
+func (r *Receipt) pointsIfTotalRoundWithNoCents(params RuleParams) (int, string) {
 	var points = 0
+	var reason = fmt.Sprintf("total %.2f is not a round dollar amount", r.Total)
 	if math.Ceil(float64(r.Total)) == float64(r.Total) {
-		points = 50
+		points = params.Points
+		reason = fmt.Sprintf("total %.2f is a round dollar amount", r.Total)
 	}
-	log.Println("GetPointsIfTotalRoundWithNoCents -> ", points)
-	return points
+	return points, reason
 }
 
 // GetPointsIfTotalIsMultipleOf25Cents returns 25 points if the amount is multiple of 0.25
 func (r *Receipt) GetPointsIfTotalIsMultipleOf25Cents() int {
+	return r.EvaluateRule(RuleMultipleOf25Cents, DefaultRuleSet())
+}
+
+func (r *Receipt) pointsIfTotalIsMultipleOf25Cents(params RuleParams) (int, string) {
 	var points = 0
+	var reason = fmt.Sprintf("total %.2f is not a multiple of 0.25", r.Total)
 	if math.Mod(float64(r.Total), 0.25) == 0 {
-		points = 25
+		points = params.Points
+		reason = fmt.Sprintf("total %.2f is a multiple of 0.25", r.Total)
 	}
-	log.Println("GetPointsIfTotalIsMultipleOf25Cents -> ", math.Mod(float64(r.Total), 0.25))
-	log.Println("GetPointsIfTotalIsMultipleOf25Cents -> ", points)
-	return points
+	return points, reason
 }
 
-// Get5PointForEvery2Items returns 5 points by every 2 items on the receipt
+// Get5PointForEvery2Items returns 5 points by every 2 items on the receipt, the quantity of the lines is counted and
+// discount and tax lines are not items
 func (r *Receipt) Get5PointForEvery2Items() int {
-	var totalItems = len(r.Items)
-	var totalPoints = 0
-	for i := 2; i <= totalItems; i += 2 {
-		totalPoints += 5
-	}
-	log.Println("Get5PointForEvery2Items -> ", totalPoints)
-	return totalPoints
+	return r.EvaluateRule(RuleEveryTwoItems, DefaultRuleSet())
+}
+
+func (r *Receipt) pointsForEvery2Items(params RuleParams) (int, string) {
+	var totalItems = r.ItemUnits()
+	var totalPoints = MulPoints(totalItems/2, params.Points)
+	return totalPoints, fmt.Sprintf("%d items (%d pairs @ %d points each)", totalItems, totalItems/2, params.Points)
 }
 
-// GetPointsFromItemsDescription returns points if item description is a multiple of 3, then it multiply the price by 0.2 and rounded by the nearest integer
+// GetPointsFromItemsDescription returns points if item description is a multiple of 3, then it multiply the price by 0.2 and rounded by the nearest integer.
+// The price is the amount of the whole line and discount and tax lines don't award points.
 func (r *Receipt) GetPointsFromItemsDescription() int {
+	return r.EvaluateRule(RuleItemDescription, DefaultRuleSet())
+}
+
+func (r *Receipt) pointsFromItemsDescription(params RuleParams) (int, string) {
 	var totalPoints = 0
+	if params.Divisor <= 0 {
+		return totalPoints, "rule has no divisor"
+	}
+	var matches, lines = 0, 0
 	for _, item := range r.Items {
+		if !item.IsItem() {
+			continue
+		}
+		lines++
 		var txt = strings.TrimSpace(item.ShortDescription)
-		if math.Mod(float64(len(txt)), 3.0) == 0 {
-			var op = item.Price * 0.2
-			// log.Println(op, " -> ", math.Ceil(float64(op)))
-			totalPoints += int(math.Ceil(float64(op)))
+		if len(txt)%params.Divisor == 0 {
+			var op = float64(item.Price) * params.Factor
+			totalPoints = AddPoints(totalPoints, PointsFromFloat(math.Ceil(float64(float32(op)))))
+			matches++
 		}
 	}
-	log.Println("GetPointsFromItemsDescription -> ", totalPoints)
-	return totalPoints
+	return totalPoints, fmt.Sprintf("%d of %d trimmed item descriptions have a length multiple of %d (price * %g, rounded up)", matches, lines, params.Divisor, params.Factor)
 }
 
 // GetPointsDayIsOdd returns 6 points if the day in the purchase date is odd
 func (r *Receipt) GetPointsDayIsOdd() int {
+	return r.EvaluateRule(RuleOddDay, DefaultRuleSet())
+}
+
+func (r *Receipt) pointsDayIsOdd(params RuleParams) (int, string) {
 	var totalPoints = 0
-	// log.Println("GetPointsDayIsOdd -> ", math.Mod(float64(r.PurchaseDT.Day()), 2.0))
+	var reason = fmt.Sprintf("purchase day %d is even", r.PurchaseDT.Day())
 
 	if math.Mod(float64(r.PurchaseDT.Day()), 2.0) != 0 {
-		totalPoints = 6
+		totalPoints = params.Points
+		reason = fmt.Sprintf("purchase day %d is odd", r.PurchaseDT.Day())
 	}
-	log.Println("GetPointsDayIsOdd -> ", totalPoints)
-	return totalPoints
+	return totalPoints, reason
 }
 
 // GetPointsBetweenTime returns 10 points if the time of purchase is after 2:00pm and before 4:00pm
 func (r *Receipt) GetPointsBetweenTime() int {
+	return r.EvaluateRule(RuleAfternoonPurchase, DefaultRuleSet())
+}
+
+func (r *Receipt) pointsBetweenTime(params RuleParams) (int, string) {
 	var totalPoints = 0
 	var now = r.PurchaseDT
-	var t1 = time.Date(now.Year(), now.Month(), now.Day(), 14, 0, 0, 0, time.UTC)
-	var t2 = time.Date(now.Year(), now.Month(), now.Day(), 16, 0, 0, 0, time.UTC)
+	var t1 = time.Date(now.Year(), now.Month(), now.Day(), params.StartHour, 0, 0, 0, now.Location())
+	var t2 = time.Date(now.Year(), now.Month(), now.Day(), params.EndHour, 0, 0, 0, now.Location())
+	var reason = fmt.Sprintf("%s is not between %s and %s", now.Format("15:04"), t1.Format("15:04"), t2.Format("15:04"))
 
 	if r.PurchaseDT.After(t1) && r.PurchaseDT.Before(t2) {
-		totalPoints = 10
+		totalPoints = params.Points
+		reason = fmt.Sprintf("%s is between %s and %s", now.Format("15:04"), t1.Format("15:04"), t2.Format("15:04"))
+	}
+	return totalPoints, reason
+}
+
+// EvaluateRule returns the points of a base rule with the parameters of the rule set, 0 if it is disabled
+func (r *Receipt) EvaluateRule(name string, rs *RuleSet) int {
+	points, _ := r.ExplainRule(name, rs)
+	return points
+}
+
+// ExplainRule returns the points of a base rule with the parameters of the rule set and the reason it did or did not fire
+func (r *Receipt) ExplainRule(name string, rs *RuleSet) (int, string) {
+	params, ok := rs.Rules[name]
+	evaluate, exists := ruleEvaluators[name]
+	if !ok || !exists {
+		return 0, "rule is not defined"
+	}
+	if !params.Enabled {
+		return 0, "rule is disabled"
 	}
-	log.Println("GetPointsBetweenTime -> ", totalPoints)
-	return totalPoints
+
+	points, reason := evaluate(r, params)
+	if params.Cap > 0 && points > params.Cap {
+		reason = fmt.Sprintf("%s, capped from %d to %d points", reason, points, params.Cap)
+		points = params.Cap
+	}
+	return points, reason
+}
+
+// Fingerprint returns a hash of the retailer, purchase time, total and items, equal for duplicated receipts
+func (r *Receipt) Fingerprint() string {
+	var h = sha256.New()
+	_, _ = fmt.Fprintf(h, "%s|%s|%.2f", strings.ToLower(strings.TrimSpace(r.Retailer)), r.PurchaseDT.Format(time.RFC3339), r.Total)
+	for _, item := range r.Items {
+		_, _ = fmt.Fprintf(h, "|%s:%.2f", strings.TrimSpace(item.ShortDescription), item.Price)
+	}
+	return hex.EncodeToString(h.Sum(nil))
 }
 
 // GetTotalPoints returns all collected points
 func (r *Receipt) GetTotalPoints() int {
+	return r.GetBreakdown().BasePoints
+}
+
+// GetBreakdown returns the points awarded by every rule of the default rule set without any multiplier applied
+func (r *Receipt) GetBreakdown() *Breakdown {
+	return r.GetBreakdownWithRules(DefaultRuleSet())
+}
+
+// RuleObserver is called before a rule is evaluated, the returned function is called with the points of the rule
+type RuleObserver func(rule string) func(points *RulePoints)
+
+// GetBreakdownWithRules returns the points awarded by every rule of the rule set without any multiplier applied
+func (r *Receipt) GetBreakdownWithRules(rs *RuleSet) *Breakdown {
+	return r.ObserveBreakdown(rs, nil)
+}
+
+// ObserveBreakdown returns the breakdown of GetBreakdownWithRules reporting the evaluation of every rule to the
+// observer, when there is one
+func (r *Receipt) ObserveBreakdown(rs *RuleSet, observe RuleObserver) *Breakdown {
+	var rules = make([]*RulePoints, 0, len(RuleNames))
 	var points = 0
-	points += r.GetPointsCountingRetailerName()
-	points += r.GetPointsIfTotalRoundWithNoCents()
-	points += r.GetPointsIfTotalIsMultipleOf25Cents()
-	points += r.Get5PointForEvery2Items()
-	points += r.GetPointsFromItemsDescription()
-	points += r.GetPointsDayIsOdd()
-	points += r.GetPointsBetweenTime()
-	log.Println("GetTotalPoints -> ", points)
-	return points
+	var evaluate = func(name string, explain func() (int, string)) {
+		var done func(points *RulePoints)
+		if observe != nil {
+			done = observe(name)
+		}
+		rulePoints, reason := explain()
+		var rule = &RulePoints{Rule: name, Points: rulePoints, Reason: reason}
+		points = AddPoints(points, rule.Points)
+		rules = append(rules, rule)
+		if done != nil {
+			done(rule)
+		}
+	}
+	for _, name := range RuleNames {
+		evaluate(name, func() (int, string) {
+			return r.ExplainRule(name, rs)
+		})
+	}
+	for i := range rs.CustomRules {
+		evaluate(rs.CustomRules[i].Name, func() (int, string) {
+			return r.ExplainCustomRule(&rs.CustomRules[i])
+		})
+	}
+	return &Breakdown{RuleSetVersion: rs.Version, Rules: rules, BasePoints: points, TotalPoints: points}
 }
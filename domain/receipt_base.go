@@ -1,9 +1,36 @@
 package domain
 
+// ReceiptBase receipt as it is submitted, the dates, times and amounts have the formats of the locale
 type ReceiptBase struct {
+	MemberID     string             `json:"memberId,omitempty"`
+	Locale       string             `json:"locale,omitempty"`
 	Retailer     string             `json:"retailer,omitempty" validate:"required"`
 	PurchaseDate string             `json:"purchaseDate,omitempty" validate:"required"`
 	PurchaseTime string             `json:"purchaseTime,omitempty" validate:"required"`
+	Currency     string             `json:"currency,omitempty" validate:"omitempty,iso4217"`
 	Total        string             `json:"total,omitempty" validate:"required"`
 	Items        []*ReceiptItemBase `json:"items,omitempty" validate:"required,min=1,dive,required"`
 }
+
+// Redacted text of the fields removed from the logs
+const Redacted = "[redacted]"
+
+// Redacted returns a copy of the receipt for the logs without the descriptions and product codes of the items, which
+// reveal what the member bought
+func (r *ReceiptBase) Redacted() *ReceiptBase {
+	var redacted = *r
+	redacted.Items = make([]*ReceiptItemBase, len(r.Items))
+	for i, item := range r.Items {
+		if item == nil {
+			continue
+		}
+		var copied = *item
+		for _, field := range []*string{&copied.ShortDescription, &copied.SKU, &copied.UPC} {
+			if *field != "" {
+				*field = Redacted
+			}
+		}
+		redacted.Items[i] = &copied
+	}
+	return &redacted
+}
@@ -0,0 +1,76 @@
+package domain
+
+import (
+	"encoding/json"
+	"time"
+)
+
+const (
+	ExportFormatCSV    = "csv"
+	ExportFormatNDJSON = "ndjson"
+)
+
+// ExportFormats the formats of the receipt exports
+var ExportFormats = []string{ExportFormatCSV, ExportFormatNDJSON}
+
+// ExportColumns the columns of the flat exports, in the order of the values of Row
+var ExportColumns = []string{
+	"id", "createdAt", "memberId", "retailer", "retailerId", "purchaseDate", "purchaseTime", "currency", "total",
+	"items", "points", "basePoints", "ruleSetVersion", "tier", "experimentId", "arm", "breakdown",
+}
+
+// ReceiptExport stored receipt with its points and breakdown as it is exported, the purchase time is local to the
+// retailer
+type ReceiptExport struct {
+	ID           string     `json:"id"`
+	CreatedAt    time.Time  `json:"createdAt"`
+	MemberID     string     `json:"memberId,omitempty"`
+	Retailer     string     `json:"retailer"`
+	RetailerID   string     `json:"retailerId,omitempty"`
+	PurchaseTime time.Time  `json:"purchaseTime"`
+	Currency     string     `json:"currency,omitempty"`
+	Total        float64    `json:"total"`
+	Items        int        `json:"items"`
+	Points       int        `json:"points"`
+	ExperimentID string     `json:"experimentId,omitempty"`
+	Arm          string     `json:"arm,omitempty"`
+	Breakdown    *Breakdown `json:"breakdown,omitempty"`
+}
+
+// NewReceiptExport returns the export of a stored receipt
+func NewReceiptExport(r *Result) *ReceiptExport {
+	var export = &ReceiptExport{
+		ID:         r.ID,
+		CreatedAt:  r.CreatedAt,
+		MemberID:   r.MemberID,
+		Retailer:   r.Retailer,
+		RetailerID: r.RetailerID,
+		Points:     r.Points,
+		Breakdown:  r.Breakdown,
+	}
+	if r.Receipt != nil {
+		export.PurchaseTime = r.Receipt.PurchaseDT
+		export.Currency = r.Receipt.Currency
+		export.Total = RoundAmount(float64(r.Receipt.Total), r.Receipt.Currency)
+		export.Items = len(r.Receipt.Items)
+	}
+	if r.Experiment != nil {
+		export.ExperimentID, export.Arm = r.Experiment.ExperimentID, r.Experiment.Arm
+	}
+	return export
+}
+
+// Row returns the values of the columns of the flat exports, the breakdown is JSON
+func (e *ReceiptExport) Row() []any {
+	var basePoints, ruleSetVersion, tier, breakdown = 0, "", "", ""
+	if e.Breakdown != nil {
+		basePoints, ruleSetVersion, tier = e.Breakdown.BasePoints, e.Breakdown.RuleSetVersion, string(e.Breakdown.Tier)
+		content, _ := json.Marshal(e.Breakdown)
+		breakdown = string(content)
+	}
+	return []any{
+		e.ID, e.CreatedAt, e.MemberID, e.Retailer, e.RetailerID, e.PurchaseTime.Format("2006-01-02"),
+		e.PurchaseTime.Format("15:04"), e.Currency, e.Total, e.Items, e.Points, basePoints, ruleSetVersion, tier,
+		e.ExperimentID, e.Arm, breakdown,
+	}
+}
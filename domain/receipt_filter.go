@@ -0,0 +1,32 @@
+package domain
+
+import "time"
+
+// ReceiptFilter criteria to select stored receipts, the zero fields match every receipt
+type ReceiptFilter struct {
+	MemberID     string    `json:"memberId,omitempty"`
+	RetailerID   string    `json:"retailerId,omitempty"`
+	ExperimentID string    `json:"experimentId,omitempty"`
+	From         time.Time `json:"from,omitempty"`
+	To           time.Time `json:"to,omitempty"`
+}
+
+// Matches returns true if the stored result satisfies every criteria, From is inclusive and To exclusive
+func (f *ReceiptFilter) Matches(r *Result) bool {
+	if f.MemberID != "" && r.MemberID != f.MemberID {
+		return false
+	}
+	if f.RetailerID != "" && r.RetailerID != f.RetailerID {
+		return false
+	}
+	if f.ExperimentID != "" && (r.Experiment == nil || r.Experiment.ExperimentID != f.ExperimentID) {
+		return false
+	}
+	if !f.From.IsZero() && r.CreatedAt.Before(f.From) {
+		return false
+	}
+	if !f.To.IsZero() && !r.CreatedAt.Before(f.To) {
+		return false
+	}
+	return true
+}
@@ -0,0 +1,146 @@
+package domain
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// Fields of the CSV import, the receipt field is the key grouping the rows of a receipt
+const (
+	ImportReceipt          = "receipt"
+	ImportMemberID         = "memberId"
+	ImportLocale           = "locale"
+	ImportRetailer         = "retailer"
+	ImportPurchaseDate     = "purchaseDate"
+	ImportPurchaseTime     = "purchaseTime"
+	ImportCurrency         = "currency"
+	ImportTotal            = "total"
+	ImportItemType         = "type"
+	ImportShortDescription = "shortDescription"
+	ImportQuantity         = "quantity"
+	ImportUnitPrice        = "unitPrice"
+	ImportPrice            = "price"
+	ImportSKU              = "sku"
+	ImportUPC              = "upc"
+	ImportCategory         = "category"
+)
+
+const (
+	ImportStatusStored = "stored"
+	ImportStatusScored = "scored"
+	ImportStatusFailed = "failed"
+)
+
+// ImportReceiptFields the fields of the receipt, the same in every row of a receipt
+var ImportReceiptFields = []string{ImportMemberID, ImportLocale, ImportRetailer, ImportPurchaseDate, ImportPurchaseTime, ImportCurrency, ImportTotal}
+
+// ImportItemFields the fields of the item of a row
+var ImportItemFields = []string{ImportItemType, ImportShortDescription, ImportQuantity, ImportUnitPrice, ImportPrice, ImportSKU, ImportUPC, ImportCategory}
+
+// ImportColumns header of the column of every field
+type ImportColumns map[string]string
+
+// DefaultImportColumns the columns named like the JSON fields, with the receipt key in the receiptId column
+func DefaultImportColumns() ImportColumns {
+	var columns = ImportColumns{ImportReceipt: "receiptId"}
+	for _, field := range append(ImportReceiptFields, ImportItemFields...) {
+		columns[field] = field
+	}
+	return columns
+}
+
+// With returns the columns with the headers of the mapping replaced, the fields must be import fields
+func (c ImportColumns) With(mapping map[string]string) (ImportColumns, error) {
+	var columns = make(ImportColumns, len(c))
+	for field, header := range c {
+		columns[field] = header
+	}
+	for field, header := range mapping {
+		if _, ok := c[field]; !ok {
+			return nil, fmt.Errorf("unknown import field %s", field)
+		}
+		columns[field] = strings.TrimSpace(header)
+	}
+	return columns, nil
+}
+
+// ParseImportColumns parses a mapping like receipt:Order ID,total:Amount
+func ParseImportColumns(spec string) (map[string]string, error) {
+	var mapping = make(map[string]string)
+	for _, pair := range strings.Split(spec, ",") {
+		if strings.TrimSpace(pair) == "" {
+			continue
+		}
+		field, header, ok := strings.Cut(pair, ":")
+		if !ok {
+			return nil, fmt.Errorf("invalid column mapping %q, expected field:header", pair)
+		}
+		mapping[strings.TrimSpace(field)] = header
+	}
+	return mapping, nil
+}
+
+// ParseImportDelimiter parses a delimiter of one character or tab, empty is a comma
+func ParseImportDelimiter(delimiter string) (rune, error) {
+	switch {
+	case delimiter == "":
+		return ',', nil
+	case delimiter == "tab" || delimiter == "\t":
+		return '\t', nil
+	case utf8.RuneCountInString(delimiter) == 1:
+		return []rune(delimiter)[0], nil
+	default:
+		return 0, fmt.Errorf("invalid delimiter %q", delimiter)
+	}
+}
+
+// ImportOptions columns and delimiter of a CSV import, dry runs score the receipts without storing them
+type ImportOptions struct {
+	Columns   map[string]string
+	Delimiter rune
+	DryRun    bool
+}
+
+// ImportRow outcome of a row of a CSV import, the rows of a receipt share its outcome
+type ImportRow struct {
+	Row     int    `json:"row"`
+	Receipt string `json:"receipt"`
+	Status  string `json:"status"`
+	ID      string `json:"id,omitempty"`
+	Points  *int   `json:"points,omitempty"`
+	Error   string `json:"error,omitempty"`
+}
+
+// ImportReport outcome of a CSV import
+type ImportReport struct {
+	Receipts int          `json:"receipts"`
+	Stored   int          `json:"stored"`
+	Scored   int          `json:"scored"`
+	Failed   int          `json:"failed"`
+	Rows     []*ImportRow `json:"rows"`
+}
+
+// SortRows sorts the rows by row number
+func (r *ImportReport) SortRows() {
+	sort.SliceStable(r.Rows, func(i, j int) bool { return r.Rows[i].Row < r.Rows[j].Row })
+}
+
+// WriteCSV writes the rows of the report as CSV with a header
+func (r *ImportReport) WriteCSV(w io.Writer) error {
+	var writer = csv.NewWriter(w)
+	_ = writer.Write([]string{"row", "receipt", "status", "id", "points", "error"})
+	for _, row := range r.Rows {
+		var points = ""
+		if row.Points != nil {
+			points = strconv.Itoa(*row.Points)
+		}
+		_ = writer.Write([]string{strconv.Itoa(row.Row), row.Receipt, row.Status, row.ID, points, row.Error})
+	}
+	writer.Flush()
+	return writer.Error()
+}
@@ -0,0 +1,53 @@
+package domain
+
+import (
+	"bytes"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestImportColumns(t *testing.T) {
+	mapping, err := ParseImportColumns("receipt:Order ID, total:Amount,")
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, map[string]string{"receipt": "Order ID", "total": "Amount"}, mapping)
+
+	columns, err := DefaultImportColumns().With(mapping)
+	if assert.NoError(t, err) {
+		assert.Equal(t, "Order ID", columns[ImportReceipt])
+		assert.Equal(t, "Amount", columns[ImportTotal])
+		assert.Equal(t, "retailer", columns[ImportRetailer])
+	}
+	// The defaults are not modified
+	assert.Equal(t, "receiptId", DefaultImportColumns()[ImportReceipt])
+
+	_, err = DefaultImportColumns().With(map[string]string{"store": "Store"})
+	assert.Error(t, err)
+	_, err = ParseImportColumns("receipt")
+	assert.Error(t, err)
+}
+
+func TestParseImportDelimiter(t *testing.T) {
+	var testCases = map[string]rune{"": ',', ";": ';', "tab": '\t', "|": '|'}
+	for delimiter, expected := range testCases {
+		comma, err := ParseImportDelimiter(delimiter)
+		assert.NoError(t, err, delimiter)
+		assert.Equal(t, expected, comma, delimiter)
+	}
+	_, err := ParseImportDelimiter(";;")
+	assert.Error(t, err)
+}
+
+func TestImportReport_WriteCSV(t *testing.T) {
+	var points = 28
+	var report = &ImportReport{Rows: []*ImportRow{
+		{Row: 3, Receipt: "A1", Status: ImportStatusStored, ID: "id-1", Points: &points},
+		{Row: 2, Receipt: "B2", Status: ImportStatusFailed, Error: "Field: Total, Error: required"},
+	}}
+	report.SortRows()
+
+	var content bytes.Buffer
+	assert.NoError(t, report.WriteCSV(&content))
+	assert.Equal(t, "row,receipt,status,id,points,error\n2,B2,failed,,,\"Field: Total, Error: required\"\n3,A1,stored,id-1,28,\n", content.String())
+}
@@ -1,6 +1,52 @@
 package domain
 
+import "math"
+
+const (
+	ItemLineItem     = "item"
+	ItemLineDiscount = "discount"
+	ItemLineTax      = "tax"
+)
+
+// MaxItemQuantity the greatest quantity of a receipt line
+const MaxItemQuantity = 9999
+
+// ReceiptItem line of the receipt. Price is the amount of the line, negative for discounts. Lines without type are
+// item lines.
 type ReceiptItem struct {
+	Type             string  `json:"type,omitempty"`
 	ShortDescription string  `json:"shortDescription" validate:"required"`
+	Quantity         float32 `json:"quantity,omitempty"`
+	UnitPrice        float32 `json:"unitPrice,omitempty"`
 	Price            float32 `json:"price" validate:"required"`
+	SKU              string  `json:"sku,omitempty"`
+	UPC              string  `json:"upc,omitempty"`
+	Category         string  `json:"category,omitempty"`
+}
+
+// IsItem returns true for the lines of purchased items, discount and tax lines are not items
+func (i *ReceiptItem) IsItem() bool {
+	return i.Type == "" || i.Type == ItemLineItem
+}
+
+// Units returns the number of items of the line. Lines without quantity are one item and the fractional quantities
+// of goods sold by weight count as one item.
+func (i *ReceiptItem) Units() int {
+	switch {
+	case !i.IsItem():
+		return 0
+	case i.Quantity <= 0 || i.Quantity != float32(math.Trunc(float64(i.Quantity))):
+		return 1
+	default:
+		return int(i.Quantity)
+	}
+}
+
+// ItemUnits returns the number of items purchased, the sum of the units of every item line
+func (r *Receipt) ItemUnits() int {
+	var units = 0
+	for _, item := range r.Items {
+		units += item.Units()
+	}
+	return units
 }
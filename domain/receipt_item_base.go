@@ -1,6 +1,14 @@
 package domain
 
+// ReceiptItemBase line of the receipt as it is submitted. Price is the amount of the line, it can be omitted when the
+// quantity and unitPrice are given.
 type ReceiptItemBase struct {
+	Type             string `json:"type,omitempty" validate:"omitempty,oneof=item discount tax"`
 	ShortDescription string `json:"shortDescription" validate:"required"`
-	Price            string `json:"price" validate:"required"`
+	Quantity         string `json:"quantity,omitempty"`
+	UnitPrice        string `json:"unitPrice,omitempty"`
+	Price            string `json:"price" validate:"required_without=UnitPrice"`
+	SKU              string `json:"sku,omitempty"`
+	UPC              string `json:"upc,omitempty" validate:"omitempty,numeric,min=8,max=14"`
+	Category         string `json:"category,omitempty"`
 }
@@ -159,3 +159,96 @@ func TestReceipt_GetTotalPoints(t *testing.T) {
 		assert.Equal(t, key.TotalPoints, total)
 	}
 }
+
+func TestReceipt_ObserveBreakdown(t *testing.T) {
+	var rs = DefaultRuleSet()
+	rs.CustomRules = []CustomRule{{Name: "bigBasket", Expression: `items.count >= 3 ? 15 : 0`}}
+	assert.NoError(t, rs.Compile())
+
+	for _, receipt := range testCases {
+		var receipt = receipt
+		var started = make([]string, 0)
+		var observed = make([]*RulePoints, 0)
+		var breakdown = receipt.ObserveBreakdown(rs, func(rule string) func(points *RulePoints) {
+			started = append(started, rule)
+			return func(points *RulePoints) {
+				assert.Equal(t, rule, points.Rule)
+				observed = append(observed, points)
+			}
+		})
+
+		assert.Equal(t, append(append([]string{}, RuleNames...), "bigBasket"), started)
+		assert.Equal(t, breakdown.Rules, observed)
+		assert.Equal(t, receipt.GetBreakdownWithRules(rs), breakdown)
+	}
+}
+
+func TestReceipt_ItemLines(t *testing.T) {
+	var receipt = &Receipt{
+		Retailer:   "M&M Corner Market",
+		PurchaseDT: time.Date(2022, 3, 20, 14, 33, 0, 0, time.UTC),
+		Items: []*ReceiptItem{
+			{ShortDescription: "Gatorade", Quantity: 3, UnitPrice: 2.25, Price: 6.75},
+			{ShortDescription: "Bananas", Quantity: 1.25, UnitPrice: 0.6, Price: 0.75},
+			{ShortDescription: "Loyalty coupon", Type: ItemLineDiscount, Price: -0.50},
+			{ShortDescription: "Sales tax", Type: ItemLineTax, Price: 0.50},
+			{ShortDescription: "Pepsi - 12-oz", Price: 1.25},
+		},
+		Total: 8.75,
+	}
+
+	assert.Equal(t, []int{3, 1, 0, 0, 1}, []int{
+		receipt.Items[0].Units(), receipt.Items[1].Units(), receipt.Items[2].Units(), receipt.Items[3].Units(), receipt.Items[4].Units(),
+	})
+	assert.Equal(t, 5, receipt.ItemUnits())
+	// 5 units make 2 pairs
+	assert.Equal(t, 10, receipt.Get5PointForEvery2Items())
+	// None of the 3 item descriptions has a length multiple of 3 and the coupon and tax lines are skipped
+	points, reason := receipt.ExplainRule(RuleItemDescription, DefaultRuleSet())
+	assert.Equal(t, 0, points)
+	assert.Equal(t, "0 of 3 trimmed item descriptions have a length multiple of 3 (price * 0.2, rounded up)", reason)
+
+	receipt.Items[2].ShortDescription = "Coupon"
+	receipt.Items[3].ShortDescription = "Tax"
+	assert.Equal(t, 0, receipt.GetPointsFromItemsDescription())
+	receipt.Items[1].ShortDescription = "Banana"
+	assert.Equal(t, 1, receipt.GetPointsFromItemsDescription())
+
+	var campaign = &Campaign{
+		StartsAt:    time.Date(2022, 3, 1, 0, 0, 0, 0, time.UTC),
+		EndsAt:      time.Date(2022, 4, 1, 0, 0, 0, 0, time.UTC),
+		ItemMatcher: "coupon",
+	}
+	assert.False(t, campaign.Matches(receipt))
+	campaign.ItemMatcher = "gatorade"
+	assert.True(t, campaign.Matches(receipt))
+}
+
+func TestReceiptBase_Redacted(t *testing.T) {
+	var receipt = &ReceiptBase{
+		MemberID:     "member-1",
+		Retailer:     "Walgreens",
+		PurchaseDate: "2022-01-02",
+		PurchaseTime: "08:13",
+		Total:        "2.65",
+		Items: []*ReceiptItemBase{
+			{ShortDescription: "Pepsi - 12-oz", Price: "1.25", SKU: "PEP-12", UPC: "012000001291"},
+			{ShortDescription: "Dasani", Price: "1.40", Category: "beverages"},
+		},
+	}
+
+	var redacted = receipt.Redacted()
+	assert.Equal(t, &ReceiptBase{
+		MemberID:     "member-1",
+		Retailer:     "Walgreens",
+		PurchaseDate: "2022-01-02",
+		PurchaseTime: "08:13",
+		Total:        "2.65",
+		Items: []*ReceiptItemBase{
+			{ShortDescription: Redacted, Price: "1.25", SKU: Redacted, UPC: Redacted},
+			{ShortDescription: Redacted, Price: "1.40", Category: "beverages"},
+		},
+	}, redacted)
+	// The receipt is not modified
+	assert.Equal(t, "Pepsi - 12-oz", receipt.Items[0].ShortDescription)
+}
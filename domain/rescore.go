@@ -0,0 +1,55 @@
+package domain
+
+// RescoreRequest re-scoring of the stored receipts matching the filter with a rule set version
+type RescoreRequest struct {
+	RuleSetVersion string         `json:"ruleSetVersion" validate:"required"`
+	Filter         *ReceiptFilter `json:"filter,omitempty"`
+	Commit         bool           `json:"commit"`
+}
+
+// RescoreDiff old and new points of a receipt
+type RescoreDiff struct {
+	ReceiptID string `json:"receiptId"`
+	OldPoints int    `json:"oldPoints"`
+	NewPoints int    `json:"newPoints"`
+	Delta     int    `json:"delta"`
+}
+
+// RescoreSummary aggregated deltas of a re-scoring
+type RescoreSummary struct {
+	Receipts  int `json:"receipts"`
+	Changed   int `json:"changed"`
+	Increased int `json:"increased"`
+	Decreased int `json:"decreased"`
+	OldTotal  int `json:"oldTotal"`
+	NewTotal  int `json:"newTotal"`
+	Delta     int `json:"delta"`
+}
+
+// RescoreReport diff between the stored points and the points under the requested rule set
+type RescoreReport struct {
+	RuleSetVersion string          `json:"ruleSetVersion"`
+	Committed      bool            `json:"committed"`
+	Summary        *RescoreSummary `json:"summary"`
+	Receipts       []*RescoreDiff  `json:"receipts"`
+}
+
+// Add records the diff of a receipt and updates the summary
+func (r *RescoreReport) Add(receiptID string, oldPoints, newPoints int) *RescoreDiff {
+	var diff = &RescoreDiff{ReceiptID: receiptID, OldPoints: oldPoints, NewPoints: newPoints, Delta: AddPoints(newPoints, -oldPoints)}
+	r.Receipts = append(r.Receipts, diff)
+
+	r.Summary.Receipts++
+	r.Summary.OldTotal = AddPoints(r.Summary.OldTotal, oldPoints)
+	r.Summary.NewTotal = AddPoints(r.Summary.NewTotal, newPoints)
+	r.Summary.Delta = AddPoints(r.Summary.Delta, diff.Delta)
+	switch {
+	case diff.Delta > 0:
+		r.Summary.Changed++
+		r.Summary.Increased++
+	case diff.Delta < 0:
+		r.Summary.Changed++
+		r.Summary.Decreased++
+	}
+	return diff
+}
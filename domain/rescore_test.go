@@ -0,0 +1,26 @@
+package domain
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestRescoreReport_Add(t *testing.T) {
+	var report = &RescoreReport{Summary: &RescoreSummary{}}
+
+	report.Add("r1", 10, 25)
+	report.Add("r2", 30, 20)
+	var diff = report.Add("r3", 5, 5)
+
+	assert.Equal(t, 0, diff.Delta)
+	assert.Len(t, report.Receipts, 3)
+	assert.Equal(t, &RescoreSummary{
+		Receipts:  3,
+		Changed:   2,
+		Increased: 1,
+		Decreased: 1,
+		OldTotal:  45,
+		NewTotal:  50,
+		Delta:     5,
+	}, report.Summary)
+}
@@ -1,6 +1,15 @@
 package domain
 
+import "time"
+
 type Result struct {
-	ID     string `json:"-"`
-	Points int16  `json:"points"`
+	ID         string                `json:"-"`
+	MemberID   string                `json:"-"`
+	Retailer   string                `json:"-"`
+	RetailerID string                `json:"-"`
+	Points     int                   `json:"points"`
+	Breakdown  *Breakdown            `json:"breakdown,omitempty"`
+	Receipt    *Receipt              `json:"-"`
+	Experiment *ExperimentAssignment `json:"-"`
+	CreatedAt  time.Time             `json:"-"`
 }
@@ -0,0 +1,41 @@
+package domain
+
+import "strings"
+
+// Retailer entry of the retailer catalog
+type Retailer struct {
+	ID            string                   `json:"id"`
+	Name          string                   `json:"name" validate:"required"`
+	Aliases       []string                 `json:"aliases,omitempty"`
+	TimeZone      string                   `json:"timeZone,omitempty" validate:"omitempty,timezone"`
+	Category      string                   `json:"category,omitempty"`
+	RuleOverrides map[string]*RuleOverride `json:"ruleOverrides,omitempty"`
+}
+
+// RetailerCategory rule overrides shared by all the retailers of a category
+type RetailerCategory struct {
+	Name          string                   `json:"name" validate:"required"`
+	RuleOverrides map[string]*RuleOverride `json:"ruleOverrides,omitempty"`
+}
+
+// Names returns the canonical name followed by the aliases
+func (r *Retailer) Names() []string {
+	return append([]string{r.Name}, r.Aliases...)
+}
+
+// MatchesName returns true if the name is the canonical name or an alias, ignoring case and surrounding spaces
+func (r *Retailer) MatchesName(name string) bool {
+	name = strings.TrimSpace(name)
+	for _, n := range r.Names() {
+		if strings.EqualFold(strings.TrimSpace(n), name) {
+			return true
+		}
+	}
+	return false
+}
+
+// RetailerCatalog content of a retailer catalog file
+type RetailerCatalog struct {
+	Retailers  []*Retailer         `json:"retailers"`
+	Categories []*RetailerCategory `json:"categories,omitempty"`
+}
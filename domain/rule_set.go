@@ -0,0 +1,115 @@
+package domain
+
+const (
+	RuleRetailerName      = "retailerName"
+	RuleRoundDollar       = "roundDollar"
+	RuleMultipleOf25Cents = "multipleOf25Cents"
+	RuleEveryTwoItems     = "everyTwoItems"
+	RuleItemDescription   = "itemDescription"
+	RuleOddDay            = "oddDay"
+	RuleAfternoonPurchase = "afternoonPurchase"
+)
+
+// RuleNames the base rules in evaluation order
+var RuleNames = []string{
+	RuleRetailerName,
+	RuleRoundDollar,
+	RuleMultipleOf25Cents,
+	RuleEveryTwoItems,
+	RuleItemDescription,
+	RuleOddDay,
+	RuleAfternoonPurchase,
+}
+
+// RuleParams parameters of a base rule. Not every rule uses every parameter.
+type RuleParams struct {
+	Enabled   bool    `json:"enabled"`
+	Points    int     `json:"points"`
+	Cap       int     `json:"cap,omitempty"`
+	Factor    float64 `json:"factor,omitempty"`
+	Divisor   int     `json:"divisor,omitempty"`
+	StartHour int     `json:"startHour,omitempty"`
+	EndHour   int     `json:"endHour,omitempty"`
+}
+
+// RuleOverride partial replacement of the parameters of a rule, the nil fields keep the current value
+type RuleOverride struct {
+	Enabled   *bool    `json:"enabled,omitempty"`
+	Points    *int     `json:"points,omitempty"`
+	Cap       *int     `json:"cap,omitempty"`
+	Factor    *float64 `json:"factor,omitempty"`
+	Divisor   *int     `json:"divisor,omitempty"`
+	StartHour *int     `json:"startHour,omitempty"`
+	EndHour   *int     `json:"endHour,omitempty"`
+}
+
+// DefaultRuleSetVersion version of the rule set built in the service
+const DefaultRuleSetVersion = "default"
+
+// RuleSet versioned parameters of every base rule and the custom rules evaluated after them
+type RuleSet struct {
+	Version     string                `json:"version" validate:"required"`
+	Rules       map[string]RuleParams `json:"rules" validate:"required"`
+	CustomRules []CustomRule          `json:"customRules,omitempty" validate:"dive"`
+}
+
+// DefaultRuleSet returns the built-in rule set
+func DefaultRuleSet() *RuleSet {
+	return &RuleSet{
+		Version: DefaultRuleSetVersion,
+		Rules: map[string]RuleParams{
+			RuleRetailerName:      {Enabled: true, Points: 1},
+			RuleRoundDollar:       {Enabled: true, Points: 50},
+			RuleMultipleOf25Cents: {Enabled: true, Points: 25},
+			RuleEveryTwoItems:     {Enabled: true, Points: 5},
+			RuleItemDescription:   {Enabled: true, Factor: 0.2, Divisor: 3},
+			RuleOddDay:            {Enabled: true, Points: 6},
+			RuleAfternoonPurchase: {Enabled: true, Points: 10, StartHour: 14, EndHour: 16},
+		},
+	}
+}
+
+// WithOverrides returns a copy of the rule set with the overrides applied
+func (rs *RuleSet) WithOverrides(overrides map[string]*RuleOverride) *RuleSet {
+	var rules = make(map[string]RuleParams, len(rs.Rules))
+	for name, params := range rs.Rules {
+		rules[name] = params
+	}
+	for name, override := range overrides {
+		if override == nil {
+			continue
+		}
+		rules[name] = override.Apply(rules[name])
+	}
+	var customRules []CustomRule
+	if rs.CustomRules != nil {
+		customRules = append(make([]CustomRule, 0, len(rs.CustomRules)), rs.CustomRules...)
+	}
+	return &RuleSet{Version: rs.Version, Rules: rules, CustomRules: customRules}
+}
+
+// Apply returns the params with the override fields replaced
+func (o *RuleOverride) Apply(params RuleParams) RuleParams {
+	if o.Enabled != nil {
+		params.Enabled = *o.Enabled
+	}
+	if o.Points != nil {
+		params.Points = *o.Points
+	}
+	if o.Cap != nil {
+		params.Cap = *o.Cap
+	}
+	if o.Factor != nil {
+		params.Factor = *o.Factor
+	}
+	if o.Divisor != nil {
+		params.Divisor = *o.Divisor
+	}
+	if o.StartHour != nil {
+		params.StartHour = *o.StartHour
+	}
+	if o.EndHour != nil {
+		params.EndHour = *o.EndHour
+	}
+	return params
+}
@@ -0,0 +1,53 @@
+package domain
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestRuleSet_WithOverrides(t *testing.T) {
+	var disabled = false
+	var points = 100
+	var maxPoints = 5
+
+	var rs = DefaultRuleSet().WithOverrides(map[string]*RuleOverride{
+		RuleRoundDollar:  {Enabled: &disabled},
+		RuleOddDay:       {Points: &points},
+		RuleRetailerName: {Cap: &maxPoints},
+	})
+
+	assert.False(t, rs.Rules[RuleRoundDollar].Enabled)
+	assert.Equal(t, 100, rs.Rules[RuleOddDay].Points)
+	assert.Equal(t, RuleParams{Enabled: true, Points: 1, Cap: 5}, rs.Rules[RuleRetailerName])
+	// The default rule set is not modified
+	assert.True(t, DefaultRuleSet().Rules[RuleRoundDollar].Enabled)
+}
+
+func TestReceipt_GetBreakdownWithRules(t *testing.T) {
+	var disabled = false
+	var maxPoints = 5
+	var receipt = &Receipt{
+		Retailer:   "M&M Corner Market",
+		PurchaseDT: time.Date(2022, 3, 20, 14, 33, 0, 0, time.UTC),
+		Items: []*ReceiptItem{
+			{ShortDescription: "Gatorade", Price: 2.25},
+			{ShortDescription: "Gatorade", Price: 2.25},
+			{ShortDescription: "Gatorade", Price: 2.25},
+			{ShortDescription: "Gatorade", Price: 2.25},
+		},
+		Total: 9.00,
+	}
+
+	var rs = DefaultRuleSet().WithOverrides(map[string]*RuleOverride{
+		RuleRoundDollar:  {Enabled: &disabled},
+		RuleRetailerName: {Cap: &maxPoints},
+	})
+	var breakdown = receipt.GetBreakdownWithRules(rs)
+
+	// 109 points of the default rule set - 50 of round dollar - 9 over the retailer name cap
+	assert.Equal(t, 50, breakdown.BasePoints)
+	assert.Equal(t, &RulePoints{Rule: RuleRoundDollar, Points: 0, Reason: "rule is disabled"}, breakdown.Rules[1])
+	assert.Equal(t, &RulePoints{Rule: RuleRetailerName, Points: 5, Reason: "retailer name (M&M Corner Market) has 14 alphanumeric characters, capped from 14 to 5 points"}, breakdown.Rules[0])
+	assert.Equal(t, &RulePoints{Rule: RuleAfternoonPurchase, Points: 10, Reason: "14:33 is between 14:00 and 16:00"}, breakdown.Rules[6])
+}
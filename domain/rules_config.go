@@ -0,0 +1,9 @@
+package domain
+
+import "time"
+
+type RulesConfig struct {
+	ActiveRuleSetVersion string        `envconfig:"RULES_ACTIVE_VERSION" default:"default"`
+	ExpressionMaxSteps   int           `envconfig:"RULES_EXPRESSION_MAX_STEPS" default:"10000"`
+	ExpressionTimeout    time.Duration `envconfig:"RULES_EXPRESSION_TIMEOUT" default:"10ms"`
+}
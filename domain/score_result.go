@@ -0,0 +1,10 @@
+package domain
+
+// ScoreResult outcome of scoring a receipt, including the warnings found while scoring it
+type ScoreResult struct {
+	Points     int                   `json:"points"`
+	Breakdown  *Breakdown            `json:"breakdown"`
+	Warnings   []string              `json:"warnings"`
+	Experiment *ExperimentAssignment `json:"experiment,omitempty"`
+	Receipt    *Receipt              `json:"-"`
+}
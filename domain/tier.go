@@ -0,0 +1,10 @@
+package domain
+
+// Tier loyalty level of a member
+type Tier string
+
+const (
+	TierBronze Tier = "bronze"
+	TierSilver Tier = "silver"
+	TierGold   Tier = "gold"
+)
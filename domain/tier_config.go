@@ -0,0 +1,47 @@
+package domain
+
+import "time"
+
+type TierConfig struct {
+	SilverThreshold  int           `envconfig:"TIER_SILVER_THRESHOLD" default:"1000"`
+	GoldThreshold    int           `envconfig:"TIER_GOLD_THRESHOLD" default:"5000"`
+	BronzeMultiplier float64       `envconfig:"TIER_BRONZE_MULTIPLIER" default:"1"`
+	SilverMultiplier float64       `envconfig:"TIER_SILVER_MULTIPLIER" default:"1.25"`
+	GoldMultiplier   float64       `envconfig:"TIER_GOLD_MULTIPLIER" default:"1.5"`
+	Window           time.Duration `envconfig:"TIER_WINDOW" default:"8760h"`
+	RecomputeHour    int           `envconfig:"TIER_RECOMPUTE_HOUR" default:"3"`
+}
+
+// TierFor returns the tier reached with the points collected in the rolling window
+func (c TierConfig) TierFor(points int) Tier {
+	switch {
+	case points >= c.GoldThreshold:
+		return TierGold
+	case points >= c.SilverThreshold:
+		return TierSilver
+	default:
+		return TierBronze
+	}
+}
+
+// MultiplierFor returns the earning multiplier of the tier
+func (c TierConfig) MultiplierFor(tier Tier) float64 {
+	switch tier {
+	case TierGold:
+		return c.GoldMultiplier
+	case TierSilver:
+		return c.SilverMultiplier
+	default:
+		return c.BronzeMultiplier
+	}
+}
+
+// NextRecompute returns the next time the tiers must be recomputed after t
+func (c TierConfig) NextRecompute(t time.Time) time.Time {
+	t = t.UTC()
+	next := time.Date(t.Year(), t.Month(), t.Day(), c.RecomputeHour, 0, 0, 0, time.UTC)
+	if !next.After(t) {
+		next = next.AddDate(0, 0, 1)
+	}
+	return next
+}
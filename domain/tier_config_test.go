@@ -0,0 +1,46 @@
+package domain
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestTierConfig_TierFor(t *testing.T) {
+	var cfg = TierConfig{SilverThreshold: 1000, GoldThreshold: 5000}
+	var testCases = map[int]Tier{
+		0:     TierBronze,
+		999:   TierBronze,
+		1000:  TierSilver,
+		4999:  TierSilver,
+		5000:  TierGold,
+		12000: TierGold,
+	}
+
+	for points, tier := range testCases {
+		assert.Equal(t, tier, cfg.TierFor(points))
+	}
+}
+
+func TestTierConfig_NextRecompute(t *testing.T) {
+	var cfg = TierConfig{RecomputeHour: 3}
+
+	t.Run("Same day", func(t *testing.T) {
+		next := cfg.NextRecompute(time.Date(2022, 1, 1, 1, 30, 0, 0, time.UTC))
+		assert.Equal(t, time.Date(2022, 1, 1, 3, 0, 0, 0, time.UTC), next)
+	})
+
+	t.Run("Next day", func(t *testing.T) {
+		next := cfg.NextRecompute(time.Date(2022, 1, 1, 3, 0, 0, 0, time.UTC))
+		assert.Equal(t, time.Date(2022, 1, 2, 3, 0, 0, 0, time.UTC), next)
+	})
+}
+
+func TestBreakdown_ApplyTierMultiplier(t *testing.T) {
+	var breakdown = &Breakdown{BasePoints: 109, TotalPoints: 109}
+	breakdown.ApplyTierMultiplier(TierSilver, 1.25)
+
+	assert.Equal(t, 136, breakdown.TotalPoints)
+	assert.Equal(t, TierSilver, breakdown.Tier)
+	assert.Equal(t, 1.25, breakdown.TierMultiplier)
+}
@@ -0,0 +1,26 @@
+package domain
+
+// Tracing exporters
+const (
+	TracingExporterNone   = "none"
+	TracingExporterStdout = "stdout"
+	TracingExporterFile   = "file"
+	TracingExporterOTLP   = "otlp"
+)
+
+type TracingConfig struct {
+	// TracingExporter where the spans are sent: none, stdout, file or otlp. With none the spans are still created, so
+	// the trace ids are propagated and logged, but they are not exported
+	TracingExporter string `envconfig:"TRACING_EXPORTER" default:"none"`
+	// TracingFile file the spans are appended to as JSON with the file exporter
+	TracingFile string `envconfig:"TRACING_FILE" default:"traces.json"`
+	// TracingOTLPEndpoint host:port of the OTLP/HTTP collector, the OTEL_EXPORTER_OTLP_* variables are used when empty
+	TracingOTLPEndpoint string `envconfig:"TRACING_OTLP_ENDPOINT"`
+	// TracingOTLPInsecure sends the spans to the collector over http instead of https
+	TracingOTLPInsecure bool `envconfig:"TRACING_OTLP_INSECURE" default:"false"`
+	// TracingSampleRatio ratio of the traces started by the API that are sampled, the traces of the callers keep
+	// their sampling decision
+	TracingSampleRatio float64 `envconfig:"TRACING_SAMPLE_RATIO" default:"1"`
+	// TracingServiceName service name of the spans
+	TracingServiceName string `envconfig:"TRACING_SERVICE_NAME" default:"receipt-processor"`
+}
@@ -0,0 +1,97 @@
+package handlers
+
+import (
+	"github.com/go-chi/chi/v5"
+	"github.com/kiramishima/receipt-processor/domain"
+	"github.com/kiramishima/receipt-processor/pkg/logging"
+	"github.com/kiramishima/receipt-processor/pkg/utils"
+	ports "github.com/kiramishima/receipt-processor/ports/services"
+	"github.com/unrolled/render"
+	"go.uber.org/zap"
+	"net/http"
+)
+
+// NewAPIKeyHandlers creates a instance of API key handlers
+func NewAPIKeyHandlers(r *chi.Mux, logger *zap.SugaredLogger, s ports.IAPIKeyService, render *render.Render) {
+	handler := &APIKeyHandlers{
+		logger:   logger,
+		service:  s,
+		response: render,
+	}
+
+	r.Route("/admin/api-keys", func(r chi.Router) {
+		r.Post("/", handler.IssueAPIKeyHandler)
+		r.Get("/", handler.ListAPIKeysHandler)
+		r.Get("/{id}", handler.GetAPIKeyHandler)
+		r.Post("/{id}/rotate", handler.RotateAPIKeyHandler)
+		r.Delete("/{id}", handler.RevokeAPIKeyHandler)
+	})
+}
+
+type APIKeyHandlers struct {
+	logger   *zap.SugaredLogger
+	service  ports.IAPIKeyService
+	response *render.Render
+}
+
+func (h *APIKeyHandlers) IssueAPIKeyHandler(w http.ResponseWriter, req *http.Request) {
+	var jsonReq = &domain.APIKeyRequest{}
+
+	if err := utils.ReadJSON(w, req, &jsonReq); err != nil {
+		logging.Logger(req.Context(), h.logger).Error(err.Error())
+		_ = h.response.JSON(w, http.StatusBadRequest, errorBody(req, err))
+		return
+	}
+
+	key, err := h.service.IssueAPIKey(req.Context(), jsonReq)
+	if err != nil {
+		logging.Logger(req.Context(), h.logger).Error(err.Error())
+		_ = h.response.JSON(w, errorStatus(err), errorBody(req, err))
+		return
+	}
+
+	_ = h.response.JSON(w, http.StatusCreated, key)
+}
+
+func (h *APIKeyHandlers) ListAPIKeysHandler(w http.ResponseWriter, req *http.Request) {
+	keys, err := h.service.ListAPIKeys()
+	if err != nil {
+		logging.Logger(req.Context(), h.logger).Error(err.Error())
+		_ = h.response.JSON(w, errorStatus(err), errorBody(req, err))
+		return
+	}
+
+	_ = h.response.JSON(w, http.StatusOK, keys)
+}
+
+func (h *APIKeyHandlers) GetAPIKeyHandler(w http.ResponseWriter, req *http.Request) {
+	key, err := h.service.RetrieveAPIKey(chi.URLParam(req, "id"))
+	if err != nil {
+		logging.Logger(req.Context(), h.logger).Error(err.Error())
+		_ = h.response.JSON(w, errorStatus(err), errorBody(req, err))
+		return
+	}
+
+	_ = h.response.JSON(w, http.StatusOK, key)
+}
+
+func (h *APIKeyHandlers) RotateAPIKeyHandler(w http.ResponseWriter, req *http.Request) {
+	key, err := h.service.RotateAPIKey(req.Context(), chi.URLParam(req, "id"))
+	if err != nil {
+		logging.Logger(req.Context(), h.logger).Error(err.Error())
+		_ = h.response.JSON(w, errorStatus(err), errorBody(req, err))
+		return
+	}
+
+	_ = h.response.JSON(w, http.StatusCreated, key)
+}
+
+func (h *APIKeyHandlers) RevokeAPIKeyHandler(w http.ResponseWriter, req *http.Request) {
+	if err := h.service.RevokeAPIKey(req.Context(), chi.URLParam(req, "id")); err != nil {
+		logging.Logger(req.Context(), h.logger).Error(err.Error())
+		_ = h.response.JSON(w, errorStatus(err), errorBody(req, err))
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
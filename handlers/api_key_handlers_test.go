@@ -0,0 +1,130 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"github.com/go-chi/chi/v5"
+	"github.com/kiramishima/receipt-processor/domain"
+	"github.com/kiramishima/receipt-processor/mocks"
+	appErrors "github.com/kiramishima/receipt-processor/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/unrolled/render"
+	"go.uber.org/mock/gomock"
+	"go.uber.org/zap"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestAPIKeyHandlers(t *testing.T) {
+	var key = &domain.APIKey{
+		ID:        "5f0c3a62",
+		Name:      "pos",
+		Prefix:    "rp_AbCdEfGh",
+		Hash:      "secret-hash",
+		Scopes:    []string{domain.ScopeReceiptsWrite},
+		CreatedAt: time.Date(2022, 3, 20, 0, 0, 0, 0, time.UTC),
+	}
+	var issued = &domain.IssuedAPIKey{APIKey: key, Key: "rp_AbCdEfGhIjKl"}
+
+	testCases := map[string]struct {
+		method        string
+		url           string
+		body          any
+		buildStubs    func(uc *mocks.MockIAPIKeyService)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		"Issue": {
+			method: http.MethodPost,
+			url:    "/admin/api-keys",
+			body:   &domain.APIKeyRequest{Name: "pos", Scopes: []string{domain.ScopeReceiptsWrite}},
+			buildStubs: func(uc *mocks.MockIAPIKeyService) {
+				uc.EXPECT().IssueAPIKey(gomock.Any(), gomock.Any()).Times(1).Return(issued, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusCreated, recorder.Code)
+				assert.Contains(t, recorder.Body.String(), `"key":"rp_AbCdEfGhIjKl"`)
+				assert.NotContains(t, recorder.Body.String(), "secret-hash")
+			},
+		},
+		"Issue invalid": {
+			method: http.MethodPost,
+			url:    "/admin/api-keys",
+			body:   &domain.APIKeyRequest{Name: "pos"},
+			buildStubs: func(uc *mocks.MockIAPIKeyService) {
+				uc.EXPECT().IssueAPIKey(gomock.Any(), gomock.Any()).Times(1).Return(nil, fmt.Errorf("%w: Field: Scopes, Error: required", appErrors.BadRequest))
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		"List": {
+			method: http.MethodGet,
+			url:    "/admin/api-keys",
+			buildStubs: func(uc *mocks.MockIAPIKeyService) {
+				uc.EXPECT().ListAPIKeys().Times(1).Return([]*domain.APIKey{key}, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusOK, recorder.Code)
+				assert.NotContains(t, recorder.Body.String(), "secret-hash")
+			},
+		},
+		"Get not found": {
+			method: http.MethodGet,
+			url:    "/admin/api-keys/unknown",
+			buildStubs: func(uc *mocks.MockIAPIKeyService) {
+				uc.EXPECT().RetrieveAPIKey(gomock.Eq("unknown")).Times(1).Return(nil, fmt.Errorf("API key with id: unknown %w", appErrors.NotFound))
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		"Rotate": {
+			method: http.MethodPost,
+			url:    fmt.Sprintf("/admin/api-keys/%s/rotate", key.ID),
+			buildStubs: func(uc *mocks.MockIAPIKeyService) {
+				uc.EXPECT().RotateAPIKey(gomock.Any(), gomock.Eq(key.ID)).Times(1).Return(issued, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusCreated, recorder.Code)
+			},
+		},
+		"Revoke": {
+			method: http.MethodDelete,
+			url:    fmt.Sprintf("/admin/api-keys/%s", key.ID),
+			buildStubs: func(uc *mocks.MockIAPIKeyService) {
+				uc.EXPECT().RevokeAPIKey(gomock.Any(), gomock.Eq(key.ID)).Times(1).Return(nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusNoContent, recorder.Code)
+			},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			uc := mocks.NewMockIAPIKeyService(ctrl)
+			tc.buildStubs(uc)
+
+			recorder := httptest.NewRecorder()
+
+			var body []byte
+			if tc.body != nil {
+				body, _ = json.Marshal(tc.body)
+			}
+			request, err := http.NewRequest(tc.method, tc.url, bytes.NewReader(body))
+			assert.NoError(t, err)
+
+			router := chi.NewRouter()
+			logger, _ := zap.NewProduction()
+			NewAPIKeyHandlers(router, logger.Sugar(), uc, render.New())
+			router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
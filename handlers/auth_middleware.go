@@ -0,0 +1,95 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/kiramishima/receipt-processor/domain"
+	"github.com/kiramishima/receipt-processor/pkg/auth"
+	appErrors "github.com/kiramishima/receipt-processor/pkg/errors"
+	"github.com/kiramishima/receipt-processor/pkg/logging"
+	ports "github.com/kiramishima/receipt-processor/ports/services"
+	"github.com/unrolled/render"
+	"go.uber.org/zap"
+)
+
+// APIKeyHeader header with the API key of the client, the key can be sent as a bearer token too
+const APIKeyHeader = "X-API-Key"
+
+// publicPaths routes without authentication, the probes and the scraper of the metrics don't have keys
+var publicPaths = map[string]bool{
+	"/healthz": true,
+	"/livez":   true,
+	"/readyz":  true,
+	"/metrics": true,
+}
+
+// AuthMiddleware requires an active API key or a valid JWT with the scope of the route: admin for the /admin routes,
+// receipts:read to read and receipts:write to submit receipts. The principal is placed in the context of the request.
+// It goes after the LoggingMiddleware, so the rejected requests are logged too.
+func AuthMiddleware(apiKeys ports.IAPIKeyService, tokens ports.ITokenService, response *render.Render, logger *zap.SugaredLogger) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			if publicPaths[req.URL.Path] {
+				next.ServeHTTP(w, req)
+				return
+			}
+
+			principal, err := authenticate(req, apiKeys, tokens)
+			if err != nil {
+				logging.Logger(req.Context(), logger).Warn(err.Error())
+				w.Header().Set("WWW-Authenticate", "Bearer")
+				_ = response.JSON(w, errorStatus(err), errorBody(req, err))
+				return
+			}
+
+			var ctx = auth.WithPrincipal(req.Context(), principal)
+			if principal.APIKeyID != "" {
+				ctx = logging.With(ctx, "apiKeyId", principal.APIKeyID)
+			} else {
+				ctx = logging.With(ctx, "subject", principal.Subject)
+			}
+			if scope := requiredScope(req); !principal.HasScope(scope) {
+				err = fmt.Errorf("%w: the credentials lack the %s scope", appErrors.Forbidden, scope)
+				logging.Logger(ctx, logger).Warn(err.Error())
+				_ = response.JSON(w, errorStatus(err), errorBody(req, err))
+				return
+			}
+			next.ServeHTTP(w, req.WithContext(ctx))
+		})
+	}
+}
+
+// authenticate returns the principal of the API key of the X-API-Key header, or of the bearer token: an API key when
+// it has the API key prefix, a JWT otherwise
+func authenticate(req *http.Request, apiKeys ports.IAPIKeyService, tokens ports.ITokenService) (*domain.Principal, error) {
+	var credential = req.Header.Get(APIKeyHeader)
+	if credential == "" {
+		scheme, token, found := strings.Cut(req.Header.Get("Authorization"), " ")
+		if found && strings.EqualFold(scheme, "Bearer") {
+			credential = strings.TrimSpace(token)
+			if !strings.HasPrefix(credential, domain.APIKeyPrefix) {
+				return tokens.Authenticate(credential)
+			}
+		}
+	}
+
+	key, err := apiKeys.Authenticate(credential)
+	if err != nil {
+		return nil, err
+	}
+	return key.Principal(), nil
+}
+
+// requiredScope returns the scope the route of the request requires
+func requiredScope(req *http.Request) string {
+	switch {
+	case req.URL.Path == "/admin" || strings.HasPrefix(req.URL.Path, "/admin/"):
+		return domain.ScopeAdmin
+	case req.Method == http.MethodGet || req.Method == http.MethodHead:
+		return domain.ScopeReceiptsRead
+	default:
+		return domain.ScopeReceiptsWrite
+	}
+}
@@ -0,0 +1,106 @@
+package handlers
+
+import (
+	"github.com/go-chi/chi/v5"
+	"github.com/kiramishima/receipt-processor/domain"
+	"github.com/kiramishima/receipt-processor/pkg/logging"
+	"github.com/kiramishima/receipt-processor/pkg/utils"
+	ports "github.com/kiramishima/receipt-processor/ports/services"
+	"github.com/unrolled/render"
+	"go.uber.org/zap"
+	"net/http"
+)
+
+// NewCampaignHandlers creates a instance of campaign handlers
+func NewCampaignHandlers(r *chi.Mux, logger *zap.SugaredLogger, s ports.ICampaignService, render *render.Render) {
+	handler := &CampaignHandlers{
+		logger:   logger,
+		service:  s,
+		response: render,
+	}
+
+	r.Route("/admin/campaigns", func(r chi.Router) {
+		r.Post("/", handler.CreateCampaignHandler)
+		r.Get("/", handler.ListCampaignsHandler)
+		r.Get("/{id}", handler.GetCampaignHandler)
+		r.Put("/{id}", handler.UpdateCampaignHandler)
+		r.Delete("/{id}", handler.DeleteCampaignHandler)
+	})
+}
+
+type CampaignHandlers struct {
+	logger   *zap.SugaredLogger
+	service  ports.ICampaignService
+	response *render.Render
+}
+
+func (h *CampaignHandlers) CreateCampaignHandler(w http.ResponseWriter, req *http.Request) {
+	var jsonReq = &domain.Campaign{}
+
+	if err := utils.ReadJSON(w, req, &jsonReq); err != nil {
+		logging.Logger(req.Context(), h.logger).Error(err.Error())
+		_ = h.response.JSON(w, http.StatusBadRequest, errorBody(req, err))
+		return
+	}
+
+	campaign, err := h.service.CreateCampaign(req.Context(), jsonReq)
+	if err != nil {
+		logging.Logger(req.Context(), h.logger).Error(err.Error())
+		_ = h.response.JSON(w, errorStatus(err), errorBody(req, err))
+		return
+	}
+
+	_ = h.response.JSON(w, http.StatusCreated, campaign)
+}
+
+func (h *CampaignHandlers) ListCampaignsHandler(w http.ResponseWriter, req *http.Request) {
+	campaigns, err := h.service.ListCampaigns()
+	if err != nil {
+		logging.Logger(req.Context(), h.logger).Error(err.Error())
+		_ = h.response.JSON(w, errorStatus(err), errorBody(req, err))
+		return
+	}
+
+	_ = h.response.JSON(w, http.StatusOK, campaigns)
+}
+
+func (h *CampaignHandlers) GetCampaignHandler(w http.ResponseWriter, req *http.Request) {
+	campaign, err := h.service.RetrieveCampaign(chi.URLParam(req, "id"))
+	if err != nil {
+		logging.Logger(req.Context(), h.logger).Error(err.Error())
+		_ = h.response.JSON(w, errorStatus(err), errorBody(req, err))
+		return
+	}
+
+	_ = h.response.JSON(w, http.StatusOK, campaign)
+}
+
+func (h *CampaignHandlers) UpdateCampaignHandler(w http.ResponseWriter, req *http.Request) {
+	var jsonReq = &domain.Campaign{}
+
+	if err := utils.ReadJSON(w, req, &jsonReq); err != nil {
+		logging.Logger(req.Context(), h.logger).Error(err.Error())
+		_ = h.response.JSON(w, http.StatusBadRequest, errorBody(req, err))
+		return
+	}
+	jsonReq.ID = chi.URLParam(req, "id")
+
+	campaign, err := h.service.UpdateCampaign(req.Context(), jsonReq)
+	if err != nil {
+		logging.Logger(req.Context(), h.logger).Error(err.Error())
+		_ = h.response.JSON(w, errorStatus(err), errorBody(req, err))
+		return
+	}
+
+	_ = h.response.JSON(w, http.StatusOK, campaign)
+}
+
+func (h *CampaignHandlers) DeleteCampaignHandler(w http.ResponseWriter, req *http.Request) {
+	if err := h.service.DeleteCampaign(chi.URLParam(req, "id")); err != nil {
+		logging.Logger(req.Context(), h.logger).Error(err.Error())
+		_ = h.response.JSON(w, errorStatus(err), errorBody(req, err))
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
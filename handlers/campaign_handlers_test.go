@@ -0,0 +1,130 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/kiramishima/receipt-processor/domain"
+	"github.com/kiramishima/receipt-processor/mocks"
+	appErrors "github.com/kiramishima/receipt-processor/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/unrolled/render"
+	"go.uber.org/mock/gomock"
+	"go.uber.org/zap"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestCampaignHandlers(t *testing.T) {
+	var uid = uuid.New().String()
+	var campaign = &domain.Campaign{
+		Name:       "Double points at Target",
+		StartsAt:   time.Date(2022, 3, 19, 0, 0, 0, 0, time.UTC),
+		EndsAt:     time.Date(2022, 3, 21, 0, 0, 0, 0, time.UTC),
+		Multiplier: 2,
+	}
+
+	testCases := map[string]struct {
+		method        string
+		url           string
+		body          any
+		buildStubs    func(uc *mocks.MockICampaignService)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		"Create": {
+			method: http.MethodPost,
+			url:    "/admin/campaigns",
+			body:   campaign,
+			buildStubs: func(uc *mocks.MockICampaignService) {
+				uc.EXPECT().CreateCampaign(gomock.Any(), gomock.Any()).Times(1).Return(campaign, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusCreated, recorder.Code)
+			},
+		},
+		"Create invalid": {
+			method: http.MethodPost,
+			url:    "/admin/campaigns",
+			body:   campaign,
+			buildStubs: func(uc *mocks.MockICampaignService) {
+				uc.EXPECT().CreateCampaign(gomock.Any(), gomock.Any()).Times(1).Return(nil, fmt.Errorf("%w: Field: Name, Error: required", appErrors.BadRequest))
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		"List": {
+			method: http.MethodGet,
+			url:    "/admin/campaigns",
+			buildStubs: func(uc *mocks.MockICampaignService) {
+				uc.EXPECT().ListCampaigns().Times(1).Return([]*domain.Campaign{campaign}, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		"Get not found": {
+			method: http.MethodGet,
+			url:    fmt.Sprintf("/admin/campaigns/%s", uid),
+			buildStubs: func(uc *mocks.MockICampaignService) {
+				uc.EXPECT().RetrieveCampaign(gomock.Eq(uid)).Times(1).Return(nil, fmt.Errorf("campaign with id: %s %w", uid, appErrors.NotFound))
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		"Update": {
+			method: http.MethodPut,
+			url:    fmt.Sprintf("/admin/campaigns/%s", uid),
+			body:   campaign,
+			buildStubs: func(uc *mocks.MockICampaignService) {
+				uc.EXPECT().UpdateCampaign(gomock.Any(), gomock.Any()).Times(1).DoAndReturn(func(_ any, c *domain.Campaign) (*domain.Campaign, error) {
+					assert.Equal(t, uid, c.ID)
+					return c, nil
+				})
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		"Delete": {
+			method: http.MethodDelete,
+			url:    fmt.Sprintf("/admin/campaigns/%s", uid),
+			buildStubs: func(uc *mocks.MockICampaignService) {
+				uc.EXPECT().DeleteCampaign(gomock.Eq(uid)).Times(1).Return(nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusNoContent, recorder.Code)
+			},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			uc := mocks.NewMockICampaignService(ctrl)
+			tc.buildStubs(uc)
+
+			recorder := httptest.NewRecorder()
+
+			var body []byte
+			if tc.body != nil {
+				body, _ = json.Marshal(tc.body)
+			}
+			request, err := http.NewRequest(tc.method, tc.url, bytes.NewReader(body))
+			assert.NoError(t, err)
+
+			router := chi.NewRouter()
+			logger, _ := zap.NewProduction()
+			NewCampaignHandlers(router, logger.Sugar(), uc, render.New())
+			router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
@@ -0,0 +1,44 @@
+package handlers
+
+import (
+	"net"
+	"net/http"
+
+	"github.com/kiramishima/receipt-processor/pkg/auth"
+)
+
+// ClientIPMiddleware adds the IP of the client to the context of the request, the services key the anonymous clients
+// by it. It goes after RealIP.
+func ClientIPMiddleware() func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			next.ServeHTTP(w, req.WithContext(auth.WithClientIP(req.Context(), clientIP(req))))
+		})
+	}
+}
+
+// RemoteIPMiddleware adds the IP of the connection of the request to the context, the limits before the
+// authentication key the requests by it because the forwarded headers can be spoofed. It goes before RealIP.
+func RemoteIPMiddleware() func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			next.ServeHTTP(w, req.WithContext(auth.WithRemoteIP(req.Context(), clientIP(req))))
+		})
+	}
+}
+
+// remoteIP returns the IP of the connection of the request, the remote address when the RemoteIPMiddleware didn't run
+func remoteIP(req *http.Request) string {
+	if ip := auth.RemoteIP(req.Context()); ip != "" {
+		return ip
+	}
+	return clientIP(req)
+}
+
+// clientIP returns the host of the remote address of the request
+func clientIP(req *http.Request) string {
+	if host, _, err := net.SplitHostPort(req.RemoteAddr); err == nil {
+		return host
+	}
+	return req.RemoteAddr
+}
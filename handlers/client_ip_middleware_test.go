@@ -0,0 +1,48 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/kiramishima/receipt-processor/pkg/auth"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClientIPMiddleware(t *testing.T) {
+	testCases := map[string]struct {
+		RemoteAddr string
+		Expected   string
+	}{
+		"Host and port": {RemoteAddr: "192.0.2.1:5000", Expected: "192.0.2.1"},
+		"IPv6":          {RemoteAddr: "[2001:db8::1]:5000", Expected: "2001:db8::1"},
+		"Without port":  {RemoteAddr: "192.0.2.1", Expected: "192.0.2.1"},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			var ip string
+			var handler = ClientIPMiddleware()(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+				ip = auth.ClientIP(req.Context())
+			}))
+			req := httptest.NewRequest(http.MethodGet, "/receipts/quota", nil)
+			req.RemoteAddr = tc.RemoteAddr
+			handler.ServeHTTP(httptest.NewRecorder(), req)
+			assert.Equal(t, tc.Expected, ip)
+		})
+	}
+}
+
+func TestRemoteIPMiddleware(t *testing.T) {
+	var remote, client string
+	var handler = RemoteIPMiddleware()(middleware.RealIP(ClientIPMiddleware()(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		remote, client = auth.RemoteIP(req.Context()), auth.ClientIP(req.Context())
+	}))))
+	req := httptest.NewRequest(http.MethodGet, "/receipts/quota", nil)
+	req.RemoteAddr = "192.0.2.1:5000"
+	req.Header.Set("X-Forwarded-For", "198.51.100.7")
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+	assert.Equal(t, "192.0.2.1", remote)
+	assert.Equal(t, "198.51.100.7", client)
+}
@@ -0,0 +1,19 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+)
+
+// streamTimeout time the file uploads and downloads have, like the timeout of the router, instead of the short read
+// and write timeouts of the server
+const streamTimeout = 60 * time.Second
+
+// extendDeadlines gives the request streamTimeout to read the body and write the response
+func extendDeadlines(w http.ResponseWriter) {
+	var deadline = time.Now().Add(streamTimeout)
+	var controller = http.NewResponseController(w)
+	// The test recorders don't support deadlines
+	_ = controller.SetReadDeadline(deadline)
+	_ = controller.SetWriteDeadline(deadline)
+}
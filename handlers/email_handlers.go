@@ -0,0 +1,62 @@
+package handlers
+
+import (
+	"github.com/go-chi/chi/v5"
+	"github.com/kiramishima/receipt-processor/pkg/logging"
+	ports "github.com/kiramishima/receipt-processor/ports/services"
+	"github.com/unrolled/render"
+	"go.uber.org/zap"
+	"io"
+	"mime"
+	"net/http"
+)
+
+// maxMessageBytes limit of the email messages uploaded
+const maxMessageBytes = 10 << 20
+
+// NewEmailHandlers creates a instance of email ingestion handlers
+func NewEmailHandlers(r *chi.Mux, logger *zap.SugaredLogger, s ports.IEmailService, render *render.Render) {
+	handler := &EmailHandlers{
+		logger:   logger,
+		service:  s,
+		response: render,
+	}
+
+	r.Post("/receipts/email", handler.ReceiptEmailHandler)
+}
+
+type EmailHandlers struct {
+	logger   *zap.SugaredLogger
+	service  ports.IEmailService
+	response *render.Render
+}
+
+// ReceiptEmailHandler stores the receipt of an email message, uploaded as the raw message or as the file field of a
+// multipart form. The memberId query parameter is the member of the receipt.
+func (h *EmailHandlers) ReceiptEmailHandler(w http.ResponseWriter, req *http.Request) {
+	extendDeadlines(w)
+	req.Body = http.MaxBytesReader(w, req.Body, maxMessageBytes)
+
+	var message io.Reader = req.Body
+	mediaType, _, _ := mime.ParseMediaType(req.Header.Get("Content-Type"))
+	if mediaType == "multipart/form-data" {
+		file, _, err := req.FormFile("file")
+		if err != nil {
+			logging.Logger(req.Context(), h.logger).Error(err.Error())
+			_ = h.response.JSON(w, http.StatusBadRequest, errorBody(req, err))
+			return
+		}
+		defer file.Close()
+		message = file
+	}
+
+	ingestion, err := h.service.IngestEmail(req.Context(), message, req.URL.Query().Get("memberId"))
+	if err != nil {
+		logging.Logger(req.Context(), h.logger).Error(err.Error())
+		retryAfterQuota(w, err)
+		_ = h.response.JSON(w, errorStatus(err), errorBody(req, err))
+		return
+	}
+
+	_ = h.response.JSON(w, http.StatusOK, ingestion)
+}
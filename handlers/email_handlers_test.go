@@ -0,0 +1,125 @@
+package handlers
+
+import (
+	"bytes"
+	"github.com/go-chi/chi/v5"
+	"github.com/kiramishima/receipt-processor/domain"
+	"github.com/kiramishima/receipt-processor/mocks"
+	appErrors "github.com/kiramishima/receipt-processor/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/unrolled/render"
+	"go.uber.org/mock/gomock"
+	"go.uber.org/zap"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestEmailHandlers(t *testing.T) {
+	var message = "Subject: receipt\r\n\r\nCorner Shop\r\nTOTAL 1.20\r\n"
+	var form = &bytes.Buffer{}
+	var writer = multipart.NewWriter(form)
+	part, _ := writer.CreateFormFile("file", "receipt.eml")
+	_, _ = part.Write([]byte(message))
+	_ = writer.Close()
+
+	var readMessage = func(r io.Reader) {
+		content, err := io.ReadAll(r)
+		assert.NoError(t, err)
+		assert.Equal(t, message, string(content))
+	}
+
+	testCases := map[string]struct {
+		url           string
+		contentType   string
+		body          []byte
+		buildStubs    func(uc *mocks.MockIEmailService)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		"Raw message": {
+			url:         "/receipts/email?memberId=member-1",
+			contentType: "message/rfc822",
+			body:        []byte(message),
+			buildStubs: func(uc *mocks.MockIEmailService) {
+				uc.EXPECT().IngestEmail(gomock.Any(), gomock.Any(), gomock.Eq("member-1")).Times(1).DoAndReturn(func(_ any, r io.Reader, _ string) (*domain.EmailIngestion, error) {
+					readMessage(r)
+					return &domain.EmailIngestion{ID: "receipt-1"}, nil
+				})
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusOK, recorder.Code)
+				assert.Contains(t, recorder.Body.String(), `"id":"receipt-1"`)
+			},
+		},
+		"Form upload": {
+			url:         "/receipts/email",
+			contentType: writer.FormDataContentType(),
+			body:        form.Bytes(),
+			buildStubs: func(uc *mocks.MockIEmailService) {
+				uc.EXPECT().IngestEmail(gomock.Any(), gomock.Any(), gomock.Eq("")).Times(1).DoAndReturn(func(_ any, r io.Reader, _ string) (*domain.EmailIngestion, error) {
+					readMessage(r)
+					return &domain.EmailIngestion{ID: "receipt-1"}, nil
+				})
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		"Form without file": {
+			url:         "/receipts/email",
+			contentType: "multipart/form-data; boundary=x",
+			body:        []byte("--x--\r\n"),
+			buildStubs: func(uc *mocks.MockIEmailService) {
+				uc.EXPECT().IngestEmail(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		"Needs review": {
+			url:         "/receipts/email",
+			contentType: "message/rfc822",
+			body:        []byte(message),
+			buildStubs: func(uc *mocks.MockIEmailService) {
+				uc.EXPECT().IngestEmail(gomock.Any(), gomock.Any(), gomock.Any()).Times(1).Return(nil, appErrors.BadRequest)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		"Repeated message": {
+			url:         "/receipts/email",
+			contentType: "message/rfc822",
+			body:        []byte(message),
+			buildStubs: func(uc *mocks.MockIEmailService) {
+				uc.EXPECT().IngestEmail(gomock.Any(), gomock.Any(), gomock.Any()).Times(1).Return(nil, appErrors.Conflict)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			uc := mocks.NewMockIEmailService(ctrl)
+			tc.buildStubs(uc)
+
+			recorder := httptest.NewRecorder()
+			request, err := http.NewRequest(http.MethodPost, tc.url, bytes.NewReader(tc.body))
+			assert.NoError(t, err)
+			request.Header.Set("Content-Type", tc.contentType)
+
+			router := chi.NewRouter()
+			logger, _ := zap.NewProduction()
+			NewEmailHandlers(router, logger.Sugar(), uc, render.New())
+			router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
@@ -0,0 +1,51 @@
+package handlers
+
+import (
+	"errors"
+	"github.com/kiramishima/receipt-processor/domain"
+	appErrors "github.com/kiramishima/receipt-processor/pkg/errors"
+	"github.com/kiramishima/receipt-processor/pkg/tracing"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// errorStatus maps the application errors to the http status code of the response
+func errorStatus(err error) int {
+	switch {
+	case errors.Is(err, appErrors.BadRequest):
+		return http.StatusBadRequest
+	case errors.Is(err, appErrors.Unauthorized), errors.Is(err, appErrors.InvalidJWTToken), errors.Is(err, appErrors.InvalidJWTClaims):
+		return http.StatusUnauthorized
+	case errors.Is(err, appErrors.Forbidden):
+		return http.StatusForbidden
+	case errors.Is(err, appErrors.TooManyRequests), errors.Is(err, appErrors.QuotaExceeded):
+		return http.StatusTooManyRequests
+	case errors.Is(err, appErrors.NotFound):
+		return http.StatusNotFound
+	case errors.Is(err, appErrors.Conflict):
+		return http.StatusConflict
+	case errors.Is(err, appErrors.ErrTimeout):
+		return http.StatusGatewayTimeout
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// errorBody returns the body of an error response with the id of the trace of the request, to find its spans and logs
+func errorBody(req *http.Request, err error) map[string]string {
+	var body = map[string]string{"error": err.Error()}
+	if traceID := tracing.TraceID(req.Context()); traceID != "" {
+		body["traceId"] = traceID
+	}
+	return body
+}
+
+// retryAfterQuota sets the Retry-After of the responses to the clients that exceeded their daily quota, the quotas
+// reset at midnight UTC
+func retryAfterQuota(w http.ResponseWriter, err error) {
+	if errors.Is(err, appErrors.QuotaExceeded) {
+		_, resetsAt := domain.QuotaDay(time.Now())
+		w.Header().Set("Retry-After", strconv.Itoa(seconds(time.Until(resetsAt))))
+	}
+}
@@ -0,0 +1,118 @@
+package handlers
+
+import (
+	"github.com/go-chi/chi/v5"
+	"github.com/kiramishima/receipt-processor/domain"
+	"github.com/kiramishima/receipt-processor/pkg/logging"
+	"github.com/kiramishima/receipt-processor/pkg/utils"
+	ports "github.com/kiramishima/receipt-processor/ports/services"
+	"github.com/unrolled/render"
+	"go.uber.org/zap"
+	"net/http"
+)
+
+// NewExperimentHandlers creates a instance of experiment handlers
+func NewExperimentHandlers(r *chi.Mux, logger *zap.SugaredLogger, s ports.IExperimentService, render *render.Render) {
+	handler := &ExperimentHandlers{
+		logger:   logger,
+		service:  s,
+		response: render,
+	}
+
+	r.Route("/admin/experiments", func(r chi.Router) {
+		r.Post("/", handler.CreateExperimentHandler)
+		r.Get("/", handler.ListExperimentsHandler)
+		r.Get("/{id}", handler.GetExperimentHandler)
+		r.Put("/{id}", handler.UpdateExperimentHandler)
+		r.Delete("/{id}", handler.DeleteExperimentHandler)
+		r.Get("/{id}/report", handler.GetExperimentReportHandler)
+	})
+}
+
+type ExperimentHandlers struct {
+	logger   *zap.SugaredLogger
+	service  ports.IExperimentService
+	response *render.Render
+}
+
+func (h *ExperimentHandlers) CreateExperimentHandler(w http.ResponseWriter, req *http.Request) {
+	var jsonReq = &domain.Experiment{}
+
+	if err := utils.ReadJSON(w, req, &jsonReq); err != nil {
+		logging.Logger(req.Context(), h.logger).Error(err.Error())
+		_ = h.response.JSON(w, http.StatusBadRequest, errorBody(req, err))
+		return
+	}
+
+	experiment, err := h.service.CreateExperiment(req.Context(), jsonReq)
+	if err != nil {
+		logging.Logger(req.Context(), h.logger).Error(err.Error())
+		_ = h.response.JSON(w, errorStatus(err), errorBody(req, err))
+		return
+	}
+
+	_ = h.response.JSON(w, http.StatusCreated, experiment)
+}
+
+func (h *ExperimentHandlers) ListExperimentsHandler(w http.ResponseWriter, req *http.Request) {
+	experiments, err := h.service.ListExperiments()
+	if err != nil {
+		logging.Logger(req.Context(), h.logger).Error(err.Error())
+		_ = h.response.JSON(w, errorStatus(err), errorBody(req, err))
+		return
+	}
+
+	_ = h.response.JSON(w, http.StatusOK, experiments)
+}
+
+func (h *ExperimentHandlers) GetExperimentHandler(w http.ResponseWriter, req *http.Request) {
+	experiment, err := h.service.RetrieveExperiment(chi.URLParam(req, "id"))
+	if err != nil {
+		logging.Logger(req.Context(), h.logger).Error(err.Error())
+		_ = h.response.JSON(w, errorStatus(err), errorBody(req, err))
+		return
+	}
+
+	_ = h.response.JSON(w, http.StatusOK, experiment)
+}
+
+func (h *ExperimentHandlers) UpdateExperimentHandler(w http.ResponseWriter, req *http.Request) {
+	var jsonReq = &domain.Experiment{}
+
+	if err := utils.ReadJSON(w, req, &jsonReq); err != nil {
+		logging.Logger(req.Context(), h.logger).Error(err.Error())
+		_ = h.response.JSON(w, http.StatusBadRequest, errorBody(req, err))
+		return
+	}
+	jsonReq.ID = chi.URLParam(req, "id")
+
+	experiment, err := h.service.UpdateExperiment(req.Context(), jsonReq)
+	if err != nil {
+		logging.Logger(req.Context(), h.logger).Error(err.Error())
+		_ = h.response.JSON(w, errorStatus(err), errorBody(req, err))
+		return
+	}
+
+	_ = h.response.JSON(w, http.StatusOK, experiment)
+}
+
+func (h *ExperimentHandlers) DeleteExperimentHandler(w http.ResponseWriter, req *http.Request) {
+	if err := h.service.DeleteExperiment(chi.URLParam(req, "id")); err != nil {
+		logging.Logger(req.Context(), h.logger).Error(err.Error())
+		_ = h.response.JSON(w, errorStatus(err), errorBody(req, err))
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *ExperimentHandlers) GetExperimentReportHandler(w http.ResponseWriter, req *http.Request) {
+	report, err := h.service.ExperimentReport(chi.URLParam(req, "id"))
+	if err != nil {
+		logging.Logger(req.Context(), h.logger).Error(err.Error())
+		_ = h.response.JSON(w, errorStatus(err), errorBody(req, err))
+		return
+	}
+
+	_ = h.response.JSON(w, http.StatusOK, report)
+}
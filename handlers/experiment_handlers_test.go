@@ -0,0 +1,141 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/kiramishima/receipt-processor/domain"
+	"github.com/kiramishima/receipt-processor/mocks"
+	appErrors "github.com/kiramishima/receipt-processor/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/unrolled/render"
+	"go.uber.org/mock/gomock"
+	"go.uber.org/zap"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestExperimentHandlers(t *testing.T) {
+	var uid = uuid.New().String()
+	var experiment = &domain.Experiment{
+		Name:             "Odd days",
+		StartsAt:         time.Date(2022, 3, 19, 0, 0, 0, 0, time.UTC),
+		EndsAt:           time.Date(2022, 3, 21, 0, 0, 0, 0, time.UTC),
+		ControlVersion:   domain.DefaultRuleSetVersion,
+		TreatmentVersion: "v2",
+		TreatmentPercent: 50,
+	}
+
+	testCases := map[string]struct {
+		method        string
+		url           string
+		body          any
+		buildStubs    func(uc *mocks.MockIExperimentService)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		"Create": {
+			method: http.MethodPost,
+			url:    "/admin/experiments",
+			body:   experiment,
+			buildStubs: func(uc *mocks.MockIExperimentService) {
+				uc.EXPECT().CreateExperiment(gomock.Any(), gomock.Any()).Times(1).Return(experiment, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusCreated, recorder.Code)
+			},
+		},
+		"Create invalid": {
+			method: http.MethodPost,
+			url:    "/admin/experiments",
+			body:   experiment,
+			buildStubs: func(uc *mocks.MockIExperimentService) {
+				uc.EXPECT().CreateExperiment(gomock.Any(), gomock.Any()).Times(1).Return(nil, fmt.Errorf("%w: Field: Name, Error: required", appErrors.BadRequest))
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		"Get not found": {
+			method: http.MethodGet,
+			url:    fmt.Sprintf("/admin/experiments/%s", uid),
+			buildStubs: func(uc *mocks.MockIExperimentService) {
+				uc.EXPECT().RetrieveExperiment(gomock.Eq(uid)).Times(1).Return(nil, fmt.Errorf("experiment with id: %s %w", uid, appErrors.NotFound))
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		"Update": {
+			method: http.MethodPut,
+			url:    fmt.Sprintf("/admin/experiments/%s", uid),
+			body:   experiment,
+			buildStubs: func(uc *mocks.MockIExperimentService) {
+				uc.EXPECT().UpdateExperiment(gomock.Any(), gomock.Any()).Times(1).DoAndReturn(func(_ any, c *domain.Experiment) (*domain.Experiment, error) {
+					assert.Equal(t, uid, c.ID)
+					return c, nil
+				})
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		"Report": {
+			method: http.MethodGet,
+			url:    fmt.Sprintf("/admin/experiments/%s/report", uid),
+			buildStubs: func(uc *mocks.MockIExperimentService) {
+				uc.EXPECT().ExperimentReport(gomock.Eq(uid)).Times(1).Return(&domain.ExperimentReport{
+					ExperimentID: uid,
+					Control:      &domain.ExperimentArmStats{Arm: domain.ExperimentArmControl, Receipts: 2, MeanPoints: 20},
+					Treatment:    &domain.ExperimentArmStats{Arm: domain.ExperimentArmTreatment, Receipts: 2, MeanPoints: 30},
+					MeanDelta:    10,
+					MeanLift:     0.5,
+				}, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusOK, recorder.Code)
+				var report domain.ExperimentReport
+				assert.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &report))
+				assert.Equal(t, 0.5, report.MeanLift)
+			},
+		},
+		"Report not found": {
+			method: http.MethodGet,
+			url:    fmt.Sprintf("/admin/experiments/%s/report", uid),
+			buildStubs: func(uc *mocks.MockIExperimentService) {
+				uc.EXPECT().ExperimentReport(gomock.Eq(uid)).Times(1).Return(nil, fmt.Errorf("experiment with id: %s %w", uid, appErrors.NotFound))
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			uc := mocks.NewMockIExperimentService(ctrl)
+			tc.buildStubs(uc)
+
+			recorder := httptest.NewRecorder()
+
+			var body []byte
+			if tc.body != nil {
+				body, _ = json.Marshal(tc.body)
+			}
+			request, err := http.NewRequest(tc.method, tc.url, bytes.NewReader(body))
+			assert.NoError(t, err)
+
+			router := chi.NewRouter()
+			logger, _ := zap.NewProduction()
+			NewExperimentHandlers(router, logger.Sugar(), uc, render.New())
+			router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
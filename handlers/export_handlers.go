@@ -0,0 +1,116 @@
+package handlers
+
+import (
+	"fmt"
+	"github.com/go-chi/chi/v5"
+	"github.com/kiramishima/receipt-processor/domain"
+	"github.com/kiramishima/receipt-processor/pkg/logging"
+	ports "github.com/kiramishima/receipt-processor/ports/services"
+	"github.com/unrolled/render"
+	"go.uber.org/zap"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// exportContentTypes content type of every export format
+var exportContentTypes = map[string]string{
+	domain.ExportFormatCSV:    "text/csv; charset=utf-8",
+	domain.ExportFormatNDJSON: "application/x-ndjson",
+}
+
+// NewExportHandlers creates a instance of receipt export handlers
+func NewExportHandlers(r *chi.Mux, logger *zap.SugaredLogger, s ports.IExportService, render *render.Render) {
+	handler := &ExportHandlers{
+		logger:   logger,
+		service:  s,
+		response: render,
+	}
+
+	r.Get("/receipts/export", handler.ReceiptExportHandler)
+}
+
+type ExportHandlers struct {
+	logger   *zap.SugaredLogger
+	service  ports.IExportService
+	response *render.Render
+}
+
+// ReceiptExportHandler streams the stored receipts matching the memberId, retailerId, experimentId, from and to query
+// parameters as a csv or ndjson attachment. Once the first receipt is written the status can't change, so
+// the errors are logged and the connection is aborted for the client to see an incomplete download.
+func (h *ExportHandlers) ReceiptExportHandler(w http.ResponseWriter, req *http.Request) {
+	extendDeadlines(w)
+	var query = req.URL.Query()
+	var format = query.Get("format")
+	if format == "" {
+		format = domain.ExportFormatCSV
+	}
+	contentType, ok := exportContentTypes[format]
+	var filter, err = exportFilter(query)
+	if err == nil && !ok {
+		err = fmt.Errorf("unknown export format %s, expected one of %v", format, domain.ExportFormats)
+	}
+	if err != nil {
+		logging.Logger(req.Context(), h.logger).Error(err.Error())
+		_ = h.response.JSON(w, http.StatusBadRequest, errorBody(req, err))
+		return
+	}
+
+	var out = &exportWriter{ResponseWriter: w, header: func() {
+		w.Header().Set("Content-Type", contentType)
+		w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="receipts.%s"`, format))
+	}}
+	count, err := h.service.ExportReceipts(req.Context(), filter, format, out)
+	if err != nil && !out.written {
+		logging.Logger(req.Context(), h.logger).Error(err.Error())
+		_ = h.response.JSON(w, errorStatus(err), errorBody(req, err))
+		return
+	}
+	if err != nil {
+		logging.Logger(req.Context(), h.logger).Errorw("receipt export interrupted", "format", format, "receipts", count, "error", err)
+		panic(http.ErrAbortHandler)
+	}
+	if !out.written {
+		out.header()
+		w.WriteHeader(http.StatusOK)
+	}
+}
+
+// exportFilter reads the receipt filter of the query, the dates are RFC 3339 timestamps or days
+func exportFilter(query url.Values) (*domain.ReceiptFilter, error) {
+	var filter = &domain.ReceiptFilter{
+		MemberID:     query.Get("memberId"),
+		RetailerID:   query.Get("retailerId"),
+		ExperimentID: query.Get("experimentId"),
+	}
+	for param, target := range map[string]*time.Time{"from": &filter.From, "to": &filter.To} {
+		var value = query.Get(param)
+		if value == "" {
+			continue
+		}
+		t, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			if t, err = time.Parse("2006-01-02", value); err != nil {
+				return nil, fmt.Errorf("invalid %s %q, expected a date like 2022-01-31 or 2022-01-31T10:00:00Z", param, value)
+			}
+		}
+		*target = t
+	}
+	return filter, nil
+}
+
+// exportWriter sets the headers of the download on the first write, so the errors before it can still be reported
+type exportWriter struct {
+	http.ResponseWriter
+	header  func()
+	written bool
+}
+
+func (w *exportWriter) Write(p []byte) (int, error) {
+	if !w.written {
+		w.header()
+		w.written = true
+	}
+	return w.ResponseWriter.Write(p)
+}
@@ -0,0 +1,125 @@
+package handlers
+
+import (
+	"errors"
+	"github.com/go-chi/chi/v5"
+	"github.com/kiramishima/receipt-processor/domain"
+	"github.com/kiramishima/receipt-processor/mocks"
+	appErrors "github.com/kiramishima/receipt-processor/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/unrolled/render"
+	"go.uber.org/mock/gomock"
+	"go.uber.org/zap"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestExportHandlers(t *testing.T) {
+	testCases := map[string]struct {
+		url           string
+		aborted       bool
+		buildStubs    func(uc *mocks.MockIExportService)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		"CSV": {
+			url: "/receipts/export?memberId=m1&retailerId=target&from=2022-01-01&to=2022-02-01T10:00:00Z",
+			buildStubs: func(uc *mocks.MockIExportService) {
+				uc.EXPECT().ExportReceipts(gomock.Any(), gomock.Eq(&domain.ReceiptFilter{
+					MemberID:   "m1",
+					RetailerID: "target",
+					From:       time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC),
+					To:         time.Date(2022, 2, 1, 10, 0, 0, 0, time.UTC),
+				}), domain.ExportFormatCSV, gomock.Any()).Times(1).DoAndReturn(func(_ any, _ any, _ string, w io.Writer) (int, error) {
+					_, err := io.WriteString(w, "id\nid-1\n")
+					return 1, err
+				})
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusOK, recorder.Code)
+				assert.Equal(t, "text/csv; charset=utf-8", recorder.Header().Get("Content-Type"))
+				assert.Equal(t, `attachment; filename="receipts.csv"`, recorder.Header().Get("Content-Disposition"))
+				assert.Equal(t, "id\nid-1\n", recorder.Body.String())
+			},
+		},
+		"Empty NDJSON": {
+			url: "/receipts/export?format=ndjson",
+			buildStubs: func(uc *mocks.MockIExportService) {
+				uc.EXPECT().ExportReceipts(gomock.Any(), gomock.Any(), domain.ExportFormatNDJSON, gomock.Any()).Times(1).Return(0, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusOK, recorder.Code)
+				assert.Equal(t, "application/x-ndjson", recorder.Header().Get("Content-Type"))
+				assert.Empty(t, recorder.Body.String())
+			},
+		},
+		"Unknown format": {
+			url: "/receipts/export?format=xml",
+			buildStubs: func(uc *mocks.MockIExportService) {
+				uc.EXPECT().ExportReceipts(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		"Invalid date": {
+			url: "/receipts/export?from=yesterday",
+			buildStubs: func(uc *mocks.MockIExportService) {
+				uc.EXPECT().ExportReceipts(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		"Error before the first receipt": {
+			url: "/receipts/export?format=ndjson",
+			buildStubs: func(uc *mocks.MockIExportService) {
+				uc.EXPECT().ExportReceipts(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(1).Return(0, appErrors.ErrTimeout)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusGatewayTimeout, recorder.Code)
+				assert.Equal(t, "application/json; charset=UTF-8", recorder.Header().Get("Content-Type"))
+			},
+		},
+		"Error while streaming": {
+			url:     "/receipts/export",
+			aborted: true,
+			buildStubs: func(uc *mocks.MockIExportService) {
+				uc.EXPECT().ExportReceipts(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(1).DoAndReturn(func(_ any, _ any, _ string, w io.Writer) (int, error) {
+					_, _ = io.WriteString(w, "id\n")
+					return 0, errors.New("broken")
+				})
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusOK, recorder.Code)
+				assert.Equal(t, "id\n", recorder.Body.String())
+			},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			uc := mocks.NewMockIExportService(ctrl)
+			tc.buildStubs(uc)
+
+			recorder := httptest.NewRecorder()
+			request, err := http.NewRequest(http.MethodGet, tc.url, nil)
+			assert.NoError(t, err)
+
+			router := chi.NewRouter()
+			logger, _ := zap.NewProduction()
+			NewExportHandlers(router, logger.Sugar(), uc, render.New())
+			if tc.aborted {
+				assert.PanicsWithValue(t, http.ErrAbortHandler, func() { router.ServeHTTP(recorder, request) })
+			} else {
+				router.ServeHTTP(recorder, request)
+			}
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
@@ -2,14 +2,52 @@ package handlers
 
 import (
 	"github.com/go-chi/chi/v5"
+	"github.com/kiramishima/receipt-processor/adapter/metrics"
 	"github.com/kiramishima/receipt-processor/services"
 	"github.com/unrolled/render"
 	"go.uber.org/fx"
 	"go.uber.org/zap"
+	"net/http"
 )
 
 var Module = fx.Module("handlers",
 	fx.Invoke(func(r *chi.Mux, logger *zap.SugaredLogger, svc *services.ReceiptService, render *render.Render) {
 		NewReceiptHandlers(r, logger, svc, render)
 	}),
+	fx.Invoke(func(r *chi.Mux, logger *zap.SugaredLogger, svc *services.CampaignService, render *render.Render) {
+		NewCampaignHandlers(r, logger, svc, render)
+	}),
+	fx.Invoke(func(r *chi.Mux, logger *zap.SugaredLogger, svc *services.RetailerService, render *render.Render) {
+		NewRetailerHandlers(r, logger, svc, render)
+	}),
+	fx.Invoke(func(r *chi.Mux, logger *zap.SugaredLogger, svc *services.ExperimentService, render *render.Render) {
+		NewExperimentHandlers(r, logger, svc, render)
+	}),
+	fx.Invoke(func(r *chi.Mux, logger *zap.SugaredLogger, svc *services.RuleSetService, render *render.Render) {
+		NewRuleSetHandlers(r, logger, svc, render)
+	}),
+	fx.Invoke(func(r *chi.Mux, logger *zap.SugaredLogger, svc *services.ParserService, render *render.Render) {
+		NewParserHandlers(r, logger, svc, render)
+	}),
+	fx.Invoke(func(r *chi.Mux, logger *zap.SugaredLogger, svc *services.EmailService, render *render.Render) {
+		NewEmailHandlers(r, logger, svc, render)
+	}),
+	fx.Invoke(func(r *chi.Mux, logger *zap.SugaredLogger, svc *services.ImportService, render *render.Render) {
+		NewImportHandlers(r, logger, svc, render)
+	}),
+	fx.Invoke(func(r *chi.Mux, logger *zap.SugaredLogger, svc *services.ExportService, render *render.Render) {
+		NewExportHandlers(r, logger, svc, render)
+	}),
+	fx.Invoke(func(r *chi.Mux, logger *zap.SugaredLogger, svc *services.HealthService, render *render.Render) {
+		NewHealthHandlers(r, logger, svc, render)
+	}),
+	fx.Invoke(func(r *chi.Mux, logger *zap.SugaredLogger, svc *services.APIKeyService, render *render.Render) {
+		NewAPIKeyHandlers(r, logger, svc, render)
+	}),
+	fx.Invoke(func(r *chi.Mux, logger *zap.SugaredLogger, svc *services.QuotaService, render *render.Render) {
+		NewQuotaHandlers(r, logger, svc, render)
+	}),
+	fx.Invoke(func(r *chi.Mux, recorder *metrics.PrometheusRecorder) {
+		r.Method(http.MethodGet, "/metrics", recorder.Handler())
+	}),
 )
@@ -0,0 +1,52 @@
+package handlers
+
+import (
+	"github.com/go-chi/chi/v5"
+	"github.com/kiramishima/receipt-processor/domain"
+	ports "github.com/kiramishima/receipt-processor/ports/services"
+	"github.com/unrolled/render"
+	"go.uber.org/zap"
+	"net/http"
+)
+
+// NewHealthHandlers creates a instance of health handlers for the orchestrator probes
+func NewHealthHandlers(r *chi.Mux, logger *zap.SugaredLogger, s ports.IHealthService, render *render.Render) {
+	handler := &HealthHandlers{
+		logger:   logger,
+		service:  s,
+		response: render,
+	}
+
+	r.Get("/healthz", handler.HealthHandler)
+	r.Get("/livez", handler.LiveHandler)
+	r.Get("/readyz", handler.ReadyHandler)
+}
+
+type HealthHandlers struct {
+	logger   *zap.SugaredLogger
+	service  ports.IHealthService
+	response *render.Render
+}
+
+// HealthHandler answers while the process is alive, without running checks
+func (h *HealthHandlers) HealthHandler(w http.ResponseWriter, req *http.Request) {
+	_ = h.response.JSON(w, http.StatusOK, map[string]domain.HealthStatus{"status": domain.HealthOK})
+}
+
+// LiveHandler runs the liveness checks, a failing check means the process has to be restarted
+func (h *HealthHandlers) LiveHandler(w http.ResponseWriter, req *http.Request) {
+	h.report(w, h.service.Live(req.Context()))
+}
+
+// ReadyHandler runs the readiness checks, failing while a dependency is unavailable or the service shuts down
+func (h *HealthHandlers) ReadyHandler(w http.ResponseWriter, req *http.Request) {
+	h.report(w, h.service.Ready(req.Context()))
+}
+
+func (h *HealthHandlers) report(w http.ResponseWriter, report *domain.HealthReport) {
+	var status = http.StatusOK
+	if report.Status != domain.HealthOK {
+		status = http.StatusServiceUnavailable
+	}
+	_ = h.response.JSON(w, status, report)
+}
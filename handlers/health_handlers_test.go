@@ -0,0 +1,81 @@
+package handlers
+
+import (
+	"github.com/go-chi/chi/v5"
+	"github.com/kiramishima/receipt-processor/domain"
+	"github.com/kiramishima/receipt-processor/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/unrolled/render"
+	"go.uber.org/mock/gomock"
+	"go.uber.org/zap"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestHealthHandlers(t *testing.T) {
+	var failing = &domain.HealthReport{Status: domain.HealthFailing, Checks: []*domain.HealthCheckResult{
+		{Name: "shutdown", Status: domain.HealthFailing, Error: "shutting down"},
+	}}
+	var ok = &domain.HealthReport{Status: domain.HealthOK, Checks: []*domain.HealthCheckResult{
+		{Name: "tier-recompute-job", Status: domain.HealthOK},
+	}}
+
+	testCases := map[string]struct {
+		url           string
+		buildStubs    func(uc *mocks.MockIHealthService)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		"Health": {
+			url: "/healthz",
+			buildStubs: func(uc *mocks.MockIHealthService) {
+				uc.EXPECT().Live(gomock.Any()).Times(0)
+				uc.EXPECT().Ready(gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusOK, recorder.Code)
+				assert.JSONEq(t, `{"status":"ok"}`, recorder.Body.String())
+			},
+		},
+		"Live": {
+			url: "/livez",
+			buildStubs: func(uc *mocks.MockIHealthService) {
+				uc.EXPECT().Live(gomock.Any()).Times(1).Return(ok)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusOK, recorder.Code)
+				assert.JSONEq(t, `{"status":"ok","checks":[{"name":"tier-recompute-job","status":"ok"}]}`, recorder.Body.String())
+			},
+		},
+		"Not ready": {
+			url: "/readyz",
+			buildStubs: func(uc *mocks.MockIHealthService) {
+				uc.EXPECT().Ready(gomock.Any()).Times(1).Return(failing)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusServiceUnavailable, recorder.Code)
+				assert.JSONEq(t, `{"status":"failing","checks":[{"name":"shutdown","status":"failing","error":"shutting down"}]}`, recorder.Body.String())
+			},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			uc := mocks.NewMockIHealthService(ctrl)
+			tc.buildStubs(uc)
+
+			recorder := httptest.NewRecorder()
+			request, err := http.NewRequest(http.MethodGet, tc.url, nil)
+			assert.NoError(t, err)
+
+			router := chi.NewRouter()
+			logger, _ := zap.NewProduction()
+			NewHealthHandlers(router, logger.Sugar(), uc, render.New())
+			router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
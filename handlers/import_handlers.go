@@ -0,0 +1,70 @@
+package handlers
+
+import (
+	"bytes"
+	"github.com/go-chi/chi/v5"
+	"github.com/kiramishima/receipt-processor/domain"
+	"github.com/kiramishima/receipt-processor/pkg/logging"
+	ports "github.com/kiramishima/receipt-processor/ports/services"
+	"github.com/unrolled/render"
+	"go.uber.org/zap"
+	"net/http"
+	"strings"
+)
+
+// maxImportBytes limit of the CSV files imported
+const maxImportBytes = 32 << 20
+
+// NewImportHandlers creates a instance of bulk import handlers
+func NewImportHandlers(r *chi.Mux, logger *zap.SugaredLogger, s ports.IImportService, render *render.Render) {
+	handler := &ImportHandlers{
+		logger:   logger,
+		service:  s,
+		response: render,
+	}
+
+	r.Post("/receipts/import", handler.ReceiptImportHandler)
+}
+
+type ImportHandlers struct {
+	logger   *zap.SugaredLogger
+	service  ports.IImportService
+	response *render.Render
+}
+
+// ReceiptImportHandler imports a CSV with a row per item. The columns query parameter maps fields to headers like
+// receipt:Order ID,total:Amount, the report is CSV with format=csv or an Accept: text/csv header and JSON otherwise.
+func (h *ImportHandlers) ReceiptImportHandler(w http.ResponseWriter, req *http.Request) {
+	extendDeadlines(w)
+	var query = req.URL.Query()
+	var opts = &domain.ImportOptions{DryRun: query.Get("dryRun") == "true"}
+	var err error
+	if opts.Columns, err = domain.ParseImportColumns(query.Get("columns")); err == nil {
+		opts.Delimiter, err = domain.ParseImportDelimiter(query.Get("delimiter"))
+	}
+	if err != nil {
+		logging.Logger(req.Context(), h.logger).Error(err.Error())
+		_ = h.response.JSON(w, http.StatusBadRequest, errorBody(req, err))
+		return
+	}
+
+	report, err := h.service.ImportReceipts(req.Context(), http.MaxBytesReader(w, req.Body, maxImportBytes), opts)
+	if err != nil {
+		logging.Logger(req.Context(), h.logger).Error(err.Error())
+		_ = h.response.JSON(w, errorStatus(err), errorBody(req, err))
+		return
+	}
+
+	if query.Get("format") == "csv" || strings.Contains(req.Header.Get("Accept"), "text/csv") {
+		var content bytes.Buffer
+		if err := report.WriteCSV(&content); err != nil {
+			logging.Logger(req.Context(), h.logger).Error(err.Error())
+			_ = h.response.JSON(w, http.StatusInternalServerError, errorBody(req, err))
+			return
+		}
+		w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+		_ = h.response.Data(w, http.StatusOK, content.Bytes())
+		return
+	}
+	_ = h.response.JSON(w, http.StatusOK, report)
+}
@@ -0,0 +1,95 @@
+package handlers
+
+import (
+	"bytes"
+	"github.com/go-chi/chi/v5"
+	"github.com/kiramishima/receipt-processor/domain"
+	"github.com/kiramishima/receipt-processor/mocks"
+	appErrors "github.com/kiramishima/receipt-processor/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/unrolled/render"
+	"go.uber.org/mock/gomock"
+	"go.uber.org/zap"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestImportHandlers(t *testing.T) {
+	var points = 28
+	var report = &domain.ImportReport{Receipts: 1, Stored: 1, Rows: []*domain.ImportRow{
+		{Row: 2, Receipt: "A1", Status: domain.ImportStatusStored, ID: "id-1", Points: &points},
+	}}
+
+	testCases := map[string]struct {
+		url           string
+		accept        string
+		buildStubs    func(uc *mocks.MockIImportService)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		"JSON report": {
+			url: "/receipts/import?columns=receipt:Order%20ID&delimiter=%3B&dryRun=true",
+			buildStubs: func(uc *mocks.MockIImportService) {
+				uc.EXPECT().ImportReceipts(gomock.Any(), gomock.Any(), gomock.Eq(&domain.ImportOptions{
+					Columns: map[string]string{"receipt": "Order ID"}, Delimiter: ';', DryRun: true,
+				})).Times(1).Return(report, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusOK, recorder.Code)
+				assert.JSONEq(t, `{"receipts":1,"stored":1,"scored":0,"failed":0,"rows":[{"row":2,"receipt":"A1","status":"stored","id":"id-1","points":28}]}`, recorder.Body.String())
+			},
+		},
+		"CSV report": {
+			url:    "/receipts/import",
+			accept: "text/csv",
+			buildStubs: func(uc *mocks.MockIImportService) {
+				uc.EXPECT().ImportReceipts(gomock.Any(), gomock.Any(), gomock.Any()).Times(1).Return(report, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusOK, recorder.Code)
+				assert.Equal(t, "text/csv; charset=utf-8", recorder.Header().Get("Content-Type"))
+				assert.Equal(t, "row,receipt,status,id,points,error\n2,A1,stored,id-1,28,\n", recorder.Body.String())
+			},
+		},
+		"Invalid delimiter": {
+			url: "/receipts/import?delimiter=%3B%3B",
+			buildStubs: func(uc *mocks.MockIImportService) {
+				uc.EXPECT().ImportReceipts(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		"Invalid file": {
+			url: "/receipts/import",
+			buildStubs: func(uc *mocks.MockIImportService) {
+				uc.EXPECT().ImportReceipts(gomock.Any(), gomock.Any(), gomock.Any()).Times(1).Return(nil, appErrors.BadRequest)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			uc := mocks.NewMockIImportService(ctrl)
+			tc.buildStubs(uc)
+
+			recorder := httptest.NewRecorder()
+			request, err := http.NewRequest(http.MethodPost, tc.url, bytes.NewReader([]byte("receiptId\nA1\n")))
+			assert.NoError(t, err)
+			request.Header.Set("Content-Type", "text/csv")
+			request.Header.Set("Accept", tc.accept)
+
+			router := chi.NewRouter()
+			logger, _ := zap.NewProduction()
+			NewImportHandlers(router, logger.Sugar(), uc, render.New())
+			router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
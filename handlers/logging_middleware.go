@@ -0,0 +1,41 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/kiramishima/receipt-processor/pkg/logging"
+	"go.uber.org/zap"
+)
+
+// LoggingMiddleware scopes the logs of every request to its request id and logs the request when it completes, with
+// its route, status, size and latency. It goes after the RequestID middleware.
+func LoggingMiddleware(logger *zap.SugaredLogger) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			var started = time.Now()
+			var ctx = req.Context()
+			if id := middleware.GetReqID(ctx); id != "" {
+				ctx = logging.With(ctx, "requestId", id)
+			}
+			var ww = middleware.NewWrapResponseWriter(w, req.ProtoMajor)
+			next.ServeHTTP(ww, req.WithContext(ctx))
+
+			var status = responseStatus(ww)
+			var log = logging.Logger(ctx, logger).Infow
+			if status >= http.StatusInternalServerError {
+				log = logging.Logger(ctx, logger).Warnw
+			}
+			log("request served",
+				"method", req.Method,
+				"route", routePattern(req),
+				"path", req.URL.Path,
+				"status", status,
+				"bytes", ww.BytesWritten(),
+				"duration", time.Since(started),
+				"remoteAddr", req.RemoteAddr,
+			)
+		})
+	}
+}
@@ -0,0 +1,54 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/kiramishima/receipt-processor/pkg/logging"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestLoggingMiddleware(t *testing.T) {
+	var core, logs = observer.New(zap.DebugLevel)
+	var logger = zap.New(core).Sugar()
+
+	router := chi.NewRouter()
+	router.Use(middleware.RequestID)
+	router.Use(LoggingMiddleware(logger))
+	router.Get("/receipts/{id}/points", func(w http.ResponseWriter, req *http.Request) {
+		logging.Logger(req.Context(), logger).Debug("in handler")
+		w.WriteHeader(http.StatusNotFound)
+	})
+	router.Get("/fail", func(w http.ResponseWriter, req *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})
+
+	var req = httptest.NewRequest(http.MethodGet, "/receipts/abc/points", nil)
+	req.Header.Set(middleware.RequestIDHeader, "req-1")
+	router.ServeHTTP(httptest.NewRecorder(), req)
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/fail", nil))
+
+	var entries = logs.AllUntimed()
+	if !assert.Len(t, entries, 3) {
+		return
+	}
+	assert.Equal(t, "in handler", entries[0].Message)
+	assert.Equal(t, "req-1", entries[0].ContextMap()["requestId"])
+
+	var served = entries[1].ContextMap()
+	assert.Equal(t, "request served", entries[1].Message)
+	assert.Equal(t, zapcore.InfoLevel, entries[1].Level)
+	assert.Equal(t, "req-1", served["requestId"])
+	assert.Equal(t, "/receipts/{id}/points", served["route"])
+	assert.Equal(t, "/receipts/abc/points", served["path"])
+	assert.Equal(t, int64(http.StatusNotFound), served["status"])
+
+	assert.Equal(t, zapcore.WarnLevel, entries[2].Level)
+	assert.NotEmpty(t, entries[2].ContextMap()["requestId"])
+}
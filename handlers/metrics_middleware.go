@@ -0,0 +1,52 @@
+package handlers
+
+import (
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	ports "github.com/kiramishima/receipt-processor/ports/metrics"
+	"net/http"
+	"time"
+)
+
+// unmatchedRoute route of the requests no route served, so unknown paths don't create a series each
+const unmatchedRoute = "unmatched"
+
+// MetricsMiddleware records the count and latency of the requests by the route pattern that served them, like
+// /receipts/{id}/points, instead of the path
+func MetricsMiddleware(recorder ports.IMetricsRecorder) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			var started = time.Now()
+			var ww = middleware.NewWrapResponseWriter(w, req.ProtoMajor)
+			next.ServeHTTP(ww, req)
+
+			recorder.ObserveRequest(req.Method, routePattern(req), responseStatus(ww), time.Since(started))
+		})
+	}
+}
+
+// routePattern returns the pattern of the route that served the request, after it was served. The requests a
+// middleware answered before the routing, like the rejected credentials, get the route they would have been routed to
+func routePattern(req *http.Request) string {
+	var rctx = chi.RouteContext(req.Context())
+	if rctx == nil {
+		return unmatchedRoute
+	}
+	if rctx.RoutePattern() != "" {
+		return rctx.RoutePattern()
+	}
+	if rctx.Routes != nil {
+		if pattern := matchRoute(rctx.Routes, req); pattern != "" {
+			return pattern
+		}
+	}
+	return unmatchedRoute
+}
+
+// responseStatus returns the status code of the response, 200 when the handler wrote the body without a header
+func responseStatus(ww middleware.WrapResponseWriter) int {
+	if status := ww.Status(); status != 0 {
+		return status
+	}
+	return http.StatusOK
+}
@@ -0,0 +1,55 @@
+package handlers
+
+import (
+	"github.com/go-chi/chi/v5"
+	"github.com/kiramishima/receipt-processor/mocks"
+	"go.uber.org/mock/gomock"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestMetricsMiddleware(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	recorder := mocks.NewMockIMetricsRecorder(ctrl)
+
+	router := chi.NewRouter()
+	router.Use(MetricsMiddleware(recorder))
+	router.Use(func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			if req.URL.Query().Has("reject") {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			next.ServeHTTP(w, req)
+		})
+	})
+	router.Route("/receipts", func(r chi.Router) {
+		r.Get("/{id}/points", func(w http.ResponseWriter, req *http.Request) {
+			w.WriteHeader(http.StatusNotFound)
+		})
+		r.Get("/", func(w http.ResponseWriter, req *http.Request) {
+			_, _ = w.Write([]byte("[]"))
+		})
+	})
+
+	testCases := map[string]struct {
+		url    string
+		route  string
+		status int
+	}{
+		"Route pattern":    {url: "/receipts/abc/points", route: "/receipts/{id}/points", status: http.StatusNotFound},
+		"Implicit status":  {url: "/receipts/", route: "/receipts", status: http.StatusOK},
+		"Unmatched":        {url: "/unknown/path", route: unmatchedRoute, status: http.StatusNotFound},
+		"Before routing":   {url: "/receipts/abc/points?reject", route: "/receipts/{id}/points", status: http.StatusUnauthorized},
+		"Unknown rejected": {url: "/unknown/path?reject", route: unmatchedRoute, status: http.StatusUnauthorized},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			recorder.EXPECT().ObserveRequest(http.MethodGet, tc.route, tc.status, gomock.Any()).Times(1)
+			router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, tc.url, nil))
+		})
+	}
+}
@@ -0,0 +1,71 @@
+package handlers
+
+import (
+	"github.com/go-chi/chi/v5"
+	"github.com/kiramishima/receipt-processor/domain"
+	"github.com/kiramishima/receipt-processor/pkg/logging"
+	"github.com/kiramishima/receipt-processor/pkg/utils"
+	ports "github.com/kiramishima/receipt-processor/ports/services"
+	"github.com/unrolled/render"
+	"go.uber.org/zap"
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+)
+
+// maxTextBytes limit of the plain text bodies
+const maxTextBytes = 1_048_576
+
+// NewParserHandlers creates a instance of receipt text parser handlers
+func NewParserHandlers(r *chi.Mux, logger *zap.SugaredLogger, s ports.IParserService, render *render.Render) {
+	handler := &ParserHandlers{
+		logger:   logger,
+		service:  s,
+		response: render,
+	}
+
+	r.Post("/receipts/parse", handler.ReceiptParseHandler)
+}
+
+type ParserHandlers struct {
+	logger   *zap.SugaredLogger
+	service  ports.IParserService
+	response *render.Render
+}
+
+// ReceiptParseHandler parses a JSON parse request or a text/plain body, the locale of the text bodies is the first
+// language of the Content-Language header
+func (h *ParserHandlers) ReceiptParseHandler(w http.ResponseWriter, req *http.Request) {
+	// The text bodies are uploads like the email ones, larger than the JSON requests
+	extendDeadlines(w)
+	var parseReq = &domain.ParseRequest{}
+
+	mediaType, _, _ := mime.ParseMediaType(req.Header.Get("Content-Type"))
+	if mediaType == "text/plain" {
+		text, err := io.ReadAll(http.MaxBytesReader(w, req.Body, maxTextBytes))
+		if err != nil {
+			logging.Logger(req.Context(), h.logger).Error(err.Error())
+			_ = h.response.JSON(w, http.StatusBadRequest, errorBody(req, err))
+			return
+		}
+		parseReq.Text = string(text)
+	} else if err := utils.ReadJSON(w, req, &parseReq); err != nil {
+		logging.Logger(req.Context(), h.logger).Error(err.Error())
+		_ = h.response.JSON(w, http.StatusBadRequest, errorBody(req, err))
+		return
+	}
+	if parseReq.Locale == "" {
+		language, _, _ := strings.Cut(req.Header.Get("Content-Language"), ",")
+		parseReq.Locale = strings.TrimSpace(language)
+	}
+
+	parsed, err := h.service.ParseReceipt(req.Context(), parseReq)
+	if err != nil {
+		logging.Logger(req.Context(), h.logger).Error(err.Error())
+		_ = h.response.JSON(w, errorStatus(err), errorBody(req, err))
+		return
+	}
+
+	_ = h.response.JSON(w, http.StatusOK, parsed)
+}
@@ -0,0 +1,101 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/go-chi/chi/v5"
+	"github.com/kiramishima/receipt-processor/domain"
+	"github.com/kiramishima/receipt-processor/mocks"
+	appErrors "github.com/kiramishima/receipt-processor/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/unrolled/render"
+	"go.uber.org/mock/gomock"
+	"go.uber.org/zap"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestParserHandlers(t *testing.T) {
+	var parsed = &domain.ParsedReceipt{
+		Receipt:    &domain.ReceiptBase{Retailer: "Target", Total: "1.25"},
+		Confidence: &domain.ParseConfidence{Retailer: 0.9},
+	}
+
+	testCases := map[string]struct {
+		contentType   string
+		header        http.Header
+		body          []byte
+		buildStubs    func(uc *mocks.MockIParserService)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		"JSON": {
+			contentType: "application/json",
+			body:        []byte(`{"text": "TARGET\nTOTAL 1.25", "locale": "en-US"}`),
+			buildStubs: func(uc *mocks.MockIParserService) {
+				uc.EXPECT().ParseReceipt(gomock.Any(), gomock.Eq(&domain.ParseRequest{Text: "TARGET\nTOTAL 1.25", Locale: "en-US"})).Times(1).Return(parsed, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusOK, recorder.Code)
+				var response = &domain.ParsedReceipt{}
+				assert.NoError(t, json.Unmarshal(recorder.Body.Bytes(), response))
+				assert.Equal(t, parsed, response)
+			},
+		},
+		"Plain text": {
+			contentType: "text/plain; charset=utf-8",
+			header:      http.Header{"Content-Language": []string{"de-DE, en"}},
+			body:        []byte("REWE\nSUMME EUR 1,25"),
+			buildStubs: func(uc *mocks.MockIParserService) {
+				uc.EXPECT().ParseReceipt(gomock.Any(), gomock.Eq(&domain.ParseRequest{Text: "REWE\nSUMME EUR 1,25", Locale: "de-DE"})).Times(1).Return(parsed, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		"Invalid JSON": {
+			contentType: "application/json",
+			body:        []byte(`{"text": 1}`),
+			buildStubs: func(uc *mocks.MockIParserService) {
+				uc.EXPECT().ParseReceipt(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		"Bad request": {
+			contentType: "text/plain",
+			body:        []byte(""),
+			buildStubs: func(uc *mocks.MockIParserService) {
+				uc.EXPECT().ParseReceipt(gomock.Any(), gomock.Any()).Times(1).Return(nil, appErrors.BadRequest)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			uc := mocks.NewMockIParserService(ctrl)
+			tc.buildStubs(uc)
+
+			recorder := httptest.NewRecorder()
+			request, err := http.NewRequest(http.MethodPost, "/receipts/parse", bytes.NewReader(tc.body))
+			assert.NoError(t, err)
+			request.Header.Set("Content-Type", tc.contentType)
+			for key, values := range tc.header {
+				request.Header[key] = values
+			}
+
+			router := chi.NewRouter()
+			logger, _ := zap.NewProduction()
+			NewParserHandlers(router, logger.Sugar(), uc, render.New())
+			router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
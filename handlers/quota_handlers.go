@@ -0,0 +1,39 @@
+package handlers
+
+import (
+	"github.com/go-chi/chi/v5"
+	"github.com/kiramishima/receipt-processor/pkg/logging"
+	ports "github.com/kiramishima/receipt-processor/ports/services"
+	"github.com/unrolled/render"
+	"go.uber.org/zap"
+	"net/http"
+)
+
+// NewQuotaHandlers creates a instance of quota handlers
+func NewQuotaHandlers(r *chi.Mux, logger *zap.SugaredLogger, s ports.IQuotaService, render *render.Render) {
+	handler := &QuotaHandlers{
+		logger:   logger,
+		service:  s,
+		response: render,
+	}
+
+	r.Get("/receipts/quota", handler.ReceiptQuotaHandler)
+}
+
+type QuotaHandlers struct {
+	logger   *zap.SugaredLogger
+	service  ports.IQuotaService
+	response *render.Render
+}
+
+// ReceiptQuotaHandler returns the daily receipt quota of the client and its usage
+func (h *QuotaHandlers) ReceiptQuotaHandler(w http.ResponseWriter, req *http.Request) {
+	status, err := h.service.ReceiptQuota(req.Context())
+	if err != nil {
+		logging.Logger(req.Context(), h.logger).Error(err.Error())
+		_ = h.response.JSON(w, errorStatus(err), errorBody(req, err))
+		return
+	}
+
+	_ = h.response.JSON(w, http.StatusOK, status)
+}
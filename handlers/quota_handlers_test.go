@@ -0,0 +1,82 @@
+package handlers
+
+import (
+	"fmt"
+	"github.com/go-chi/chi/v5"
+	"github.com/kiramishima/receipt-processor/domain"
+	"github.com/kiramishima/receipt-processor/mocks"
+	appErrors "github.com/kiramishima/receipt-processor/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/unrolled/render"
+	"go.uber.org/mock/gomock"
+	"go.uber.org/zap"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestQuotaHandlers(t *testing.T) {
+	var status = &domain.QuotaStatus{
+		QuotaUsage: domain.QuotaUsage{Client: "apikey:key-1", Day: "2022-03-20", Used: 3},
+		Quota:      10,
+		ResetsAt:   time.Date(2022, 3, 21, 0, 0, 0, 0, time.UTC),
+	}
+
+	testCases := map[string]struct {
+		buildStubs    func(uc *mocks.MockIQuotaService)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		"OK": {
+			buildStubs: func(uc *mocks.MockIQuotaService) {
+				uc.EXPECT().ReceiptQuota(gomock.Any()).Times(1).Return(status, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusOK, recorder.Code)
+				assert.JSONEq(t, `{"client":"apikey:key-1","day":"2022-03-20","used":3,"quota":10,"resetsAt":"2022-03-21T00:00:00Z"}`, recorder.Body.String())
+			},
+		},
+		"Anonymous": {
+			buildStubs: func(uc *mocks.MockIQuotaService) {
+				uc.EXPECT().ReceiptQuota(gomock.Any()).Times(1).Return(nil, fmt.Errorf("%w: the quotas apply to authenticated clients", appErrors.Unauthorized))
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			uc := mocks.NewMockIQuotaService(ctrl)
+			tc.buildStubs(uc)
+
+			recorder := httptest.NewRecorder()
+			request, err := http.NewRequest(http.MethodGet, "/receipts/quota", nil)
+			assert.NoError(t, err)
+
+			router := chi.NewRouter()
+			logger, _ := zap.NewProduction()
+			NewQuotaHandlers(router, logger.Sugar(), uc, render.New())
+			router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+func TestRetryAfterQuota(t *testing.T) {
+	var recorder = httptest.NewRecorder()
+	retryAfterQuota(recorder, fmt.Errorf("%w: 10 receipts per day", appErrors.QuotaExceeded))
+	retryAfter, err := strconv.Atoi(recorder.Header().Get("Retry-After"))
+	assert.NoError(t, err)
+	assert.True(t, retryAfter > 0 && retryAfter <= 24*60*60)
+	assert.Equal(t, http.StatusTooManyRequests, errorStatus(fmt.Errorf("%w: 10 receipts per day", appErrors.QuotaExceeded)))
+
+	recorder = httptest.NewRecorder()
+	retryAfterQuota(recorder, appErrors.BadRequest)
+	assert.Empty(t, recorder.Header().Get("Retry-After"))
+}
@@ -0,0 +1,103 @@
+package handlers
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/kiramishima/receipt-processor/domain"
+	"github.com/kiramishima/receipt-processor/pkg/auth"
+	appErrors "github.com/kiramishima/receipt-processor/pkg/errors"
+	"github.com/kiramishima/receipt-processor/pkg/logging"
+	"github.com/kiramishima/receipt-processor/ports/ratelimit"
+	"github.com/unrolled/render"
+	"go.uber.org/zap"
+)
+
+// ipPolicy policy of the limit of the IPs
+const ipPolicy = "ip"
+
+// RateLimitMiddleware limits the requests of every client to the limit of the chi route pattern of the request, the
+// routes without their own limit share the default one. The clients are the API key or the member of the principal,
+// or the RealIP of the anonymous requests. Every response has the RateLimit-Limit, RateLimit-Remaining,
+// RateLimit-Reset and RateLimit-Policy headers, the limited requests are answered with 429 and Retry-After. It goes
+// after the AuthMiddleware and RealIP.
+func RateLimitMiddleware(routes chi.Routes, policies *domain.RateLimitPolicies, limiter ratelimit.IRateLimiter, response *render.Render, logger *zap.SugaredLogger) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			if publicPaths[req.URL.Path] {
+				next.ServeHTTP(w, req)
+				return
+			}
+
+			var policy, limit = policies.For(req.Method, matchRoute(routes, req))
+			if allowRequest(w, req, rateLimitClient(req), policy, limit, limiter, response, logger) {
+				next.ServeHTTP(w, req)
+			}
+		})
+	}
+}
+
+// IPRateLimitMiddleware limits all the requests of every IP, authenticated or not. It goes before the AuthMiddleware,
+// so the credentials can't be guessed without limit. The IPs are the ones of the connections added by the
+// RemoteIPMiddleware, not the RealIP, so the clients can't spread their requests over forged X-Forwarded-For headers.
+// The RateLimitMiddleware replaces its headers with the ones of the client.
+func IPRateLimitMiddleware(limit domain.RateLimit, limiter ratelimit.IRateLimiter, response *render.Render, logger *zap.SugaredLogger) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			if publicPaths[req.URL.Path] {
+				next.ServeHTTP(w, req)
+				return
+			}
+
+			if allowRequest(w, req, "ip:"+remoteIP(req), ipPolicy, limit, limiter, response, logger) {
+				next.ServeHTTP(w, req)
+			}
+		})
+	}
+}
+
+// allowRequest takes a token of the bucket of the client and the policy and sets the rate limit headers, the limited
+// requests are answered and false returned
+func allowRequest(w http.ResponseWriter, req *http.Request, client string, policy string, limit domain.RateLimit, limiter ratelimit.IRateLimiter, response *render.Render, logger *zap.SugaredLogger) bool {
+	var decision = limiter.Allow(client+" "+policy, limit, time.Now())
+
+	var header = w.Header()
+	header.Set("RateLimit-Limit", strconv.Itoa(limit.Requests))
+	header.Set("RateLimit-Remaining", strconv.Itoa(decision.Remaining))
+	header.Set("RateLimit-Reset", strconv.Itoa(seconds(decision.Reset)))
+	header.Set("RateLimit-Policy", limit.String())
+	if !decision.Allowed {
+		var err = fmt.Errorf("%w: %d requests per %s", appErrors.TooManyRequests, limit.Requests, limit.Period)
+		logging.Logger(req.Context(), logger).Warnw(err.Error(), "client", client, "policy", policy)
+		header.Set("Retry-After", strconv.Itoa(seconds(decision.RetryAfter)))
+		_ = response.JSON(w, errorStatus(err), errorBody(req, err))
+		return false
+	}
+	return true
+}
+
+// matchRoute returns the route pattern the request will be routed to, empty when no route matches
+func matchRoute(routes chi.Routes, req *http.Request) string {
+	var rctx = chi.NewRouteContext()
+	if !routes.Match(rctx, req.Method, req.URL.Path) {
+		return ""
+	}
+	return rctx.RoutePattern()
+}
+
+// rateLimitClient returns the client of the request, its principal or its IP
+func rateLimitClient(req *http.Request) string {
+	if principal := auth.Principal(req.Context()); principal != nil {
+		return principal.ClientKey()
+	}
+	return "ip:" + clientIP(req)
+}
+
+// seconds rounds up the duration to whole seconds, for the headers
+func seconds(d time.Duration) int {
+	return int(math.Ceil(d.Seconds()))
+}
@@ -0,0 +1,198 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/kiramishima/receipt-processor/domain"
+	"github.com/kiramishima/receipt-processor/mocks"
+	"github.com/kiramishima/receipt-processor/pkg/auth"
+	"github.com/stretchr/testify/assert"
+	"github.com/unrolled/render"
+	"go.uber.org/mock/gomock"
+	"go.uber.org/zap"
+)
+
+func TestRateLimitMiddleware(t *testing.T) {
+	policies, err := domain.ParseRateLimitPolicies("20/s", "POST /receipts/process=5/s,/receipts/import=10/m")
+	if !assert.NoError(t, err) {
+		return
+	}
+	var process = domain.RateLimit{Requests: 5, Period: time.Second}
+	var member = domain.NewTokenPrincipal("user-1", "member-1", []string{domain.RoleMember})
+
+	testCases := map[string]struct {
+		method        string
+		url           string
+		principal     *domain.Principal
+		buildStubs    func(limiter *mocks.MockIRateLimiter)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		"Allowed": {
+			method: http.MethodPost,
+			url:    "/receipts/process",
+			buildStubs: func(limiter *mocks.MockIRateLimiter) {
+				limiter.EXPECT().Allow(gomock.Eq("ip:192.0.2.1 POST /receipts/process"), gomock.Eq(process), gomock.Any()).Times(1).
+					Return(domain.RateLimitDecision{Allowed: true, Limit: process, Remaining: 4, Reset: 200 * time.Millisecond})
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusOK, recorder.Code)
+				assert.Equal(t, "5", recorder.Header().Get("RateLimit-Limit"))
+				assert.Equal(t, "4", recorder.Header().Get("RateLimit-Remaining"))
+				assert.Equal(t, "1", recorder.Header().Get("RateLimit-Reset"))
+				assert.Equal(t, "5;w=1", recorder.Header().Get("RateLimit-Policy"))
+				assert.Empty(t, recorder.Header().Get("Retry-After"))
+			},
+		},
+		"Limited": {
+			method:    http.MethodPost,
+			url:       "/receipts/process",
+			principal: member,
+			buildStubs: func(limiter *mocks.MockIRateLimiter) {
+				limiter.EXPECT().Allow(gomock.Eq("member:member-1 POST /receipts/process"), gomock.Eq(process), gomock.Any()).Times(1).
+					Return(domain.RateLimitDecision{Limit: process, Reset: time.Second, RetryAfter: 1500 * time.Millisecond})
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusTooManyRequests, recorder.Code)
+				assert.Equal(t, "0", recorder.Header().Get("RateLimit-Remaining"))
+				assert.Equal(t, "2", recorder.Header().Get("Retry-After"))
+				assert.Contains(t, recorder.Body.String(), "Too many requests: 5 requests per 1s")
+			},
+		},
+		"Route pattern": {
+			method: http.MethodGet,
+			url:    "/receipts/abc/points",
+			buildStubs: func(limiter *mocks.MockIRateLimiter) {
+				limiter.EXPECT().Allow(gomock.Eq("ip:192.0.2.1 default"), gomock.Eq(policies.Default), gomock.Any()).Times(1).
+					Return(domain.RateLimitDecision{Allowed: true, Limit: policies.Default, Remaining: 19})
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusOK, recorder.Code)
+				assert.Equal(t, "20", recorder.Header().Get("RateLimit-Limit"))
+			},
+		},
+		"Health probe": {
+			method:     http.MethodGet,
+			url:        "/readyz",
+			buildStubs: func(limiter *mocks.MockIRateLimiter) {},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusOK, recorder.Code)
+				assert.Empty(t, recorder.Header().Get("RateLimit-Limit"))
+			},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			limiter := mocks.NewMockIRateLimiter(ctrl)
+			tc.buildStubs(limiter)
+
+			router := chi.NewRouter()
+			router.Use(func(next http.Handler) http.Handler {
+				return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+					if tc.principal != nil {
+						req = req.WithContext(auth.WithPrincipal(req.Context(), tc.principal))
+					}
+					next.ServeHTTP(w, req)
+				})
+			})
+			router.Use(RateLimitMiddleware(router, policies, limiter, render.New(), zap.NewNop().Sugar()))
+			var ok = func(w http.ResponseWriter, req *http.Request) { w.WriteHeader(http.StatusOK) }
+			router.Route("/receipts", func(r chi.Router) {
+				r.Post("/process", ok)
+				r.Get("/{id}/points", ok)
+			})
+			router.Get("/readyz", ok)
+
+			var req = httptest.NewRequest(tc.method, tc.url, nil)
+			req.RemoteAddr = "192.0.2.1:5000"
+			recorder := httptest.NewRecorder()
+			router.ServeHTTP(recorder, req)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+func TestIPRateLimitMiddleware(t *testing.T) {
+	var limit = domain.RateLimit{Requests: 50, Period: time.Second}
+
+	testCases := map[string]struct {
+		url           string
+		forwardedFor  string
+		buildStubs    func(limiter *mocks.MockIRateLimiter)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		"Allowed": {
+			url: "/receipts/abc/points",
+			buildStubs: func(limiter *mocks.MockIRateLimiter) {
+				limiter.EXPECT().Allow(gomock.Eq("ip:192.0.2.1 ip"), gomock.Eq(limit), gomock.Any()).Times(1).
+					Return(domain.RateLimitDecision{Allowed: true, Limit: limit, Remaining: 49})
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusOK, recorder.Code)
+				assert.Equal(t, "49", recorder.Header().Get("RateLimit-Remaining"))
+			},
+		},
+		"Limited": {
+			url: "/receipts/abc/points",
+			buildStubs: func(limiter *mocks.MockIRateLimiter) {
+				limiter.EXPECT().Allow(gomock.Eq("ip:192.0.2.1 ip"), gomock.Eq(limit), gomock.Any()).Times(1).
+					Return(domain.RateLimitDecision{Limit: limit, Reset: time.Second, RetryAfter: 20 * time.Millisecond})
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusTooManyRequests, recorder.Code)
+				assert.Equal(t, "1", recorder.Header().Get("Retry-After"))
+				assert.Contains(t, recorder.Body.String(), "Too many requests: 50 requests per 1s")
+			},
+		},
+		"Forwarded for": {
+			url:          "/receipts/abc/points",
+			forwardedFor: "198.51.100.7",
+			buildStubs: func(limiter *mocks.MockIRateLimiter) {
+				limiter.EXPECT().Allow(gomock.Eq("ip:192.0.2.1 ip"), gomock.Eq(limit), gomock.Any()).Times(1).
+					Return(domain.RateLimitDecision{Allowed: true, Limit: limit, Remaining: 49})
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		"Health probe": {
+			url:        "/readyz",
+			buildStubs: func(limiter *mocks.MockIRateLimiter) {},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusOK, recorder.Code)
+				assert.Empty(t, recorder.Header().Get("RateLimit-Limit"))
+			},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			limiter := mocks.NewMockIRateLimiter(ctrl)
+			tc.buildStubs(limiter)
+
+			var handler = IPRateLimitMiddleware(limit, limiter, render.New(), zap.NewNop().Sugar())(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+				w.WriteHeader(http.StatusOK)
+			}))
+			handler = RemoteIPMiddleware()(middleware.RealIP(handler))
+			var req = httptest.NewRequest(http.MethodGet, tc.url, nil)
+			req.RemoteAddr = "192.0.2.1:5000"
+			if tc.forwardedFor != "" {
+				req.Header.Set("X-Forwarded-For", tc.forwardedFor)
+			}
+			recorder := httptest.NewRecorder()
+			handler.ServeHTTP(recorder, req)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
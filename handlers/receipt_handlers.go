@@ -4,9 +4,11 @@ import (
 	"github.com/go-chi/chi/v5"
 	"github.com/kiramishima/receipt-processor/domain"
 	appErrors "github.com/kiramishima/receipt-processor/pkg/errors"
+	"github.com/kiramishima/receipt-processor/pkg/logging"
 	"github.com/kiramishima/receipt-processor/pkg/utils"
 	"github.com/unrolled/render"
 	"net/http"
+	"strings"
 
 	ports "github.com/kiramishima/receipt-processor/ports/services"
 	"go.uber.org/zap"
@@ -22,8 +24,10 @@ func NewReceiptHandlers(r *chi.Mux, logger *zap.SugaredLogger, s ports.IReceiptS
 
 	r.Route("/receipts", func(r chi.Router) {
 		r.Post("/process", handler.ReceiptProcessHandler)
+		r.Post("/score", handler.ReceiptScoreHandler)
 		r.Get("/{id}/points", handler.ReceiptGetPointsHandler)
 	})
+	r.Post("/admin/receipts/rescore", handler.ReceiptRescoreHandler)
 }
 
 type ReceiptHandlers struct {
@@ -33,35 +37,93 @@ type ReceiptHandlers struct {
 }
 
 func (h *ReceiptHandlers) ReceiptProcessHandler(w http.ResponseWriter, req *http.Request) {
+	ctx, span := tracer.Start(req.Context(), "ReceiptProcessHandler")
+	defer span.End()
+	req = req.WithContext(ctx)
+
 	var jsonReq = &domain.ReceiptBase{}
 
 	err := utils.ReadJSON(w, req, &jsonReq)
 
 	if err != nil {
-		h.logger.Error(err.Error())
-		_ = h.response.JSON(w, http.StatusInternalServerError, map[string]string{"error": err.Error()})
+		logging.Logger(req.Context(), h.logger).Error(err.Error())
+		_ = h.response.JSON(w, http.StatusInternalServerError, errorBody(req, err))
+		return
+	}
+
+	logging.Logger(req.Context(), h.logger).Debugw("receipt received", "receipt", jsonReq.Redacted())
+	declareLocale(req, jsonReq)
+	if req.URL.Query().Get("dryRun") == "true" {
+		h.score(w, req, jsonReq)
 		return
 	}
 
-	h.logger.Info(jsonReq)
-	ctx := req.Context()
 	id, err := h.service.StoreReceipt(ctx, jsonReq)
 
 	if err != nil {
-		h.logger.Error(err.Error())
+		span.RecordError(err)
+		logging.Logger(req.Context(), h.logger).Error(err.Error())
 
 		select {
 		case <-ctx.Done():
-			_ = h.response.JSON(w, http.StatusGatewayTimeout, appErrors.ErrTimeout)
+			_ = h.response.JSON(w, http.StatusGatewayTimeout, errorBody(req, appErrors.ErrTimeout))
 		default:
-			_ = h.response.JSON(w, http.StatusInternalServerError, map[string]string{"error": err.Error()})
+			retryAfterQuota(w, err)
+			_ = h.response.JSON(w, errorStatus(err), errorBody(req, err))
 		}
 		return
 	}
 
 	if err := h.response.JSON(w, http.StatusOK, map[string]string{"id": id}); err != nil {
-		h.logger.Error(err)
-		_ = h.response.JSON(w, http.StatusInternalServerError, map[string]string{"error": err.Error()})
+		logging.Logger(req.Context(), h.logger).Error(err)
+		_ = h.response.JSON(w, http.StatusInternalServerError, errorBody(req, err))
+		return
+	}
+}
+
+// ReceiptScoreHandler scores a receipt without storing it
+func (h *ReceiptHandlers) ReceiptScoreHandler(w http.ResponseWriter, req *http.Request) {
+	var jsonReq = &domain.ReceiptBase{}
+
+	err := utils.ReadJSON(w, req, &jsonReq)
+
+	if err != nil {
+		logging.Logger(req.Context(), h.logger).Error(err.Error())
+		_ = h.response.JSON(w, http.StatusInternalServerError, errorBody(req, err))
+		return
+	}
+
+	declareLocale(req, jsonReq)
+	h.score(w, req, jsonReq)
+}
+
+// declareLocale uses the first language of the Content-Language header as the locale of the receipts without locale
+func declareLocale(req *http.Request, receipt *domain.ReceiptBase) {
+	if receipt.Locale == "" {
+		language, _, _ := strings.Cut(req.Header.Get("Content-Language"), ",")
+		receipt.Locale = strings.TrimSpace(language)
+	}
+}
+
+func (h *ReceiptHandlers) score(w http.ResponseWriter, req *http.Request, receipt *domain.ReceiptBase) {
+	ctx := req.Context()
+	result, err := h.service.ScoreReceipt(ctx, receipt)
+
+	if err != nil {
+		logging.Logger(req.Context(), h.logger).Error(err.Error())
+
+		select {
+		case <-ctx.Done():
+			_ = h.response.JSON(w, http.StatusGatewayTimeout, errorBody(req, appErrors.ErrTimeout))
+		default:
+			_ = h.response.JSON(w, errorStatus(err), errorBody(req, err))
+		}
+		return
+	}
+
+	if err := h.response.JSON(w, http.StatusOK, result); err != nil {
+		logging.Logger(req.Context(), h.logger).Error(err)
+		_ = h.response.JSON(w, http.StatusInternalServerError, errorBody(req, err))
 		return
 	}
 }
@@ -69,17 +131,38 @@ func (h *ReceiptHandlers) ReceiptProcessHandler(w http.ResponseWriter, req *http
 func (h *ReceiptHandlers) ReceiptGetPointsHandler(w http.ResponseWriter, req *http.Request) {
 	receiptID := chi.URLParam(req, "id")
 
-	item, err := h.service.RetrieveReceipt(receiptID)
+	item, err := h.service.RetrieveReceipt(req.Context(), receiptID)
 
 	if err != nil {
-		h.logger.Error(err.Error())
-		_ = h.response.JSON(w, http.StatusInternalServerError, map[string]string{"error": err.Error()})
+		logging.Logger(req.Context(), h.logger).Error(err.Error())
+		_ = h.response.JSON(w, errorStatus(err), errorBody(req, err))
 		return
 	}
 
 	if err := h.response.JSON(w, http.StatusOK, item); err != nil {
-		h.logger.Error(err)
-		_ = h.response.JSON(w, http.StatusInternalServerError, map[string]string{"error": err.Error()})
+		logging.Logger(req.Context(), h.logger).Error(err)
+		_ = h.response.JSON(w, http.StatusInternalServerError, errorBody(req, err))
 		return
 	}
 }
+
+// ReceiptRescoreHandler rescores the stored receipts, a long running admin request
+func (h *ReceiptHandlers) ReceiptRescoreHandler(w http.ResponseWriter, req *http.Request) {
+	extendDeadlines(w)
+	var jsonReq = &domain.RescoreRequest{}
+
+	if err := utils.ReadJSON(w, req, &jsonReq); err != nil {
+		logging.Logger(req.Context(), h.logger).Error(err.Error())
+		_ = h.response.JSON(w, http.StatusBadRequest, errorBody(req, err))
+		return
+	}
+
+	report, err := h.service.RescoreReceipts(req.Context(), jsonReq)
+	if err != nil {
+		logging.Logger(req.Context(), h.logger).Error(err.Error())
+		_ = h.response.JSON(w, errorStatus(err), errorBody(req, err))
+		return
+	}
+
+	_ = h.response.JSON(w, http.StatusOK, report)
+}
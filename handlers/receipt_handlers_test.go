@@ -2,6 +2,7 @@ package handlers
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -9,6 +10,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/kiramishima/receipt-processor/domain"
 	"github.com/kiramishima/receipt-processor/mocks"
+	appErrors "github.com/kiramishima/receipt-processor/pkg/errors"
 	"github.com/stretchr/testify/assert"
 	"github.com/unrolled/render"
 	"go.uber.org/mock/gomock"
@@ -175,7 +177,7 @@ func TestReceiptHandlers_ReceiptGetPointsHandler(t *testing.T) {
 			ID: uids[0],
 			buildStubs: func(uc *mocks.MockIReceiptService) {
 				uc.EXPECT().
-					RetrieveReceipt(gomock.Eq(uids[0])).
+					RetrieveReceipt(gomock.Any(), gomock.Eq(uids[0])).
 					Times(1).
 					Return(&domain.Result{
 						ID:     uids[0],
@@ -190,9 +192,21 @@ func TestReceiptHandlers_ReceiptGetPointsHandler(t *testing.T) {
 			ID: uids[1],
 			buildStubs: func(uc *mocks.MockIReceiptService) {
 				uc.EXPECT().
-					RetrieveReceipt(gomock.Any()).
+					RetrieveReceipt(gomock.Any(), gomock.Any()).
 					AnyTimes().
-					Return(nil, errors.New(fmt.Sprintf("element with id: %s don't found", uids[1])))
+					Return(nil, fmt.Errorf("receipt with id: %s %w", uids[1], appErrors.NotFound))
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		"Repository error": {
+			ID: uids[1],
+			buildStubs: func(uc *mocks.MockIReceiptService) {
+				uc.EXPECT().
+					RetrieveReceipt(gomock.Any(), gomock.Eq(uids[1])).
+					Times(1).
+					Return(nil, errors.New("unavailable"))
 			},
 			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
 				assert.Equal(t, http.StatusInternalServerError, recorder.Code)
@@ -226,3 +240,160 @@ func TestReceiptHandlers_ReceiptGetPointsHandler(t *testing.T) {
 	}
 
 }
+
+func TestReceiptHandlers_ReceiptScoreHandler(t *testing.T) {
+	var receipt = domain.ReceiptBase{
+		Retailer:     "Target",
+		PurchaseDate: "2022-01-01",
+		PurchaseTime: "13:01",
+		Items: []*domain.ReceiptItemBase{
+			{ShortDescription: "Mountain Dew 12PK", Price: "6.49"},
+		},
+		Total: "6.49",
+	}
+
+	testCases := map[string]struct {
+		url           string
+		header        string
+		buildStubs    func(uc *mocks.MockIReceiptService)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		"Score": {
+			url: "/receipts/score",
+			buildStubs: func(uc *mocks.MockIReceiptService) {
+				uc.EXPECT().StoreReceipt(gomock.Any(), gomock.Any()).Times(0)
+				uc.EXPECT().ScoreReceipt(gomock.Any(), gomock.Any()).Times(1).Return(&domain.ScoreResult{
+					Points:    6,
+					Breakdown: &domain.Breakdown{BasePoints: 6, TotalPoints: 6},
+					Warnings:  []string{},
+				}, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusOK, recorder.Code)
+				var result domain.ScoreResult
+				assert.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &result))
+				assert.Equal(t, 6, result.Points)
+			},
+		},
+		"Process dry run": {
+			url: "/receipts/process?dryRun=true",
+			buildStubs: func(uc *mocks.MockIReceiptService) {
+				uc.EXPECT().StoreReceipt(gomock.Any(), gomock.Any()).Times(0)
+				uc.EXPECT().ScoreReceipt(gomock.Any(), gomock.Any()).Times(1).Return(&domain.ScoreResult{Points: 6}, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		"Invalid receipt": {
+			url: "/receipts/score",
+			buildStubs: func(uc *mocks.MockIReceiptService) {
+				uc.EXPECT().ScoreReceipt(gomock.Any(), gomock.Any()).Times(1).Return(nil, errors.New("Field: Retailer, Error: required\n"))
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+		"Ambiguous date": {
+			url: "/receipts/score",
+			buildStubs: func(uc *mocks.MockIReceiptService) {
+				uc.EXPECT().ScoreReceipt(gomock.Any(), gomock.Any()).Times(1).Return(nil, fmt.Errorf("%w: ambiguous input: date 03/04/2022 can be 2022-04-03 or 2022-03-04", appErrors.BadRequest))
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		"Content-Language": {
+			url:    "/receipts/score",
+			header: "de-DE, en",
+			buildStubs: func(uc *mocks.MockIReceiptService) {
+				uc.EXPECT().ScoreReceipt(gomock.Any(), gomock.Any()).Times(1).DoAndReturn(func(_ context.Context, receipt *domain.ReceiptBase) (*domain.ScoreResult, error) {
+					assert.Equal(t, "de-DE", receipt.Locale)
+					return &domain.ScoreResult{Points: 6}, nil
+				})
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			uc := mocks.NewMockIReceiptService(ctrl)
+			tc.buildStubs(uc)
+
+			recorder := httptest.NewRecorder()
+			marshalled, _ := json.Marshal(receipt)
+			request, err := http.NewRequest(http.MethodPost, tc.url, bytes.NewReader(marshalled))
+			assert.NoError(t, err)
+			if tc.header != "" {
+				request.Header.Set("Content-Language", tc.header)
+			}
+
+			router := chi.NewRouter()
+			logger, _ := zap.NewProduction()
+			NewReceiptHandlers(router, logger.Sugar(), uc, render.New())
+			router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+func TestReceiptHandlers_ReceiptRescoreHandler(t *testing.T) {
+	testCases := map[string]struct {
+		body          any
+		buildStubs    func(uc *mocks.MockIReceiptService)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		"Rescore": {
+			body: &domain.RescoreRequest{RuleSetVersion: "v2", Filter: &domain.ReceiptFilter{RetailerID: "target"}},
+			buildStubs: func(uc *mocks.MockIReceiptService) {
+				uc.EXPECT().RescoreReceipts(gomock.Any(), gomock.Any()).Times(1).DoAndReturn(func(_ any, req *domain.RescoreRequest) (*domain.RescoreReport, error) {
+					assert.Equal(t, "target", req.Filter.RetailerID)
+					assert.False(t, req.Commit)
+					return &domain.RescoreReport{RuleSetVersion: "v2", Summary: &domain.RescoreSummary{Receipts: 1, Delta: 12}}, nil
+				})
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusOK, recorder.Code)
+				var report domain.RescoreReport
+				assert.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &report))
+				assert.Equal(t, 12, report.Summary.Delta)
+			},
+		},
+		"Unknown version": {
+			body: &domain.RescoreRequest{RuleSetVersion: "v9"},
+			buildStubs: func(uc *mocks.MockIReceiptService) {
+				uc.EXPECT().RescoreReceipts(gomock.Any(), gomock.Any()).Times(1).Return(nil, appErrors.NotFound)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			uc := mocks.NewMockIReceiptService(ctrl)
+			tc.buildStubs(uc)
+
+			recorder := httptest.NewRecorder()
+			marshalled, _ := json.Marshal(tc.body)
+			request, err := http.NewRequest(http.MethodPost, "/admin/receipts/rescore", bytes.NewReader(marshalled))
+			assert.NoError(t, err)
+
+			router := chi.NewRouter()
+			logger, _ := zap.NewProduction()
+			NewReceiptHandlers(router, logger.Sugar(), uc, render.New())
+			router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
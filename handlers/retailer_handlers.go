@@ -0,0 +1,141 @@
+package handlers
+
+import (
+	"github.com/go-chi/chi/v5"
+	"github.com/kiramishima/receipt-processor/domain"
+	"github.com/kiramishima/receipt-processor/pkg/logging"
+	"github.com/kiramishima/receipt-processor/pkg/utils"
+	ports "github.com/kiramishima/receipt-processor/ports/services"
+	"github.com/unrolled/render"
+	"go.uber.org/zap"
+	"net/http"
+)
+
+// NewRetailerHandlers creates a instance of retailer catalog handlers
+func NewRetailerHandlers(r *chi.Mux, logger *zap.SugaredLogger, s ports.IRetailerService, render *render.Render) {
+	handler := &RetailerHandlers{
+		logger:   logger,
+		service:  s,
+		response: render,
+	}
+
+	r.Route("/admin/retailers", func(r chi.Router) {
+		r.Post("/", handler.CreateRetailerHandler)
+		r.Get("/", handler.ListRetailersHandler)
+		r.Get("/{id}", handler.GetRetailerHandler)
+		r.Put("/{id}", handler.UpdateRetailerHandler)
+		r.Delete("/{id}", handler.DeleteRetailerHandler)
+	})
+	r.Route("/admin/retailer-categories", func(r chi.Router) {
+		r.Get("/", handler.ListCategoriesHandler)
+		r.Put("/{name}", handler.SaveCategoryHandler)
+	})
+}
+
+type RetailerHandlers struct {
+	logger   *zap.SugaredLogger
+	service  ports.IRetailerService
+	response *render.Render
+}
+
+func (h *RetailerHandlers) CreateRetailerHandler(w http.ResponseWriter, req *http.Request) {
+	var jsonReq = &domain.Retailer{}
+
+	if err := utils.ReadJSON(w, req, &jsonReq); err != nil {
+		logging.Logger(req.Context(), h.logger).Error(err.Error())
+		_ = h.response.JSON(w, http.StatusBadRequest, errorBody(req, err))
+		return
+	}
+
+	retailer, err := h.service.CreateRetailer(req.Context(), jsonReq)
+	if err != nil {
+		logging.Logger(req.Context(), h.logger).Error(err.Error())
+		_ = h.response.JSON(w, errorStatus(err), errorBody(req, err))
+		return
+	}
+
+	_ = h.response.JSON(w, http.StatusCreated, retailer)
+}
+
+func (h *RetailerHandlers) ListRetailersHandler(w http.ResponseWriter, req *http.Request) {
+	retailers, err := h.service.ListRetailers()
+	if err != nil {
+		logging.Logger(req.Context(), h.logger).Error(err.Error())
+		_ = h.response.JSON(w, errorStatus(err), errorBody(req, err))
+		return
+	}
+
+	_ = h.response.JSON(w, http.StatusOK, retailers)
+}
+
+func (h *RetailerHandlers) GetRetailerHandler(w http.ResponseWriter, req *http.Request) {
+	retailer, err := h.service.RetrieveRetailer(chi.URLParam(req, "id"))
+	if err != nil {
+		logging.Logger(req.Context(), h.logger).Error(err.Error())
+		_ = h.response.JSON(w, errorStatus(err), errorBody(req, err))
+		return
+	}
+
+	_ = h.response.JSON(w, http.StatusOK, retailer)
+}
+
+func (h *RetailerHandlers) UpdateRetailerHandler(w http.ResponseWriter, req *http.Request) {
+	var jsonReq = &domain.Retailer{}
+
+	if err := utils.ReadJSON(w, req, &jsonReq); err != nil {
+		logging.Logger(req.Context(), h.logger).Error(err.Error())
+		_ = h.response.JSON(w, http.StatusBadRequest, errorBody(req, err))
+		return
+	}
+	jsonReq.ID = chi.URLParam(req, "id")
+
+	retailer, err := h.service.UpdateRetailer(req.Context(), jsonReq)
+	if err != nil {
+		logging.Logger(req.Context(), h.logger).Error(err.Error())
+		_ = h.response.JSON(w, errorStatus(err), errorBody(req, err))
+		return
+	}
+
+	_ = h.response.JSON(w, http.StatusOK, retailer)
+}
+
+func (h *RetailerHandlers) DeleteRetailerHandler(w http.ResponseWriter, req *http.Request) {
+	if err := h.service.DeleteRetailer(chi.URLParam(req, "id")); err != nil {
+		logging.Logger(req.Context(), h.logger).Error(err.Error())
+		_ = h.response.JSON(w, errorStatus(err), errorBody(req, err))
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *RetailerHandlers) ListCategoriesHandler(w http.ResponseWriter, req *http.Request) {
+	categories, err := h.service.ListCategories()
+	if err != nil {
+		logging.Logger(req.Context(), h.logger).Error(err.Error())
+		_ = h.response.JSON(w, errorStatus(err), errorBody(req, err))
+		return
+	}
+
+	_ = h.response.JSON(w, http.StatusOK, categories)
+}
+
+func (h *RetailerHandlers) SaveCategoryHandler(w http.ResponseWriter, req *http.Request) {
+	var jsonReq = &domain.RetailerCategory{}
+
+	if err := utils.ReadJSON(w, req, &jsonReq); err != nil {
+		logging.Logger(req.Context(), h.logger).Error(err.Error())
+		_ = h.response.JSON(w, http.StatusBadRequest, errorBody(req, err))
+		return
+	}
+	jsonReq.Name = chi.URLParam(req, "name")
+
+	category, err := h.service.SaveCategory(req.Context(), jsonReq)
+	if err != nil {
+		logging.Logger(req.Context(), h.logger).Error(err.Error())
+		_ = h.response.JSON(w, errorStatus(err), errorBody(req, err))
+		return
+	}
+
+	_ = h.response.JSON(w, http.StatusOK, category)
+}
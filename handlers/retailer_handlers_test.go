@@ -0,0 +1,90 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/go-chi/chi/v5"
+	"github.com/kiramishima/receipt-processor/domain"
+	"github.com/kiramishima/receipt-processor/mocks"
+	appErrors "github.com/kiramishima/receipt-processor/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/unrolled/render"
+	"go.uber.org/mock/gomock"
+	"go.uber.org/zap"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestRetailerHandlers(t *testing.T) {
+	var retailer = &domain.Retailer{ID: "target", Name: "Target", Aliases: []string{"Target Store"}, Category: "general"}
+
+	testCases := map[string]struct {
+		method        string
+		url           string
+		body          any
+		buildStubs    func(uc *mocks.MockIRetailerService)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		"Create": {
+			method: http.MethodPost,
+			url:    "/admin/retailers",
+			body:   retailer,
+			buildStubs: func(uc *mocks.MockIRetailerService) {
+				uc.EXPECT().CreateRetailer(gomock.Any(), gomock.Any()).Times(1).Return(retailer, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusCreated, recorder.Code)
+			},
+		},
+		"Get not found": {
+			method: http.MethodGet,
+			url:    "/admin/retailers/walgreens",
+			buildStubs: func(uc *mocks.MockIRetailerService) {
+				uc.EXPECT().RetrieveRetailer(gomock.Eq("walgreens")).Times(1).Return(nil, appErrors.NotFound)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		"Save category": {
+			method: http.MethodPut,
+			url:    "/admin/retailer-categories/grocery",
+			body:   &domain.RetailerCategory{},
+			buildStubs: func(uc *mocks.MockIRetailerService) {
+				uc.EXPECT().SaveCategory(gomock.Any(), gomock.Any()).Times(1).DoAndReturn(func(_ any, c *domain.RetailerCategory) (*domain.RetailerCategory, error) {
+					assert.Equal(t, "grocery", c.Name)
+					return c, nil
+				})
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			uc := mocks.NewMockIRetailerService(ctrl)
+			tc.buildStubs(uc)
+
+			recorder := httptest.NewRecorder()
+
+			var body []byte
+			if tc.body != nil {
+				body, _ = json.Marshal(tc.body)
+			}
+			request, err := http.NewRequest(tc.method, tc.url, bytes.NewReader(body))
+			assert.NoError(t, err)
+
+			router := chi.NewRouter()
+			logger, _ := zap.NewProduction()
+			NewRetailerHandlers(router, logger.Sugar(), uc, render.New())
+			router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
@@ -0,0 +1,97 @@
+package handlers
+
+import (
+	"github.com/go-chi/chi/v5"
+	"github.com/kiramishima/receipt-processor/domain"
+	"github.com/kiramishima/receipt-processor/pkg/logging"
+	"github.com/kiramishima/receipt-processor/pkg/utils"
+	ports "github.com/kiramishima/receipt-processor/ports/services"
+	"github.com/unrolled/render"
+	"go.uber.org/zap"
+	"net/http"
+)
+
+// NewRuleSetHandlers creates a instance of rule set handlers
+func NewRuleSetHandlers(r *chi.Mux, logger *zap.SugaredLogger, s ports.IRuleSetService, render *render.Render) {
+	handler := &RuleSetHandlers{
+		logger:   logger,
+		service:  s,
+		response: render,
+	}
+
+	r.Route("/admin/rule-sets", func(r chi.Router) {
+		r.Post("/", handler.CreateRuleSetHandler)
+		r.Get("/", handler.ListRuleSetsHandler)
+		r.Get("/active", handler.GetActiveRuleSetHandler)
+		r.Get("/{version}", handler.GetRuleSetHandler)
+		r.Put("/{version}/activate", handler.ActivateRuleSetHandler)
+	})
+}
+
+type RuleSetHandlers struct {
+	logger   *zap.SugaredLogger
+	service  ports.IRuleSetService
+	response *render.Render
+}
+
+func (h *RuleSetHandlers) CreateRuleSetHandler(w http.ResponseWriter, req *http.Request) {
+	var jsonReq = &domain.RuleSet{}
+
+	if err := utils.ReadJSON(w, req, &jsonReq); err != nil {
+		logging.Logger(req.Context(), h.logger).Error(err.Error())
+		_ = h.response.JSON(w, http.StatusBadRequest, errorBody(req, err))
+		return
+	}
+
+	rs, err := h.service.CreateRuleSet(req.Context(), jsonReq)
+	if err != nil {
+		logging.Logger(req.Context(), h.logger).Error(err.Error())
+		_ = h.response.JSON(w, errorStatus(err), errorBody(req, err))
+		return
+	}
+
+	_ = h.response.JSON(w, http.StatusCreated, rs)
+}
+
+func (h *RuleSetHandlers) ListRuleSetsHandler(w http.ResponseWriter, req *http.Request) {
+	ruleSets, err := h.service.ListRuleSets()
+	if err != nil {
+		logging.Logger(req.Context(), h.logger).Error(err.Error())
+		_ = h.response.JSON(w, errorStatus(err), errorBody(req, err))
+		return
+	}
+
+	_ = h.response.JSON(w, http.StatusOK, ruleSets)
+}
+
+func (h *RuleSetHandlers) GetActiveRuleSetHandler(w http.ResponseWriter, req *http.Request) {
+	rs, err := h.service.ActiveRuleSet()
+	if err != nil {
+		logging.Logger(req.Context(), h.logger).Error(err.Error())
+		_ = h.response.JSON(w, errorStatus(err), errorBody(req, err))
+		return
+	}
+
+	_ = h.response.JSON(w, http.StatusOK, rs)
+}
+
+func (h *RuleSetHandlers) GetRuleSetHandler(w http.ResponseWriter, req *http.Request) {
+	rs, err := h.service.RetrieveRuleSet(chi.URLParam(req, "version"))
+	if err != nil {
+		logging.Logger(req.Context(), h.logger).Error(err.Error())
+		_ = h.response.JSON(w, errorStatus(err), errorBody(req, err))
+		return
+	}
+
+	_ = h.response.JSON(w, http.StatusOK, rs)
+}
+
+func (h *RuleSetHandlers) ActivateRuleSetHandler(w http.ResponseWriter, req *http.Request) {
+	if err := h.service.ActivateRuleSet(chi.URLParam(req, "version")); err != nil {
+		logging.Logger(req.Context(), h.logger).Error(err.Error())
+		_ = h.response.JSON(w, errorStatus(err), errorBody(req, err))
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
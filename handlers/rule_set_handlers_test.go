@@ -0,0 +1,120 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/go-chi/chi/v5"
+	"github.com/kiramishima/receipt-processor/domain"
+	"github.com/kiramishima/receipt-processor/mocks"
+	appErrors "github.com/kiramishima/receipt-processor/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/unrolled/render"
+	"go.uber.org/mock/gomock"
+	"go.uber.org/zap"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestRuleSetHandlers(t *testing.T) {
+	var rs = &domain.RuleSet{Version: "v2", Rules: domain.DefaultRuleSet().Rules}
+
+	testCases := map[string]struct {
+		method        string
+		url           string
+		body          any
+		buildStubs    func(uc *mocks.MockIRuleSetService)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		"Create": {
+			method: http.MethodPost,
+			url:    "/admin/rule-sets",
+			body:   rs,
+			buildStubs: func(uc *mocks.MockIRuleSetService) {
+				uc.EXPECT().CreateRuleSet(gomock.Any(), gomock.Any()).Times(1).Return(rs, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusCreated, recorder.Code)
+			},
+		},
+		"Create duplicated version": {
+			method: http.MethodPost,
+			url:    "/admin/rule-sets",
+			body:   rs,
+			buildStubs: func(uc *mocks.MockIRuleSetService) {
+				uc.EXPECT().CreateRuleSet(gomock.Any(), gomock.Any()).Times(1).Return(nil, appErrors.BadRequest)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		"Get": {
+			method: http.MethodGet,
+			url:    "/admin/rule-sets/v2",
+			buildStubs: func(uc *mocks.MockIRuleSetService) {
+				uc.EXPECT().RetrieveRuleSet(gomock.Eq("v2")).Times(1).Return(rs, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusOK, recorder.Code)
+				var result domain.RuleSet
+				assert.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &result))
+				assert.Equal(t, "v2", result.Version)
+			},
+		},
+		"Active": {
+			method: http.MethodGet,
+			url:    "/admin/rule-sets/active",
+			buildStubs: func(uc *mocks.MockIRuleSetService) {
+				uc.EXPECT().ActiveRuleSet().Times(1).Return(domain.DefaultRuleSet(), nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		"Activate not found": {
+			method: http.MethodPut,
+			url:    "/admin/rule-sets/v9/activate",
+			buildStubs: func(uc *mocks.MockIRuleSetService) {
+				uc.EXPECT().ActivateRuleSet(gomock.Eq("v9")).Times(1).Return(appErrors.NotFound)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		"Activate": {
+			method: http.MethodPut,
+			url:    "/admin/rule-sets/v2/activate",
+			buildStubs: func(uc *mocks.MockIRuleSetService) {
+				uc.EXPECT().ActivateRuleSet(gomock.Eq("v2")).Times(1).Return(nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusNoContent, recorder.Code)
+			},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			uc := mocks.NewMockIRuleSetService(ctrl)
+			tc.buildStubs(uc)
+
+			recorder := httptest.NewRecorder()
+
+			var body []byte
+			if tc.body != nil {
+				body, _ = json.Marshal(tc.body)
+			}
+			request, err := http.NewRequest(tc.method, tc.url, bytes.NewReader(body))
+			assert.NoError(t, err)
+
+			router := chi.NewRouter()
+			logger, _ := zap.NewProduction()
+			NewRuleSetHandlers(router, logger.Sugar(), uc, render.New())
+			router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
@@ -0,0 +1,41 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/go-chi/chi/v5/middleware"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// tracer of the handlers, it sends the spans to the global tracer provider
+var tracer = otel.Tracer("github.com/kiramishima/receipt-processor/handlers")
+
+// TracingMiddleware starts the server span of every request, continuing the trace of the W3C traceparent header of
+// the caller when there is one. The span is named by the route pattern that served the request, like the metrics.
+func TracingMiddleware() func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			var ctx = otel.GetTextMapPropagator().Extract(req.Context(), propagation.HeaderCarrier(req.Header))
+			ctx, span := tracer.Start(ctx, req.Method,
+				trace.WithSpanKind(trace.SpanKindServer),
+				trace.WithAttributes(semconv.HTTPMethod(req.Method), semconv.URLPath(req.URL.Path)),
+			)
+			defer span.End()
+
+			var ww = middleware.NewWrapResponseWriter(w, req.ProtoMajor)
+			next.ServeHTTP(ww, req.WithContext(ctx))
+
+			var route, status = routePattern(req), responseStatus(ww)
+			span.SetName(fmt.Sprintf("%s %s", req.Method, route))
+			span.SetAttributes(semconv.HTTPRoute(route), semconv.HTTPStatusCode(status))
+			if status >= http.StatusInternalServerError {
+				span.SetStatus(codes.Error, http.StatusText(status))
+			}
+		})
+	}
+}
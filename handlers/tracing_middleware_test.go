@@ -0,0 +1,70 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestTracingMiddleware(t *testing.T) {
+	var exporter = tracetest.NewInMemoryExporter()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter)))
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+
+	router := chi.NewRouter()
+	router.Use(TracingMiddleware())
+	router.Get("/receipts/{id}/points", func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusInternalServerError)
+		_ = json.NewEncoder(w).Encode(errorBody(req, errors.New("unavailable")))
+	})
+
+	const traceID = "4bf92f3577b34da6a3ce929d0e0e4736"
+	testCases := map[string]struct {
+		traceparent string
+		remote      bool
+	}{
+		"Propagated trace": {traceparent: "00-" + traceID + "-00f067aa0ba902b7-01", remote: true},
+		"New trace":        {},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			exporter.Reset()
+			var req = httptest.NewRequest(http.MethodGet, "/receipts/abc/points", nil)
+			if tc.traceparent != "" {
+				req.Header.Set("traceparent", tc.traceparent)
+			}
+			var recorder = httptest.NewRecorder()
+			router.ServeHTTP(recorder, req)
+
+			var spans = exporter.GetSpans()
+			if !assert.Len(t, spans, 1) {
+				return
+			}
+			var span = spans[0]
+			assert.Equal(t, "GET /receipts/{id}/points", span.Name)
+			assert.Equal(t, codes.Error, span.Status.Code)
+			assert.Contains(t, span.Attributes, attribute.Int("http.status_code", http.StatusInternalServerError))
+			assert.Equal(t, tc.remote, span.Parent.IsRemote())
+			if tc.remote {
+				assert.Equal(t, traceID, span.SpanContext.TraceID().String())
+			}
+
+			var body map[string]string
+			assert.NoError(t, json.NewDecoder(recorder.Body).Decode(&body))
+			assert.Equal(t, map[string]string{"error": "unavailable", "traceId": span.SpanContext.TraceID().String()}, body)
+		})
+	}
+}
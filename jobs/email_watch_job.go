@@ -0,0 +1,149 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"github.com/kiramishima/receipt-processor/domain"
+	appErrors "github.com/kiramishima/receipt-processor/pkg/errors"
+	ports "github.com/kiramishima/receipt-processor/ports/services"
+	"go.uber.org/zap"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+const (
+	// ProcessedDir subfolder of the watched folder with the messages stored
+	ProcessedDir = "processed"
+	// FailedDir subfolder of the watched folder with the messages rejected and a .error file with the reason
+	FailedDir = "failed"
+	// settleTime files modified more recently are still being written
+	settleTime = time.Second
+)
+
+// EmailWatchJob ingests the .eml files dropped into the watched folder, the folder is scanned at the configured interval
+type EmailWatchJob struct {
+	logger  *zap.SugaredLogger
+	cfg     domain.EmailConfig
+	service ports.IEmailService
+	cancel  context.CancelFunc
+	done    chan struct{}
+}
+
+func NewEmailWatchJob(cfg domain.EmailConfig, service ports.IEmailService, logger *zap.SugaredLogger) *EmailWatchJob {
+	return &EmailWatchJob{
+		logger:  logger,
+		cfg:     cfg,
+		service: service,
+	}
+}
+
+// Start creates the subfolders of the watched folder and launches the job in background
+func (job *EmailWatchJob) Start() error {
+	for _, dir := range []string{ProcessedDir, FailedDir} {
+		if err := os.MkdirAll(filepath.Join(job.cfg.EmailWatchDir, dir), 0o755); err != nil {
+			return err
+		}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	job.cancel = cancel
+	job.done = make(chan struct{})
+
+	go func() {
+		defer close(job.done)
+		job.logger.Infof("Watching %s for email receipts every %s", job.cfg.EmailWatchDir, job.cfg.EmailWatchInterval)
+		ticker := time.NewTicker(job.cfg.EmailWatchInterval)
+		defer ticker.Stop()
+
+		for {
+			job.Scan(ctx)
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+	return nil
+}
+
+// Stop cancels the job and waits until the running scan, if any, ends
+func (job *EmailWatchJob) Stop(ctx context.Context) error {
+	if job.cancel == nil {
+		return nil
+	}
+	job.cancel()
+
+	select {
+	case <-job.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Check fails when the job is not running or the watched folder can't be read
+func (job *EmailWatchJob) Check(ctx context.Context) error {
+	if err := running(job.done); err != nil {
+		return err
+	}
+	_, err := os.Stat(job.cfg.EmailWatchDir)
+	return err
+}
+
+// Scan ingests the .eml files of the watched folder and moves them to the processed or failed subfolder, the repeats
+// of the messages already ingested go to the processed one
+func (job *EmailWatchJob) Scan(ctx context.Context) {
+	entries, err := os.ReadDir(job.cfg.EmailWatchDir)
+	if err != nil {
+		job.logger.Error(err)
+		return
+	}
+
+	for _, entry := range entries {
+		if ctx.Err() != nil {
+			return
+		}
+		if entry.IsDir() || !strings.EqualFold(filepath.Ext(entry.Name()), ".eml") {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil || time.Since(info.ModTime()) < settleTime {
+			continue
+		}
+		job.ingest(ctx, entry.Name())
+	}
+}
+
+// ingest stores the receipt of a file and moves the file
+func (job *EmailWatchJob) ingest(ctx context.Context, name string) {
+	var path = filepath.Join(job.cfg.EmailWatchDir, name)
+	file, err := os.Open(path)
+	if err != nil {
+		job.logger.Error(err)
+		return
+	}
+	ingestion, err := job.service.IngestEmail(ctx, file, "")
+	_ = file.Close()
+
+	var target = filepath.Join(job.cfg.EmailWatchDir, ProcessedDir, name)
+	switch {
+	case errors.Is(err, appErrors.Conflict):
+		// A repeat of a message already ingested, like a copy of the file
+		job.logger.Infow("email receipt skipped", "file", name, "reason", err)
+	case err != nil:
+		job.logger.Errorw("email receipt rejected", "file", name, "error", err)
+		target = filepath.Join(job.cfg.EmailWatchDir, FailedDir, name)
+		if err := os.WriteFile(target+".error", []byte(err.Error()+"\n"), 0o644); err != nil {
+			job.logger.Error(err)
+		}
+	default:
+		job.logger.Infow("email receipt ingested", "file", name, "id", ingestion.ID)
+	}
+
+	if err := os.Rename(path, target); err != nil {
+		job.logger.Error(err)
+	}
+}
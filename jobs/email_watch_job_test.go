@@ -0,0 +1,82 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/kiramishima/receipt-processor/domain"
+	"github.com/kiramishima/receipt-processor/mocks"
+	appErrors "github.com/kiramishima/receipt-processor/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"go.uber.org/zap"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestEmailWatchJob_Scan(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	logger, _ := zap.NewProduction()
+
+	var dir = t.TempDir()
+	var write = func(name string, content string, age time.Duration) {
+		var path = filepath.Join(dir, name)
+		assert.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+		assert.NoError(t, os.Chtimes(path, time.Now().Add(-age), time.Now().Add(-age)))
+	}
+	write("stored.eml", "stored", time.Minute)
+	write("rejected.EML", "rejected", time.Minute)
+	write("repeated.eml", "repeated", time.Minute)
+	write("writing.eml", "writing", 0)
+	write("notes.txt", "notes", time.Minute)
+
+	service := mocks.NewMockIEmailService(ctrl)
+	service.EXPECT().IngestEmail(gomock.Any(), gomock.Any(), gomock.Eq("")).Times(3).DoAndReturn(func(_ any, file *os.File, _ string) (*domain.EmailIngestion, error) {
+		switch filepath.Base(file.Name()) {
+		case "rejected.EML":
+			return nil, errors.New("receipt needs review")
+		case "repeated.eml":
+			return nil, fmt.Errorf("%w: message 1234@target.com was ingested as receipt receipt-1", appErrors.Conflict)
+		}
+		return &domain.EmailIngestion{ID: "receipt-1"}, nil
+	})
+
+	job := NewEmailWatchJob(domain.EmailConfig{EmailWatchDir: dir, EmailWatchInterval: time.Hour}, service, logger.Sugar())
+	assert.NoError(t, job.Start())
+	defer func() { assert.NoError(t, job.Stop(context.Background())) }()
+	assert.Eventually(t, func() bool {
+		_, errFailed := os.Stat(filepath.Join(dir, FailedDir, "rejected.EML"))
+		_, errProcessed := os.Stat(filepath.Join(dir, ProcessedDir, "stored.eml"))
+		_, errRepeated := os.Stat(filepath.Join(dir, ProcessedDir, "repeated.eml"))
+		return errFailed == nil && errProcessed == nil && errRepeated == nil
+	}, time.Second, 10*time.Millisecond)
+
+	content, err := os.ReadFile(filepath.Join(dir, FailedDir, "rejected.EML.error"))
+	assert.NoError(t, err)
+	assert.Equal(t, "receipt needs review\n", string(content))
+	assert.NoFileExists(t, filepath.Join(dir, FailedDir, "repeated.eml.error"))
+	// The files being written and the other files are kept
+	assert.FileExists(t, filepath.Join(dir, "writing.eml"))
+	assert.FileExists(t, filepath.Join(dir, "notes.txt"))
+}
+
+func TestEmailWatchJob_Check(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	logger, _ := zap.NewProduction()
+
+	var dir = filepath.Join(t.TempDir(), "inbox")
+	job := NewEmailWatchJob(domain.EmailConfig{EmailWatchDir: dir, EmailWatchInterval: time.Hour}, mocks.NewMockIEmailService(ctrl), logger.Sugar())
+	assert.EqualError(t, job.Check(context.Background()), "not started")
+
+	assert.NoError(t, job.Start())
+	assert.NoError(t, job.Check(context.Background()))
+	assert.NoError(t, os.RemoveAll(dir))
+	assert.Error(t, job.Check(context.Background()))
+
+	assert.NoError(t, job.Stop(context.Background()))
+	assert.EqualError(t, job.Check(context.Background()), "stopped")
+}
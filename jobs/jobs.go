@@ -0,0 +1,59 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"github.com/kiramishima/receipt-processor/domain"
+	"github.com/kiramishima/receipt-processor/services"
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+)
+
+// running fails when the loop of a job closing done is not running
+func running(done chan struct{}) error {
+	if done == nil {
+		return errors.New("not started")
+	}
+	select {
+	case <-done:
+		return errors.New("stopped")
+	default:
+		return nil
+	}
+}
+
+var Module = fx.Module("jobs",
+	fx.Provide(func(cfg *domain.Configuration, svc *services.TierService, logger *zap.SugaredLogger) *TierRecomputeJob {
+		return NewTierRecomputeJob(cfg.TierConfig, svc, logger)
+	}),
+	fx.Invoke(func(lifecycle fx.Lifecycle, job *TierRecomputeJob) {
+		lifecycle.Append(fx.Hook{
+			OnStart: func(ctx context.Context) error {
+				job.Start()
+				return nil
+			},
+			OnStop: job.Stop,
+		})
+	}),
+	fx.Provide(func(cfg *domain.Configuration, svc *services.EmailService, logger *zap.SugaredLogger) *EmailWatchJob {
+		return NewEmailWatchJob(cfg.EmailConfig, svc, logger)
+	}),
+	fx.Invoke(func(lifecycle fx.Lifecycle, cfg *domain.Configuration, job *EmailWatchJob) {
+		if cfg.EmailWatchDir == "" {
+			return
+		}
+		lifecycle.Append(fx.Hook{
+			OnStart: func(ctx context.Context) error {
+				return job.Start()
+			},
+			OnStop: job.Stop,
+		})
+	}),
+	fx.Provide(fx.Annotate(func(cfg *domain.Configuration, tierJob *TierRecomputeJob, emailJob *EmailWatchJob) []domain.HealthCheck {
+		var checks = []domain.HealthCheck{{Name: "tier-recompute-job", Kind: domain.HealthLiveness, Check: tierJob.Check}}
+		if cfg.EmailWatchDir != "" {
+			checks = append(checks, domain.HealthCheck{Name: "email-watch-job", Kind: domain.HealthLiveness, Check: emailJob.Check})
+		}
+		return checks
+	}, fx.ResultTags(`group:"health,flatten"`))),
+)
@@ -0,0 +1,72 @@
+package jobs
+
+import (
+	"context"
+	"github.com/kiramishima/receipt-processor/domain"
+	ports "github.com/kiramishima/receipt-processor/ports/services"
+	"go.uber.org/zap"
+	"time"
+)
+
+// TierRecomputeJob recomputes the member tiers once a day at the configured hour
+type TierRecomputeJob struct {
+	logger  *zap.SugaredLogger
+	cfg     domain.TierConfig
+	service ports.ITierService
+	cancel  context.CancelFunc
+	done    chan struct{}
+}
+
+func NewTierRecomputeJob(cfg domain.TierConfig, service ports.ITierService, logger *zap.SugaredLogger) *TierRecomputeJob {
+	return &TierRecomputeJob{
+		logger:  logger,
+		cfg:     cfg,
+		service: service,
+	}
+}
+
+// Start launches the job in background
+func (job *TierRecomputeJob) Start() {
+	ctx, cancel := context.WithCancel(context.Background())
+	job.cancel = cancel
+	job.done = make(chan struct{})
+
+	go func() {
+		defer close(job.done)
+		for {
+			var next = job.cfg.NextRecompute(time.Now())
+			job.logger.Infof("Next tier recompute at %s", next.Format(time.RFC3339))
+			timer := time.NewTimer(time.Until(next))
+
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return
+			case <-timer.C:
+				if _, err := job.service.RecomputeTiers(ctx); err != nil {
+					job.logger.Error(err)
+				}
+			}
+		}
+	}()
+}
+
+// Stop cancels the job and waits until the running recompute, if any, ends
+func (job *TierRecomputeJob) Stop(ctx context.Context) error {
+	if job.cancel == nil {
+		return nil
+	}
+	job.cancel()
+
+	select {
+	case <-job.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Check fails when the job is not running
+func (job *TierRecomputeJob) Check(ctx context.Context) error {
+	return running(job.done)
+}
@@ -0,0 +1,128 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ports/repository/api_key_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source ports/repository/api_key_repository.go -destination mocks/api_key_repository.go -package mocks
+//
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+	time "time"
+
+	domain "github.com/kiramishima/receipt-processor/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockIAPIKeyRepository is a mock of IAPIKeyRepository interface.
+type MockIAPIKeyRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockIAPIKeyRepositoryMockRecorder
+}
+
+// MockIAPIKeyRepositoryMockRecorder is the mock recorder for MockIAPIKeyRepository.
+type MockIAPIKeyRepositoryMockRecorder struct {
+	mock *MockIAPIKeyRepository
+}
+
+// NewMockIAPIKeyRepository creates a new mock instance.
+func NewMockIAPIKeyRepository(ctrl *gomock.Controller) *MockIAPIKeyRepository {
+	mock := &MockIAPIKeyRepository{ctrl: ctrl}
+	mock.recorder = &MockIAPIKeyRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIAPIKeyRepository) EXPECT() *MockIAPIKeyRepositoryMockRecorder {
+	return m.recorder
+}
+
+// FindAPIKeyByHash mocks base method.
+func (m *MockIAPIKeyRepository) FindAPIKeyByHash(hash string) (*domain.APIKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAPIKeyByHash", hash)
+	ret0, _ := ret[0].(*domain.APIKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAPIKeyByHash indicates an expected call of FindAPIKeyByHash.
+func (mr *MockIAPIKeyRepositoryMockRecorder) FindAPIKeyByHash(hash any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAPIKeyByHash", reflect.TypeOf((*MockIAPIKeyRepository)(nil).FindAPIKeyByHash), hash)
+}
+
+// FindAPIKeyById mocks base method.
+func (m *MockIAPIKeyRepository) FindAPIKeyById(id string) (*domain.APIKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAPIKeyById", id)
+	ret0, _ := ret[0].(*domain.APIKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAPIKeyById indicates an expected call of FindAPIKeyById.
+func (mr *MockIAPIKeyRepositoryMockRecorder) FindAPIKeyById(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAPIKeyById", reflect.TypeOf((*MockIAPIKeyRepository)(nil).FindAPIKeyById), id)
+}
+
+// ListAPIKeys mocks base method.
+func (m *MockIAPIKeyRepository) ListAPIKeys() ([]*domain.APIKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAPIKeys")
+	ret0, _ := ret[0].([]*domain.APIKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAPIKeys indicates an expected call of ListAPIKeys.
+func (mr *MockIAPIKeyRepositoryMockRecorder) ListAPIKeys() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAPIKeys", reflect.TypeOf((*MockIAPIKeyRepository)(nil).ListAPIKeys))
+}
+
+// SaveAPIKey mocks base method.
+func (m *MockIAPIKeyRepository) SaveAPIKey(key *domain.APIKey) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveAPIKey", key)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SaveAPIKey indicates an expected call of SaveAPIKey.
+func (mr *MockIAPIKeyRepositoryMockRecorder) SaveAPIKey(key any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveAPIKey", reflect.TypeOf((*MockIAPIKeyRepository)(nil).SaveAPIKey), key)
+}
+
+// TouchAPIKey mocks base method.
+func (m *MockIAPIKeyRepository) TouchAPIKey(id string, usedAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TouchAPIKey", id, usedAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// TouchAPIKey indicates an expected call of TouchAPIKey.
+func (mr *MockIAPIKeyRepositoryMockRecorder) TouchAPIKey(id, usedAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TouchAPIKey", reflect.TypeOf((*MockIAPIKeyRepository)(nil).TouchAPIKey), id, usedAt)
+}
+
+// UpdateAPIKey mocks base method.
+func (m *MockIAPIKeyRepository) UpdateAPIKey(key *domain.APIKey) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateAPIKey", key)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateAPIKey indicates an expected call of UpdateAPIKey.
+func (mr *MockIAPIKeyRepositoryMockRecorder) UpdateAPIKey(key any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAPIKey", reflect.TypeOf((*MockIAPIKeyRepository)(nil).UpdateAPIKey), key)
+}
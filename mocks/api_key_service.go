@@ -0,0 +1,129 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ports/services/api_key_service.go
+//
+// Generated by this command:
+//
+//	mockgen -source ports/services/api_key_service.go -destination mocks/api_key_service.go -package mocks
+//
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	domain "github.com/kiramishima/receipt-processor/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockIAPIKeyService is a mock of IAPIKeyService interface.
+type MockIAPIKeyService struct {
+	ctrl     *gomock.Controller
+	recorder *MockIAPIKeyServiceMockRecorder
+}
+
+// MockIAPIKeyServiceMockRecorder is the mock recorder for MockIAPIKeyService.
+type MockIAPIKeyServiceMockRecorder struct {
+	mock *MockIAPIKeyService
+}
+
+// NewMockIAPIKeyService creates a new mock instance.
+func NewMockIAPIKeyService(ctrl *gomock.Controller) *MockIAPIKeyService {
+	mock := &MockIAPIKeyService{ctrl: ctrl}
+	mock.recorder = &MockIAPIKeyServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIAPIKeyService) EXPECT() *MockIAPIKeyServiceMockRecorder {
+	return m.recorder
+}
+
+// Authenticate mocks base method.
+func (m *MockIAPIKeyService) Authenticate(key string) (*domain.APIKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Authenticate", key)
+	ret0, _ := ret[0].(*domain.APIKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Authenticate indicates an expected call of Authenticate.
+func (mr *MockIAPIKeyServiceMockRecorder) Authenticate(key any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Authenticate", reflect.TypeOf((*MockIAPIKeyService)(nil).Authenticate), key)
+}
+
+// IssueAPIKey mocks base method.
+func (m *MockIAPIKeyService) IssueAPIKey(ctx context.Context, req *domain.APIKeyRequest) (*domain.IssuedAPIKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IssueAPIKey", ctx, req)
+	ret0, _ := ret[0].(*domain.IssuedAPIKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IssueAPIKey indicates an expected call of IssueAPIKey.
+func (mr *MockIAPIKeyServiceMockRecorder) IssueAPIKey(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IssueAPIKey", reflect.TypeOf((*MockIAPIKeyService)(nil).IssueAPIKey), ctx, req)
+}
+
+// ListAPIKeys mocks base method.
+func (m *MockIAPIKeyService) ListAPIKeys() ([]*domain.APIKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAPIKeys")
+	ret0, _ := ret[0].([]*domain.APIKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAPIKeys indicates an expected call of ListAPIKeys.
+func (mr *MockIAPIKeyServiceMockRecorder) ListAPIKeys() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAPIKeys", reflect.TypeOf((*MockIAPIKeyService)(nil).ListAPIKeys))
+}
+
+// RetrieveAPIKey mocks base method.
+func (m *MockIAPIKeyService) RetrieveAPIKey(id string) (*domain.APIKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RetrieveAPIKey", id)
+	ret0, _ := ret[0].(*domain.APIKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RetrieveAPIKey indicates an expected call of RetrieveAPIKey.
+func (mr *MockIAPIKeyServiceMockRecorder) RetrieveAPIKey(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RetrieveAPIKey", reflect.TypeOf((*MockIAPIKeyService)(nil).RetrieveAPIKey), id)
+}
+
+// RevokeAPIKey mocks base method.
+func (m *MockIAPIKeyService) RevokeAPIKey(ctx context.Context, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeAPIKey", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeAPIKey indicates an expected call of RevokeAPIKey.
+func (mr *MockIAPIKeyServiceMockRecorder) RevokeAPIKey(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeAPIKey", reflect.TypeOf((*MockIAPIKeyService)(nil).RevokeAPIKey), ctx, id)
+}
+
+// RotateAPIKey mocks base method.
+func (m *MockIAPIKeyService) RotateAPIKey(ctx context.Context, id string) (*domain.IssuedAPIKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RotateAPIKey", ctx, id)
+	ret0, _ := ret[0].(*domain.IssuedAPIKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RotateAPIKey indicates an expected call of RotateAPIKey.
+func (mr *MockIAPIKeyServiceMockRecorder) RotateAPIKey(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RotateAPIKey", reflect.TypeOf((*MockIAPIKeyService)(nil).RotateAPIKey), ctx, id)
+}
@@ -0,0 +1,112 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ports/repository/campaign_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source ports/repository/campaign_repository.go -destination mocks/campaign_repository.go -package mocks
+//
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+
+	domain "github.com/kiramishima/receipt-processor/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockICampaignRepository is a mock of ICampaignRepository interface.
+type MockICampaignRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockICampaignRepositoryMockRecorder
+}
+
+// MockICampaignRepositoryMockRecorder is the mock recorder for MockICampaignRepository.
+type MockICampaignRepositoryMockRecorder struct {
+	mock *MockICampaignRepository
+}
+
+// NewMockICampaignRepository creates a new mock instance.
+func NewMockICampaignRepository(ctrl *gomock.Controller) *MockICampaignRepository {
+	mock := &MockICampaignRepository{ctrl: ctrl}
+	mock.recorder = &MockICampaignRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockICampaignRepository) EXPECT() *MockICampaignRepositoryMockRecorder {
+	return m.recorder
+}
+
+// DeleteCampaign mocks base method.
+func (m *MockICampaignRepository) DeleteCampaign(id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCampaign", id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteCampaign indicates an expected call of DeleteCampaign.
+func (mr *MockICampaignRepositoryMockRecorder) DeleteCampaign(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCampaign", reflect.TypeOf((*MockICampaignRepository)(nil).DeleteCampaign), id)
+}
+
+// FindCampaignById mocks base method.
+func (m *MockICampaignRepository) FindCampaignById(id string) (*domain.Campaign, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindCampaignById", id)
+	ret0, _ := ret[0].(*domain.Campaign)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindCampaignById indicates an expected call of FindCampaignById.
+func (mr *MockICampaignRepositoryMockRecorder) FindCampaignById(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindCampaignById", reflect.TypeOf((*MockICampaignRepository)(nil).FindCampaignById), id)
+}
+
+// ListCampaigns mocks base method.
+func (m *MockICampaignRepository) ListCampaigns() ([]*domain.Campaign, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListCampaigns")
+	ret0, _ := ret[0].([]*domain.Campaign)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListCampaigns indicates an expected call of ListCampaigns.
+func (mr *MockICampaignRepositoryMockRecorder) ListCampaigns() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCampaigns", reflect.TypeOf((*MockICampaignRepository)(nil).ListCampaigns))
+}
+
+// SaveCampaign mocks base method.
+func (m *MockICampaignRepository) SaveCampaign(campaign *domain.Campaign) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveCampaign", campaign)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SaveCampaign indicates an expected call of SaveCampaign.
+func (mr *MockICampaignRepositoryMockRecorder) SaveCampaign(campaign any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveCampaign", reflect.TypeOf((*MockICampaignRepository)(nil).SaveCampaign), campaign)
+}
+
+// UpdateCampaign mocks base method.
+func (m *MockICampaignRepository) UpdateCampaign(campaign *domain.Campaign) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateCampaign", campaign)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateCampaign indicates an expected call of UpdateCampaign.
+func (mr *MockICampaignRepositoryMockRecorder) UpdateCampaign(campaign any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCampaign", reflect.TypeOf((*MockICampaignRepository)(nil).UpdateCampaign), campaign)
+}
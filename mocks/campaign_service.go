@@ -0,0 +1,130 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ports/services/campaign_service.go
+//
+// Generated by this command:
+//
+//	mockgen -source ports/services/campaign_service.go -destination mocks/campaign_service.go -package mocks
+//
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	domain "github.com/kiramishima/receipt-processor/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockICampaignService is a mock of ICampaignService interface.
+type MockICampaignService struct {
+	ctrl     *gomock.Controller
+	recorder *MockICampaignServiceMockRecorder
+}
+
+// MockICampaignServiceMockRecorder is the mock recorder for MockICampaignService.
+type MockICampaignServiceMockRecorder struct {
+	mock *MockICampaignService
+}
+
+// NewMockICampaignService creates a new mock instance.
+func NewMockICampaignService(ctrl *gomock.Controller) *MockICampaignService {
+	mock := &MockICampaignService{ctrl: ctrl}
+	mock.recorder = &MockICampaignServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockICampaignService) EXPECT() *MockICampaignServiceMockRecorder {
+	return m.recorder
+}
+
+// ActiveCampaigns mocks base method.
+func (m *MockICampaignService) ActiveCampaigns(at time.Time) ([]*domain.Campaign, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ActiveCampaigns", at)
+	ret0, _ := ret[0].([]*domain.Campaign)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ActiveCampaigns indicates an expected call of ActiveCampaigns.
+func (mr *MockICampaignServiceMockRecorder) ActiveCampaigns(at any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ActiveCampaigns", reflect.TypeOf((*MockICampaignService)(nil).ActiveCampaigns), at)
+}
+
+// CreateCampaign mocks base method.
+func (m *MockICampaignService) CreateCampaign(ctx context.Context, campaign *domain.Campaign) (*domain.Campaign, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateCampaign", ctx, campaign)
+	ret0, _ := ret[0].(*domain.Campaign)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateCampaign indicates an expected call of CreateCampaign.
+func (mr *MockICampaignServiceMockRecorder) CreateCampaign(ctx, campaign any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCampaign", reflect.TypeOf((*MockICampaignService)(nil).CreateCampaign), ctx, campaign)
+}
+
+// DeleteCampaign mocks base method.
+func (m *MockICampaignService) DeleteCampaign(id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCampaign", id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteCampaign indicates an expected call of DeleteCampaign.
+func (mr *MockICampaignServiceMockRecorder) DeleteCampaign(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCampaign", reflect.TypeOf((*MockICampaignService)(nil).DeleteCampaign), id)
+}
+
+// ListCampaigns mocks base method.
+func (m *MockICampaignService) ListCampaigns() ([]*domain.Campaign, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListCampaigns")
+	ret0, _ := ret[0].([]*domain.Campaign)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListCampaigns indicates an expected call of ListCampaigns.
+func (mr *MockICampaignServiceMockRecorder) ListCampaigns() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCampaigns", reflect.TypeOf((*MockICampaignService)(nil).ListCampaigns))
+}
+
+// RetrieveCampaign mocks base method.
+func (m *MockICampaignService) RetrieveCampaign(id string) (*domain.Campaign, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RetrieveCampaign", id)
+	ret0, _ := ret[0].(*domain.Campaign)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RetrieveCampaign indicates an expected call of RetrieveCampaign.
+func (mr *MockICampaignServiceMockRecorder) RetrieveCampaign(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RetrieveCampaign", reflect.TypeOf((*MockICampaignService)(nil).RetrieveCampaign), id)
+}
+
+// UpdateCampaign mocks base method.
+func (m *MockICampaignService) UpdateCampaign(ctx context.Context, campaign *domain.Campaign) (*domain.Campaign, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateCampaign", ctx, campaign)
+	ret0, _ := ret[0].(*domain.Campaign)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateCampaign indicates an expected call of UpdateCampaign.
+func (mr *MockICampaignServiceMockRecorder) UpdateCampaign(ctx, campaign any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCampaign", reflect.TypeOf((*MockICampaignService)(nil).UpdateCampaign), ctx, campaign)
+}
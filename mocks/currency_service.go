@@ -0,0 +1,82 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ports/services/currency_service.go
+//
+// Generated by this command:
+//
+//	mockgen -source ports/services/currency_service.go -destination mocks/currency_service.go -package mocks
+//
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+
+	domain "github.com/kiramishima/receipt-processor/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockICurrencyService is a mock of ICurrencyService interface.
+type MockICurrencyService struct {
+	ctrl     *gomock.Controller
+	recorder *MockICurrencyServiceMockRecorder
+}
+
+// MockICurrencyServiceMockRecorder is the mock recorder for MockICurrencyService.
+type MockICurrencyServiceMockRecorder struct {
+	mock *MockICurrencyService
+}
+
+// NewMockICurrencyService creates a new mock instance.
+func NewMockICurrencyService(ctrl *gomock.Controller) *MockICurrencyService {
+	mock := &MockICurrencyService{ctrl: ctrl}
+	mock.recorder = &MockICurrencyServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockICurrencyService) EXPECT() *MockICurrencyServiceMockRecorder {
+	return m.recorder
+}
+
+// BaseCurrency mocks base method.
+func (m *MockICurrencyService) BaseCurrency() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BaseCurrency")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// BaseCurrency indicates an expected call of BaseCurrency.
+func (mr *MockICurrencyServiceMockRecorder) BaseCurrency() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BaseCurrency", reflect.TypeOf((*MockICurrencyService)(nil).BaseCurrency))
+}
+
+// ReceiptRate mocks base method.
+func (m *MockICurrencyService) ReceiptRate(currency string) (float64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReceiptRate", currency)
+	ret0, _ := ret[0].(float64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReceiptRate indicates an expected call of ReceiptRate.
+func (mr *MockICurrencyServiceMockRecorder) ReceiptRate(currency any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReceiptRate", reflect.TypeOf((*MockICurrencyService)(nil).ReceiptRate), currency)
+}
+
+// RulesReceipt mocks base method.
+func (m *MockICurrencyService) RulesReceipt(receipt *domain.Receipt) *domain.Receipt {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RulesReceipt", receipt)
+	ret0, _ := ret[0].(*domain.Receipt)
+	return ret0
+}
+
+// RulesReceipt indicates an expected call of RulesReceipt.
+func (mr *MockICurrencyServiceMockRecorder) RulesReceipt(receipt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RulesReceipt", reflect.TypeOf((*MockICurrencyService)(nil).RulesReceipt), receipt)
+}
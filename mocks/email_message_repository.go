@@ -0,0 +1,97 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ports/repository/email_message_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source ports/repository/email_message_repository.go -destination mocks/email_message_repository.go -package mocks
+//
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+	time "time"
+
+	domain "github.com/kiramishima/receipt-processor/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockIEmailMessageRepository is a mock of IEmailMessageRepository interface.
+type MockIEmailMessageRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockIEmailMessageRepositoryMockRecorder
+}
+
+// MockIEmailMessageRepositoryMockRecorder is the mock recorder for MockIEmailMessageRepository.
+type MockIEmailMessageRepositoryMockRecorder struct {
+	mock *MockIEmailMessageRepository
+}
+
+// NewMockIEmailMessageRepository creates a new mock instance.
+func NewMockIEmailMessageRepository(ctrl *gomock.Controller) *MockIEmailMessageRepository {
+	mock := &MockIEmailMessageRepository{ctrl: ctrl}
+	mock.recorder = &MockIEmailMessageRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIEmailMessageRepository) EXPECT() *MockIEmailMessageRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CompleteEmailMessage mocks base method.
+func (m *MockIEmailMessageRepository) CompleteEmailMessage(messageID, receiptID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CompleteEmailMessage", messageID, receiptID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CompleteEmailMessage indicates an expected call of CompleteEmailMessage.
+func (mr *MockIEmailMessageRepositoryMockRecorder) CompleteEmailMessage(messageID, receiptID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CompleteEmailMessage", reflect.TypeOf((*MockIEmailMessageRepository)(nil).CompleteEmailMessage), messageID, receiptID)
+}
+
+// FindEmailMessage mocks base method.
+func (m *MockIEmailMessageRepository) FindEmailMessage(messageID string) (*domain.EmailMessage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindEmailMessage", messageID)
+	ret0, _ := ret[0].(*domain.EmailMessage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindEmailMessage indicates an expected call of FindEmailMessage.
+func (mr *MockIEmailMessageRepositoryMockRecorder) FindEmailMessage(messageID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindEmailMessage", reflect.TypeOf((*MockIEmailMessageRepository)(nil).FindEmailMessage), messageID)
+}
+
+// ReleaseEmailMessage mocks base method.
+func (m *MockIEmailMessageRepository) ReleaseEmailMessage(messageID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReleaseEmailMessage", messageID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReleaseEmailMessage indicates an expected call of ReleaseEmailMessage.
+func (mr *MockIEmailMessageRepositoryMockRecorder) ReleaseEmailMessage(messageID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReleaseEmailMessage", reflect.TypeOf((*MockIEmailMessageRepository)(nil).ReleaseEmailMessage), messageID)
+}
+
+// ReserveEmailMessage mocks base method.
+func (m *MockIEmailMessageRepository) ReserveEmailMessage(messageID string, receivedAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReserveEmailMessage", messageID, receivedAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReserveEmailMessage indicates an expected call of ReserveEmailMessage.
+func (mr *MockIEmailMessageRepositoryMockRecorder) ReserveEmailMessage(messageID, receivedAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReserveEmailMessage", reflect.TypeOf((*MockIEmailMessageRepository)(nil).ReserveEmailMessage), messageID, receivedAt)
+}
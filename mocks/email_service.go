@@ -0,0 +1,56 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ports/services/email_service.go
+//
+// Generated by this command:
+//
+//	mockgen -source ports/services/email_service.go -destination mocks/email_service.go -package mocks
+//
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	io "io"
+	reflect "reflect"
+
+	domain "github.com/kiramishima/receipt-processor/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockIEmailService is a mock of IEmailService interface.
+type MockIEmailService struct {
+	ctrl     *gomock.Controller
+	recorder *MockIEmailServiceMockRecorder
+}
+
+// MockIEmailServiceMockRecorder is the mock recorder for MockIEmailService.
+type MockIEmailServiceMockRecorder struct {
+	mock *MockIEmailService
+}
+
+// NewMockIEmailService creates a new mock instance.
+func NewMockIEmailService(ctrl *gomock.Controller) *MockIEmailService {
+	mock := &MockIEmailService{ctrl: ctrl}
+	mock.recorder = &MockIEmailServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIEmailService) EXPECT() *MockIEmailServiceMockRecorder {
+	return m.recorder
+}
+
+// IngestEmail mocks base method.
+func (m *MockIEmailService) IngestEmail(ctx context.Context, message io.Reader, memberID string) (*domain.EmailIngestion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IngestEmail", ctx, message, memberID)
+	ret0, _ := ret[0].(*domain.EmailIngestion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IngestEmail indicates an expected call of IngestEmail.
+func (mr *MockIEmailServiceMockRecorder) IngestEmail(ctx, message, memberID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IngestEmail", reflect.TypeOf((*MockIEmailService)(nil).IngestEmail), ctx, message, memberID)
+}
@@ -0,0 +1,54 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ports/events/event_publisher.go
+//
+// Generated by this command:
+//
+//	mockgen -source ports/events/event_publisher.go -destination mocks/event_publisher.go -package mocks
+//
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	domain "github.com/kiramishima/receipt-processor/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockIEventPublisher is a mock of IEventPublisher interface.
+type MockIEventPublisher struct {
+	ctrl     *gomock.Controller
+	recorder *MockIEventPublisherMockRecorder
+}
+
+// MockIEventPublisherMockRecorder is the mock recorder for MockIEventPublisher.
+type MockIEventPublisherMockRecorder struct {
+	mock *MockIEventPublisher
+}
+
+// NewMockIEventPublisher creates a new mock instance.
+func NewMockIEventPublisher(ctrl *gomock.Controller) *MockIEventPublisher {
+	mock := &MockIEventPublisher{ctrl: ctrl}
+	mock.recorder = &MockIEventPublisherMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIEventPublisher) EXPECT() *MockIEventPublisherMockRecorder {
+	return m.recorder
+}
+
+// Publish mocks base method.
+func (m *MockIEventPublisher) Publish(ctx context.Context, event domain.Event) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Publish", ctx, event)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Publish indicates an expected call of Publish.
+func (mr *MockIEventPublisherMockRecorder) Publish(ctx, event any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Publish", reflect.TypeOf((*MockIEventPublisher)(nil).Publish), ctx, event)
+}
@@ -0,0 +1,112 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ports/repository/experiment_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source ports/repository/experiment_repository.go -destination mocks/experiment_repository.go -package mocks
+//
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+
+	domain "github.com/kiramishima/receipt-processor/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockIExperimentRepository is a mock of IExperimentRepository interface.
+type MockIExperimentRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockIExperimentRepositoryMockRecorder
+}
+
+// MockIExperimentRepositoryMockRecorder is the mock recorder for MockIExperimentRepository.
+type MockIExperimentRepositoryMockRecorder struct {
+	mock *MockIExperimentRepository
+}
+
+// NewMockIExperimentRepository creates a new mock instance.
+func NewMockIExperimentRepository(ctrl *gomock.Controller) *MockIExperimentRepository {
+	mock := &MockIExperimentRepository{ctrl: ctrl}
+	mock.recorder = &MockIExperimentRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIExperimentRepository) EXPECT() *MockIExperimentRepositoryMockRecorder {
+	return m.recorder
+}
+
+// DeleteExperiment mocks base method.
+func (m *MockIExperimentRepository) DeleteExperiment(id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteExperiment", id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteExperiment indicates an expected call of DeleteExperiment.
+func (mr *MockIExperimentRepositoryMockRecorder) DeleteExperiment(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteExperiment", reflect.TypeOf((*MockIExperimentRepository)(nil).DeleteExperiment), id)
+}
+
+// FindExperimentById mocks base method.
+func (m *MockIExperimentRepository) FindExperimentById(id string) (*domain.Experiment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindExperimentById", id)
+	ret0, _ := ret[0].(*domain.Experiment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindExperimentById indicates an expected call of FindExperimentById.
+func (mr *MockIExperimentRepositoryMockRecorder) FindExperimentById(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindExperimentById", reflect.TypeOf((*MockIExperimentRepository)(nil).FindExperimentById), id)
+}
+
+// ListExperiments mocks base method.
+func (m *MockIExperimentRepository) ListExperiments() ([]*domain.Experiment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListExperiments")
+	ret0, _ := ret[0].([]*domain.Experiment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListExperiments indicates an expected call of ListExperiments.
+func (mr *MockIExperimentRepositoryMockRecorder) ListExperiments() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListExperiments", reflect.TypeOf((*MockIExperimentRepository)(nil).ListExperiments))
+}
+
+// SaveExperiment mocks base method.
+func (m *MockIExperimentRepository) SaveExperiment(experiment *domain.Experiment) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveExperiment", experiment)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SaveExperiment indicates an expected call of SaveExperiment.
+func (mr *MockIExperimentRepositoryMockRecorder) SaveExperiment(experiment any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveExperiment", reflect.TypeOf((*MockIExperimentRepository)(nil).SaveExperiment), experiment)
+}
+
+// UpdateExperiment mocks base method.
+func (m *MockIExperimentRepository) UpdateExperiment(experiment *domain.Experiment) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateExperiment", experiment)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateExperiment indicates an expected call of UpdateExperiment.
+func (mr *MockIExperimentRepositoryMockRecorder) UpdateExperiment(experiment any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateExperiment", reflect.TypeOf((*MockIExperimentRepository)(nil).UpdateExperiment), experiment)
+}
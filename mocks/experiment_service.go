@@ -0,0 +1,145 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ports/services/experiment_service.go
+//
+// Generated by this command:
+//
+//	mockgen -source ports/services/experiment_service.go -destination mocks/experiment_service.go -package mocks
+//
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	domain "github.com/kiramishima/receipt-processor/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockIExperimentService is a mock of IExperimentService interface.
+type MockIExperimentService struct {
+	ctrl     *gomock.Controller
+	recorder *MockIExperimentServiceMockRecorder
+}
+
+// MockIExperimentServiceMockRecorder is the mock recorder for MockIExperimentService.
+type MockIExperimentServiceMockRecorder struct {
+	mock *MockIExperimentService
+}
+
+// NewMockIExperimentService creates a new mock instance.
+func NewMockIExperimentService(ctrl *gomock.Controller) *MockIExperimentService {
+	mock := &MockIExperimentService{ctrl: ctrl}
+	mock.recorder = &MockIExperimentServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIExperimentService) EXPECT() *MockIExperimentServiceMockRecorder {
+	return m.recorder
+}
+
+// ActiveExperiment mocks base method.
+func (m *MockIExperimentService) ActiveExperiment(at time.Time) (*domain.Experiment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ActiveExperiment", at)
+	ret0, _ := ret[0].(*domain.Experiment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ActiveExperiment indicates an expected call of ActiveExperiment.
+func (mr *MockIExperimentServiceMockRecorder) ActiveExperiment(at any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ActiveExperiment", reflect.TypeOf((*MockIExperimentService)(nil).ActiveExperiment), at)
+}
+
+// CreateExperiment mocks base method.
+func (m *MockIExperimentService) CreateExperiment(ctx context.Context, experiment *domain.Experiment) (*domain.Experiment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateExperiment", ctx, experiment)
+	ret0, _ := ret[0].(*domain.Experiment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateExperiment indicates an expected call of CreateExperiment.
+func (mr *MockIExperimentServiceMockRecorder) CreateExperiment(ctx, experiment any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateExperiment", reflect.TypeOf((*MockIExperimentService)(nil).CreateExperiment), ctx, experiment)
+}
+
+// DeleteExperiment mocks base method.
+func (m *MockIExperimentService) DeleteExperiment(id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteExperiment", id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteExperiment indicates an expected call of DeleteExperiment.
+func (mr *MockIExperimentServiceMockRecorder) DeleteExperiment(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteExperiment", reflect.TypeOf((*MockIExperimentService)(nil).DeleteExperiment), id)
+}
+
+// ExperimentReport mocks base method.
+func (m *MockIExperimentService) ExperimentReport(id string) (*domain.ExperimentReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExperimentReport", id)
+	ret0, _ := ret[0].(*domain.ExperimentReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExperimentReport indicates an expected call of ExperimentReport.
+func (mr *MockIExperimentServiceMockRecorder) ExperimentReport(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExperimentReport", reflect.TypeOf((*MockIExperimentService)(nil).ExperimentReport), id)
+}
+
+// ListExperiments mocks base method.
+func (m *MockIExperimentService) ListExperiments() ([]*domain.Experiment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListExperiments")
+	ret0, _ := ret[0].([]*domain.Experiment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListExperiments indicates an expected call of ListExperiments.
+func (mr *MockIExperimentServiceMockRecorder) ListExperiments() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListExperiments", reflect.TypeOf((*MockIExperimentService)(nil).ListExperiments))
+}
+
+// RetrieveExperiment mocks base method.
+func (m *MockIExperimentService) RetrieveExperiment(id string) (*domain.Experiment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RetrieveExperiment", id)
+	ret0, _ := ret[0].(*domain.Experiment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RetrieveExperiment indicates an expected call of RetrieveExperiment.
+func (mr *MockIExperimentServiceMockRecorder) RetrieveExperiment(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RetrieveExperiment", reflect.TypeOf((*MockIExperimentService)(nil).RetrieveExperiment), id)
+}
+
+// UpdateExperiment mocks base method.
+func (m *MockIExperimentService) UpdateExperiment(ctx context.Context, experiment *domain.Experiment) (*domain.Experiment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateExperiment", ctx, experiment)
+	ret0, _ := ret[0].(*domain.Experiment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateExperiment indicates an expected call of UpdateExperiment.
+func (mr *MockIExperimentServiceMockRecorder) UpdateExperiment(ctx, experiment any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateExperiment", reflect.TypeOf((*MockIExperimentService)(nil).UpdateExperiment), ctx, experiment)
+}
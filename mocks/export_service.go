@@ -0,0 +1,56 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ports/services/export_service.go
+//
+// Generated by this command:
+//
+//	mockgen -source ports/services/export_service.go -destination mocks/export_service.go -package mocks
+//
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	io "io"
+	reflect "reflect"
+
+	domain "github.com/kiramishima/receipt-processor/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockIExportService is a mock of IExportService interface.
+type MockIExportService struct {
+	ctrl     *gomock.Controller
+	recorder *MockIExportServiceMockRecorder
+}
+
+// MockIExportServiceMockRecorder is the mock recorder for MockIExportService.
+type MockIExportServiceMockRecorder struct {
+	mock *MockIExportService
+}
+
+// NewMockIExportService creates a new mock instance.
+func NewMockIExportService(ctrl *gomock.Controller) *MockIExportService {
+	mock := &MockIExportService{ctrl: ctrl}
+	mock.recorder = &MockIExportServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIExportService) EXPECT() *MockIExportServiceMockRecorder {
+	return m.recorder
+}
+
+// ExportReceipts mocks base method.
+func (m *MockIExportService) ExportReceipts(ctx context.Context, filter *domain.ReceiptFilter, format string, w io.Writer) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportReceipts", ctx, filter, format, w)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExportReceipts indicates an expected call of ExportReceipts.
+func (mr *MockIExportServiceMockRecorder) ExportReceipts(ctx, filter, format, w any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportReceipts", reflect.TypeOf((*MockIExportService)(nil).ExportReceipts), ctx, filter, format, w)
+}
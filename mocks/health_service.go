@@ -0,0 +1,68 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ports/services/health_service.go
+//
+// Generated by this command:
+//
+//	mockgen -source ports/services/health_service.go -destination mocks/health_service.go -package mocks
+//
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	domain "github.com/kiramishima/receipt-processor/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockIHealthService is a mock of IHealthService interface.
+type MockIHealthService struct {
+	ctrl     *gomock.Controller
+	recorder *MockIHealthServiceMockRecorder
+}
+
+// MockIHealthServiceMockRecorder is the mock recorder for MockIHealthService.
+type MockIHealthServiceMockRecorder struct {
+	mock *MockIHealthService
+}
+
+// NewMockIHealthService creates a new mock instance.
+func NewMockIHealthService(ctrl *gomock.Controller) *MockIHealthService {
+	mock := &MockIHealthService{ctrl: ctrl}
+	mock.recorder = &MockIHealthServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIHealthService) EXPECT() *MockIHealthServiceMockRecorder {
+	return m.recorder
+}
+
+// Live mocks base method.
+func (m *MockIHealthService) Live(ctx context.Context) *domain.HealthReport {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Live", ctx)
+	ret0, _ := ret[0].(*domain.HealthReport)
+	return ret0
+}
+
+// Live indicates an expected call of Live.
+func (mr *MockIHealthServiceMockRecorder) Live(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Live", reflect.TypeOf((*MockIHealthService)(nil).Live), ctx)
+}
+
+// Ready mocks base method.
+func (m *MockIHealthService) Ready(ctx context.Context) *domain.HealthReport {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Ready", ctx)
+	ret0, _ := ret[0].(*domain.HealthReport)
+	return ret0
+}
+
+// Ready indicates an expected call of Ready.
+func (mr *MockIHealthServiceMockRecorder) Ready(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Ready", reflect.TypeOf((*MockIHealthService)(nil).Ready), ctx)
+}
@@ -0,0 +1,56 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ports/services/import_service.go
+//
+// Generated by this command:
+//
+//	mockgen -source ports/services/import_service.go -destination mocks/import_service.go -package mocks
+//
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	io "io"
+	reflect "reflect"
+
+	domain "github.com/kiramishima/receipt-processor/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockIImportService is a mock of IImportService interface.
+type MockIImportService struct {
+	ctrl     *gomock.Controller
+	recorder *MockIImportServiceMockRecorder
+}
+
+// MockIImportServiceMockRecorder is the mock recorder for MockIImportService.
+type MockIImportServiceMockRecorder struct {
+	mock *MockIImportService
+}
+
+// NewMockIImportService creates a new mock instance.
+func NewMockIImportService(ctrl *gomock.Controller) *MockIImportService {
+	mock := &MockIImportService{ctrl: ctrl}
+	mock.recorder = &MockIImportServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIImportService) EXPECT() *MockIImportServiceMockRecorder {
+	return m.recorder
+}
+
+// ImportReceipts mocks base method.
+func (m *MockIImportService) ImportReceipts(ctx context.Context, r io.Reader, opts *domain.ImportOptions) (*domain.ImportReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ImportReceipts", ctx, r, opts)
+	ret0, _ := ret[0].(*domain.ImportReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ImportReceipts indicates an expected call of ImportReceipts.
+func (mr *MockIImportServiceMockRecorder) ImportReceipts(ctx, r, opts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportReceipts", reflect.TypeOf((*MockIImportService)(nil).ImportReceipts), ctx, r, opts)
+}
@@ -0,0 +1,69 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ports/repository/ledger_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source ports/repository/ledger_repository.go -destination mocks/ledger_repository.go -package mocks
+//
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+
+	domain "github.com/kiramishima/receipt-processor/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockILedgerRepository is a mock of ILedgerRepository interface.
+type MockILedgerRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockILedgerRepositoryMockRecorder
+}
+
+// MockILedgerRepositoryMockRecorder is the mock recorder for MockILedgerRepository.
+type MockILedgerRepositoryMockRecorder struct {
+	mock *MockILedgerRepository
+}
+
+// NewMockILedgerRepository creates a new mock instance.
+func NewMockILedgerRepository(ctrl *gomock.Controller) *MockILedgerRepository {
+	mock := &MockILedgerRepository{ctrl: ctrl}
+	mock.recorder = &MockILedgerRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockILedgerRepository) EXPECT() *MockILedgerRepositoryMockRecorder {
+	return m.recorder
+}
+
+// ListLedgerEntries mocks base method.
+func (m *MockILedgerRepository) ListLedgerEntries(receiptID string) ([]*domain.LedgerEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListLedgerEntries", receiptID)
+	ret0, _ := ret[0].([]*domain.LedgerEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListLedgerEntries indicates an expected call of ListLedgerEntries.
+func (mr *MockILedgerRepositoryMockRecorder) ListLedgerEntries(receiptID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListLedgerEntries", reflect.TypeOf((*MockILedgerRepository)(nil).ListLedgerEntries), receiptID)
+}
+
+// SaveLedgerEntry mocks base method.
+func (m *MockILedgerRepository) SaveLedgerEntry(entry *domain.LedgerEntry) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveLedgerEntry", entry)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SaveLedgerEntry indicates an expected call of SaveLedgerEntry.
+func (mr *MockILedgerRepositoryMockRecorder) SaveLedgerEntry(entry any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveLedgerEntry", reflect.TypeOf((*MockILedgerRepository)(nil).SaveLedgerEntry), entry)
+}
@@ -0,0 +1,83 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ports/repository/member_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source ports/repository/member_repository.go -destination mocks/member_repository.go -package mocks
+//
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+
+	domain "github.com/kiramishima/receipt-processor/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockIMemberRepository is a mock of IMemberRepository interface.
+type MockIMemberRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockIMemberRepositoryMockRecorder
+}
+
+// MockIMemberRepositoryMockRecorder is the mock recorder for MockIMemberRepository.
+type MockIMemberRepositoryMockRecorder struct {
+	mock *MockIMemberRepository
+}
+
+// NewMockIMemberRepository creates a new mock instance.
+func NewMockIMemberRepository(ctrl *gomock.Controller) *MockIMemberRepository {
+	mock := &MockIMemberRepository{ctrl: ctrl}
+	mock.recorder = &MockIMemberRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIMemberRepository) EXPECT() *MockIMemberRepositoryMockRecorder {
+	return m.recorder
+}
+
+// FindMemberById mocks base method.
+func (m *MockIMemberRepository) FindMemberById(id string) (*domain.Member, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindMemberById", id)
+	ret0, _ := ret[0].(*domain.Member)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindMemberById indicates an expected call of FindMemberById.
+func (mr *MockIMemberRepositoryMockRecorder) FindMemberById(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindMemberById", reflect.TypeOf((*MockIMemberRepository)(nil).FindMemberById), id)
+}
+
+// ListMembers mocks base method.
+func (m *MockIMemberRepository) ListMembers() ([]*domain.Member, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListMembers")
+	ret0, _ := ret[0].([]*domain.Member)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListMembers indicates an expected call of ListMembers.
+func (mr *MockIMemberRepositoryMockRecorder) ListMembers() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListMembers", reflect.TypeOf((*MockIMemberRepository)(nil).ListMembers))
+}
+
+// SaveMember mocks base method.
+func (m *MockIMemberRepository) SaveMember(member *domain.Member) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveMember", member)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveMember indicates an expected call of SaveMember.
+func (mr *MockIMemberRepositoryMockRecorder) SaveMember(member any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveMember", reflect.TypeOf((*MockIMemberRepository)(nil).SaveMember), member)
+}
@@ -0,0 +1,99 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ports/metrics/metrics_recorder.go
+//
+// Generated by this command:
+//
+//	mockgen -source ports/metrics/metrics_recorder.go -destination mocks/metrics_recorder.go -package mocks
+//
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+	time "time"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockIMetricsRecorder is a mock of IMetricsRecorder interface.
+type MockIMetricsRecorder struct {
+	ctrl     *gomock.Controller
+	recorder *MockIMetricsRecorderMockRecorder
+}
+
+// MockIMetricsRecorderMockRecorder is the mock recorder for MockIMetricsRecorder.
+type MockIMetricsRecorderMockRecorder struct {
+	mock *MockIMetricsRecorder
+}
+
+// NewMockIMetricsRecorder creates a new mock instance.
+func NewMockIMetricsRecorder(ctrl *gomock.Controller) *MockIMetricsRecorder {
+	mock := &MockIMetricsRecorder{ctrl: ctrl}
+	mock.recorder = &MockIMetricsRecorderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIMetricsRecorder) EXPECT() *MockIMetricsRecorderMockRecorder {
+	return m.recorder
+}
+
+// ObserveRepository mocks base method.
+func (m *MockIMetricsRecorder) ObserveRepository(repository, operation string, duration time.Duration, err error) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "ObserveRepository", repository, operation, duration, err)
+}
+
+// ObserveRepository indicates an expected call of ObserveRepository.
+func (mr *MockIMetricsRecorderMockRecorder) ObserveRepository(repository, operation, duration, err any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ObserveRepository", reflect.TypeOf((*MockIMetricsRecorder)(nil).ObserveRepository), repository, operation, duration, err)
+}
+
+// ObserveRequest mocks base method.
+func (m *MockIMetricsRecorder) ObserveRequest(method, route string, status int, duration time.Duration) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "ObserveRequest", method, route, status, duration)
+}
+
+// ObserveRequest indicates an expected call of ObserveRequest.
+func (mr *MockIMetricsRecorderMockRecorder) ObserveRequest(method, route, status, duration any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ObserveRequest", reflect.TypeOf((*MockIMetricsRecorder)(nil).ObserveRequest), method, route, status, duration)
+}
+
+// ReceiptProcessed mocks base method.
+func (m *MockIMetricsRecorder) ReceiptProcessed(points int) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "ReceiptProcessed", points)
+}
+
+// ReceiptProcessed indicates an expected call of ReceiptProcessed.
+func (mr *MockIMetricsRecorderMockRecorder) ReceiptProcessed(points any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReceiptProcessed", reflect.TypeOf((*MockIMetricsRecorder)(nil).ReceiptProcessed), points)
+}
+
+// ReceiptRejected mocks base method.
+func (m *MockIMetricsRecorder) ReceiptRejected(reason string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "ReceiptRejected", reason)
+}
+
+// ReceiptRejected indicates an expected call of ReceiptRejected.
+func (mr *MockIMetricsRecorderMockRecorder) ReceiptRejected(reason any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReceiptRejected", reflect.TypeOf((*MockIMetricsRecorder)(nil).ReceiptRejected), reason)
+}
+
+// RuleFired mocks base method.
+func (m *MockIMetricsRecorder) RuleFired(rule string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "RuleFired", rule)
+}
+
+// RuleFired indicates an expected call of RuleFired.
+func (mr *MockIMetricsRecorderMockRecorder) RuleFired(rule any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RuleFired", reflect.TypeOf((*MockIMetricsRecorder)(nil).RuleFired), rule)
+}
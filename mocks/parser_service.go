@@ -0,0 +1,55 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ports/services/parser_service.go
+//
+// Generated by this command:
+//
+//	mockgen -source ports/services/parser_service.go -destination mocks/parser_service.go -package mocks
+//
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	domain "github.com/kiramishima/receipt-processor/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockIParserService is a mock of IParserService interface.
+type MockIParserService struct {
+	ctrl     *gomock.Controller
+	recorder *MockIParserServiceMockRecorder
+}
+
+// MockIParserServiceMockRecorder is the mock recorder for MockIParserService.
+type MockIParserServiceMockRecorder struct {
+	mock *MockIParserService
+}
+
+// NewMockIParserService creates a new mock instance.
+func NewMockIParserService(ctrl *gomock.Controller) *MockIParserService {
+	mock := &MockIParserService{ctrl: ctrl}
+	mock.recorder = &MockIParserServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIParserService) EXPECT() *MockIParserServiceMockRecorder {
+	return m.recorder
+}
+
+// ParseReceipt mocks base method.
+func (m *MockIParserService) ParseReceipt(ctx context.Context, req *domain.ParseRequest) (*domain.ParsedReceipt, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ParseReceipt", ctx, req)
+	ret0, _ := ret[0].(*domain.ParsedReceipt)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ParseReceipt indicates an expected call of ParseReceipt.
+func (mr *MockIParserServiceMockRecorder) ParseReceipt(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ParseReceipt", reflect.TypeOf((*MockIParserService)(nil).ParseReceipt), ctx, req)
+}
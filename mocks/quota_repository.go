@@ -0,0 +1,83 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ports/repository/quota_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source ports/repository/quota_repository.go -destination mocks/quota_repository.go -package mocks
+//
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+
+	domain "github.com/kiramishima/receipt-processor/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockIQuotaRepository is a mock of IQuotaRepository interface.
+type MockIQuotaRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockIQuotaRepositoryMockRecorder
+}
+
+// MockIQuotaRepositoryMockRecorder is the mock recorder for MockIQuotaRepository.
+type MockIQuotaRepositoryMockRecorder struct {
+	mock *MockIQuotaRepository
+}
+
+// NewMockIQuotaRepository creates a new mock instance.
+func NewMockIQuotaRepository(ctrl *gomock.Controller) *MockIQuotaRepository {
+	mock := &MockIQuotaRepository{ctrl: ctrl}
+	mock.recorder = &MockIQuotaRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIQuotaRepository) EXPECT() *MockIQuotaRepositoryMockRecorder {
+	return m.recorder
+}
+
+// DecrementQuotaUsage mocks base method.
+func (m *MockIQuotaRepository) DecrementQuotaUsage(client, day string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DecrementQuotaUsage", client, day)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DecrementQuotaUsage indicates an expected call of DecrementQuotaUsage.
+func (mr *MockIQuotaRepositoryMockRecorder) DecrementQuotaUsage(client, day any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DecrementQuotaUsage", reflect.TypeOf((*MockIQuotaRepository)(nil).DecrementQuotaUsage), client, day)
+}
+
+// FindQuotaUsage mocks base method.
+func (m *MockIQuotaRepository) FindQuotaUsage(client, day string) (*domain.QuotaUsage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindQuotaUsage", client, day)
+	ret0, _ := ret[0].(*domain.QuotaUsage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindQuotaUsage indicates an expected call of FindQuotaUsage.
+func (mr *MockIQuotaRepositoryMockRecorder) FindQuotaUsage(client, day any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindQuotaUsage", reflect.TypeOf((*MockIQuotaRepository)(nil).FindQuotaUsage), client, day)
+}
+
+// IncrementQuotaUsage mocks base method.
+func (m *MockIQuotaRepository) IncrementQuotaUsage(client, day string, limit int) (*domain.QuotaUsage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IncrementQuotaUsage", client, day, limit)
+	ret0, _ := ret[0].(*domain.QuotaUsage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IncrementQuotaUsage indicates an expected call of IncrementQuotaUsage.
+func (mr *MockIQuotaRepositoryMockRecorder) IncrementQuotaUsage(client, day, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IncrementQuotaUsage", reflect.TypeOf((*MockIQuotaRepository)(nil).IncrementQuotaUsage), client, day, limit)
+}
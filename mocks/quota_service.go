@@ -0,0 +1,70 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ports/services/quota_service.go
+//
+// Generated by this command:
+//
+//	mockgen -source ports/services/quota_service.go -destination mocks/quota_service.go -package mocks
+//
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	domain "github.com/kiramishima/receipt-processor/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockIQuotaService is a mock of IQuotaService interface.
+type MockIQuotaService struct {
+	ctrl     *gomock.Controller
+	recorder *MockIQuotaServiceMockRecorder
+}
+
+// MockIQuotaServiceMockRecorder is the mock recorder for MockIQuotaService.
+type MockIQuotaServiceMockRecorder struct {
+	mock *MockIQuotaService
+}
+
+// NewMockIQuotaService creates a new mock instance.
+func NewMockIQuotaService(ctrl *gomock.Controller) *MockIQuotaService {
+	mock := &MockIQuotaService{ctrl: ctrl}
+	mock.recorder = &MockIQuotaServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIQuotaService) EXPECT() *MockIQuotaServiceMockRecorder {
+	return m.recorder
+}
+
+// ConsumeReceiptQuota mocks base method.
+func (m *MockIQuotaService) ConsumeReceiptQuota(ctx context.Context) (func(), error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConsumeReceiptQuota", ctx)
+	ret0, _ := ret[0].(func())
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ConsumeReceiptQuota indicates an expected call of ConsumeReceiptQuota.
+func (mr *MockIQuotaServiceMockRecorder) ConsumeReceiptQuota(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConsumeReceiptQuota", reflect.TypeOf((*MockIQuotaService)(nil).ConsumeReceiptQuota), ctx)
+}
+
+// ReceiptQuota mocks base method.
+func (m *MockIQuotaService) ReceiptQuota(ctx context.Context) (*domain.QuotaStatus, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReceiptQuota", ctx)
+	ret0, _ := ret[0].(*domain.QuotaStatus)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReceiptQuota indicates an expected call of ReceiptQuota.
+func (mr *MockIQuotaServiceMockRecorder) ReceiptQuota(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReceiptQuota", reflect.TypeOf((*MockIQuotaService)(nil).ReceiptQuota), ctx)
+}
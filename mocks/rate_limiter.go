@@ -0,0 +1,54 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ports/ratelimit/rate_limiter.go
+//
+// Generated by this command:
+//
+//	mockgen -source ports/ratelimit/rate_limiter.go -destination mocks/rate_limiter.go -package mocks
+//
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+	time "time"
+
+	domain "github.com/kiramishima/receipt-processor/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockIRateLimiter is a mock of IRateLimiter interface.
+type MockIRateLimiter struct {
+	ctrl     *gomock.Controller
+	recorder *MockIRateLimiterMockRecorder
+}
+
+// MockIRateLimiterMockRecorder is the mock recorder for MockIRateLimiter.
+type MockIRateLimiterMockRecorder struct {
+	mock *MockIRateLimiter
+}
+
+// NewMockIRateLimiter creates a new mock instance.
+func NewMockIRateLimiter(ctrl *gomock.Controller) *MockIRateLimiter {
+	mock := &MockIRateLimiter{ctrl: ctrl}
+	mock.recorder = &MockIRateLimiterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIRateLimiter) EXPECT() *MockIRateLimiterMockRecorder {
+	return m.recorder
+}
+
+// Allow mocks base method.
+func (m *MockIRateLimiter) Allow(key string, limit domain.RateLimit, now time.Time) domain.RateLimitDecision {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Allow", key, limit, now)
+	ret0, _ := ret[0].(domain.RateLimitDecision)
+	return ret0
+}
+
+// Allow indicates an expected call of Allow.
+func (mr *MockIRateLimiterMockRecorder) Allow(key, limit, now any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Allow", reflect.TypeOf((*MockIRateLimiter)(nil).Allow), key, limit, now)
+}
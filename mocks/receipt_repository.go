@@ -1,15 +1,16 @@
 // Code generated by MockGen. DO NOT EDIT.
-// Source: .\ports\repository\receipt_repository.go
+// Source: ports/repository/receipt_repository.go
 //
 // Generated by this command:
 //
-//	mockgen.exe -source .\ports\repository\receipt_repository.go -destination .\mocks\receipt_repository.go -package mocks
+//	mockgen -source ports/repository/receipt_repository.go -destination mocks/receipt_repository.go -package mocks
 //
 // Package mocks is a generated GoMock package.
 package mocks
 
 import (
 	reflect "reflect"
+	time "time"
 
 	domain "github.com/kiramishima/receipt-processor/domain"
 	gomock "go.uber.org/mock/gomock"
@@ -38,6 +39,20 @@ func (m *MockIReceiptRepository) EXPECT() *MockIReceiptRepositoryMockRecorder {
 	return m.recorder
 }
 
+// EachReceipt mocks base method.
+func (m *MockIReceiptRepository) EachReceipt(filter *domain.ReceiptFilter, fn func(*domain.Result) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EachReceipt", filter, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// EachReceipt indicates an expected call of EachReceipt.
+func (mr *MockIReceiptRepositoryMockRecorder) EachReceipt(filter, fn any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EachReceipt", reflect.TypeOf((*MockIReceiptRepository)(nil).EachReceipt), filter, fn)
+}
+
 // FindReceiptById mocks base method.
 func (m *MockIReceiptRepository) FindReceiptById(id string) (*domain.Result, error) {
 	m.ctrl.T.Helper()
@@ -53,17 +68,76 @@ func (mr *MockIReceiptRepositoryMockRecorder) FindReceiptById(id any) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindReceiptById", reflect.TypeOf((*MockIReceiptRepository)(nil).FindReceiptById), id)
 }
 
+// ListReceipts mocks base method.
+func (m *MockIReceiptRepository) ListReceipts(filter *domain.ReceiptFilter) ([]*domain.Result, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListReceipts", filter)
+	ret0, _ := ret[0].([]*domain.Result)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListReceipts indicates an expected call of ListReceipts.
+func (mr *MockIReceiptRepositoryMockRecorder) ListReceipts(filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListReceipts", reflect.TypeOf((*MockIReceiptRepository)(nil).ListReceipts), filter)
+}
+
+// SaveCappedReceiptPoints mocks base method.
+func (m *MockIReceiptRepository) SaveCappedReceiptPoints(result *domain.Result, dailyCap int, since time.Time) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveCappedReceiptPoints", result, dailyCap, since)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SaveCappedReceiptPoints indicates an expected call of SaveCappedReceiptPoints.
+func (mr *MockIReceiptRepositoryMockRecorder) SaveCappedReceiptPoints(result, dailyCap, since any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveCappedReceiptPoints", reflect.TypeOf((*MockIReceiptRepository)(nil).SaveCappedReceiptPoints), result, dailyCap, since)
+}
+
 // SaveReceiptPoints mocks base method.
-func (m *MockIReceiptRepository) SaveReceiptPoints(points int16) (string, error) {
+func (m *MockIReceiptRepository) SaveReceiptPoints(result *domain.Result) (string, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "SaveReceiptPoints", points)
+	ret := m.ctrl.Call(m, "SaveReceiptPoints", result)
 	ret0, _ := ret[0].(string)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // SaveReceiptPoints indicates an expected call of SaveReceiptPoints.
-func (mr *MockIReceiptRepositoryMockRecorder) SaveReceiptPoints(points any) *gomock.Call {
+func (mr *MockIReceiptRepositoryMockRecorder) SaveReceiptPoints(result any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveReceiptPoints", reflect.TypeOf((*MockIReceiptRepository)(nil).SaveReceiptPoints), result)
+}
+
+// SumMemberPointsSince mocks base method.
+func (m *MockIReceiptRepository) SumMemberPointsSince(memberID string, since time.Time) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SumMemberPointsSince", memberID, since)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SumMemberPointsSince indicates an expected call of SumMemberPointsSince.
+func (mr *MockIReceiptRepositoryMockRecorder) SumMemberPointsSince(memberID, since any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SumMemberPointsSince", reflect.TypeOf((*MockIReceiptRepository)(nil).SumMemberPointsSince), memberID, since)
+}
+
+// UpdateReceiptPoints mocks base method.
+func (m *MockIReceiptRepository) UpdateReceiptPoints(id string, points int, breakdown *domain.Breakdown) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateReceiptPoints", id, points, breakdown)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateReceiptPoints indicates an expected call of UpdateReceiptPoints.
+func (mr *MockIReceiptRepositoryMockRecorder) UpdateReceiptPoints(id, points, breakdown any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveReceiptPoints", reflect.TypeOf((*MockIReceiptRepository)(nil).SaveReceiptPoints), points)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateReceiptPoints", reflect.TypeOf((*MockIReceiptRepository)(nil).UpdateReceiptPoints), id, points, breakdown)
 }
@@ -1,9 +1,9 @@
 // Code generated by MockGen. DO NOT EDIT.
-// Source: .\ports\services\receipt_service.go
+// Source: ports/services/receipt_service.go
 //
 // Generated by this command:
 //
-//	mockgen.exe -source .\ports\services\receipt_service.go -destination .\mocks\receipt_service.go -package mocks
+//	mockgen -source ports/services/receipt_service.go -destination mocks/receipt_service.go -package mocks
 //
 // Package mocks is a generated GoMock package.
 package mocks
@@ -39,19 +39,49 @@ func (m *MockIReceiptService) EXPECT() *MockIReceiptServiceMockRecorder {
 	return m.recorder
 }
 
+// RescoreReceipts mocks base method.
+func (m *MockIReceiptService) RescoreReceipts(ctx context.Context, req *domain.RescoreRequest) (*domain.RescoreReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RescoreReceipts", ctx, req)
+	ret0, _ := ret[0].(*domain.RescoreReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RescoreReceipts indicates an expected call of RescoreReceipts.
+func (mr *MockIReceiptServiceMockRecorder) RescoreReceipts(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RescoreReceipts", reflect.TypeOf((*MockIReceiptService)(nil).RescoreReceipts), ctx, req)
+}
+
 // RetrieveReceipt mocks base method.
-func (m *MockIReceiptService) RetrieveReceipt(id string) (*domain.Result, error) {
+func (m *MockIReceiptService) RetrieveReceipt(ctx context.Context, id string) (*domain.Result, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "RetrieveReceipt", id)
+	ret := m.ctrl.Call(m, "RetrieveReceipt", ctx, id)
 	ret0, _ := ret[0].(*domain.Result)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // RetrieveReceipt indicates an expected call of RetrieveReceipt.
-func (mr *MockIReceiptServiceMockRecorder) RetrieveReceipt(id any) *gomock.Call {
+func (mr *MockIReceiptServiceMockRecorder) RetrieveReceipt(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RetrieveReceipt", reflect.TypeOf((*MockIReceiptService)(nil).RetrieveReceipt), ctx, id)
+}
+
+// ScoreReceipt mocks base method.
+func (m *MockIReceiptService) ScoreReceipt(ctx context.Context, base *domain.ReceiptBase) (*domain.ScoreResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ScoreReceipt", ctx, base)
+	ret0, _ := ret[0].(*domain.ScoreResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ScoreReceipt indicates an expected call of ScoreReceipt.
+func (mr *MockIReceiptServiceMockRecorder) ScoreReceipt(ctx, base any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RetrieveReceipt", reflect.TypeOf((*MockIReceiptService)(nil).RetrieveReceipt), id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ScoreReceipt", reflect.TypeOf((*MockIReceiptService)(nil).ScoreReceipt), ctx, base)
 }
 
 // StoreReceipt mocks base method.
@@ -0,0 +1,156 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ports/repository/retailer_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source ports/repository/retailer_repository.go -destination mocks/retailer_repository.go -package mocks
+//
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+
+	domain "github.com/kiramishima/receipt-processor/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockIRetailerRepository is a mock of IRetailerRepository interface.
+type MockIRetailerRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockIRetailerRepositoryMockRecorder
+}
+
+// MockIRetailerRepositoryMockRecorder is the mock recorder for MockIRetailerRepository.
+type MockIRetailerRepositoryMockRecorder struct {
+	mock *MockIRetailerRepository
+}
+
+// NewMockIRetailerRepository creates a new mock instance.
+func NewMockIRetailerRepository(ctrl *gomock.Controller) *MockIRetailerRepository {
+	mock := &MockIRetailerRepository{ctrl: ctrl}
+	mock.recorder = &MockIRetailerRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIRetailerRepository) EXPECT() *MockIRetailerRepositoryMockRecorder {
+	return m.recorder
+}
+
+// DeleteRetailer mocks base method.
+func (m *MockIRetailerRepository) DeleteRetailer(id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteRetailer", id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteRetailer indicates an expected call of DeleteRetailer.
+func (mr *MockIRetailerRepositoryMockRecorder) DeleteRetailer(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteRetailer", reflect.TypeOf((*MockIRetailerRepository)(nil).DeleteRetailer), id)
+}
+
+// FindCategoryByName mocks base method.
+func (m *MockIRetailerRepository) FindCategoryByName(name string) (*domain.RetailerCategory, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindCategoryByName", name)
+	ret0, _ := ret[0].(*domain.RetailerCategory)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindCategoryByName indicates an expected call of FindCategoryByName.
+func (mr *MockIRetailerRepositoryMockRecorder) FindCategoryByName(name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindCategoryByName", reflect.TypeOf((*MockIRetailerRepository)(nil).FindCategoryByName), name)
+}
+
+// FindRetailerById mocks base method.
+func (m *MockIRetailerRepository) FindRetailerById(id string) (*domain.Retailer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindRetailerById", id)
+	ret0, _ := ret[0].(*domain.Retailer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindRetailerById indicates an expected call of FindRetailerById.
+func (mr *MockIRetailerRepositoryMockRecorder) FindRetailerById(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindRetailerById", reflect.TypeOf((*MockIRetailerRepository)(nil).FindRetailerById), id)
+}
+
+// ListCategories mocks base method.
+func (m *MockIRetailerRepository) ListCategories() ([]*domain.RetailerCategory, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListCategories")
+	ret0, _ := ret[0].([]*domain.RetailerCategory)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListCategories indicates an expected call of ListCategories.
+func (mr *MockIRetailerRepositoryMockRecorder) ListCategories() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCategories", reflect.TypeOf((*MockIRetailerRepository)(nil).ListCategories))
+}
+
+// ListRetailers mocks base method.
+func (m *MockIRetailerRepository) ListRetailers() ([]*domain.Retailer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListRetailers")
+	ret0, _ := ret[0].([]*domain.Retailer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListRetailers indicates an expected call of ListRetailers.
+func (mr *MockIRetailerRepositoryMockRecorder) ListRetailers() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRetailers", reflect.TypeOf((*MockIRetailerRepository)(nil).ListRetailers))
+}
+
+// SaveCategory mocks base method.
+func (m *MockIRetailerRepository) SaveCategory(category *domain.RetailerCategory) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveCategory", category)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveCategory indicates an expected call of SaveCategory.
+func (mr *MockIRetailerRepositoryMockRecorder) SaveCategory(category any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveCategory", reflect.TypeOf((*MockIRetailerRepository)(nil).SaveCategory), category)
+}
+
+// SaveRetailer mocks base method.
+func (m *MockIRetailerRepository) SaveRetailer(retailer *domain.Retailer) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveRetailer", retailer)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SaveRetailer indicates an expected call of SaveRetailer.
+func (mr *MockIRetailerRepositoryMockRecorder) SaveRetailer(retailer any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveRetailer", reflect.TypeOf((*MockIRetailerRepository)(nil).SaveRetailer), retailer)
+}
+
+// UpdateRetailer mocks base method.
+func (m *MockIRetailerRepository) UpdateRetailer(retailer *domain.Retailer) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateRetailer", retailer)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateRetailer indicates an expected call of UpdateRetailer.
+func (mr *MockIRetailerRepositoryMockRecorder) UpdateRetailer(retailer any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateRetailer", reflect.TypeOf((*MockIRetailerRepository)(nil).UpdateRetailer), retailer)
+}
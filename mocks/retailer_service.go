@@ -0,0 +1,174 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ports/services/retailer_service.go
+//
+// Generated by this command:
+//
+//	mockgen -source ports/services/retailer_service.go -destination mocks/retailer_service.go -package mocks
+//
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	domain "github.com/kiramishima/receipt-processor/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockIRetailerService is a mock of IRetailerService interface.
+type MockIRetailerService struct {
+	ctrl     *gomock.Controller
+	recorder *MockIRetailerServiceMockRecorder
+}
+
+// MockIRetailerServiceMockRecorder is the mock recorder for MockIRetailerService.
+type MockIRetailerServiceMockRecorder struct {
+	mock *MockIRetailerService
+}
+
+// NewMockIRetailerService creates a new mock instance.
+func NewMockIRetailerService(ctrl *gomock.Controller) *MockIRetailerService {
+	mock := &MockIRetailerService{ctrl: ctrl}
+	mock.recorder = &MockIRetailerServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIRetailerService) EXPECT() *MockIRetailerServiceMockRecorder {
+	return m.recorder
+}
+
+// CreateRetailer mocks base method.
+func (m *MockIRetailerService) CreateRetailer(ctx context.Context, retailer *domain.Retailer) (*domain.Retailer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateRetailer", ctx, retailer)
+	ret0, _ := ret[0].(*domain.Retailer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateRetailer indicates an expected call of CreateRetailer.
+func (mr *MockIRetailerServiceMockRecorder) CreateRetailer(ctx, retailer any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateRetailer", reflect.TypeOf((*MockIRetailerService)(nil).CreateRetailer), ctx, retailer)
+}
+
+// DeleteRetailer mocks base method.
+func (m *MockIRetailerService) DeleteRetailer(id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteRetailer", id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteRetailer indicates an expected call of DeleteRetailer.
+func (mr *MockIRetailerServiceMockRecorder) DeleteRetailer(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteRetailer", reflect.TypeOf((*MockIRetailerService)(nil).DeleteRetailer), id)
+}
+
+// ListCategories mocks base method.
+func (m *MockIRetailerService) ListCategories() ([]*domain.RetailerCategory, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListCategories")
+	ret0, _ := ret[0].([]*domain.RetailerCategory)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListCategories indicates an expected call of ListCategories.
+func (mr *MockIRetailerServiceMockRecorder) ListCategories() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCategories", reflect.TypeOf((*MockIRetailerService)(nil).ListCategories))
+}
+
+// ListRetailers mocks base method.
+func (m *MockIRetailerService) ListRetailers() ([]*domain.Retailer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListRetailers")
+	ret0, _ := ret[0].([]*domain.Retailer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListRetailers indicates an expected call of ListRetailers.
+func (mr *MockIRetailerServiceMockRecorder) ListRetailers() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRetailers", reflect.TypeOf((*MockIRetailerService)(nil).ListRetailers))
+}
+
+// ResolveRetailer mocks base method.
+func (m *MockIRetailerService) ResolveRetailer(name string) (*domain.Retailer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResolveRetailer", name)
+	ret0, _ := ret[0].(*domain.Retailer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ResolveRetailer indicates an expected call of ResolveRetailer.
+func (mr *MockIRetailerServiceMockRecorder) ResolveRetailer(name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResolveRetailer", reflect.TypeOf((*MockIRetailerService)(nil).ResolveRetailer), name)
+}
+
+// RetrieveRetailer mocks base method.
+func (m *MockIRetailerService) RetrieveRetailer(id string) (*domain.Retailer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RetrieveRetailer", id)
+	ret0, _ := ret[0].(*domain.Retailer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RetrieveRetailer indicates an expected call of RetrieveRetailer.
+func (mr *MockIRetailerServiceMockRecorder) RetrieveRetailer(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RetrieveRetailer", reflect.TypeOf((*MockIRetailerService)(nil).RetrieveRetailer), id)
+}
+
+// RuleSetFor mocks base method.
+func (m *MockIRetailerService) RuleSetFor(base *domain.RuleSet, retailer *domain.Retailer) (*domain.RuleSet, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RuleSetFor", base, retailer)
+	ret0, _ := ret[0].(*domain.RuleSet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RuleSetFor indicates an expected call of RuleSetFor.
+func (mr *MockIRetailerServiceMockRecorder) RuleSetFor(base, retailer any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RuleSetFor", reflect.TypeOf((*MockIRetailerService)(nil).RuleSetFor), base, retailer)
+}
+
+// SaveCategory mocks base method.
+func (m *MockIRetailerService) SaveCategory(ctx context.Context, category *domain.RetailerCategory) (*domain.RetailerCategory, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveCategory", ctx, category)
+	ret0, _ := ret[0].(*domain.RetailerCategory)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SaveCategory indicates an expected call of SaveCategory.
+func (mr *MockIRetailerServiceMockRecorder) SaveCategory(ctx, category any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveCategory", reflect.TypeOf((*MockIRetailerService)(nil).SaveCategory), ctx, category)
+}
+
+// UpdateRetailer mocks base method.
+func (m *MockIRetailerService) UpdateRetailer(ctx context.Context, retailer *domain.Retailer) (*domain.Retailer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateRetailer", ctx, retailer)
+	ret0, _ := ret[0].(*domain.Retailer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateRetailer indicates an expected call of UpdateRetailer.
+func (mr *MockIRetailerServiceMockRecorder) UpdateRetailer(ctx, retailer any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateRetailer", reflect.TypeOf((*MockIRetailerService)(nil).UpdateRetailer), ctx, retailer)
+}
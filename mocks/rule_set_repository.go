@@ -0,0 +1,83 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ports/repository/rule_set_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source ports/repository/rule_set_repository.go -destination mocks/rule_set_repository.go -package mocks
+//
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+
+	domain "github.com/kiramishima/receipt-processor/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockIRuleSetRepository is a mock of IRuleSetRepository interface.
+type MockIRuleSetRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockIRuleSetRepositoryMockRecorder
+}
+
+// MockIRuleSetRepositoryMockRecorder is the mock recorder for MockIRuleSetRepository.
+type MockIRuleSetRepositoryMockRecorder struct {
+	mock *MockIRuleSetRepository
+}
+
+// NewMockIRuleSetRepository creates a new mock instance.
+func NewMockIRuleSetRepository(ctrl *gomock.Controller) *MockIRuleSetRepository {
+	mock := &MockIRuleSetRepository{ctrl: ctrl}
+	mock.recorder = &MockIRuleSetRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIRuleSetRepository) EXPECT() *MockIRuleSetRepositoryMockRecorder {
+	return m.recorder
+}
+
+// FindRuleSetByVersion mocks base method.
+func (m *MockIRuleSetRepository) FindRuleSetByVersion(version string) (*domain.RuleSet, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindRuleSetByVersion", version)
+	ret0, _ := ret[0].(*domain.RuleSet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindRuleSetByVersion indicates an expected call of FindRuleSetByVersion.
+func (mr *MockIRuleSetRepositoryMockRecorder) FindRuleSetByVersion(version any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindRuleSetByVersion", reflect.TypeOf((*MockIRuleSetRepository)(nil).FindRuleSetByVersion), version)
+}
+
+// ListRuleSets mocks base method.
+func (m *MockIRuleSetRepository) ListRuleSets() ([]*domain.RuleSet, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListRuleSets")
+	ret0, _ := ret[0].([]*domain.RuleSet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListRuleSets indicates an expected call of ListRuleSets.
+func (mr *MockIRuleSetRepositoryMockRecorder) ListRuleSets() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRuleSets", reflect.TypeOf((*MockIRuleSetRepository)(nil).ListRuleSets))
+}
+
+// SaveRuleSet mocks base method.
+func (m *MockIRuleSetRepository) SaveRuleSet(rs *domain.RuleSet) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveRuleSet", rs)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveRuleSet indicates an expected call of SaveRuleSet.
+func (mr *MockIRuleSetRepositoryMockRecorder) SaveRuleSet(rs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveRuleSet", reflect.TypeOf((*MockIRuleSetRepository)(nil).SaveRuleSet), rs)
+}
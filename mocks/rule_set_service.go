@@ -0,0 +1,114 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ports/services/rule_set_service.go
+//
+// Generated by this command:
+//
+//	mockgen -source ports/services/rule_set_service.go -destination mocks/rule_set_service.go -package mocks
+//
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	domain "github.com/kiramishima/receipt-processor/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockIRuleSetService is a mock of IRuleSetService interface.
+type MockIRuleSetService struct {
+	ctrl     *gomock.Controller
+	recorder *MockIRuleSetServiceMockRecorder
+}
+
+// MockIRuleSetServiceMockRecorder is the mock recorder for MockIRuleSetService.
+type MockIRuleSetServiceMockRecorder struct {
+	mock *MockIRuleSetService
+}
+
+// NewMockIRuleSetService creates a new mock instance.
+func NewMockIRuleSetService(ctrl *gomock.Controller) *MockIRuleSetService {
+	mock := &MockIRuleSetService{ctrl: ctrl}
+	mock.recorder = &MockIRuleSetServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIRuleSetService) EXPECT() *MockIRuleSetServiceMockRecorder {
+	return m.recorder
+}
+
+// ActivateRuleSet mocks base method.
+func (m *MockIRuleSetService) ActivateRuleSet(version string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ActivateRuleSet", version)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ActivateRuleSet indicates an expected call of ActivateRuleSet.
+func (mr *MockIRuleSetServiceMockRecorder) ActivateRuleSet(version any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ActivateRuleSet", reflect.TypeOf((*MockIRuleSetService)(nil).ActivateRuleSet), version)
+}
+
+// ActiveRuleSet mocks base method.
+func (m *MockIRuleSetService) ActiveRuleSet() (*domain.RuleSet, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ActiveRuleSet")
+	ret0, _ := ret[0].(*domain.RuleSet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ActiveRuleSet indicates an expected call of ActiveRuleSet.
+func (mr *MockIRuleSetServiceMockRecorder) ActiveRuleSet() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ActiveRuleSet", reflect.TypeOf((*MockIRuleSetService)(nil).ActiveRuleSet))
+}
+
+// CreateRuleSet mocks base method.
+func (m *MockIRuleSetService) CreateRuleSet(ctx context.Context, rs *domain.RuleSet) (*domain.RuleSet, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateRuleSet", ctx, rs)
+	ret0, _ := ret[0].(*domain.RuleSet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateRuleSet indicates an expected call of CreateRuleSet.
+func (mr *MockIRuleSetServiceMockRecorder) CreateRuleSet(ctx, rs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateRuleSet", reflect.TypeOf((*MockIRuleSetService)(nil).CreateRuleSet), ctx, rs)
+}
+
+// ListRuleSets mocks base method.
+func (m *MockIRuleSetService) ListRuleSets() ([]*domain.RuleSet, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListRuleSets")
+	ret0, _ := ret[0].([]*domain.RuleSet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListRuleSets indicates an expected call of ListRuleSets.
+func (mr *MockIRuleSetServiceMockRecorder) ListRuleSets() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRuleSets", reflect.TypeOf((*MockIRuleSetService)(nil).ListRuleSets))
+}
+
+// RetrieveRuleSet mocks base method.
+func (m *MockIRuleSetService) RetrieveRuleSet(version string) (*domain.RuleSet, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RetrieveRuleSet", version)
+	ret0, _ := ret[0].(*domain.RuleSet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RetrieveRuleSet indicates an expected call of RetrieveRuleSet.
+func (mr *MockIRuleSetServiceMockRecorder) RetrieveRuleSet(version any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RetrieveRuleSet", reflect.TypeOf((*MockIRuleSetService)(nil).RetrieveRuleSet), version)
+}
@@ -0,0 +1,99 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ports/services/tier_service.go
+//
+// Generated by this command:
+//
+//	mockgen -source ports/services/tier_service.go -destination mocks/tier_service.go -package mocks
+//
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	domain "github.com/kiramishima/receipt-processor/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockITierService is a mock of ITierService interface.
+type MockITierService struct {
+	ctrl     *gomock.Controller
+	recorder *MockITierServiceMockRecorder
+}
+
+// MockITierServiceMockRecorder is the mock recorder for MockITierService.
+type MockITierServiceMockRecorder struct {
+	mock *MockITierService
+}
+
+// NewMockITierService creates a new mock instance.
+func NewMockITierService(ctrl *gomock.Controller) *MockITierService {
+	mock := &MockITierService{ctrl: ctrl}
+	mock.recorder = &MockITierServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockITierService) EXPECT() *MockITierServiceMockRecorder {
+	return m.recorder
+}
+
+// FindMember mocks base method.
+func (m *MockITierService) FindMember(memberID string) (*domain.Member, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindMember", memberID)
+	ret0, _ := ret[0].(*domain.Member)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindMember indicates an expected call of FindMember.
+func (mr *MockITierServiceMockRecorder) FindMember(memberID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindMember", reflect.TypeOf((*MockITierService)(nil).FindMember), memberID)
+}
+
+// MultiplierFor mocks base method.
+func (m *MockITierService) MultiplierFor(tier domain.Tier) float64 {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MultiplierFor", tier)
+	ret0, _ := ret[0].(float64)
+	return ret0
+}
+
+// MultiplierFor indicates an expected call of MultiplierFor.
+func (mr *MockITierServiceMockRecorder) MultiplierFor(tier any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MultiplierFor", reflect.TypeOf((*MockITierService)(nil).MultiplierFor), tier)
+}
+
+// RecomputeTiers mocks base method.
+func (m *MockITierService) RecomputeTiers(ctx context.Context) ([]*domain.TierChangeEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecomputeTiers", ctx)
+	ret0, _ := ret[0].([]*domain.TierChangeEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RecomputeTiers indicates an expected call of RecomputeTiers.
+func (mr *MockITierServiceMockRecorder) RecomputeTiers(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecomputeTiers", reflect.TypeOf((*MockITierService)(nil).RecomputeTiers), ctx)
+}
+
+// ResolveMember mocks base method.
+func (m *MockITierService) ResolveMember(memberID string) (*domain.Member, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResolveMember", memberID)
+	ret0, _ := ret[0].(*domain.Member)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ResolveMember indicates an expected call of ResolveMember.
+func (mr *MockITierServiceMockRecorder) ResolveMember(memberID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResolveMember", reflect.TypeOf((*MockITierService)(nil).ResolveMember), memberID)
+}
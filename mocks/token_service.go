@@ -0,0 +1,54 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ports/services/token_service.go
+//
+// Generated by this command:
+//
+//	mockgen -source ports/services/token_service.go -destination mocks/token_service.go -package mocks
+//
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+
+	domain "github.com/kiramishima/receipt-processor/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockITokenService is a mock of ITokenService interface.
+type MockITokenService struct {
+	ctrl     *gomock.Controller
+	recorder *MockITokenServiceMockRecorder
+}
+
+// MockITokenServiceMockRecorder is the mock recorder for MockITokenService.
+type MockITokenServiceMockRecorder struct {
+	mock *MockITokenService
+}
+
+// NewMockITokenService creates a new mock instance.
+func NewMockITokenService(ctrl *gomock.Controller) *MockITokenService {
+	mock := &MockITokenService{ctrl: ctrl}
+	mock.recorder = &MockITokenServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockITokenService) EXPECT() *MockITokenServiceMockRecorder {
+	return m.recorder
+}
+
+// Authenticate mocks base method.
+func (m *MockITokenService) Authenticate(token string) (*domain.Principal, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Authenticate", token)
+	ret0, _ := ret[0].(*domain.Principal)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Authenticate indicates an expected call of Authenticate.
+func (mr *MockITokenServiceMockRecorder) Authenticate(token any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Authenticate", reflect.TypeOf((*MockITokenService)(nil).Authenticate), token)
+}
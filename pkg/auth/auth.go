@@ -0,0 +1,49 @@
+// Package auth carries the authenticated principal of a request in its context, from the auth middleware to the
+// services, and the IPs of the client.
+package auth
+
+import (
+	"context"
+
+	"github.com/kiramishima/receipt-processor/domain"
+)
+
+type principalKey struct{}
+
+type clientIPKey struct{}
+
+type remoteIPKey struct{}
+
+// WithPrincipal returns a copy of the context with the principal
+func WithPrincipal(ctx context.Context, principal *domain.Principal) context.Context {
+	return context.WithValue(ctx, principalKey{}, principal)
+}
+
+// Principal returns the principal of the context, nil when the request is not authenticated
+func Principal(ctx context.Context) *domain.Principal {
+	principal, _ := ctx.Value(principalKey{}).(*domain.Principal)
+	return principal
+}
+
+// WithClientIP returns a copy of the context with the IP of the client
+func WithClientIP(ctx context.Context, ip string) context.Context {
+	return context.WithValue(ctx, clientIPKey{}, ip)
+}
+
+// ClientIP returns the IP of the client of the context, empty when the context is not of a request
+func ClientIP(ctx context.Context) string {
+	ip, _ := ctx.Value(clientIPKey{}).(string)
+	return ip
+}
+
+// WithRemoteIP returns a copy of the context with the IP of the connection of the request
+func WithRemoteIP(ctx context.Context, ip string) context.Context {
+	return context.WithValue(ctx, remoteIPKey{}, ip)
+}
+
+// RemoteIP returns the IP of the connection of the request of the context, unlike the ClientIP it is never taken from
+// the forwarded headers. Empty when the context is not of a request
+func RemoteIP(ctx context.Context) string {
+	ip, _ := ctx.Value(remoteIPKey{}).(string)
+	return ip
+}
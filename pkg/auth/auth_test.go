@@ -0,0 +1,26 @@
+package auth
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kiramishima/receipt-processor/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPrincipal(t *testing.T) {
+	assert.Nil(t, Principal(context.Background()))
+
+	var principal = domain.NewTokenPrincipal("user-1", "member-1", []string{domain.RoleMember})
+	assert.Equal(t, principal, Principal(WithPrincipal(context.Background(), principal)))
+}
+
+func TestClientIP(t *testing.T) {
+	assert.Empty(t, ClientIP(context.Background()))
+	assert.Equal(t, "10.0.0.1", ClientIP(WithClientIP(context.Background(), "10.0.0.1")))
+}
+
+func TestRemoteIP(t *testing.T) {
+	assert.Empty(t, RemoteIP(context.Background()))
+	assert.Equal(t, "10.0.0.1", RemoteIP(WithRemoteIP(context.Background(), "10.0.0.1")))
+}
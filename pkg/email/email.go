@@ -0,0 +1,163 @@
+package email
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/mail"
+	"strings"
+	"time"
+
+	"golang.org/x/text/encoding/htmlindex"
+)
+
+// maxPartBytes limit of the decoded size of a body part
+const maxPartBytes = 1_048_576
+
+// maxDepth limit of nested multiparts
+const maxDepth = 8
+
+var ErrNoBody = errors.New("message has no text or html body")
+
+// Message headers and bodies of a RFC 5322 message, the bodies are decoded to UTF-8
+type Message struct {
+	MessageID string
+	From      *mail.Address
+	Subject   string
+	Date      time.Time
+	Text      string
+	HTML      string
+}
+
+var decoder = &mime.WordDecoder{CharsetReader: charsetReader}
+
+// Parse reads a RFC 5322 message, the first text/plain and text/html parts that are not attachments are its bodies
+func Parse(r io.Reader) (*Message, error) {
+	msg, err := mail.ReadMessage(r)
+	if err != nil {
+		return nil, fmt.Errorf("error reading message: %w", err)
+	}
+
+	var message = &Message{MessageID: strings.Trim(msg.Header.Get("Message-Id"), "<> ")}
+	if message.Subject, err = decoder.DecodeHeader(msg.Header.Get("Subject")); err != nil {
+		message.Subject = msg.Header.Get("Subject")
+	}
+	if from := msg.Header.Get("From"); from != "" {
+		var parser = &mail.AddressParser{WordDecoder: decoder}
+		if message.From, err = parser.Parse(from); err != nil {
+			return nil, fmt.Errorf("error reading message: from %w", err)
+		}
+	}
+	if date, err := msg.Header.Date(); err == nil {
+		message.Date = date
+	}
+
+	if err := message.readPart(mailHeader(msg.Header), msg.Body, 0); err != nil {
+		return nil, err
+	}
+	if message.Text == "" && message.HTML == "" {
+		return nil, ErrNoBody
+	}
+	return message, nil
+}
+
+// Body returns the text body, or the text of the html body for the messages with only html
+func (m *Message) Body() string {
+	if strings.TrimSpace(m.Text) != "" {
+		return m.Text
+	}
+	return HTMLText(m.HTML)
+}
+
+// header the headers of the message and of the parts
+type header interface {
+	Get(key string) string
+}
+
+type mailHeader mail.Header
+
+func (h mailHeader) Get(key string) string {
+	return mail.Header(h).Get(key)
+}
+
+// readPart reads the bodies of a part, the multiparts are read recursively
+func (m *Message) readPart(h header, body io.Reader, depth int) error {
+	mediaType, params, err := mime.ParseMediaType(h.Get("Content-Type"))
+	if err != nil {
+		// Messages without content type are plain text
+		mediaType, params = "text/plain", map[string]string{}
+	}
+	if disposition, _, _ := mime.ParseMediaType(h.Get("Content-Disposition")); disposition == "attachment" {
+		return nil
+	}
+
+	switch {
+	case strings.HasPrefix(mediaType, "multipart/"):
+		if depth >= maxDepth {
+			return fmt.Errorf("error reading message: more than %d nested multiparts", maxDepth)
+		}
+		var reader = multipart.NewReader(body, params["boundary"])
+		for {
+			part, err := reader.NextPart()
+			if err == io.EOF {
+				return nil
+			}
+			if err != nil {
+				return fmt.Errorf("error reading message: %w", err)
+			}
+			if err := m.readPart(part.Header, part, depth+1); err != nil {
+				return err
+			}
+		}
+	case mediaType == "text/plain" && m.Text == "":
+		m.Text, err = decodeText(h, body, params["charset"])
+	case mediaType == "text/html" && m.HTML == "":
+		m.HTML, err = decodeText(h, body, params["charset"])
+	}
+	return err
+}
+
+// decodeText decodes the transfer encoding and the charset of a text part. The multipart reader decodes the
+// quoted-printable parts and removes their Content-Transfer-Encoding header.
+func decodeText(h header, body io.Reader, charset string) (string, error) {
+	switch strings.ToLower(strings.TrimSpace(h.Get("Content-Transfer-Encoding"))) {
+	case "quoted-printable":
+		body = quotedprintable.NewReader(body)
+	case "base64":
+		body = base64.NewDecoder(base64.StdEncoding, body)
+	}
+
+	content, err := io.ReadAll(io.LimitReader(body, maxPartBytes+1))
+	if err != nil {
+		return "", fmt.Errorf("error reading message: %w", err)
+	}
+	if len(content) > maxPartBytes {
+		return "", fmt.Errorf("error reading message: part larger than %d bytes", maxPartBytes)
+	}
+
+	if charset == "" || strings.EqualFold(charset, "utf-8") || strings.EqualFold(charset, "us-ascii") {
+		return string(content), nil
+	}
+	reader, err := charsetReader(charset, bytes.NewReader(content))
+	if err != nil {
+		return "", err
+	}
+	decoded, err := io.ReadAll(reader)
+	if err != nil {
+		return "", fmt.Errorf("error reading message: %w", err)
+	}
+	return string(decoded), nil
+}
+
+func charsetReader(charset string, input io.Reader) (io.Reader, error) {
+	encoding, err := htmlindex.Get(charset)
+	if err != nil {
+		return nil, fmt.Errorf("error reading message: unknown charset %s", charset)
+	}
+	return encoding.NewDecoder().Reader(input), nil
+}
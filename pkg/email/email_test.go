@@ -0,0 +1,69 @@
+package email
+
+import (
+	"encoding/base64"
+	"github.com/stretchr/testify/assert"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParse(t *testing.T) {
+	t.Run("Multipart", func(t *testing.T) {
+		file, err := os.Open("testdata/target.eml")
+		if !assert.NoError(t, err) {
+			return
+		}
+		defer file.Close()
+
+		message, err := Parse(file)
+		if !assert.NoError(t, err) {
+			return
+		}
+		assert.Equal(t, "receipt-1234@target.com", message.MessageID)
+		assert.Equal(t, "Target Café", message.From.Name)
+		assert.Equal(t, "receipts@target.com", message.From.Address)
+		assert.Equal(t, "Your Target receipt – March 18", message.Subject)
+		assert.True(t, message.Date.Equal(time.Date(2022, 3, 18, 19, 35, 2, 0, time.UTC)))
+		assert.Equal(t, "TARGET\n03/18/2022 2:33 PM\nPepsi 12PK 4.99\nCafé Latte 3.50\nTOTAL 8.49", strings.TrimSpace(message.Text))
+		assert.Equal(t, "<html><body><p>TARGET</p></body></html>", strings.TrimSpace(message.HTML))
+		// The attachment is not the text body
+		assert.Equal(t, message.Text, message.Body())
+	})
+
+	t.Run("Base64 html", func(t *testing.T) {
+		var document = "<html><head><style>td { color: red }</style></head><body><table>" +
+			"<tr><td>Cr\xe8me br\xfbl\xe9e</td><td>4.50</td></tr><tr><th>Total</th><th>4.50</th></tr></table></body></html>"
+		var encoded = base64.StdEncoding.EncodeToString([]byte(document))
+		var raw = "From: shop@example.com\r\nContent-Type: text/html; charset=iso-8859-1\r\n" +
+			"Content-Transfer-Encoding: base64\r\n\r\n" + encoded[:40] + "\r\n" + encoded[40:] + "\r\n"
+
+		message, err := Parse(strings.NewReader(raw))
+		if assert.NoError(t, err) {
+			assert.Empty(t, message.Text)
+			assert.Equal(t, "Crème brûlée    4.50\nTotal    4.50", message.Body())
+		}
+	})
+
+	t.Run("Plain message", func(t *testing.T) {
+		message, err := Parse(strings.NewReader("Subject: receipt\r\n\r\nCorner Shop\r\nTOTAL 1.20\r\n"))
+		if assert.NoError(t, err) {
+			assert.Nil(t, message.From)
+			assert.Equal(t, "Corner Shop\r\nTOTAL 1.20\r\n", message.Body())
+		}
+	})
+
+	t.Run("Invalid messages", func(t *testing.T) {
+		_, err := Parse(strings.NewReader("not a message"))
+		assert.Error(t, err)
+		_, err = Parse(strings.NewReader("Content-Type: image/png\r\n\r\nPNG"))
+		assert.ErrorIs(t, err, ErrNoBody)
+		_, err = Parse(strings.NewReader("Content-Type: text/plain; charset=x-unknown\r\n\r\ntext"))
+		assert.Error(t, err)
+	})
+}
+
+func TestHTMLText(t *testing.T) {
+	assert.Equal(t, "Hello world\nTOTAL 1.20", HTMLText("<div>Hello <b>world</b></div><br/><span>TOTAL</span> <span>1.20</span><script>x()</script>"))
+}
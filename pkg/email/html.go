@@ -0,0 +1,79 @@
+package email
+
+import (
+	"strings"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// blocks elements ending a line of text
+var blocks = map[atom.Atom]bool{
+	atom.Br: true, atom.P: true, atom.Div: true, atom.Tr: true, atom.Li: true, atom.Table: true, atom.Hr: true,
+	atom.H1: true, atom.H2: true, atom.H3: true, atom.H4: true, atom.H5: true, atom.H6: true,
+}
+
+// cells elements separated from the next one in the same line
+var cells = map[atom.Atom]bool{atom.Td: true, atom.Th: true}
+
+// hidden elements without visible text
+var hidden = map[atom.Atom]bool{atom.Head: true, atom.Script: true, atom.Style: true, atom.Title: true}
+
+// HTMLText returns the visible text of a html document with a line for every block and table row, the cells of a row
+// are separated by 4 spaces
+func HTMLText(document string) string {
+	var tokenizer = html.NewTokenizer(strings.NewReader(document))
+	var lines []string
+	var line strings.Builder
+	var skip = 0
+
+	var endLine = func() {
+		if text := strings.TrimSpace(line.String()); text != "" {
+			lines = append(lines, text)
+		}
+		line.Reset()
+	}
+
+	for {
+		switch tokenizer.Next() {
+		case html.ErrorToken:
+			endLine()
+			return strings.Join(lines, "\n")
+		case html.TextToken:
+			var text = tokenizer.Text()
+			if skip > 0 || len(text) == 0 {
+				continue
+			}
+			if isSpace(text[0]) {
+				space(&line)
+			}
+			line.WriteString(strings.Join(strings.Fields(string(text)), " "))
+			if isSpace(text[len(text)-1]) {
+				space(&line)
+			}
+		case html.StartTagToken, html.SelfClosingTagToken, html.EndTagToken:
+			var token = tokenizer.Token()
+			switch {
+			case hidden[token.DataAtom] && token.Type == html.StartTagToken:
+				skip++
+			case hidden[token.DataAtom] && token.Type == html.EndTagToken:
+				skip = max(skip-1, 0)
+			case blocks[token.DataAtom]:
+				endLine()
+			case cells[token.DataAtom] && token.Type == html.EndTagToken:
+				line.WriteString("    ")
+			}
+		}
+	}
+}
+
+// space separates the next text of the line
+func space(line *strings.Builder) {
+	if text := line.String(); text != "" && !strings.HasSuffix(text, " ") {
+		line.WriteByte(' ')
+	}
+}
+
+func isSpace(b byte) bool {
+	return b == ' ' || b == '\t' || b == '\n' || b == '\r'
+}
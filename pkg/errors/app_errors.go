@@ -33,4 +33,7 @@ var (
 	InvalidJWTToken       = errors.New("Invalid JWT token")
 	InvalidJWTClaims      = errors.New("Invalid JWT claims")
 	NotAllowedImageHeader = errors.New("Not allowed image header")
+	TooManyRequests       = errors.New("Too many requests")
+	QuotaExceeded         = errors.New("Quota exceeded")
+	Conflict              = errors.New("Conflict")
 )
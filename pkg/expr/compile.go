@@ -0,0 +1,419 @@
+package expr
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+)
+
+var (
+	ErrOverflow       = errors.New("integer overflow")
+	ErrDivisionByZero = errors.New("division by zero")
+	ErrOutOfRange     = errors.New("number out of range")
+)
+
+// evalFn compiled node, evaluates it with the state
+type evalFn func(s *state) (any, error)
+
+// local variable bound by a list macro
+type local struct {
+	name string
+	typ  *Type
+}
+
+// compiler type checks the syntax tree and turns every node in a closure
+type compiler struct {
+	env       map[string]*Type
+	scope     []local
+	maxLocals int
+}
+
+// compile returns the type of the node and its closure, every evaluation of the closure is a step
+func (c *compiler) compile(n node) (*Type, evalFn, error) {
+	typ, fn, err := c.compileNode(n)
+	if err != nil {
+		return nil, nil, err
+	}
+	return typ, func(s *state) (any, error) {
+		if err := s.step(); err != nil {
+			return nil, err
+		}
+		return fn(s)
+	}, nil
+}
+
+func (c *compiler) compileNode(n node) (*Type, evalFn, error) {
+	switch n := n.(type) {
+	case *literalNode:
+		var value = n.value
+		return n.typ, func(*state) (any, error) { return value, nil }, nil
+	case *identNode:
+		return c.ident(n)
+	case *unaryNode:
+		return c.unary(n)
+	case *binaryNode:
+		return c.binary(n)
+	case *conditionalNode:
+		return c.conditional(n)
+	case *selectorNode:
+		return c.selector(n)
+	case *callNode:
+		if n.recv != nil {
+			return c.method(n)
+		}
+		return c.function(n)
+	default:
+		return nil, nil, errorf(n.position(), "unsupported expression")
+	}
+}
+
+func (c *compiler) ident(n *identNode) (*Type, evalFn, error) {
+	for i := len(c.scope) - 1; i >= 0; i-- {
+		if c.scope[i].name == n.name {
+			var slot = i
+			return c.scope[i].typ, func(s *state) (any, error) { return s.locals[slot], nil }, nil
+		}
+	}
+
+	typ, ok := c.env[n.name]
+	if !ok {
+		return nil, nil, errorf(n.pos, "undefined variable %s", n.name)
+	}
+	var name = n.name
+	return typ, func(s *state) (any, error) {
+		value, ok := s.vars[name]
+		if !ok {
+			return nil, fmt.Errorf("variable %s has no value", name)
+		}
+		return value, nil
+	}, nil
+}
+
+func (c *compiler) unary(n *unaryNode) (*Type, evalFn, error) {
+	typ, x, err := c.compile(n.x)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	switch {
+	case n.op == "!" && typ.Kind == KindBool:
+		return Bool, func(s *state) (any, error) {
+			v, err := x(s)
+			if err != nil {
+				return nil, err
+			}
+			return !v.(bool), nil
+		}, nil
+	case n.op == "-" && typ.Kind == KindInt:
+		return Int, func(s *state) (any, error) {
+			v, err := x(s)
+			if err != nil {
+				return nil, err
+			}
+			if v.(int) == math.MinInt {
+				return nil, ErrOverflow
+			}
+			return -v.(int), nil
+		}, nil
+	case n.op == "-" && typ.Kind == KindFloat:
+		return Float, func(s *state) (any, error) {
+			v, err := x(s)
+			if err != nil {
+				return nil, err
+			}
+			return -v.(float64), nil
+		}, nil
+	default:
+		return nil, nil, errorf(n.pos, "operator %s is not defined on %s", n.op, typ)
+	}
+}
+
+func (c *compiler) binary(n *binaryNode) (*Type, evalFn, error) {
+	xt, x, err := c.compile(n.x)
+	if err != nil {
+		return nil, nil, err
+	}
+	yt, y, err := c.compile(n.y)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	switch n.op {
+	case "&&", "||":
+		if xt.Kind != KindBool || yt.Kind != KindBool {
+			return nil, nil, errorf(n.pos, "operator %s is not defined on %s and %s", n.op, xt, yt)
+		}
+		var shortCircuit = n.op == "||"
+		return Bool, func(s *state) (any, error) {
+			a, err := x(s)
+			if err != nil || a.(bool) == shortCircuit {
+				return a, err
+			}
+			return y(s)
+		}, nil
+	case "==", "!=":
+		var equal func(a, b any) bool
+		switch {
+		case xt.numeric() && yt.numeric():
+			equal = func(a, b any) bool { return compareNumbers(a, b) == 0 }
+		case xt.Equal(yt) && xt.comparable():
+			equal = func(a, b any) bool { return a == b }
+		default:
+			return nil, nil, errorf(n.pos, "cannot compare %s with %s", xt, yt)
+		}
+		var negate = n.op == "!="
+		return Bool, binaryFn(x, y, func(a, b any) (any, error) { return equal(a, b) != negate, nil }), nil
+	case "<", "<=", ">", ">=":
+		var compare func(a, b any) int
+		switch {
+		case xt.numeric() && yt.numeric():
+			compare = compareNumbers
+		case xt.Kind == KindString && yt.Kind == KindString:
+			compare = func(a, b any) int { return strings.Compare(a.(string), b.(string)) }
+		default:
+			return nil, nil, errorf(n.pos, "cannot compare %s with %s", xt, yt)
+		}
+		var op = n.op
+		return Bool, binaryFn(x, y, func(a, b any) (any, error) {
+			var cmp = compare(a, b)
+			switch op {
+			case "<":
+				return cmp < 0, nil
+			case "<=":
+				return cmp <= 0, nil
+			case ">":
+				return cmp > 0, nil
+			default:
+				return cmp >= 0, nil
+			}
+		}), nil
+	case "+":
+		if xt.Kind == KindString && yt.Kind == KindString {
+			return String, binaryFn(x, y, func(a, b any) (any, error) { return a.(string) + b.(string), nil }), nil
+		}
+	}
+
+	if !xt.numeric() || !yt.numeric() {
+		return nil, nil, errorf(n.pos, "operator %s is not defined on %s and %s", n.op, xt, yt)
+	}
+	if xt.Kind == KindInt && yt.Kind == KindInt {
+		var op = n.op
+		return Int, binaryFn(x, y, func(a, b any) (any, error) { return intArithmetic(op, a.(int), b.(int)) }), nil
+	}
+	var op = n.op
+	return Float, binaryFn(x, y, func(a, b any) (any, error) { return floatArithmetic(op, toFloat(a), toFloat(b)) }), nil
+}
+
+func (c *compiler) conditional(n *conditionalNode) (*Type, evalFn, error) {
+	ct, cond, err := c.compile(n.cond)
+	if err != nil {
+		return nil, nil, err
+	}
+	if ct.Kind != KindBool {
+		return nil, nil, errorf(n.pos, "condition must be bool, got %s", ct)
+	}
+	tt, then, err := c.compile(n.then)
+	if err != nil {
+		return nil, nil, err
+	}
+	et, els, err := c.compile(n.els)
+	if err != nil {
+		return nil, nil, err
+	}
+	typ, ok := unify(tt, et)
+	if !ok {
+		return nil, nil, errorf(n.pos, "branches have different types %s and %s", tt, et)
+	}
+
+	then, els = convert(then, tt, typ), convert(els, et, typ)
+	return typ, func(s *state) (any, error) {
+		v, err := cond(s)
+		if err != nil {
+			return nil, err
+		}
+		if v.(bool) {
+			return then(s)
+		}
+		return els(s)
+	}, nil
+}
+
+func (c *compiler) selector(n *selectorNode) (*Type, evalFn, error) {
+	typ, x, err := c.compile(n.x)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var name = n.name
+	switch {
+	case typ.Kind == KindObject && typ.Fields[name] != nil:
+		return typ.Fields[name], func(s *state) (any, error) {
+			v, err := x(s)
+			if err != nil {
+				return nil, err
+			}
+			field, ok := v.(map[string]any)[name]
+			if !ok {
+				return nil, fmt.Errorf("field %s has no value", name)
+			}
+			return field, nil
+		}, nil
+	case typ.Kind == KindList && name == "count":
+		return Int, func(s *state) (any, error) {
+			v, err := x(s)
+			if err != nil {
+				return nil, err
+			}
+			return len(v.([]any)), nil
+		}, nil
+	default:
+		return nil, nil, errorf(n.pos, "%s has no field %s", typ, name)
+	}
+}
+
+// binaryFn evaluates both operands and then applies the operation
+func binaryFn(x, y evalFn, op func(a, b any) (any, error)) evalFn {
+	return func(s *state) (any, error) {
+		a, err := x(s)
+		if err != nil {
+			return nil, err
+		}
+		b, err := y(s)
+		if err != nil {
+			return nil, err
+		}
+		return op(a, b)
+	}
+}
+
+// unify returns the type both types can be converted to, ints are converted to float when mixed with floats
+func unify(a, b *Type) (*Type, bool) {
+	switch {
+	case a.Equal(b):
+		return a, true
+	case a.numeric() && b.numeric():
+		return Float, true
+	default:
+		return nil, false
+	}
+}
+
+// convert wraps the closure to return values of the target type
+func convert(fn evalFn, from, to *Type) evalFn {
+	if from.Kind != KindInt || to.Kind != KindFloat {
+		return fn
+	}
+	return func(s *state) (any, error) {
+		v, err := fn(s)
+		if err != nil {
+			return nil, err
+		}
+		return float64(v.(int)), nil
+	}
+}
+
+func toFloat(v any) float64 {
+	if i, ok := v.(int); ok {
+		return float64(i)
+	}
+	return v.(float64)
+}
+
+// toInt converts a float without fractional part to int
+func toInt(f float64) (int, error) {
+	if math.IsNaN(f) || f < math.MinInt || f >= math.MaxInt {
+		return 0, ErrOutOfRange
+	}
+	return int(f), nil
+}
+
+func compareNumbers(a, b any) int {
+	ai, aInt := a.(int)
+	bi, bInt := b.(int)
+	if aInt && bInt {
+		switch {
+		case ai < bi:
+			return -1
+		case ai > bi:
+			return 1
+		default:
+			return 0
+		}
+	}
+
+	var af, bf = toFloat(a), toFloat(b)
+	switch {
+	case af < bf:
+		return -1
+	case af > bf:
+		return 1
+	default:
+		return 0
+	}
+}
+
+func intArithmetic(op string, a, b int) (any, error) {
+	switch op {
+	case "+":
+		var r = a + b
+		if (a > 0 && b > 0 && r < 0) || (a < 0 && b < 0 && r >= 0) {
+			return nil, ErrOverflow
+		}
+		return r, nil
+	case "-":
+		var r = a - b
+		if (a >= 0 && b < 0 && r < 0) || (a < 0 && b > 0 && r >= 0) {
+			return nil, ErrOverflow
+		}
+		return r, nil
+	case "*":
+		if a == 0 || b == 0 {
+			return 0, nil
+		}
+		var r = a * b
+		if r/b != a || (a == -1 && b == math.MinInt) || (b == -1 && a == math.MinInt) {
+			return nil, ErrOverflow
+		}
+		return r, nil
+	case "/":
+		if b == 0 {
+			return nil, ErrDivisionByZero
+		}
+		if a == math.MinInt && b == -1 {
+			return nil, ErrOverflow
+		}
+		return a / b, nil
+	default:
+		if b == 0 {
+			return nil, ErrDivisionByZero
+		}
+		return a % b, nil
+	}
+}
+
+func floatArithmetic(op string, a, b float64) (any, error) {
+	var r float64
+	switch op {
+	case "+":
+		r = a + b
+	case "-":
+		r = a - b
+	case "*":
+		r = a * b
+	case "/":
+		if b == 0 {
+			return nil, ErrDivisionByZero
+		}
+		r = a / b
+	default:
+		if b == 0 {
+			return nil, ErrDivisionByZero
+		}
+		r = math.Mod(a, b)
+	}
+	if math.IsInf(r, 0) || math.IsNaN(r) {
+		return nil, ErrOutOfRange
+	}
+	return r, nil
+}
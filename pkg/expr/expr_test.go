@@ -0,0 +1,186 @@
+package expr
+
+import (
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+	"time"
+)
+
+var item = Object("item", map[string]*Type{"description": String, "price": Float})
+
+var env = map[string]*Type{
+	"retailer": String,
+	"total":    Float,
+	"items":    List(item),
+}
+
+var vars = map[string]any{
+	"retailer": "Target",
+	"total":    35.35,
+	"items": []any{
+		map[string]any{"description": "Mountain Dew 12PK", "price": 6.49},
+		map[string]any{"description": "Emils Cheese Pizza", "price": 12.25},
+		map[string]any{"description": "   Klarbrunn 12-PK 12 FL OZ  ", "price": 15.00},
+	},
+}
+
+func TestProgram_Eval(t *testing.T) {
+	var testCases = map[string]any{
+		`retailer.matches("Target") && items.count >= 3 ? 15 : 0`: 15,
+		`retailer.matches("^Walgreens") ? 15 : 0`:                 0,
+		`1 + 2 * 3 - 4 / 2 % 3`:                                   5,
+		`(1 + 2) * 3`:                                             9,
+		`-2 * -3`:                                                 6,
+		`7 / 2`:                                                   3,
+		`7 / 2.0`:                                                 3.5,
+		`total % 0.25 == 0`:                                       false,
+		`1 == 1.0 && 2 > 1.5 && "a" < "b" && "a" != "b"`: true,
+		`!false || 1 / 0 == 0`:                           true,
+		`false && 1 / 0 == 0`:                            false,
+		`true ? 1 : 2.5`:                                 1.0,
+		`"a" + "b"`:                                      "ab",
+		`len(alnum("M&M Corner Market"))`:                14,
+		`len(alnum("Café"))`:                             3,
+		`trim("  a ") + lower("B") + upper("c")`:         "abC",
+		`retailer.contains("arg") && retailer.startsWith("T") && retailer.endsWith("et")`: true,
+		`ceil(15.0 * 0.2)`:                    3,
+		`ceil(12.25 * 0.2)`:                   3,
+		`floor(2.9) + round(2.5) + int(-2.7)`: 3,
+		`abs(-3) + min(1, 2) + max(1, 2)`:     6,
+		`max(1, 2.5)`:                         2.5,
+		`float(1)`:                            1.0,
+		`clock("14:30")`:                      52200,
+		`size(items) + items.count`:           6,
+		`items.exists(i, i.price > 15.0)`:     false,
+		`items.all(i, i.price > 6.0)`:         true,
+		`items.filter(i, len(trim(i.description)) % 3 == 0).count`:                  2,
+		`items.sum(i, len(trim(i.description)) % 3 == 0 ? ceil(i.price * 0.2) : 0)`: 6,
+		`items.sum(i, i.price)`:                               33.74,
+		`items.map(i, i.price > 10.0).count`:                  3,
+		`items.exists(i, items.exists(j, j.price > i.price))`: true,
+	}
+
+	for source, expected := range testCases {
+		program, err := Compile(source, env)
+		if !assert.NoError(t, err, source) {
+			continue
+		}
+		result, err := program.Eval(vars)
+		assert.NoError(t, err, source)
+		if f, ok := expected.(float64); ok {
+			assert.InDelta(t, f, result, 1e-9, source)
+			continue
+		}
+		assert.Equal(t, expected, result, source)
+	}
+}
+
+func TestCompile_Errors(t *testing.T) {
+	var testCases = map[string]string{
+		``:                                "position 1: unexpected end of expression",
+		`1 +`:                             "position 4: unexpected end of expression",
+		`(1 + 2`:                          "position 7: unexpected end of expression",
+		`1 2`:                             "position 3: unexpected 2",
+		`1 # 2`:                           "position 3: unexpected character '#'",
+		`"abc`:                            "position 1: unterminated string",
+		`price`:                           "position 1: undefined variable price",
+		`retailer + 1`:                    "position 10: operator + is not defined on string and int",
+		`retailer == 1`:                   "position 10: cannot compare string with int",
+		`items == items`:                  "position 7: cannot compare list<item> with list<item>",
+		`total ? 1 : 2`:                   "position 7: condition must be bool, got float",
+		`true ? 1 : "a"`:                  "position 6: branches have different types int and string",
+		`!total`:                          "position 1: operator ! is not defined on float",
+		`retailer.name`:                   "position 10: string has no field name",
+		`items.first`:                     "position 7: list<item> has no field first",
+		`retailer.matches(retailer)`:      "position 10: matches expects a literal pattern",
+		`retailer.matches("(")`:           "position 18: invalid pattern: error parsing regexp: missing closing ): `(`",
+		`retailer.size(1)`:                "position 10: string has no method size",
+		`items.sum(i, i.description)`:     "position 7: sum expects a number, got string",
+		`items.exists(1, true)`:           "position 14: exists expects a variable name as first argument",
+		`items.filter(i, i.price)`:        "position 7: filter expects a bool condition, got float",
+		`items.sort(i, i.price)`:          "position 7: list<item> has no method sort",
+		`now()`:                           "position 1: undefined function now",
+		`len(1)`:                          "position 1: len expects a string, got int",
+		`min(1)`:                          "position 1: min expects 2 arguments, got 1",
+		`clock("25:00")`:                  "position 7: invalid time of day \"25:00\"",
+		`items.sum(i, i.price) + i.price`: "position 25: undefined variable i",
+	}
+
+	for source, expected := range testCases {
+		_, err := Compile(source, env)
+		assert.EqualError(t, err, expected, source)
+	}
+
+	t.Run("Too long", func(t *testing.T) {
+		_, err := Compile(strings.Repeat("1+", MaxSourceLength)+"1", env)
+		assert.Error(t, err)
+	})
+
+	t.Run("Too deep", func(t *testing.T) {
+		_, err := Compile(strings.Repeat("(", 100)+"1"+strings.Repeat(")", 100), env)
+		assert.ErrorContains(t, err, "nested too deeply")
+	})
+}
+
+func TestProgram_EvalErrors(t *testing.T) {
+	var testCases = map[string]error{
+		`1 / (items.count - 3)`:                     ErrDivisionByZero,
+		`total / 0.0`:                               ErrDivisionByZero,
+		`9223372036854775807 + items.count`:         ErrOverflow,
+		`ceil(total * 1000000000000000000000000.0)`: ErrOutOfRange,
+	}
+	for source, expected := range testCases {
+		program, err := Compile(source, env)
+		if !assert.NoError(t, err, source) {
+			continue
+		}
+		_, err = program.Eval(vars)
+		assert.ErrorIs(t, err, expected, source)
+	}
+
+	t.Run("Missing variable", func(t *testing.T) {
+		program, _ := Compile(`total > 1.0`, env)
+		_, err := program.Eval(map[string]any{})
+		assert.EqualError(t, err, "variable total has no value")
+	})
+
+	t.Run("Invalid variable", func(t *testing.T) {
+		program, _ := Compile(`total > 1.0`, env)
+		_, err := program.Eval(map[string]any{"total": "1"})
+		assert.ErrorContains(t, err, "expression failed")
+	})
+}
+
+func TestProgram_Limits(t *testing.T) {
+	var many = make([]any, 1000)
+	for i := range many {
+		many[i] = map[string]any{"description": "item", "price": 1.0}
+	}
+	var source = `items.sum(i, items.filter(j, j.price >= i.price).count)`
+
+	t.Run("Steps", func(t *testing.T) {
+		program, err := Compile(source, env, WithMaxSteps(1000))
+		assert.NoError(t, err)
+		_, err = program.Eval(map[string]any{"items": many})
+		assert.True(t, errors.Is(err, ErrStepLimit))
+	})
+
+	t.Run("Time", func(t *testing.T) {
+		program, err := Compile(source, env, WithMaxSteps(1<<40), WithTimeout(time.Millisecond))
+		assert.NoError(t, err)
+		_, err = program.Eval(map[string]any{"items": many})
+		assert.True(t, errors.Is(err, ErrTimeout))
+	})
+
+	t.Run("Within limits", func(t *testing.T) {
+		program, err := Compile(source, env)
+		assert.NoError(t, err)
+		result, err := program.Eval(vars)
+		assert.NoError(t, err)
+		assert.Equal(t, 6, result)
+		assert.Equal(t, Int, program.Type())
+		assert.Equal(t, source, program.Source())
+	})
+}
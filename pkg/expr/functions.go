@@ -0,0 +1,347 @@
+package expr
+
+import (
+	"math"
+	"regexp"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+)
+
+// epsilon float errors ignored by ceil and floor, so 15 * 0.2 is 3 and not 3.0000000000000004
+const epsilon = 1e-9
+
+// function builds the closure of a function call with the compiled arguments
+type function struct {
+	args  int
+	build func(n *callNode, types []*Type, args []evalFn) (*Type, evalFn, error)
+}
+
+// functions built-in functions, alnum keeps only the ASCII letters and digits
+var functions = map[string]function{
+	"len":   {1, stringFunction(func(v string) any { return utf8.RuneCountInString(v) }, Int)},
+	"trim":  {1, stringFunction(func(v string) any { return strings.TrimSpace(v) }, String)},
+	"lower": {1, stringFunction(func(v string) any { return strings.ToLower(v) }, String)},
+	"upper": {1, stringFunction(func(v string) any { return strings.ToUpper(v) }, String)},
+	"alnum": {1, stringFunction(func(v string) any {
+		return strings.Map(func(r rune) rune {
+			if r < utf8.RuneSelf && (unicode.IsLetter(r) || unicode.IsDigit(r)) {
+				return r
+			}
+			return -1
+		}, v)
+	}, String)},
+	"size":  {1, sizeFunction},
+	"ceil":  {1, roundingFunction(func(f float64) float64 { return math.Ceil(f - epsilon) })},
+	"floor": {1, roundingFunction(func(f float64) float64 { return math.Floor(f + epsilon) })},
+	"round": {1, roundingFunction(math.Round)},
+	"int":   {1, roundingFunction(math.Trunc)},
+	"float": {1, floatFunction},
+	"abs":   {1, absFunction},
+	"min":   {2, extremeFunction(-1)},
+	"max":   {2, extremeFunction(1)},
+	"clock": {1, clockFunction},
+}
+
+func (c *compiler) function(n *callNode) (*Type, evalFn, error) {
+	fn, ok := functions[n.name]
+	if !ok {
+		return nil, nil, errorf(n.pos, "undefined function %s", n.name)
+	}
+	if len(n.args) != fn.args {
+		return nil, nil, errorf(n.pos, "%s expects %d arguments, got %d", n.name, fn.args, len(n.args))
+	}
+
+	var types = make([]*Type, len(n.args))
+	var args = make([]evalFn, len(n.args))
+	for i, arg := range n.args {
+		typ, eval, err := c.compile(arg)
+		if err != nil {
+			return nil, nil, err
+		}
+		types[i], args[i] = typ, eval
+	}
+	return fn.build(n, types, args)
+}
+
+func stringFunction(f func(v string) any, result *Type) func(n *callNode, types []*Type, args []evalFn) (*Type, evalFn, error) {
+	return func(n *callNode, types []*Type, args []evalFn) (*Type, evalFn, error) {
+		if types[0].Kind != KindString {
+			return nil, nil, errorf(n.pos, "%s expects a string, got %s", n.name, types[0])
+		}
+		return result, func(s *state) (any, error) {
+			v, err := args[0](s)
+			if err != nil {
+				return nil, err
+			}
+			return f(v.(string)), nil
+		}, nil
+	}
+}
+
+func sizeFunction(n *callNode, types []*Type, args []evalFn) (*Type, evalFn, error) {
+	if types[0].Kind != KindList {
+		return nil, nil, errorf(n.pos, "size expects a list, got %s", types[0])
+	}
+	return Int, func(s *state) (any, error) {
+		v, err := args[0](s)
+		if err != nil {
+			return nil, err
+		}
+		return len(v.([]any)), nil
+	}, nil
+}
+
+// roundingFunction converts a number to int with the rounding function, ints are returned as they are
+func roundingFunction(round func(f float64) float64) func(n *callNode, types []*Type, args []evalFn) (*Type, evalFn, error) {
+	return func(n *callNode, types []*Type, args []evalFn) (*Type, evalFn, error) {
+		if !types[0].numeric() {
+			return nil, nil, errorf(n.pos, "%s expects a number, got %s", n.name, types[0])
+		}
+		if types[0].Kind == KindInt {
+			return Int, args[0], nil
+		}
+		return Int, func(s *state) (any, error) {
+			v, err := args[0](s)
+			if err != nil {
+				return nil, err
+			}
+			return toInt(round(v.(float64)))
+		}, nil
+	}
+}
+
+func floatFunction(n *callNode, types []*Type, args []evalFn) (*Type, evalFn, error) {
+	if !types[0].numeric() {
+		return nil, nil, errorf(n.pos, "float expects a number, got %s", types[0])
+	}
+	return Float, convert(args[0], types[0], Float), nil
+}
+
+func absFunction(n *callNode, types []*Type, args []evalFn) (*Type, evalFn, error) {
+	if !types[0].numeric() {
+		return nil, nil, errorf(n.pos, "abs expects a number, got %s", types[0])
+	}
+	return types[0], func(s *state) (any, error) {
+		v, err := args[0](s)
+		if err != nil {
+			return nil, err
+		}
+		if i, ok := v.(int); ok {
+			if i == math.MinInt {
+				return nil, ErrOverflow
+			}
+			if i < 0 {
+				return -i, nil
+			}
+			return i, nil
+		}
+		return math.Abs(v.(float64)), nil
+	}, nil
+}
+
+// extremeFunction returns min when sign is -1 and max when it is 1
+func extremeFunction(sign int) func(n *callNode, types []*Type, args []evalFn) (*Type, evalFn, error) {
+	return func(n *callNode, types []*Type, args []evalFn) (*Type, evalFn, error) {
+		typ, ok := unify(types[0], types[1])
+		if !ok || !typ.numeric() {
+			return nil, nil, errorf(n.pos, "%s expects numbers, got %s and %s", n.name, types[0], types[1])
+		}
+		var x, y = convert(args[0], types[0], typ), convert(args[1], types[1], typ)
+		return typ, binaryFn(x, y, func(a, b any) (any, error) {
+			if compareNumbers(a, b)*sign >= 0 {
+				return a, nil
+			}
+			return b, nil
+		}), nil
+	}
+}
+
+// clockFunction returns the seconds since midnight of a literal time of day, HH:MM or HH:MM:SS
+func clockFunction(n *callNode, types []*Type, _ []evalFn) (*Type, evalFn, error) {
+	lit, ok := n.args[0].(*literalNode)
+	if !ok || lit.typ.Kind != KindString {
+		return nil, nil, errorf(n.pos, "clock expects a literal time of day")
+	}
+	var layout = "15:04"
+	if strings.Count(lit.value.(string), ":") == 2 {
+		layout = "15:04:05"
+	}
+	t, err := time.Parse(layout, lit.value.(string))
+	if err != nil {
+		return nil, nil, errorf(lit.pos, "invalid time of day %q", lit.value)
+	}
+	var seconds = t.Hour()*3600 + t.Minute()*60 + t.Second()
+	return Int, func(*state) (any, error) { return seconds, nil }, nil
+}
+
+func (c *compiler) method(n *callNode) (*Type, evalFn, error) {
+	typ, recv, err := c.compile(n.recv)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	switch {
+	case typ.Kind == KindString:
+		return c.stringMethod(n, recv)
+	case typ.Kind == KindList:
+		return c.listMacro(n, typ, recv)
+	default:
+		return nil, nil, errorf(n.pos, "%s has no method %s", typ, n.name)
+	}
+}
+
+func (c *compiler) stringMethod(n *callNode, recv evalFn) (*Type, evalFn, error) {
+	if len(n.args) != 1 {
+		return nil, nil, errorf(n.pos, "%s expects 1 argument, got %d", n.name, len(n.args))
+	}
+
+	var test func(v, arg string) bool
+	switch n.name {
+	case "matches":
+		// Patterns are compiled once, so they must be literals
+		lit, ok := n.args[0].(*literalNode)
+		if !ok || lit.typ.Kind != KindString {
+			return nil, nil, errorf(n.pos, "matches expects a literal pattern")
+		}
+		re, err := regexp.Compile(lit.value.(string))
+		if err != nil {
+			return nil, nil, errorf(lit.pos, "invalid pattern: %s", err)
+		}
+		return Bool, func(s *state) (any, error) {
+			v, err := recv(s)
+			if err != nil {
+				return nil, err
+			}
+			return re.MatchString(v.(string)), nil
+		}, nil
+	case "contains":
+		test = strings.Contains
+	case "startsWith":
+		test = strings.HasPrefix
+	case "endsWith":
+		test = strings.HasSuffix
+	default:
+		return nil, nil, errorf(n.pos, "string has no method %s", n.name)
+	}
+
+	typ, arg, err := c.compile(n.args[0])
+	if err != nil {
+		return nil, nil, err
+	}
+	if typ.Kind != KindString {
+		return nil, nil, errorf(n.pos, "%s expects a string, got %s", n.name, typ)
+	}
+	return Bool, binaryFn(recv, arg, func(a, b any) (any, error) { return test(a.(string), b.(string)), nil }), nil
+}
+
+// listMacro compiles list.exists(x, cond), list.all(x, cond), list.filter(x, cond), list.map(x, expr) and
+// list.sum(x, expr), where x is bound to every element of the list while evaluating the second argument
+func (c *compiler) listMacro(n *callNode, typ *Type, recv evalFn) (*Type, evalFn, error) {
+	if len(n.args) != 2 {
+		return nil, nil, errorf(n.pos, "%s expects 2 arguments, got %d", n.name, len(n.args))
+	}
+	variable, ok := n.args[0].(*identNode)
+	if !ok {
+		return nil, nil, errorf(n.args[0].position(), "%s expects a variable name as first argument", n.name)
+	}
+
+	var slot = len(c.scope)
+	c.scope = append(c.scope, local{name: variable.name, typ: typ.Elem})
+	c.maxLocals = max(c.maxLocals, len(c.scope))
+	bodyType, body, err := c.compile(n.args[1])
+	c.scope = c.scope[:slot]
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// each calls visit with the result of the body for every element until it returns false
+	var each = func(s *state, visit func(item, result any) (bool, error)) error {
+		v, err := recv(s)
+		if err != nil {
+			return err
+		}
+		for _, item := range v.([]any) {
+			s.locals[slot] = item
+			result, err := body(s)
+			if err != nil {
+				return err
+			}
+			next, err := visit(item, result)
+			if err != nil || !next {
+				return err
+			}
+		}
+		return nil
+	}
+
+	switch n.name {
+	case "exists", "all", "filter":
+		if bodyType.Kind != KindBool {
+			return nil, nil, errorf(n.pos, "%s expects a bool condition, got %s", n.name, bodyType)
+		}
+	case "sum":
+		if !bodyType.numeric() {
+			return nil, nil, errorf(n.pos, "sum expects a number, got %s", bodyType)
+		}
+	case "map":
+	default:
+		return nil, nil, errorf(n.pos, "%s has no method %s", typ, n.name)
+	}
+
+	switch n.name {
+	case "exists", "all":
+		var want = n.name == "exists"
+		return Bool, func(s *state) (any, error) {
+			var found = !want
+			err := each(s, func(_, result any) (bool, error) {
+				if result.(bool) == want {
+					found = want
+					return false, nil
+				}
+				return true, nil
+			})
+			return found, err
+		}, nil
+	case "filter", "map":
+		var resultType = typ
+		if n.name == "map" {
+			resultType = List(bodyType)
+		}
+		var filter = n.name == "filter"
+		return resultType, func(s *state) (any, error) {
+			var items = make([]any, 0)
+			err := each(s, func(item, result any) (bool, error) {
+				switch {
+				case !filter:
+					items = append(items, result)
+				case result.(bool):
+					items = append(items, item)
+				}
+				return true, nil
+			})
+			return items, err
+		}, nil
+	default:
+		return bodyType, func(s *state) (any, error) {
+			var total any = 0
+			if bodyType.Kind == KindFloat {
+				total = 0.0
+			}
+			err := each(s, func(_, result any) (bool, error) {
+				var err error
+				if bodyType.Kind == KindInt {
+					total, err = intArithmetic("+", total.(int), result.(int))
+				} else {
+					total, err = floatArithmetic("+", total.(float64), result.(float64))
+				}
+				return err == nil, err
+			})
+			if err != nil {
+				return nil, err
+			}
+			return total, nil
+		}, nil
+	}
+}
@@ -0,0 +1,141 @@
+package expr
+
+import (
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenInt
+	tokenFloat
+	tokenString
+	tokenIdent
+	tokenOperator
+)
+
+type token struct {
+	kind tokenKind
+	text string
+	pos  int
+	// value decoded value of the int, float and string literals
+	value any
+}
+
+var operators = []string{"&&", "||", "==", "!=", "<=", ">=", "<", ">", "+", "-", "*", "/", "%", "!", "?", ":", "(", ")", ",", "."}
+
+// lex splits the source in tokens, the last one is always tokenEOF
+func lex(src string) ([]token, error) {
+	var tokens []token
+	var pos = 0
+	for pos < len(src) {
+		r, size := utf8.DecodeRuneInString(src[pos:])
+		switch {
+		case unicode.IsSpace(r):
+			pos += size
+		case r >= '0' && r <= '9':
+			tok, err := lexNumber(src, pos)
+			if err != nil {
+				return nil, err
+			}
+			tokens = append(tokens, tok)
+			pos += len(tok.text)
+		case r == '"' || r == '\'':
+			tok, err := lexString(src, pos, r)
+			if err != nil {
+				return nil, err
+			}
+			tokens = append(tokens, tok)
+			pos += len(tok.text)
+		case r == '_' || unicode.IsLetter(r):
+			var end = pos
+			for end < len(src) {
+				r, size := utf8.DecodeRuneInString(src[end:])
+				if r != '_' && !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+					break
+				}
+				end += size
+			}
+			tokens = append(tokens, token{kind: tokenIdent, text: src[pos:end], pos: pos})
+			pos = end
+		default:
+			var op = ""
+			for _, candidate := range operators {
+				if strings.HasPrefix(src[pos:], candidate) {
+					op = candidate
+					break
+				}
+			}
+			if op == "" {
+				return nil, errorf(pos, "unexpected character %q", r)
+			}
+			tokens = append(tokens, token{kind: tokenOperator, text: op, pos: pos})
+			pos += len(op)
+		}
+	}
+	return append(tokens, token{kind: tokenEOF, pos: pos}), nil
+}
+
+func lexNumber(src string, pos int) (token, error) {
+	var end = pos
+	for end < len(src) && src[end] >= '0' && src[end] <= '9' {
+		end++
+	}
+	var isFloat = false
+	if end+1 < len(src) && src[end] == '.' && src[end+1] >= '0' && src[end+1] <= '9' {
+		isFloat = true
+		end++
+		for end < len(src) && src[end] >= '0' && src[end] <= '9' {
+			end++
+		}
+	}
+
+	var text = src[pos:end]
+	if isFloat {
+		value, err := strconv.ParseFloat(text, 64)
+		if err != nil {
+			return token{}, errorf(pos, "invalid number %s", text)
+		}
+		return token{kind: tokenFloat, text: text, pos: pos, value: value}, nil
+	}
+	value, err := strconv.Atoi(text)
+	if err != nil {
+		return token{}, errorf(pos, "invalid number %s", text)
+	}
+	return token{kind: tokenInt, text: text, pos: pos, value: value}, nil
+}
+
+func lexString(src string, pos int, quote rune) (token, error) {
+	var sb strings.Builder
+	var end = pos + 1
+	for end < len(src) {
+		var c = src[end]
+		switch {
+		case rune(c) == quote:
+			return token{kind: tokenString, text: src[pos : end+1], pos: pos, value: sb.String()}, nil
+		case c == '\\':
+			if end+1 >= len(src) {
+				return token{}, errorf(end, "unterminated string")
+			}
+			switch src[end+1] {
+			case 'n':
+				sb.WriteByte('\n')
+			case 't':
+				sb.WriteByte('\t')
+			case '\\', '"', '\'':
+				sb.WriteByte(src[end+1])
+			default:
+				return token{}, errorf(end, "invalid escape \\%c", src[end+1])
+			}
+			end += 2
+		default:
+			sb.WriteByte(c)
+			end++
+		}
+	}
+	return token{}, errorf(pos, "unterminated string")
+}
@@ -0,0 +1,282 @@
+package expr
+
+// maxDepth nesting limit of the expressions, it keeps the parser and the evaluation stack bounded
+const maxDepth = 64
+
+type node interface {
+	position() int
+}
+
+type literalNode struct {
+	pos   int
+	typ   *Type
+	value any
+}
+
+type identNode struct {
+	pos  int
+	name string
+}
+
+type unaryNode struct {
+	pos int
+	op  string
+	x   node
+}
+
+type binaryNode struct {
+	pos  int
+	op   string
+	x, y node
+}
+
+type conditionalNode struct {
+	pos             int
+	cond, then, els node
+}
+
+type selectorNode struct {
+	pos  int
+	x    node
+	name string
+}
+
+// callNode function call, or method call when recv is not nil
+type callNode struct {
+	pos  int
+	recv node
+	name string
+	args []node
+}
+
+func (n *literalNode) position() int     { return n.pos }
+func (n *identNode) position() int       { return n.pos }
+func (n *unaryNode) position() int       { return n.pos }
+func (n *binaryNode) position() int      { return n.pos }
+func (n *conditionalNode) position() int { return n.pos }
+func (n *selectorNode) position() int    { return n.pos }
+func (n *callNode) position() int        { return n.pos }
+
+// binaryPrecedence precedence of the binary operators, higher binds tighter
+var binaryPrecedence = map[string]int{
+	"||": 1,
+	"&&": 2,
+	"==": 3, "!=": 3,
+	"<": 4, "<=": 4, ">": 4, ">=": 4,
+	"+": 5, "-": 5,
+	"*": 6, "/": 6, "%": 6,
+}
+
+type parser struct {
+	tokens []token
+	pos    int
+	depth  int
+}
+
+func parse(src string) (node, error) {
+	tokens, err := lex(src)
+	if err != nil {
+		return nil, err
+	}
+	var p = &parser{tokens: tokens}
+	n, err := p.expression()
+	if err != nil {
+		return nil, err
+	}
+	if tok := p.peek(); tok.kind != tokenEOF {
+		return nil, errorf(tok.pos, "unexpected %s", tok.text)
+	}
+	return n, nil
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() token {
+	var tok = p.tokens[p.pos]
+	if tok.kind != tokenEOF {
+		p.pos++
+	}
+	return tok
+}
+
+func (p *parser) isOperator(op string) bool {
+	var tok = p.peek()
+	return tok.kind == tokenOperator && tok.text == op
+}
+
+func (p *parser) expect(op string) error {
+	if !p.isOperator(op) {
+		return p.unexpected()
+	}
+	p.next()
+	return nil
+}
+
+func (p *parser) unexpected() error {
+	var tok = p.peek()
+	if tok.kind == tokenEOF {
+		return errorf(tok.pos, "unexpected end of expression")
+	}
+	return errorf(tok.pos, "unexpected %s", tok.text)
+}
+
+// expression parses a conditional expression: cond ? then : else
+func (p *parser) expression() (node, error) {
+	p.depth++
+	defer func() { p.depth-- }()
+	if p.depth > maxDepth {
+		return nil, errorf(p.peek().pos, "expression is nested too deeply")
+	}
+
+	cond, err := p.binary(1)
+	if err != nil {
+		return nil, err
+	}
+	if !p.isOperator("?") {
+		return cond, nil
+	}
+	var pos = p.next().pos
+	then, err := p.expression()
+	if err != nil {
+		return nil, err
+	}
+	if err := p.expect(":"); err != nil {
+		return nil, err
+	}
+	els, err := p.expression()
+	if err != nil {
+		return nil, err
+	}
+	return &conditionalNode{pos: pos, cond: cond, then: then, els: els}, nil
+}
+
+// binary parses the binary operators with at least the given precedence, they are left associative
+func (p *parser) binary(precedence int) (node, error) {
+	x, err := p.unary()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		var tok = p.peek()
+		prec, ok := binaryPrecedence[tok.text]
+		if tok.kind != tokenOperator || !ok || prec < precedence {
+			return x, nil
+		}
+		p.next()
+		y, err := p.binary(prec + 1)
+		if err != nil {
+			return nil, err
+		}
+		x = &binaryNode{pos: tok.pos, op: tok.text, x: x, y: y}
+	}
+}
+
+func (p *parser) unary() (node, error) {
+	if p.isOperator("!") || p.isOperator("-") {
+		p.depth++
+		defer func() { p.depth-- }()
+		if p.depth > maxDepth {
+			return nil, errorf(p.peek().pos, "expression is nested too deeply")
+		}
+
+		var tok = p.next()
+		x, err := p.unary()
+		if err != nil {
+			return nil, err
+		}
+		return &unaryNode{pos: tok.pos, op: tok.text, x: x}, nil
+	}
+	return p.postfix()
+}
+
+// postfix parses the field selections and method calls following a primary expression
+func (p *parser) postfix() (node, error) {
+	x, err := p.primary()
+	if err != nil {
+		return nil, err
+	}
+	for p.isOperator(".") {
+		p.next()
+		var tok = p.peek()
+		if tok.kind != tokenIdent {
+			return nil, p.unexpected()
+		}
+		p.next()
+		if !p.isOperator("(") {
+			x = &selectorNode{pos: tok.pos, x: x, name: tok.text}
+			continue
+		}
+		args, err := p.arguments()
+		if err != nil {
+			return nil, err
+		}
+		x = &callNode{pos: tok.pos, recv: x, name: tok.text, args: args}
+	}
+	return x, nil
+}
+
+func (p *parser) primary() (node, error) {
+	var tok = p.peek()
+	if tok.kind == tokenEOF || tok.kind == tokenOperator && tok.text != "(" {
+		return nil, p.unexpected()
+	}
+	p.next()
+	switch tok.kind {
+	case tokenInt:
+		return &literalNode{pos: tok.pos, typ: Int, value: tok.value}, nil
+	case tokenFloat:
+		return &literalNode{pos: tok.pos, typ: Float, value: tok.value}, nil
+	case tokenString:
+		return &literalNode{pos: tok.pos, typ: String, value: tok.value}, nil
+	case tokenIdent:
+		switch {
+		case tok.text == "true" || tok.text == "false":
+			return &literalNode{pos: tok.pos, typ: Bool, value: tok.text == "true"}, nil
+		case p.isOperator("("):
+			args, err := p.arguments()
+			if err != nil {
+				return nil, err
+			}
+			return &callNode{pos: tok.pos, name: tok.text, args: args}, nil
+		default:
+			return &identNode{pos: tok.pos, name: tok.text}, nil
+		}
+	default:
+		x, err := p.expression()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expect(")"); err != nil {
+			return nil, err
+		}
+		return x, nil
+	}
+}
+
+// arguments parses a parenthesized list of comma separated expressions
+func (p *parser) arguments() ([]node, error) {
+	if err := p.expect("("); err != nil {
+		return nil, err
+	}
+	var args []node
+	if p.isOperator(")") {
+		p.next()
+		return args, nil
+	}
+	for {
+		arg, err := p.expression()
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, arg)
+		if p.isOperator(")") {
+			p.next()
+			return args, nil
+		}
+		if err := p.expect(","); err != nil {
+			return nil, err
+		}
+	}
+}
@@ -0,0 +1,136 @@
+package expr
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+const (
+	// DefaultMaxSteps evaluation steps allowed by default, every evaluated node is a step
+	DefaultMaxSteps = 10000
+	// DefaultTimeout evaluation time allowed by default
+	DefaultTimeout = 10 * time.Millisecond
+	// MaxSourceLength longest expression accepted
+	MaxSourceLength = 4096
+)
+
+var (
+	ErrStepLimit = errors.New("expression exceeded the step limit")
+	ErrTimeout   = errors.New("expression exceeded the time limit")
+)
+
+// Error compile error at a position (1-based) of the expression
+type Error struct {
+	Pos int
+	Msg string
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("position %d: %s", e.Pos, e.Msg)
+}
+
+func errorf(pos int, format string, args ...any) error {
+	return &Error{Pos: pos + 1, Msg: fmt.Sprintf(format, args...)}
+}
+
+// Program type checked expression ready to be evaluated many times
+type Program struct {
+	source   string
+	typ      *Type
+	eval     evalFn
+	locals   int
+	maxSteps int
+	timeout  time.Duration
+}
+
+type Option func(p *Program)
+
+// WithMaxSteps limits the evaluation steps, a non-positive value keeps the default
+func WithMaxSteps(steps int) Option {
+	return func(p *Program) {
+		if steps > 0 {
+			p.maxSteps = steps
+		}
+	}
+}
+
+// WithTimeout limits the evaluation time, a non-positive value keeps the default
+func WithTimeout(timeout time.Duration) Option {
+	return func(p *Program) {
+		if timeout > 0 {
+			p.timeout = timeout
+		}
+	}
+}
+
+// Compile parses and type checks the source with the variables declared in env
+func Compile(source string, env map[string]*Type, opts ...Option) (*Program, error) {
+	if len(source) > MaxSourceLength {
+		return nil, fmt.Errorf("expression is longer than %d characters", MaxSourceLength)
+	}
+	root, err := parse(source)
+	if err != nil {
+		return nil, err
+	}
+
+	var c = &compiler{env: env}
+	typ, eval, err := c.compile(root)
+	if err != nil {
+		return nil, err
+	}
+
+	var p = &Program{source: source, typ: typ, eval: eval, locals: c.maxLocals, maxSteps: DefaultMaxSteps, timeout: DefaultTimeout}
+	for _, opt := range opts {
+		opt(p)
+	}
+	return p, nil
+}
+
+// Source returns the expression the program was compiled from
+func (p *Program) Source() string {
+	return p.source
+}
+
+// Type returns the type of the values the program evaluates to
+func (p *Program) Type() *Type {
+	return p.typ
+}
+
+// Eval evaluates the program with the given variables, their values must match the types they were declared with
+func (p *Program) Eval(vars map[string]any) (result any, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			result, err = nil, fmt.Errorf("expression failed: %v", r)
+		}
+	}()
+
+	var s = &state{
+		vars:     vars,
+		locals:   make([]any, p.locals),
+		maxSteps: p.maxSteps,
+		deadline: time.Now().Add(p.timeout),
+	}
+	return p.eval(s)
+}
+
+// state of an evaluation
+type state struct {
+	vars     map[string]any
+	locals   []any
+	steps    int
+	maxSteps int
+	deadline time.Time
+}
+
+// step accounts an evaluation step, the clock is only checked every few steps
+func (s *state) step() error {
+	s.steps++
+	if s.steps > s.maxSteps {
+		return ErrStepLimit
+	}
+	if s.steps%64 == 0 && time.Now().After(s.deadline) {
+		return ErrTimeout
+	}
+	return nil
+}
@@ -0,0 +1,81 @@
+package expr
+
+import "fmt"
+
+// Kind of the values of the language
+type Kind int
+
+const (
+	KindInt Kind = iota
+	KindFloat
+	KindBool
+	KindString
+	KindList
+	KindObject
+)
+
+// Type static type of an expression. At runtime ints are int, floats float64, lists []any and objects map[string]any.
+type Type struct {
+	Kind   Kind
+	Elem   *Type
+	Name   string
+	Fields map[string]*Type
+}
+
+var (
+	Int    = &Type{Kind: KindInt}
+	Float  = &Type{Kind: KindFloat}
+	Bool   = &Type{Kind: KindBool}
+	String = &Type{Kind: KindString}
+)
+
+// List returns the type of the lists of elem
+func List(elem *Type) *Type {
+	return &Type{Kind: KindList, Elem: elem}
+}
+
+// Object returns a named type with the given fields
+func Object(name string, fields map[string]*Type) *Type {
+	return &Type{Kind: KindObject, Name: name, Fields: fields}
+}
+
+func (t *Type) String() string {
+	switch t.Kind {
+	case KindInt:
+		return "int"
+	case KindFloat:
+		return "float"
+	case KindBool:
+		return "bool"
+	case KindString:
+		return "string"
+	case KindList:
+		return fmt.Sprintf("list<%s>", t.Elem)
+	default:
+		return t.Name
+	}
+}
+
+// Equal returns true if both types are the same
+func (t *Type) Equal(other *Type) bool {
+	if t.Kind != other.Kind {
+		return false
+	}
+	switch t.Kind {
+	case KindList:
+		return t.Elem.Equal(other.Elem)
+	case KindObject:
+		return t.Name == other.Name
+	default:
+		return true
+	}
+}
+
+func (t *Type) numeric() bool {
+	return t.Kind == KindInt || t.Kind == KindFloat
+}
+
+// comparable returns true if the values of the type can be compared with == and !=
+func (t *Type) comparable() bool {
+	return t.Kind != KindList && t.Kind != KindObject
+}
@@ -0,0 +1,174 @@
+// Package jwt verifies the JSON Web Tokens signed with RS256, ES256 or HS256 against the keys of a JSON Web Key Set,
+// and validates their registered claims. Only the verification is supported, the tokens are issued by the identity
+// provider.
+package jwt
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+)
+
+// Signing algorithms
+const (
+	RS256 = "RS256"
+	ES256 = "ES256"
+	HS256 = "HS256"
+)
+
+const (
+	// minRSABits shortest RSA modulus accepted
+	minRSABits = 2048
+	// minHMACBytes shortest HMAC secret accepted, the size of the SHA-256 hash
+	minHMACBytes = 32
+)
+
+// Key verification key of a key set
+type Key struct {
+	// ID the kid of the key, tokens with a kid are only verified with the key of the same id
+	ID string
+	// Algorithm the only algorithm the key verifies
+	Algorithm string
+	key       any
+}
+
+// KeySet verification keys
+type KeySet struct {
+	keys []*Key
+}
+
+// jwk JSON Web Key, RFC 7517
+type jwk struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+	// RSA
+	N string `json:"n"`
+	E string `json:"e"`
+	// EC
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+	// oct
+	K string `json:"k"`
+}
+
+// ParseJWKS parses a JSON Web Key Set. The encryption keys are skipped, the keys of unsupported types or algorithms
+// are an error.
+func ParseJWKS(data []byte) (*KeySet, error) {
+	var jwks struct {
+		Keys []jwk `json:"keys"`
+	}
+	if err := json.Unmarshal(data, &jwks); err != nil {
+		return nil, fmt.Errorf("invalid JWKS: %w", err)
+	}
+
+	var set = &KeySet{}
+	for i, k := range jwks.Keys {
+		if k.Use != "" && k.Use != "sig" {
+			continue
+		}
+		key, err := parseJWK(k)
+		if err != nil {
+			return nil, fmt.Errorf("invalid JWKS key %d %s: %w", i, k.Kid, err)
+		}
+		set.keys = append(set.keys, key)
+	}
+	return set, nil
+}
+
+// parseJWK parses the public key or secret of a JWK
+func parseJWK(k jwk) (*Key, error) {
+	var key = &Key{ID: k.Kid}
+	switch k.Kty {
+	case "RSA":
+		key.Algorithm = RS256
+		n, err := decodeInt(k.N)
+		if err != nil {
+			return nil, fmt.Errorf("n: %w", err)
+		}
+		e, err := decodeInt(k.E)
+		if err != nil {
+			return nil, fmt.Errorf("e: %w", err)
+		}
+		if n.BitLen() < minRSABits {
+			return nil, fmt.Errorf("RSA keys must have at least %d bits", minRSABits)
+		}
+		if !e.IsInt64() || e.Int64() < 3 || e.Int64() > 1<<31-1 {
+			return nil, fmt.Errorf("invalid RSA exponent")
+		}
+		key.key = &rsa.PublicKey{N: n, E: int(e.Int64())}
+	case "EC":
+		key.Algorithm = ES256
+		if k.Crv != "P-256" {
+			return nil, fmt.Errorf("unsupported curve %q", k.Crv)
+		}
+		x, err := decodeInt(k.X)
+		if err != nil {
+			return nil, fmt.Errorf("x: %w", err)
+		}
+		y, err := decodeInt(k.Y)
+		if err != nil {
+			return nil, fmt.Errorf("y: %w", err)
+		}
+		if !elliptic.P256().IsOnCurve(x, y) {
+			return nil, fmt.Errorf("the point is not on the P-256 curve")
+		}
+		key.key = &ecdsa.PublicKey{Curve: elliptic.P256(), X: x, Y: y}
+	case "oct":
+		key.Algorithm = HS256
+		secret, err := base64.RawURLEncoding.DecodeString(k.K)
+		if err != nil {
+			return nil, fmt.Errorf("k: %w", err)
+		}
+		if len(secret) < minHMACBytes {
+			return nil, fmt.Errorf("HMAC secrets must have at least %d bytes", minHMACBytes)
+		}
+		key.key = secret
+	default:
+		return nil, fmt.Errorf("unsupported key type %q", k.Kty)
+	}
+	if k.Alg != "" && k.Alg != key.Algorithm {
+		return nil, fmt.Errorf("unsupported algorithm %q for %s keys", k.Alg, k.Kty)
+	}
+	return key, nil
+}
+
+// decodeInt decodes a base64url big-endian unsigned integer
+func decodeInt(value string) (*big.Int, error) {
+	if value == "" {
+		return nil, fmt.Errorf("missing value")
+	}
+	data, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, err
+	}
+	return new(big.Int).SetBytes(data), nil
+}
+
+// Add adds the keys of other to the key set
+func (ks *KeySet) Add(other *KeySet) {
+	ks.keys = append(ks.keys, other.keys...)
+}
+
+// Len returns the number of keys
+func (ks *KeySet) Len() int {
+	return len(ks.keys)
+}
+
+// candidates returns the keys that can verify a token signed with the algorithm by the key id, every key of the
+// algorithm when the token has no key id
+func (ks *KeySet) candidates(alg string, kid string) []*Key {
+	var keys []*Key
+	for _, key := range ks.keys {
+		if key.Algorithm == alg && (kid == "" || key.ID == kid) {
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
@@ -0,0 +1,188 @@
+package jwt
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/hmac"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	appErrors "github.com/kiramishima/receipt-processor/pkg/errors"
+	"github.com/samber/lo"
+)
+
+// Claims claims of a verified token, the numbers are json.Number
+type Claims map[string]any
+
+type header struct {
+	Alg string `json:"alg"`
+	Kid string `json:"kid"`
+	Typ string `json:"typ"`
+}
+
+// Verify checks the signature of a compact serialized token with the keys of the set and returns its claims. The
+// errors wrap errors.InvalidJWTToken.
+func (ks *KeySet) Verify(token string) (Claims, error) {
+	var parts = strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("%w: malformed token", appErrors.InvalidJWTToken)
+	}
+
+	var h header
+	if err := decodeSegment(parts[0], &h); err != nil {
+		return nil, fmt.Errorf("%w: malformed header", appErrors.InvalidJWTToken)
+	}
+	if h.Alg != RS256 && h.Alg != ES256 && h.Alg != HS256 {
+		return nil, fmt.Errorf("%w: unsupported algorithm %q", appErrors.InvalidJWTToken, h.Alg)
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, fmt.Errorf("%w: malformed signature", appErrors.InvalidJWTToken)
+	}
+
+	var keys = ks.candidates(h.Alg, h.Kid)
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("%w: no %s key with id %q", appErrors.InvalidJWTToken, h.Alg, h.Kid)
+	}
+	var signed = []byte(parts[0] + "." + parts[1])
+	var verified bool
+	for _, key := range keys {
+		if verified = key.verify(signed, signature); verified {
+			break
+		}
+	}
+	if !verified {
+		return nil, fmt.Errorf("%w: invalid signature", appErrors.InvalidJWTToken)
+	}
+
+	var claims Claims
+	if err := decodeSegment(parts[1], &claims); err != nil || claims == nil {
+		return nil, fmt.Errorf("%w: malformed claims", appErrors.InvalidJWTToken)
+	}
+	return claims, nil
+}
+
+// verify checks the signature of the signed data with the key
+func (k *Key) verify(signed []byte, signature []byte) bool {
+	var digest = sha256.Sum256(signed)
+	switch key := k.key.(type) {
+	case *rsa.PublicKey:
+		return rsa.VerifyPKCS1v15(key, crypto.SHA256, digest[:], signature) == nil
+	case *ecdsa.PublicKey:
+		// JWS ECDSA signatures are the 32 bytes of r followed by the 32 bytes of s
+		if len(signature) != 64 {
+			return false
+		}
+		var r, s = new(big.Int).SetBytes(signature[:32]), new(big.Int).SetBytes(signature[32:])
+		return ecdsa.Verify(key, digest[:], r, s)
+	case []byte:
+		var mac = hmac.New(sha256.New, key)
+		mac.Write(signed)
+		return hmac.Equal(mac.Sum(nil), signature)
+	default:
+		return false
+	}
+}
+
+// decodeSegment decodes a base64url JSON segment, keeping the numbers as json.Number
+func decodeSegment(segment string, v any) error {
+	data, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return err
+	}
+	var decoder = json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	return decoder.Decode(v)
+}
+
+// String returns the claim when it is a string
+func (c Claims) String(name string) string {
+	value, _ := c[name].(string)
+	return value
+}
+
+// Strings returns the claim when it is a string or an array of strings
+func (c Claims) Strings(name string) []string {
+	switch value := c[name].(type) {
+	case string:
+		return []string{value}
+	case []any:
+		var values = make([]string, 0, len(value))
+		for _, item := range value {
+			if s, ok := item.(string); ok {
+				values = append(values, s)
+			}
+		}
+		return values
+	default:
+		return nil
+	}
+}
+
+// Time returns the NumericDate claim, false when it is missing
+func (c Claims) Time(name string) (time.Time, bool, error) {
+	value, ok := c[name]
+	if !ok {
+		return time.Time{}, false, nil
+	}
+	number, ok := value.(json.Number)
+	if !ok {
+		return time.Time{}, false, fmt.Errorf("%w: %s is not a number", appErrors.InvalidJWTClaims, name)
+	}
+	seconds, err := number.Float64()
+	if err != nil {
+		return time.Time{}, false, fmt.Errorf("%w: %s is not a number", appErrors.InvalidJWTClaims, name)
+	}
+	return time.Unix(0, int64(seconds*float64(time.Second))).UTC(), true, nil
+}
+
+// Validator validates the registered claims of the tokens
+type Validator struct {
+	// Issuer the iss the tokens must have, any when empty
+	Issuer string
+	// Audience the tokens must have in their aud, any when empty
+	Audience string
+	// Leeway clock skew tolerated between the issuer and the service
+	Leeway time.Duration
+}
+
+// Validate checks the token has not expired at now and is already valid, and its issuer and audience. The exp claim
+// is required. The errors wrap errors.InvalidJWTClaims.
+func (v Validator) Validate(claims Claims, now time.Time) error {
+	exp, ok, err := claims.Time("exp")
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return fmt.Errorf("%w: missing exp", appErrors.InvalidJWTClaims)
+	}
+	if !now.Before(exp.Add(v.Leeway)) {
+		return fmt.Errorf("%w: token expired at %s", appErrors.InvalidJWTClaims, exp.Format(time.RFC3339))
+	}
+	for _, name := range []string{"nbf", "iat"} {
+		value, ok, err := claims.Time(name)
+		if err != nil {
+			return err
+		}
+		if ok && now.Add(v.Leeway).Before(value) {
+			return fmt.Errorf("%w: token not valid before %s", appErrors.InvalidJWTClaims, value.Format(time.RFC3339))
+		}
+	}
+
+	if v.Issuer != "" && claims.String("iss") != v.Issuer {
+		return fmt.Errorf("%w: unexpected issuer %q", appErrors.InvalidJWTClaims, claims.String("iss"))
+	}
+	if v.Audience != "" {
+		if !lo.Contains(claims.Strings("aud"), v.Audience) {
+			return fmt.Errorf("%w: the token is not for the audience %q", appErrors.InvalidJWTClaims, v.Audience)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,164 @@
+package jwt
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	appErrors "github.com/kiramishima/receipt-processor/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+var b64 = base64.RawURLEncoding.EncodeToString
+
+// sign serializes a token signed with the key
+func sign(t *testing.T, alg string, kid string, key any, claims map[string]any) string {
+	headerJSON, _ := json.Marshal(map[string]string{"alg": alg, "kid": kid, "typ": "JWT"})
+	claimsJSON, _ := json.Marshal(claims)
+	var signed = b64(headerJSON) + "." + b64(claimsJSON)
+	var digest = sha256.Sum256([]byte(signed))
+
+	var signature []byte
+	switch key := key.(type) {
+	case *rsa.PrivateKey:
+		var err error
+		signature, err = rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, digest[:])
+		assert.NoError(t, err)
+	case *ecdsa.PrivateKey:
+		r, s, err := ecdsa.Sign(rand.Reader, key, digest[:])
+		assert.NoError(t, err)
+		signature = append(r.FillBytes(make([]byte, 32)), s.FillBytes(make([]byte, 32))...)
+	case []byte:
+		var mac = hmac.New(sha256.New, key)
+		mac.Write([]byte(signed))
+		signature = mac.Sum(nil)
+	}
+	return signed + "." + b64(signature)
+}
+
+func TestKeySet_Verify(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NoError(t, err)
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+	var secret = []byte("0123456789abcdef0123456789abcdef")
+
+	var jwks = fmt.Sprintf(`{"keys": [
+		{"kty": "RSA", "kid": "rsa-1", "use": "sig", "alg": "RS256", "n": %q, "e": %q},
+		{"kty": "EC", "kid": "ec-1", "crv": "P-256", "x": %q, "y": %q},
+		{"kty": "oct", "kid": "hmac-1", "k": %q},
+		{"kty": "RSA", "kid": "enc-1", "use": "enc", "n": "AQAB", "e": "AQAB"}
+	]}`, b64(rsaKey.N.Bytes()), b64(big.NewInt(int64(rsaKey.E)).Bytes()), b64(ecKey.X.Bytes()), b64(ecKey.Y.Bytes()), b64(secret))
+	keys, err := ParseJWKS([]byte(jwks))
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, 3, keys.Len())
+
+	var claims = map[string]any{"sub": "member-1", "exp": time.Now().Add(time.Hour).Unix()}
+	var otherRSAKey, _ = rsa.GenerateKey(rand.Reader, 2048)
+	var valid = sign(t, RS256, "rsa-1", rsaKey, claims)
+	claimsJSON, _ := json.Marshal(claims)
+
+	testCases := map[string]struct {
+		token string
+		valid bool
+	}{
+		"RS256":                   {token: valid, valid: true},
+		"ES256":                   {token: sign(t, ES256, "ec-1", ecKey, claims), valid: true},
+		"HS256":                   {token: sign(t, HS256, "hmac-1", secret, claims), valid: true},
+		"Without kid":             {token: sign(t, RS256, "", rsaKey, claims), valid: true},
+		"Unknown kid":             {token: sign(t, RS256, "rsa-2", rsaKey, claims), valid: false},
+		"Other key":               {token: sign(t, RS256, "rsa-1", otherRSAKey, claims), valid: false},
+		"Algorithm of other key":  {token: sign(t, HS256, "rsa-1", secret, claims), valid: false},
+		"HMAC with the RSA key":   {token: sign(t, HS256, "", rsaKey.N.Bytes(), claims), valid: false},
+		"None":                    {token: b64([]byte(`{"alg":"none"}`)) + "." + b64([]byte(`{"sub":"member-1"}`)) + ".", valid: false},
+		"Tampered signature":      {token: valid[:len(valid)-10] + "AAAAAAAAAA", valid: false},
+		"Tampered claims":         {token: strings.Replace(valid, b64(claimsJSON), b64([]byte(`{"sub":"member-2"}`)), 1), valid: false},
+		"Malformed":               {token: "abc.def", valid: false},
+		"Encryption key excluded": {token: sign(t, RS256, "enc-1", rsaKey, claims), valid: false},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			verified, err := keys.Verify(tc.token)
+			if tc.valid {
+				assert.NoError(t, err)
+				assert.Equal(t, "member-1", verified.String("sub"))
+			} else {
+				assert.ErrorIs(t, err, appErrors.InvalidJWTToken)
+			}
+		})
+	}
+}
+
+func TestParseJWKS_Invalid(t *testing.T) {
+	testCases := map[string]string{
+		"Not JSON":         `keys`,
+		"Short RSA key":    `{"keys": [{"kty": "RSA", "n": "AQAB", "e": "AQAB"}]}`,
+		"Short secret":     `{"keys": [{"kty": "oct", "k": "c2VjcmV0"}]}`,
+		"Unsupported type": `{"keys": [{"kty": "OKP", "crv": "Ed25519", "x": "AQAB"}]}`,
+		"Other curve":      `{"keys": [{"kty": "EC", "crv": "P-384", "x": "AQAB", "y": "AQAB"}]}`,
+		"Not on curve":     `{"keys": [{"kty": "EC", "crv": "P-256", "x": "AQAB", "y": "AQAB"}]}`,
+		"Other algorithm":  `{"keys": [{"kty": "oct", "alg": "HS512", "k": "MDEyMzQ1Njc4OWFiY2RlZjAxMjM0NTY3ODlhYmNkZWY"}]}`,
+	}
+
+	for name, jwks := range testCases {
+		t.Run(name, func(t *testing.T) {
+			_, err := ParseJWKS([]byte(jwks))
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestValidator_Validate(t *testing.T) {
+	var now = time.Date(2022, 3, 20, 14, 33, 0, 0, time.UTC)
+	var validator = Validator{Issuer: "https://id.example.com", Audience: "receipt-processor", Leeway: time.Minute}
+	var number = func(t time.Time) json.Number { return json.Number(fmt.Sprint(t.Unix())) }
+	var claims = func(extra Claims) Claims {
+		var c = Claims{"iss": "https://id.example.com", "aud": "receipt-processor", "exp": number(now.Add(time.Hour))}
+		for k, v := range extra {
+			c[k] = v
+		}
+		return c
+	}
+
+	testCases := map[string]struct {
+		claims Claims
+		valid  bool
+	}{
+		"Valid":                  {claims: claims(nil), valid: true},
+		"Audience array":         {claims: claims(Claims{"aud": []any{"app", "receipt-processor"}}), valid: true},
+		"Expired within leeway":  {claims: claims(Claims{"exp": number(now.Add(-30 * time.Second))}), valid: true},
+		"Expired":                {claims: claims(Claims{"exp": number(now.Add(-2 * time.Minute))}), valid: false},
+		"Missing exp":            {claims: Claims{"iss": "https://id.example.com", "aud": "receipt-processor"}, valid: false},
+		"Exp not a number":       {claims: claims(Claims{"exp": "tomorrow"}), valid: false},
+		"Not before with leeway": {claims: claims(Claims{"nbf": number(now.Add(30 * time.Second))}), valid: true},
+		"Not before":             {claims: claims(Claims{"nbf": number(now.Add(2 * time.Minute))}), valid: false},
+		"Issued in the future":   {claims: claims(Claims{"iat": number(now.Add(2 * time.Minute))}), valid: false},
+		"Other issuer":           {claims: claims(Claims{"iss": "https://evil.example.com"}), valid: false},
+		"Other audience":         {claims: claims(Claims{"aud": []any{"app"}}), valid: false},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			err := validator.Validate(tc.claims, now)
+			if tc.valid {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, appErrors.InvalidJWTClaims)
+			}
+		})
+	}
+}
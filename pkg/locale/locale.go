@@ -0,0 +1,245 @@
+package locale
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode"
+)
+
+const (
+	// Canonical the profile of the receipts without locale, ISO dates, 24-hour times and dot decimals
+	Canonical = "iso"
+	// Auto the profile accepting the formats of every locale, the inputs that can be read in more than one way are
+	// rejected as ambiguous
+	Auto = "auto"
+)
+
+var (
+	ErrAmbiguous     = errors.New("ambiguous input")
+	ErrInvalid       = errors.New("invalid input")
+	ErrUnknownLocale = errors.New("unknown locale")
+)
+
+// Profile formats of the dates, times and amounts of a locale
+type Profile struct {
+	Name        string
+	DateLayouts []string
+	TimeLayouts []string
+	// Decimal separator of the amounts, 0 accepts the dot and the comma when only one of them is a decimal separator
+	Decimal rune
+	// Groups separators of the thousands accepted
+	Groups []rune
+}
+
+var (
+	dayFirst   = []string{"2006-01-02", "2/1/2006"}
+	monthFirst = []string{"2006-01-02", "1/2/2006"}
+	clock24    = []string{"15:04"}
+	clock12    = []string{"15:04", "3:04 PM", "3:04PM"}
+	spaces     = []rune{' ', '\u00a0', '\u202f'}
+)
+
+// profiles the profiles by lowercase name
+var profiles = map[string]*Profile{
+	Canonical: {Name: Canonical, DateLayouts: []string{"2006-01-02"}, TimeLayouts: clock24, Decimal: '.'},
+	Auto: {
+		Name:        Auto,
+		DateLayouts: []string{"2006-01-02", "2/1/2006", "1/2/2006", "2.1.2006"},
+		TimeLayouts: []string{"15:04", "3:04 PM", "3:04PM", "15.04", "15H04"},
+		Groups:      append([]rune{'.', ','}, spaces...),
+	},
+	"en-us": {Name: "en-US", DateLayouts: monthFirst, TimeLayouts: clock12, Decimal: '.', Groups: []rune{','}},
+	"en-ca": {Name: "en-CA", DateLayouts: []string{"2006-01-02", "2/1/2006"}, TimeLayouts: clock12, Decimal: '.', Groups: []rune{','}},
+	"en-gb": {Name: "en-GB", DateLayouts: dayFirst, TimeLayouts: clock12, Decimal: '.', Groups: []rune{','}},
+	"es-mx": {Name: "es-MX", DateLayouts: dayFirst, TimeLayouts: clock12, Decimal: '.', Groups: []rune{','}},
+	"es-es": {Name: "es-ES", DateLayouts: dayFirst, TimeLayouts: clock24, Decimal: ',', Groups: []rune{'.'}},
+	"fr-fr": {Name: "fr-FR", DateLayouts: dayFirst, TimeLayouts: []string{"15:04", "15H04"}, Decimal: ',', Groups: spaces},
+	"fr-ca": {Name: "fr-CA", DateLayouts: []string{"2006-01-02", "2/1/2006"}, TimeLayouts: []string{"15:04", "15H04"}, Decimal: ',', Groups: spaces},
+	"de-de": {Name: "de-DE", DateLayouts: []string{"2006-01-02", "2.1.2006"}, TimeLayouts: []string{"15:04", "15.04"}, Decimal: ',', Groups: []rune{'.'}},
+	"it-it": {Name: "it-IT", DateLayouts: dayFirst, TimeLayouts: []string{"15:04", "15.04"}, Decimal: ',', Groups: []rune{'.'}},
+	"pt-br": {Name: "pt-BR", DateLayouts: dayFirst, TimeLayouts: clock24, Decimal: ',', Groups: []rune{'.'}},
+	"nl-nl": {Name: "nl-NL", DateLayouts: []string{"2006-01-02", "2-1-2006"}, TimeLayouts: clock24, Decimal: ',', Groups: []rune{'.'}},
+}
+
+// languages the profile of the tags with only the language or with a region without profile
+var languages = map[string]string{
+	"en": "en-us",
+	"es": "es-es",
+	"fr": "fr-fr",
+	"de": "de-de",
+	"it": "it-it",
+	"pt": "pt-br",
+	"nl": "nl-nl",
+}
+
+// Lookup returns the profile of a locale tag like de-DE, tags of regions without profile use the profile of the
+// language. An empty tag is the canonical profile.
+func Lookup(tag string) (*Profile, error) {
+	var name = strings.ToLower(strings.ReplaceAll(strings.TrimSpace(tag), "_", "-"))
+	if name == "" {
+		name = Canonical
+	}
+	if profile, ok := profiles[name]; ok {
+		return profile, nil
+	}
+	language, _, _ := strings.Cut(name, "-")
+	if profile, ok := profiles[languages[language]]; ok {
+		return profile, nil
+	}
+	return nil, fmt.Errorf("%w: %s", ErrUnknownLocale, tag)
+}
+
+// ParseDateTime parses the date and time of the day with the layouts of the profile in the location
+func (p *Profile) ParseDateTime(date string, clock string, loc *time.Location) (time.Time, error) {
+	day, err := p.ParseDate(date)
+	if err != nil {
+		return time.Time{}, err
+	}
+	hour, err := p.ParseTime(clock)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return time.Date(day.Year(), day.Month(), day.Day(), hour.Hour(), hour.Minute(), 0, 0, loc), nil
+}
+
+// ParseDate parses a date with the layouts of the profile
+func (p *Profile) ParseDate(date string) (time.Time, error) {
+	return parse("date", strings.TrimSpace(date), p.DateLayouts, "2006-01-02")
+}
+
+// ParseTime parses a time of the day with the layouts of the profile
+func (p *Profile) ParseTime(clock string) (time.Time, error) {
+	var normalized = strings.NewReplacer("A.M.", "AM", "P.M.", "PM", "A. M.", "AM", "P. M.", "PM").
+		Replace(strings.ToUpper(strings.TrimSpace(clock)))
+	return parse("time", normalized, p.TimeLayouts, "15:04")
+}
+
+// parse parses the value with every layout, the values read differently by two layouts are ambiguous
+func parse(kind string, value string, layouts []string, canonical string) (time.Time, error) {
+	var result time.Time
+	var found = false
+	for _, layout := range layouts {
+		t, err := time.Parse(layout, value)
+		if err != nil {
+			continue
+		}
+		if found && !t.Equal(result) {
+			return time.Time{}, fmt.Errorf("%w: %s %s can be %s or %s", ErrAmbiguous, kind, value, result.Format(canonical), t.Format(canonical))
+		}
+		result, found = t, true
+	}
+	if !found {
+		return time.Time{}, fmt.Errorf("%w: %s %s", ErrInvalid, kind, value)
+	}
+	return result, nil
+}
+
+// ParseAmount returns the amount in the canonical format, without group separators and with a dot decimal separator
+func (p *Profile) ParseAmount(amount string) (string, error) {
+	var value = strings.TrimSpace(amount)
+	var sign = ""
+	if strings.HasPrefix(value, "-") || strings.HasPrefix(value, "+") {
+		sign, value = strings.TrimPrefix(value[:1], "+"), value[1:]
+	}
+
+	var decimal = p.Decimal
+	if decimal == 0 {
+		var err error
+		if decimal, err = detectDecimal(value); err != nil {
+			return "", fmt.Errorf("%w: amount %s", err, amount)
+		}
+	}
+
+	integer, fraction, hasFraction := strings.Cut(value, string(decimal))
+	if hasFraction && (fraction == "" || !digits(fraction)) {
+		return "", fmt.Errorf("%w: amount %s", ErrInvalid, amount)
+	}
+	integer, ok := p.ungroup(integer)
+	if !ok {
+		return "", fmt.Errorf("%w: amount %s", ErrInvalid, amount)
+	}
+	if hasFraction {
+		return sign + integer + "." + fraction, nil
+	}
+	return sign + integer, nil
+}
+
+// detectDecimal returns the decimal separator of an amount in the auto profile. With both a dot and a comma the last
+// one is the decimal separator and a separator repeated is a group separator. A single separator followed by 3 digits
+// can be a group separator too.
+func detectDecimal(value string) (rune, error) {
+	var dot, comma = strings.LastIndex(value, "."), strings.LastIndex(value, ",")
+	switch {
+	case dot >= 0 && comma >= 0:
+		if dot > comma {
+			return '.', nil
+		}
+		return ',', nil
+	case dot < 0 && comma < 0:
+		return '.', nil
+	}
+
+	var separator, last = '.', dot
+	if comma >= 0 {
+		separator, last = ',', comma
+	}
+	switch {
+	case strings.Count(value, string(separator)) > 1:
+		// 1,234,567 has only group separators
+		if separator == '.' {
+			return ',', nil
+		}
+		return '.', nil
+	case len(value)-last-1 == 3 && last <= 3 && strings.Trim(value[:last], "0") != "":
+		// 1,234 can be a group of thousands, 1234,567 and 0,125 can't
+		return 0, ErrAmbiguous
+	default:
+		return separator, nil
+	}
+}
+
+// ungroup removes the group separators of the integer part, all of them must be the same and the groups after the
+// first must have 3 digits
+func (p *Profile) ungroup(integer string) (string, bool) {
+	var separator rune
+	for _, r := range integer {
+		switch {
+		case !containsRune(p.Groups, r):
+		case separator == 0:
+			separator = r
+		case separator != r:
+			return "", false
+		}
+	}
+	if separator == 0 {
+		return integer, digits(integer)
+	}
+
+	var groups = strings.Split(integer, string(separator))
+	for i, group := range groups {
+		if !digits(group) || (i > 0 && len(group) != 3) || len(group) > 3 {
+			return "", false
+		}
+	}
+	return strings.Join(groups, ""), true
+}
+
+func digits(s string) bool {
+	for _, r := range s {
+		if !unicode.IsDigit(r) || r > unicode.MaxASCII {
+			return false
+		}
+	}
+	return s != ""
+}
+
+func containsRune(runes []rune, r rune) bool {
+	for _, candidate := range runes {
+		if candidate == r {
+			return true
+		}
+	}
+	return false
+}
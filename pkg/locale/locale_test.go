@@ -0,0 +1,126 @@
+package locale
+
+import (
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestLookup(t *testing.T) {
+	var testCases = map[string]string{
+		"":      Canonical,
+		"iso":   Canonical,
+		"AUTO":  Auto,
+		"de-DE": "de-DE",
+		"de_de": "de-DE",
+		"de-AT": "de-DE",
+		"fr":    "fr-FR",
+		"en-GB": "en-GB",
+		"en-AU": "en-US",
+	}
+	for tag, expected := range testCases {
+		profile, err := Lookup(tag)
+		if assert.NoError(t, err, tag) {
+			assert.Equal(t, expected, profile.Name, tag)
+		}
+	}
+
+	_, err := Lookup("xx-YY")
+	assert.ErrorIs(t, err, ErrUnknownLocale)
+}
+
+func TestProfile_ParseDateTime(t *testing.T) {
+	var testCases = map[[3]string]string{
+		{"iso", "2022-03-18", "14:33"}:       "2022-03-18 14:33",
+		{"en-US", "03/18/2022", "2:33 PM"}:   "2022-03-18 14:33",
+		{"en-US", "3/4/2022", "2:33pm"}:      "2022-03-04 14:33",
+		{"en-US", "2022-03-18", "14:33"}:     "2022-03-18 14:33",
+		{"en-GB", "18/03/2022", "2:33 PM"}:   "2022-03-18 14:33",
+		{"es-MX", "03/04/2022", "2:33 p.m."}: "2022-04-03 14:33",
+		{"de-DE", "18.03.2022", "14.33"}:     "2022-03-18 14:33",
+		{"fr-FR", "18/03/2022", "14h33"}:     "2022-03-18 14:33",
+		{"nl-NL", "18-3-2022", "14:33"}:      "2022-03-18 14:33",
+		{"auto", "18/03/2022", "2:33 PM"}:    "2022-03-18 14:33",
+		{"auto", "03/18/2022", "14:33"}:      "2022-03-18 14:33",
+		{"auto", "04/04/2022", "14:33"}:      "2022-04-04 14:33",
+		{"auto", "18.03.2022", "14.33"}:      "2022-03-18 14:33",
+	}
+	var loc, _ = time.LoadLocation("Europe/Berlin")
+	for input, expected := range testCases {
+		profile, _ := Lookup(input[0])
+		result, err := profile.ParseDateTime(input[1], input[2], loc)
+		if assert.NoError(t, err, input) {
+			assert.Equal(t, expected, result.Format("2006-01-02 15:04"), input)
+			assert.Equal(t, loc, result.Location(), input)
+		}
+	}
+
+	var invalid = map[[3]string]error{
+		{"iso", "18/03/2022", "14:33"}:      ErrInvalid,
+		{"iso", "2022-03-18", "2:33 PM"}:    ErrInvalid,
+		{"en-US", "18/03/2022", "14:33"}:    ErrInvalid,
+		{"en-GB", "03/18/2022", "14:33"}:    ErrInvalid,
+		{"de-DE", "18/03/2022", "14:33"}:    ErrInvalid,
+		{"en-US", "03/18/2022", "14:33 PM"}: ErrInvalid,
+		{"auto", "03/04/2022", "14:33"}:     ErrAmbiguous,
+	}
+	for input, expected := range invalid {
+		profile, _ := Lookup(input[0])
+		_, err := profile.ParseDateTime(input[1], input[2], time.UTC)
+		assert.ErrorIs(t, err, expected, input)
+	}
+
+	profile, _ := Lookup(Auto)
+	_, err := profile.ParseDateTime("03/04/2022", "14:33", time.UTC)
+	assert.EqualError(t, err, "ambiguous input: date 03/04/2022 can be 2022-04-03 or 2022-03-04")
+}
+
+func TestProfile_ParseAmount(t *testing.T) {
+	var testCases = map[[2]string]string{
+		{"iso", "35.35"}:          "35.35",
+		{"iso", "-0.50"}:          "-0.50",
+		{"iso", "100"}:            "100",
+		{"en-US", "1,234.56"}:     "1234.56",
+		{"en-US", "+9.00"}:        "9.00",
+		{"de-DE", "9,00"}:         "9.00",
+		{"de-DE", "1.234.567,89"}: "1234567.89",
+		{"fr-FR", "1 234,56"}:     "1234.56",
+		{"fr-FR", "1 234,56"}:     "1234.56",
+		{"auto", "9,00"}:          "9.00",
+		{"auto", "9.00"}:          "9.00",
+		{"auto", "1.234,56"}:      "1234.56",
+		{"auto", "1,234.56"}:      "1234.56",
+		{"auto", "1,234,567"}:     "1234567",
+		{"auto", "0,125"}:         "0.125",
+		{"auto", "1234.567"}:      "1234.567",
+		{"auto", "1 234"}:         "1234",
+	}
+	for input, expected := range testCases {
+		profile, _ := Lookup(input[0])
+		amount, err := profile.ParseAmount(input[1])
+		if assert.NoError(t, err, input) {
+			assert.Equal(t, expected, amount, input)
+		}
+	}
+
+	var invalid = map[[2]string]error{
+		{"iso", "9,00"}:       ErrInvalid,
+		{"iso", "1e3"}:        ErrInvalid,
+		{"en-US", "9,00"}:     ErrInvalid,
+		{"de-DE", "9.00"}:     ErrInvalid,
+		{"de-DE", "1.23,00"}:  ErrInvalid,
+		{"de-DE", "9,"}:       ErrInvalid,
+		{"fr-FR", "1 234.56"}: ErrInvalid,
+		{"auto", "1.234 567"}: ErrInvalid,
+		{"auto", "$9.00"}:     ErrInvalid,
+		{"auto", ""}:          ErrInvalid,
+		{"auto", "1,234"}:     ErrAmbiguous,
+		{"auto", "1.234"}:     ErrAmbiguous,
+	}
+	for input, expected := range invalid {
+		profile, _ := Lookup(input[0])
+		_, err := profile.ParseAmount(input[1])
+		assert.True(t, errors.Is(err, expected), "%v: %v", input, err)
+	}
+}
@@ -0,0 +1,32 @@
+// Package logging scopes the fields of the logs to the context of the request, like the request, receipt and member
+// ids, so every log line of the request carries them with the ids of its trace and span.
+package logging
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+type fieldsKey struct{}
+
+// With returns a copy of the context with the key value pairs added to the fields of its logs
+func With(ctx context.Context, keysAndValues ...any) context.Context {
+	var fields, _ = ctx.Value(fieldsKey{}).([]any)
+	var merged = make([]any, 0, len(fields)+len(keysAndValues))
+	merged = append(append(merged, fields...), keysAndValues...)
+	return context.WithValue(ctx, fieldsKey{}, merged)
+}
+
+// Logger returns the logger with the fields of the context and the trace and span ids of its span
+func Logger(ctx context.Context, logger *zap.SugaredLogger) *zap.SugaredLogger {
+	var fields, _ = ctx.Value(fieldsKey{}).([]any)
+	if spanContext := trace.SpanContextFromContext(ctx); spanContext.IsValid() {
+		fields = append(fields[:len(fields):len(fields)], "traceId", spanContext.TraceID().String(), "spanId", spanContext.SpanID().String())
+	}
+	if len(fields) == 0 {
+		return logger
+	}
+	return logger.With(fields...)
+}
@@ -0,0 +1,42 @@
+package logging
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestLogger(t *testing.T) {
+	var core, logs = observer.New(zap.InfoLevel)
+	var logger = zap.New(core).Sugar()
+
+	Logger(context.Background(), logger).Info("without fields")
+
+	var ctx = With(context.Background(), "requestId", "req-1")
+	var member = With(ctx, "memberId", "m-1")
+	Logger(ctx, logger).Info("request fields")
+	Logger(member, logger).Info("member fields")
+
+	var provider = sdktrace.NewTracerProvider()
+	traced, span := provider.Tracer("test").Start(member, "request")
+	defer span.End()
+	Logger(traced, logger).Info("span fields")
+
+	var entries = logs.AllUntimed()
+	if !assert.Len(t, entries, 4) {
+		return
+	}
+	assert.Empty(t, entries[0].ContextMap())
+	assert.Equal(t, map[string]interface{}{"requestId": "req-1"}, entries[1].ContextMap())
+	assert.Equal(t, map[string]interface{}{"requestId": "req-1", "memberId": "m-1"}, entries[2].ContextMap())
+	assert.Equal(t, map[string]interface{}{
+		"requestId": "req-1",
+		"memberId":  "m-1",
+		"traceId":   span.SpanContext().TraceID().String(),
+		"spanId":    span.SpanContext().SpanID().String(),
+	}, entries[3].ContextMap())
+}
@@ -0,0 +1,73 @@
+package normalize
+
+import (
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/runes"
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
+)
+
+// DefaultAbbreviations common abbreviations found in retailer names
+var DefaultAbbreviations = map[string]string{
+	"mkt":    "market",
+	"mkts":   "markets",
+	"mrkt":   "market",
+	"st":     "store",
+	"str":    "store",
+	"ctr":    "center",
+	"cntr":   "center",
+	"co":     "company",
+	"corp":   "corporation",
+	"inc":    "incorporated",
+	"intl":   "international",
+	"ph":     "pharmacy",
+	"phcy":   "pharmacy",
+	"sprmkt": "supermarket",
+}
+
+// Normalizer turns free-text names into a canonical form suitable for comparison
+type Normalizer struct {
+	abbreviations map[string]string
+}
+
+// NewNormalizer creates a normalizer, the given abbreviations are added to the default ones
+func NewNormalizer(abbreviations map[string]string) *Normalizer {
+	var merged = make(map[string]string, len(DefaultAbbreviations)+len(abbreviations))
+	for k, v := range DefaultAbbreviations {
+		merged[k] = v
+	}
+	for k, v := range abbreviations {
+		merged[strings.ToLower(strings.TrimSpace(k))] = strings.ToLower(strings.TrimSpace(v))
+	}
+	return &Normalizer{abbreviations: merged}
+}
+
+// Normalize applies the normalization pipeline: Unicode decomposition removing the diacritics, case folding,
+// punctuation and whitespace collapse and abbreviation expansion.
+// "M & M CORNER MKT" and "m&m corner market " are both normalized to "m and m corner market".
+func (n *Normalizer) Normalize(s string) string {
+	s = removeDiacritics(s)
+	s = strings.ToLower(s)
+	s = strings.ReplaceAll(s, "&", " and ")
+
+	var tokens = strings.FieldsFunc(s, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	for i, token := range tokens {
+		if expanded, ok := n.abbreviations[token]; ok {
+			tokens[i] = expanded
+		}
+	}
+	return strings.Join(tokens, " ")
+}
+
+func removeDiacritics(s string) string {
+	t := transform.Chain(norm.NFKD, runes.Remove(runes.In(unicode.Mn)), norm.NFC)
+	result, _, err := transform.String(t, s)
+	if err != nil {
+		return s
+	}
+	return result
+}
@@ -0,0 +1,30 @@
+package normalize
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestNormalizer_Normalize(t *testing.T) {
+	var normalizer = NewNormalizer(map[string]string{"WG": "walgreens"})
+	var testCases = map[string]string{
+		"M&M Corner Market":     "m and m corner market",
+		"M & M CORNER MKT":      "m and m corner market",
+		"m&m corner market ":    "m and m corner market",
+		"  Café   Müller, Inc.": "cafe muller incorporated",
+		"WG #1234":              "walgreens 1234",
+		"":                      "",
+	}
+
+	for input, expected := range testCases {
+		assert.Equal(t, expected, normalizer.Normalize(input), input)
+	}
+}
+
+func TestSimilarity(t *testing.T) {
+	assert.Equal(t, 1.0, Similarity("target", "target"))
+	assert.Equal(t, 1.0, Similarity("", ""))
+	assert.Equal(t, 0.0, Similarity("abc", "xyz"))
+	assert.InDelta(t, 0.889, Similarity("walgreen", "walgreens"), 0.001)
+	assert.Greater(t, Similarity("m and m corner markt", "m and m corner market"), 0.9)
+}
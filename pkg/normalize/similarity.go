@@ -0,0 +1,36 @@
+package normalize
+
+// Similarity returns a score between 0 and 1 of how similar the strings are, based on the Levenshtein distance
+func Similarity(a, b string) float64 {
+	var ra, rb = []rune(a), []rune(b)
+	var longest = len(ra)
+	if len(rb) > longest {
+		longest = len(rb)
+	}
+	if longest == 0 {
+		return 1
+	}
+	return 1 - float64(levenshtein(ra, rb))/float64(longest)
+}
+
+// levenshtein returns the minimum number of single-rune edits to turn a into b
+func levenshtein(a, b []rune) int {
+	var previous = make([]int, len(b)+1)
+	var current = make([]int, len(b)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		current[0] = i
+		for j := 1; j <= len(b); j++ {
+			var cost = 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+	return previous[len(b)]
+}
@@ -0,0 +1,373 @@
+package receipttext
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/kiramishima/receipt-processor/pkg/locale"
+)
+
+const (
+	ItemLineDiscount = "discount"
+	ItemLineTax      = "tax"
+)
+
+// Confidences of the values found by the templates and by the heuristics, from 0 to 1
+const (
+	templateConfidence     = 0.95
+	dateConfidence         = 0.85
+	timeConfidence         = 0.8
+	totalConfidence        = 0.85
+	checkedConfidence      = 0.95
+	retailerConfidence     = 0.6
+	itemConfidence         = 0.7
+	quantityConfidence     = 0.8
+	templateItemConfidence = 0.9
+	fallbackConfidence     = 0.3
+)
+
+var (
+	// amountPattern line ending in an amount, with an optional currency symbol, minus sign of discounts and tax flags
+	amountPattern    = regexp.MustCompile(`^(.*?)\s+(-?)[$€£]?\s?(\d{1,3}(?:[.,]\d{3})+[.,]\d{2}|\d+[.,]\d{2})(-?)(?:\s*[A-Z*]{1,2})?$`)
+	totalKeywords    = regexp.MustCompile(`(?i)^\W*(grand\s+total|total|amount\s+due|balance\s+due|importe|summe|gesamt|totale)\b`)
+	subtotalKeywords = regexp.MustCompile(`(?i)\b(sub\s*-?\s*total|zwischensumme|sous-total)\b`)
+	paymentKeywords  = regexp.MustCompile(`(?i)^\W*(change|cash|tender|visa|mastercard|amex|debit|credit|card|paid|payment|cambio|efectivo|tarjeta|rückgeld|wechselgeld|espèces|carte)\b`)
+	taxKeywords      = regexp.MustCompile(`(?i)\b(tax|vat|hst|gst|pst|iva|mwst|ust|tva)\b`)
+	discountKeywords = regexp.MustCompile(`(?i)\b(discount|coupon|savings|promo|descuento|rabatt|remise|sconto)\b`)
+	quantityPrefix   = regexp.MustCompile(`^(\d+)\s*[xX*@]\s+(.+)$`)
+	quantitySuffix   = regexp.MustCompile(`^(.+?)\s+(\d+(?:[.,]\d+)?)\s*[xX@]\s*[$€£]?(\d+[.,]\d{2})$`)
+	datePattern      = regexp.MustCompile(`\b(\d{4}-\d{1,2}-\d{1,2}|\d{1,2}[/.\-]\d{1,2}[/.\-](?:\d{4}|\d{2}))\b`)
+	timePattern      = regexp.MustCompile(`(?i)\b(\d{1,2}:\d{2})(?::\d{2})?(\s*[ap]\.?\s?m\.?)?`)
+	headerSkip       = regexp.MustCompile(`(?i)^\W*(welcome|thank|receipt|store\s*#|tel\b|phone|www\.|http)`)
+	letters          = regexp.MustCompile(`\pL.*\pL.*\pL`)
+)
+
+// Field value extracted from the text in the canonical format and the confidence of the extraction, from 0 to 1.
+// Values not found are empty with 0 confidence.
+type Field struct {
+	Value      string
+	Confidence float64
+}
+
+// Item line of the receipt, the amounts are in the canonical format and the price of discounts is positive
+type Item struct {
+	Type        string
+	Description string
+	Quantity    string
+	UnitPrice   string
+	Price       string
+	Confidence  float64
+}
+
+// Result values extracted from the text of a receipt
+type Result struct {
+	Template string
+	Locale   string
+	Retailer Field
+	Date     Field
+	Time     Field
+	Total    Field
+	Items    []*Item
+	Warnings []string
+}
+
+// Parser extracts the receipts of plain text with the templates of the retailers and generic heuristics
+type Parser struct {
+	templates []*compiledTemplate
+}
+
+// NewParser compiles the templates, the first template matching a text is used
+func NewParser(templates []*Template) (*Parser, error) {
+	var parser = &Parser{}
+	for _, template := range templates {
+		compiled, err := template.compile()
+		if err != nil {
+			return nil, err
+		}
+		if _, err := locale.Lookup(template.Locale); template.Locale != "" && err != nil {
+			return nil, fmt.Errorf("template %s: %w", template.Name, err)
+		}
+		parser.templates = append(parser.templates, compiled)
+	}
+	return parser, nil
+}
+
+// Parse extracts the retailer, purchase date and time, items and total of the text. The formats are the ones of the
+// locale of the template matching the text, of the given locale or the auto locale.
+func (p *Parser) Parse(text string, tag string) (*Result, error) {
+	var lines = make([]string, 0)
+	for _, line := range strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			lines = append(lines, line)
+		}
+	}
+
+	var result = &Result{Items: make([]*Item, 0), Warnings: make([]string, 0)}
+	var template = p.match(text)
+	if template != nil {
+		result.Template = template.Name
+		result.Retailer = Field{Value: template.Retailer, Confidence: templateConfidence}
+		if template.Locale != "" {
+			tag = template.Locale
+		}
+	}
+	if tag == "" {
+		tag = locale.Auto
+	}
+	profile, err := locale.Lookup(tag)
+	if err != nil {
+		return nil, err
+	}
+	result.Locale = profile.Name
+
+	var scanner = &scanner{profile: profile, template: template, result: result}
+	for _, line := range lines {
+		scanner.scan(line)
+	}
+	scanner.check()
+	return result, nil
+}
+
+// match returns the first template matching the text
+func (p *Parser) match(text string) *compiledTemplate {
+	for _, template := range p.templates {
+		if template.match.MatchString(text) {
+			return template
+		}
+	}
+	return nil
+}
+
+// scanner extracts the values of the lines of a text
+type scanner struct {
+	profile  *locale.Profile
+	template *compiledTemplate
+	result   *Result
+}
+
+func (s *scanner) scan(line string) {
+	if s.template != nil && s.template.ignored(line) {
+		return
+	}
+	s.scanDate(line)
+	s.scanTime(line)
+
+	if s.template != nil && s.template.total != nil {
+		if total, ok := group(s.template.total, line, "total"); ok {
+			if amount, ok := s.amount(total); ok && s.result.Total.Value == "" {
+				s.result.Total = Field{Value: amount, Confidence: templateConfidence}
+			}
+			return
+		}
+	}
+	if s.template != nil && s.template.item != nil && s.scanTemplateItem(line) {
+		return
+	}
+	s.scanLine(line)
+}
+
+// scanDate keeps the first date of the text
+func (s *scanner) scanDate(line string) {
+	if s.result.Date.Value != "" {
+		return
+	}
+	var value, confidence = "", dateConfidence
+	if s.template != nil && s.template.date != nil {
+		if date, ok := group(s.template.date, line, "date"); ok {
+			value, confidence = date, templateConfidence
+		}
+	}
+	if match := datePattern.FindString(line); value == "" && match != "" {
+		value = match
+		// Two digit years are years of this century
+		if i := strings.LastIndexAny(value, "/.-"); len(value)-i-1 == 2 && !strings.Contains(value[:i], "-") {
+			value = value[:i+1] + "20" + value[i+1:]
+		}
+	}
+	if value == "" {
+		return
+	}
+
+	date, err := s.profile.ParseDate(value)
+	if err != nil {
+		s.result.Warnings = append(s.result.Warnings, fmt.Sprintf("date not recognized: %s", err))
+		return
+	}
+	s.result.Date = Field{Value: date.Format("2006-01-02"), Confidence: confidence}
+}
+
+// scanTime keeps the first time of the day of the text
+func (s *scanner) scanTime(line string) {
+	if s.result.Time.Value != "" {
+		return
+	}
+	var value, confidence = "", timeConfidence
+	if s.template != nil && s.template.time != nil {
+		if clock, ok := group(s.template.time, line, "time"); ok {
+			value, confidence = clock, templateConfidence
+		}
+	}
+	if match := timePattern.FindStringSubmatch(line); value == "" && match != nil {
+		value = match[1] + match[2]
+	}
+	if value == "" {
+		return
+	}
+
+	clock, err := s.profile.ParseTime(value)
+	if err != nil {
+		return
+	}
+	s.result.Time = Field{Value: clock.Format("15:04"), Confidence: confidence}
+}
+
+// scanTemplateItem adds the item of a line matching the item pattern of the template
+func (s *scanner) scanTemplateItem(line string) bool {
+	var match = s.template.item.FindStringSubmatch(line)
+	if match == nil {
+		return false
+	}
+	var value = func(name string) string {
+		if index := s.template.item.SubexpIndex(name); index > 0 {
+			return strings.TrimSpace(match[index])
+		}
+		return ""
+	}
+
+	price, ok := s.amount(value("price"))
+	if !ok {
+		return false
+	}
+	var item = &Item{Description: value("description"), Price: price, Confidence: templateItemConfidence}
+	if quantity, ok := s.amount(value("quantity")); ok {
+		item.Quantity = quantity
+	}
+	if unitPrice, ok := s.amount(value("unitPrice")); ok {
+		item.UnitPrice = unitPrice
+	}
+	s.classify(item, false)
+	s.result.Items = append(s.result.Items, item)
+	return true
+}
+
+// scanLine classifies a line ending in an amount as total, payment, tax, discount or item
+func (s *scanner) scanLine(line string) {
+	var match = amountPattern.FindStringSubmatch(line)
+	if match == nil {
+		if s.result.Retailer.Value == "" && letters.MatchString(line) && !headerSkip.MatchString(line) &&
+			!datePattern.MatchString(line) && !timePattern.MatchString(line) {
+			s.result.Retailer = Field{Value: line, Confidence: retailerConfidence}
+		}
+		return
+	}
+
+	var description = strings.TrimSpace(strings.TrimRight(match[1], ":$€£ "))
+	amount, ok := s.amount(match[3])
+	if !ok {
+		return
+	}
+	var negative = match[2] == "-" || match[4] == "-"
+
+	switch {
+	case subtotalKeywords.MatchString(description), paymentKeywords.MatchString(description):
+		return
+	case totalKeywords.MatchString(description):
+		// Total savings are not the total
+		if !discountKeywords.MatchString(description) && s.result.Total.Value == "" {
+			s.result.Total = Field{Value: amount, Confidence: totalConfidence}
+		}
+		return
+	}
+
+	var item = &Item{Description: description, Price: amount, Confidence: itemConfidence}
+	if m := quantityPrefix.FindStringSubmatch(description); m != nil {
+		item.Quantity, item.Description = m[1], m[2]
+	} else if m := quantitySuffix.FindStringSubmatch(description); m != nil {
+		quantity, okQuantity := s.amount(m[2])
+		unitPrice, okUnitPrice := s.amount(m[3])
+		if okQuantity && okUnitPrice {
+			item.Description, item.Quantity, item.UnitPrice = m[1], quantity, unitPrice
+			if sameAmount(multiply(quantity, unitPrice), parseFloat(amount)) {
+				item.Confidence = quantityConfidence
+			}
+		}
+	}
+	s.classify(item, negative)
+	s.result.Items = append(s.result.Items, item)
+}
+
+// classify sets the type of tax and discount lines
+func (s *scanner) classify(item *Item, negative bool) {
+	switch {
+	case negative || discountKeywords.MatchString(item.Description):
+		item.Type = ItemLineDiscount
+	case taxKeywords.MatchString(item.Description):
+		item.Type = ItemLineTax
+	}
+}
+
+// amount returns the amount in the canonical format
+func (s *scanner) amount(value string) (string, bool) {
+	if value == "" {
+		return "", false
+	}
+	amount, err := s.profile.ParseAmount(value)
+	if err != nil {
+		return "", false
+	}
+	return strings.TrimPrefix(amount, "-"), true
+}
+
+// check compares the total with the items, the total is the sum of the items when it was not found
+func (s *scanner) check() {
+	var result = s.result
+	var sum = 0.0
+	for _, item := range result.Items {
+		var price, _ = strconv.ParseFloat(item.Price, 64)
+		if item.Type == ItemLineDiscount {
+			price = -price
+		}
+		sum += price
+	}
+
+	switch {
+	case len(result.Items) == 0:
+		result.Warnings = append(result.Warnings, "no items found")
+	case result.Total.Value == "":
+		result.Total = Field{Value: strconv.FormatFloat(sum, 'f', 2, 64), Confidence: fallbackConfidence}
+		result.Warnings = append(result.Warnings, "total not found, the sum of the items is used")
+	case sameAmount(sum, parseFloat(result.Total.Value)):
+		result.Total.Confidence = math.Max(result.Total.Confidence, checkedConfidence)
+		for _, item := range result.Items {
+			item.Confidence = math.Max(item.Confidence, totalConfidence)
+		}
+	default:
+		result.Warnings = append(result.Warnings, fmt.Sprintf("items add up to %.2f but the total is %s", sum, result.Total.Value))
+	}
+
+	if result.Retailer.Value == "" {
+		result.Warnings = append(result.Warnings, "retailer not found")
+	}
+	if result.Date.Value == "" {
+		result.Warnings = append(result.Warnings, "purchase date not found")
+	}
+	if result.Time.Value == "" {
+		result.Warnings = append(result.Warnings, "purchase time not found")
+	}
+}
+
+func parseFloat(value string) float64 {
+	f, _ := strconv.ParseFloat(value, 64)
+	return f
+}
+
+func multiply(quantity, unitPrice string) float64 {
+	return parseFloat(quantity) * parseFloat(unitPrice)
+}
+
+func sameAmount(a, b float64) bool {
+	return math.Abs(a-b) < 0.005
+}
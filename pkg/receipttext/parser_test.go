@@ -0,0 +1,132 @@
+package receipttext
+
+import (
+	"github.com/kiramishima/receipt-processor/pkg/locale"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+var walmart = `
+   WALMART SUPERCENTER
+Store #1234 Tel 555-123-4567
+03/18/2022 2:33 PM
+Mountain Dew 12PK          6.49
+2 x Emils Cheese Pizza    25.00 T
+Gatorade 3 @ 2.25          6.75
+COUPON MTN DEW             1.00-
+SUBTOTAL                  37.24
+TAX                        0.50
+TOTAL                     37.74
+VISA TEND                 37.74
+CHANGE DUE                 0.00
+`
+
+var rewe = `
+REWE Markt GmbH
+Musterstr. 1
+BANANEN                1,29 B
+2 Stk x 0,89
+MILCH                  1,78 B
+SUMME EUR              3,07
+Datum: 18.03.2022 Uhrzeit: 14:33
+`
+
+var reweTemplate = &Template{
+	Name:     "rewe",
+	Retailer: "REWE",
+	Locale:   "de-DE",
+	Match:    `(?m)^REWE`,
+	Item:     `^(?P<description>[A-ZÄÖÜ ]+?)\s+(?P<price>\d+,\d{2}) [AB]$`,
+	Total:    `^SUMME\s+EUR\s+(?P<total>\d+,\d{2})$`,
+	Date:     `Datum: (?P<date>\S+)`,
+	Time:     `Uhrzeit: (?P<time>\S+)`,
+	Ignore:   []string{`Stk x`},
+}
+
+func TestParser_Parse(t *testing.T) {
+	parser, err := NewParser([]*Template{reweTemplate})
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	t.Run("Heuristics", func(t *testing.T) {
+		result, err := parser.Parse(walmart, "")
+		if !assert.NoError(t, err) {
+			return
+		}
+		assert.Equal(t, "", result.Template)
+		assert.Equal(t, locale.Auto, result.Locale)
+		assert.Equal(t, Field{Value: "WALMART SUPERCENTER", Confidence: retailerConfidence}, result.Retailer)
+		assert.Equal(t, Field{Value: "2022-03-18", Confidence: dateConfidence}, result.Date)
+		assert.Equal(t, Field{Value: "14:33", Confidence: timeConfidence}, result.Time)
+		// The items add up to the total
+		assert.Equal(t, Field{Value: "37.74", Confidence: checkedConfidence}, result.Total)
+		assert.Equal(t, []*Item{
+			{Description: "Mountain Dew 12PK", Price: "6.49", Confidence: totalConfidence},
+			{Description: "Emils Cheese Pizza", Quantity: "2", Price: "25.00", Confidence: totalConfidence},
+			{Description: "Gatorade", Quantity: "3", UnitPrice: "2.25", Price: "6.75", Confidence: totalConfidence},
+			{Type: ItemLineDiscount, Description: "COUPON MTN DEW", Price: "1.00", Confidence: totalConfidence},
+			{Type: ItemLineTax, Description: "TAX", Price: "0.50", Confidence: totalConfidence},
+		}, result.Items)
+		assert.Empty(t, result.Warnings)
+	})
+
+	t.Run("Template", func(t *testing.T) {
+		result, err := parser.Parse(rewe, "en-US")
+		if !assert.NoError(t, err) {
+			return
+		}
+		assert.Equal(t, "rewe", result.Template)
+		assert.Equal(t, "de-DE", result.Locale)
+		assert.Equal(t, Field{Value: "REWE", Confidence: templateConfidence}, result.Retailer)
+		assert.Equal(t, Field{Value: "2022-03-18", Confidence: templateConfidence}, result.Date)
+		assert.Equal(t, Field{Value: "14:33", Confidence: templateConfidence}, result.Time)
+		assert.Equal(t, Field{Value: "3.07", Confidence: templateConfidence}, result.Total)
+		assert.Equal(t, []*Item{
+			{Description: "BANANEN", Price: "1.29", Confidence: templateItemConfidence},
+			{Description: "MILCH", Price: "1.78", Confidence: templateItemConfidence},
+		}, result.Items)
+		assert.Empty(t, result.Warnings)
+	})
+
+	t.Run("Missing fields", func(t *testing.T) {
+		result, err := parser.Parse("Corner Shop\n03/04/2022\nBread 2.50\nMilk 1.20\n", "")
+		if !assert.NoError(t, err) {
+			return
+		}
+		assert.Equal(t, "Corner Shop", result.Retailer.Value)
+		assert.Equal(t, Field{}, result.Date)
+		assert.Equal(t, Field{}, result.Time)
+		assert.Equal(t, Field{Value: "3.70", Confidence: fallbackConfidence}, result.Total)
+		assert.Equal(t, []string{
+			"date not recognized: ambiguous input: date 03/04/2022 can be 2022-04-03 or 2022-03-04",
+			"total not found, the sum of the items is used",
+			"purchase date not found",
+			"purchase time not found",
+		}, result.Warnings)
+	})
+
+	t.Run("Locale", func(t *testing.T) {
+		result, err := parser.Parse("Corner Shop\n03/04/2022 14:05\nBread 2.50\nTOTAL 2.50\n", "en-GB")
+		if assert.NoError(t, err) {
+			assert.Equal(t, "2022-04-03", result.Date.Value)
+			assert.Equal(t, "14:05", result.Time.Value)
+		}
+
+		_, err = parser.Parse("Corner Shop", "xx-YY")
+		assert.ErrorIs(t, err, locale.ErrUnknownLocale)
+	})
+}
+
+func TestNewParser(t *testing.T) {
+	var testCases = map[string]*Template{
+		"Invalid match":  {Name: "a", Retailer: "A", Match: `(`},
+		"Missing group":  {Name: "a", Retailer: "A", Match: `A`, Total: `TOTAL (\S+)`},
+		"Invalid ignore": {Name: "a", Retailer: "A", Match: `A`, Ignore: []string{`[`}},
+		"Unknown locale": {Name: "a", Retailer: "A", Match: `A`, Locale: "xx-YY"},
+	}
+	for name, template := range testCases {
+		_, err := NewParser([]*Template{template})
+		assert.Error(t, err, name)
+	}
+}
@@ -0,0 +1,98 @@
+package receipttext
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// Template patterns of the receipts of a retailer. Match identifies the receipts of the retailer, the other patterns
+// are optional and extract the named groups description, quantity, unitPrice and price of the items, total, date and
+// time. The lines matching an ignore pattern are skipped.
+type Template struct {
+	Name     string   `json:"name" validate:"required"`
+	Retailer string   `json:"retailer" validate:"required"`
+	Locale   string   `json:"locale,omitempty"`
+	Match    string   `json:"match" validate:"required"`
+	Item     string   `json:"item,omitempty"`
+	Total    string   `json:"total,omitempty"`
+	Date     string   `json:"date,omitempty"`
+	Time     string   `json:"time,omitempty"`
+	Ignore   []string `json:"ignore,omitempty"`
+}
+
+// compiledTemplate template with its patterns compiled
+type compiledTemplate struct {
+	*Template
+	match  *regexp.Regexp
+	item   *regexp.Regexp
+	total  *regexp.Regexp
+	date   *regexp.Regexp
+	time   *regexp.Regexp
+	ignore []*regexp.Regexp
+}
+
+// compile compiles the patterns of the template, the item, total, date and time patterns must have their groups
+func (t *Template) compile() (*compiledTemplate, error) {
+	var compiled = &compiledTemplate{Template: t}
+	var err error
+	if compiled.match, err = regexp.Compile(t.Match); err != nil {
+		return nil, fmt.Errorf("template %s: match: %w", t.Name, err)
+	}
+
+	var patterns = []struct {
+		name    string
+		pattern string
+		group   string
+		target  **regexp.Regexp
+	}{
+		{"item", t.Item, "price", &compiled.item},
+		{"total", t.Total, "total", &compiled.total},
+		{"date", t.Date, "date", &compiled.date},
+		{"time", t.Time, "time", &compiled.time},
+	}
+	for _, p := range patterns {
+		if p.pattern == "" {
+			continue
+		}
+		re, err := regexp.Compile(p.pattern)
+		if err != nil {
+			return nil, fmt.Errorf("template %s: %s: %w", t.Name, p.name, err)
+		}
+		if re.SubexpIndex(p.group) < 0 {
+			return nil, fmt.Errorf("template %s: %s: missing group %s", t.Name, p.name, p.group)
+		}
+		*p.target = re
+	}
+
+	for _, pattern := range t.Ignore {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("template %s: ignore: %w", t.Name, err)
+		}
+		compiled.ignore = append(compiled.ignore, re)
+	}
+	return compiled, nil
+}
+
+// ignored returns true if the line matches an ignore pattern
+func (t *compiledTemplate) ignored(line string) bool {
+	for _, re := range t.ignore {
+		if re.MatchString(line) {
+			return true
+		}
+	}
+	return false
+}
+
+// group returns the named group of the first match of the pattern in the line
+func group(re *regexp.Regexp, line string, name string) (string, bool) {
+	var match = re.FindStringSubmatch(line)
+	if match == nil {
+		return "", false
+	}
+	var index = re.SubexpIndex(name)
+	if index < 0 {
+		return "", true
+	}
+	return match[index], true
+}
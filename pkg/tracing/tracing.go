@@ -0,0 +1,27 @@
+// Package tracing correlates the errors with the traces of the requests and ends the spans with their errors.
+package tracing
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// TraceID returns the id of the trace of the span of the context, empty without span
+func TraceID(ctx context.Context) string {
+	var spanContext = trace.SpanContextFromContext(ctx)
+	if !spanContext.IsValid() {
+		return ""
+	}
+	return spanContext.TraceID().String()
+}
+
+// End records the error in the span, when there is one, and ends it
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
@@ -0,0 +1,38 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestTraceID(t *testing.T) {
+	assert.Empty(t, TraceID(context.Background()))
+
+	ctx, span := sdktrace.NewTracerProvider().Tracer("test").Start(context.Background(), "request")
+	defer span.End()
+	assert.Equal(t, span.SpanContext().TraceID().String(), TraceID(ctx))
+}
+
+func TestEnd(t *testing.T) {
+	var recorder = tracetest.NewSpanRecorder()
+	var tracer = sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)).Tracer("test")
+
+	_, span := tracer.Start(context.Background(), "ok")
+	End(span, nil)
+	_, span = tracer.Start(context.Background(), "failed")
+	End(span, errors.New("boom"))
+
+	var spans = recorder.Ended()
+	if assert.Len(t, spans, 2) {
+		assert.Equal(t, codes.Unset, spans[0].Status().Code)
+		assert.Equal(t, codes.Error, spans[1].Status().Code)
+		assert.Equal(t, "boom", spans[1].Status().Description)
+		assert.Len(t, spans[1].Events(), 1)
+	}
+}
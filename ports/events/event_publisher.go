@@ -0,0 +1,10 @@
+package events
+
+import (
+	"context"
+	"github.com/kiramishima/receipt-processor/domain"
+)
+
+type IEventPublisher interface {
+	Publish(ctx context.Context, event domain.Event) error
+}
@@ -0,0 +1,17 @@
+package metrics
+
+import "time"
+
+// IMetricsRecorder records the operational metrics, the services and adapters don't depend on the metrics library
+type IMetricsRecorder interface {
+	// ObserveRequest records a request by the route pattern that served it
+	ObserveRequest(method string, route string, status int, duration time.Duration)
+	// ReceiptProcessed records a stored receipt and the points it was awarded
+	ReceiptProcessed(points int)
+	// ReceiptRejected records a receipt that was not stored, by rejection reason
+	ReceiptRejected(reason string)
+	// RuleFired records a rule that awarded points to a stored receipt
+	RuleFired(rule string)
+	// ObserveRepository records the latency of a repository operation
+	ObserveRepository(repository string, operation string, duration time.Duration, err error)
+}
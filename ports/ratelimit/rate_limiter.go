@@ -0,0 +1,11 @@
+package ratelimit
+
+import (
+	"github.com/kiramishima/receipt-processor/domain"
+	"time"
+)
+
+type IRateLimiter interface {
+	// Allow takes a token of the bucket of the key, with the capacity and refill rate of the limit
+	Allow(key string, limit domain.RateLimit, now time.Time) domain.RateLimitDecision
+}
@@ -0,0 +1,15 @@
+package repository
+
+import (
+	"github.com/kiramishima/receipt-processor/domain"
+	"time"
+)
+
+type IAPIKeyRepository interface {
+	SaveAPIKey(key *domain.APIKey) (string, error)
+	FindAPIKeyById(id string) (*domain.APIKey, error)
+	FindAPIKeyByHash(hash string) (*domain.APIKey, error)
+	ListAPIKeys() ([]*domain.APIKey, error)
+	UpdateAPIKey(key *domain.APIKey) error
+	TouchAPIKey(id string, usedAt time.Time) error
+}
@@ -0,0 +1,11 @@
+package repository
+
+import "github.com/kiramishima/receipt-processor/domain"
+
+type ICampaignRepository interface {
+	SaveCampaign(campaign *domain.Campaign) (string, error)
+	FindCampaignById(id string) (*domain.Campaign, error)
+	ListCampaigns() ([]*domain.Campaign, error)
+	UpdateCampaign(campaign *domain.Campaign) error
+	DeleteCampaign(id string) error
+}
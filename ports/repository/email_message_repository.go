@@ -0,0 +1,16 @@
+package repository
+
+import (
+	"github.com/kiramishima/receipt-processor/domain"
+	"time"
+)
+
+type IEmailMessageRepository interface {
+	// ReserveEmailMessage records the message unless it was recorded before, then the error is errors.Conflict
+	ReserveEmailMessage(messageID string, receivedAt time.Time) error
+	// CompleteEmailMessage sets the receipt stored from the message
+	CompleteEmailMessage(messageID string, receiptID string) error
+	// ReleaseEmailMessage forgets the message, for the messages whose receipt was not stored
+	ReleaseEmailMessage(messageID string) error
+	FindEmailMessage(messageID string) (*domain.EmailMessage, error)
+}
@@ -0,0 +1,11 @@
+package repository
+
+import "github.com/kiramishima/receipt-processor/domain"
+
+type IExperimentRepository interface {
+	SaveExperiment(experiment *domain.Experiment) (string, error)
+	FindExperimentById(id string) (*domain.Experiment, error)
+	ListExperiments() ([]*domain.Experiment, error)
+	UpdateExperiment(experiment *domain.Experiment) error
+	DeleteExperiment(id string) error
+}
@@ -0,0 +1,8 @@
+package repository
+
+import "github.com/kiramishima/receipt-processor/domain"
+
+type ILedgerRepository interface {
+	SaveLedgerEntry(entry *domain.LedgerEntry) (string, error)
+	ListLedgerEntries(receiptID string) ([]*domain.LedgerEntry, error)
+}
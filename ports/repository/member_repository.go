@@ -0,0 +1,9 @@
+package repository
+
+import "github.com/kiramishima/receipt-processor/domain"
+
+type IMemberRepository interface {
+	FindMemberById(id string) (*domain.Member, error)
+	SaveMember(member *domain.Member) error
+	ListMembers() ([]*domain.Member, error)
+}
@@ -0,0 +1,12 @@
+package repository
+
+import "github.com/kiramishima/receipt-processor/domain"
+
+type IQuotaRepository interface {
+	// IncrementQuotaUsage adds one to the usage of the client in the day unless it reached the limit, then the error is
+	// errors.QuotaExceeded
+	IncrementQuotaUsage(client string, day string, limit int) (*domain.QuotaUsage, error)
+	// DecrementQuotaUsage gives back a use of the client in the day, for the receipts that were not stored
+	DecrementQuotaUsage(client string, day string) error
+	FindQuotaUsage(client string, day string) (*domain.QuotaUsage, error)
+}
@@ -1,8 +1,16 @@
 package repository
 
-import "github.com/kiramishima/receipt-processor/domain"
+import (
+	"github.com/kiramishima/receipt-processor/domain"
+	"time"
+)
 
 type IReceiptRepository interface {
-	SaveReceiptPoints(points int16) (string, error)
+	SaveReceiptPoints(result *domain.Result) (string, error)
+	SaveCappedReceiptPoints(result *domain.Result, dailyCap int, since time.Time) (string, error)
 	FindReceiptById(id string) (*domain.Result, error)
+	SumMemberPointsSince(memberID string, since time.Time) (int, error)
+	ListReceipts(filter *domain.ReceiptFilter) ([]*domain.Result, error)
+	EachReceipt(filter *domain.ReceiptFilter, fn func(result *domain.Result) error) error
+	UpdateReceiptPoints(id string, points int, breakdown *domain.Breakdown) error
 }
@@ -0,0 +1,14 @@
+package repository
+
+import "github.com/kiramishima/receipt-processor/domain"
+
+type IRetailerRepository interface {
+	SaveRetailer(retailer *domain.Retailer) (string, error)
+	FindRetailerById(id string) (*domain.Retailer, error)
+	ListRetailers() ([]*domain.Retailer, error)
+	UpdateRetailer(retailer *domain.Retailer) error
+	DeleteRetailer(id string) error
+	SaveCategory(category *domain.RetailerCategory) error
+	FindCategoryByName(name string) (*domain.RetailerCategory, error)
+	ListCategories() ([]*domain.RetailerCategory, error)
+}
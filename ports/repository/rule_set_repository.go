@@ -0,0 +1,9 @@
+package repository
+
+import "github.com/kiramishima/receipt-processor/domain"
+
+type IRuleSetRepository interface {
+	SaveRuleSet(rs *domain.RuleSet) error
+	FindRuleSetByVersion(version string) (*domain.RuleSet, error)
+	ListRuleSets() ([]*domain.RuleSet, error)
+}
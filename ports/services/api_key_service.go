@@ -0,0 +1,15 @@
+package services
+
+import (
+	"context"
+	"github.com/kiramishima/receipt-processor/domain"
+)
+
+type IAPIKeyService interface {
+	IssueAPIKey(ctx context.Context, req *domain.APIKeyRequest) (*domain.IssuedAPIKey, error)
+	RetrieveAPIKey(id string) (*domain.APIKey, error)
+	ListAPIKeys() ([]*domain.APIKey, error)
+	RevokeAPIKey(ctx context.Context, id string) error
+	RotateAPIKey(ctx context.Context, id string) (*domain.IssuedAPIKey, error)
+	Authenticate(key string) (*domain.APIKey, error)
+}
@@ -0,0 +1,16 @@
+package services
+
+import (
+	"context"
+	"github.com/kiramishima/receipt-processor/domain"
+	"time"
+)
+
+type ICampaignService interface {
+	CreateCampaign(ctx context.Context, campaign *domain.Campaign) (*domain.Campaign, error)
+	RetrieveCampaign(id string) (*domain.Campaign, error)
+	ListCampaigns() ([]*domain.Campaign, error)
+	UpdateCampaign(ctx context.Context, campaign *domain.Campaign) (*domain.Campaign, error)
+	DeleteCampaign(id string) error
+	ActiveCampaigns(at time.Time) ([]*domain.Campaign, error)
+}
@@ -0,0 +1,9 @@
+package services
+
+import "github.com/kiramishima/receipt-processor/domain"
+
+type ICurrencyService interface {
+	BaseCurrency() string
+	ReceiptRate(currency string) (float64, error)
+	RulesReceipt(receipt *domain.Receipt) *domain.Receipt
+}
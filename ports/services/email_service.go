@@ -0,0 +1,11 @@
+package services
+
+import (
+	"context"
+	"github.com/kiramishima/receipt-processor/domain"
+	"io"
+)
+
+type IEmailService interface {
+	IngestEmail(ctx context.Context, message io.Reader, memberID string) (*domain.EmailIngestion, error)
+}
@@ -0,0 +1,17 @@
+package services
+
+import (
+	"context"
+	"github.com/kiramishima/receipt-processor/domain"
+	"time"
+)
+
+type IExperimentService interface {
+	CreateExperiment(ctx context.Context, experiment *domain.Experiment) (*domain.Experiment, error)
+	RetrieveExperiment(id string) (*domain.Experiment, error)
+	ListExperiments() ([]*domain.Experiment, error)
+	UpdateExperiment(ctx context.Context, experiment *domain.Experiment) (*domain.Experiment, error)
+	DeleteExperiment(id string) error
+	ActiveExperiment(at time.Time) (*domain.Experiment, error)
+	ExperimentReport(id string) (*domain.ExperimentReport, error)
+}
@@ -0,0 +1,11 @@
+package services
+
+import (
+	"context"
+	"github.com/kiramishima/receipt-processor/domain"
+	"io"
+)
+
+type IExportService interface {
+	ExportReceipts(ctx context.Context, filter *domain.ReceiptFilter, format string, w io.Writer) (int, error)
+}
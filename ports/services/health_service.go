@@ -0,0 +1,11 @@
+package services
+
+import (
+	"context"
+	"github.com/kiramishima/receipt-processor/domain"
+)
+
+type IHealthService interface {
+	Live(ctx context.Context) *domain.HealthReport
+	Ready(ctx context.Context) *domain.HealthReport
+}
@@ -0,0 +1,11 @@
+package services
+
+import (
+	"context"
+	"github.com/kiramishima/receipt-processor/domain"
+	"io"
+)
+
+type IImportService interface {
+	ImportReceipts(ctx context.Context, r io.Reader, opts *domain.ImportOptions) (*domain.ImportReport, error)
+}
@@ -0,0 +1,10 @@
+package services
+
+import (
+	"context"
+	"github.com/kiramishima/receipt-processor/domain"
+)
+
+type IParserService interface {
+	ParseReceipt(ctx context.Context, req *domain.ParseRequest) (*domain.ParsedReceipt, error)
+}
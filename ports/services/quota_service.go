@@ -0,0 +1,11 @@
+package services
+
+import (
+	"context"
+	"github.com/kiramishima/receipt-processor/domain"
+)
+
+type IQuotaService interface {
+	ConsumeReceiptQuota(ctx context.Context) (refund func(), err error)
+	ReceiptQuota(ctx context.Context) (*domain.QuotaStatus, error)
+}
@@ -7,5 +7,7 @@ import (
 
 type IReceiptService interface {
 	StoreReceipt(ctx context.Context, base *domain.ReceiptBase) (string, error)
-	RetrieveReceipt(id string) (*domain.Result, error)
+	ScoreReceipt(ctx context.Context, base *domain.ReceiptBase) (*domain.ScoreResult, error)
+	RetrieveReceipt(ctx context.Context, id string) (*domain.Result, error)
+	RescoreReceipts(ctx context.Context, req *domain.RescoreRequest) (*domain.RescoreReport, error)
 }
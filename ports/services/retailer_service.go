@@ -0,0 +1,18 @@
+package services
+
+import (
+	"context"
+	"github.com/kiramishima/receipt-processor/domain"
+)
+
+type IRetailerService interface {
+	CreateRetailer(ctx context.Context, retailer *domain.Retailer) (*domain.Retailer, error)
+	RetrieveRetailer(id string) (*domain.Retailer, error)
+	ListRetailers() ([]*domain.Retailer, error)
+	UpdateRetailer(ctx context.Context, retailer *domain.Retailer) (*domain.Retailer, error)
+	DeleteRetailer(id string) error
+	SaveCategory(ctx context.Context, category *domain.RetailerCategory) (*domain.RetailerCategory, error)
+	ListCategories() ([]*domain.RetailerCategory, error)
+	ResolveRetailer(name string) (*domain.Retailer, error)
+	RuleSetFor(base *domain.RuleSet, retailer *domain.Retailer) (*domain.RuleSet, error)
+}
@@ -0,0 +1,14 @@
+package services
+
+import (
+	"context"
+	"github.com/kiramishima/receipt-processor/domain"
+)
+
+type IRuleSetService interface {
+	CreateRuleSet(ctx context.Context, rs *domain.RuleSet) (*domain.RuleSet, error)
+	RetrieveRuleSet(version string) (*domain.RuleSet, error)
+	ListRuleSets() ([]*domain.RuleSet, error)
+	ActiveRuleSet() (*domain.RuleSet, error)
+	ActivateRuleSet(version string) error
+}
@@ -0,0 +1,13 @@
+package services
+
+import (
+	"context"
+	"github.com/kiramishima/receipt-processor/domain"
+)
+
+type ITierService interface {
+	ResolveMember(memberID string) (*domain.Member, error)
+	FindMember(memberID string) (*domain.Member, error)
+	MultiplierFor(tier domain.Tier) float64
+	RecomputeTiers(ctx context.Context) ([]*domain.TierChangeEvent, error)
+}
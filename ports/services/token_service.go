@@ -0,0 +1,7 @@
+package services
+
+import "github.com/kiramishima/receipt-processor/domain"
+
+type ITokenService interface {
+	Authenticate(token string) (*domain.Principal, error)
+}
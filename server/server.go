@@ -4,10 +4,8 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net"
 	"net/http"
-	"os"
-	"os/signal"
-	"syscall"
 	"time"
 
 	"github.com/kiramishima/receipt-processor/domain"
@@ -18,17 +16,19 @@ import (
 )
 
 const (
-	certFile                = "ssl/Server.crt"
-	keyFile                 = "ssl/Server.pem"
 	maxHeaderBytes          = 1 << 20
-	ctxTimeout              = 10
 	ServerReadHeaderTimeout = 3 * time.Second
 )
 
 type Server struct {
-	router chi.Router
-	logger *zap.SugaredLogger
-	cfg    *domain.Configuration
+	router   chi.Router
+	logger   *zap.SugaredLogger
+	cfg      *domain.Configuration
+	server   *http.Server
+	listener net.Listener
+	done     chan struct{}
+	// stopWatch stops reloading the certificates
+	stopWatch context.CancelFunc
 }
 
 func NewServer(cfg *domain.Configuration, logger *zap.SugaredLogger, r *chi.Mux) *Server {
@@ -39,45 +39,90 @@ func NewServer(cfg *domain.Configuration, logger *zap.SugaredLogger, r *chi.Mux)
 	}
 }
 
-func (s *Server) Run() error {
-	// Create a new http.Server with the specified read header timeout and handler
-	var addr = fmt.Sprintf("%s:%d", s.cfg.ServerAddress, s.cfg.Port)
-
-	server := &http.Server{
-		Addr:              addr,
+// Start listens on the configured address and serves the requests in background, over TLS when it is enabled.
+// Listening and certificate errors, like a port in use, are returned so the application doesn't start.
+func (s *Server) Start() error {
+	s.server = &http.Server{
 		ReadHeaderTimeout: ServerReadHeaderTimeout,
+		ReadTimeout:       s.cfg.ReadTimeout,
+		WriteTimeout:      s.cfg.WriteTimeout,
+		IdleTimeout:       s.cfg.IdleTimeout,
 		MaxHeaderBytes:    maxHeaderBytes,
 		Handler:           s.router,
 	}
+	if s.cfg.TLS {
+		certificates, err := newCertificates(s.cfg.HTTPServer, s.logger)
+		if err != nil {
+			s.server = nil
+			return err
+		}
+		s.server.TLSConfig = certificates.config()
+
+		if s.cfg.TLSReloadInterval > 0 {
+			var ctx context.Context
+			ctx, s.stopWatch = context.WithCancel(context.Background())
+			go certificates.watch(ctx, s.cfg.TLSReloadInterval)
+		}
+	}
+
+	var addr = fmt.Sprintf("%s:%d", s.cfg.ServerAddress, s.cfg.Port)
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		s.server = nil
+		s.stop()
+		return err
+	}
+	s.listener = listener
+	s.done = make(chan struct{})
 
 	go func() {
-		s.logger.Infof("Server is listening on PORT: %d", s.cfg.Port)
+		defer close(s.done)
+		s.logger.Infow("Server is listening", "address", listener.Addr().String(), "tls", s.cfg.TLS, "clientCertificates", s.cfg.TLSClientCAFile != "")
 
-		if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
-			s.logger.Fatalf("Error starting Server: ", err)
+		var err error
+		if s.server.TLSConfig != nil {
+			// The certificates come from the TLS configuration
+			err = s.server.ServeTLS(listener, "", "")
+		} else {
+			err = s.server.Serve(listener)
+		}
+		if err != nil && !errors.Is(err, http.ErrServerClosed) {
+			s.logger.Errorf("Error serving: %s", err)
 		}
 	}()
 	return nil
-	// graceful shutdown
-	// return waitForShutdown(s.logger, server)
 }
 
-// waitForShutdown graceful shutdown
-func waitForShutdown(logger *zap.SugaredLogger, server *http.Server) error {
-	sig := make(chan os.Signal, 1)
-	signal.Notify(sig, os.Interrupt, syscall.SIGTERM)
-	<-sig
+// stop stops the certificate reloads
+func (s *Server) stop() {
+	if s.stopWatch != nil {
+		s.stopWatch()
+	}
+}
 
-	ctx, cancel := context.WithTimeout(context.Background(), ctxTimeout*time.Second)
+// Addr address the server listens on, useful with the port 0
+func (s *Server) Addr() net.Addr {
+	return s.listener.Addr()
+}
+
+// Stop stops accepting connections and waits up to the shutdown timeout for the in-flight requests to complete, then
+// closes the connections still open
+func (s *Server) Stop(ctx context.Context) error {
+	if s.server == nil {
+		return nil
+	}
+	defer s.stop()
+	ctx, cancel := context.WithTimeout(ctx, s.cfg.ShutdownTimeout)
 	defer cancel()
 
-	if err := server.Shutdown(ctx); err != nil {
-		logger.Info("Server Exited Properly")
-		logger.Fatal("Failed gracefully")
-		logger.Fatal("failed to gracefully shut down server", err)
-		return err
+	s.logger.Info("Shutting down the server")
+	err := s.server.Shutdown(ctx)
+	if err != nil {
+		s.logger.Errorf("Requests still running after the shutdown timeout: %s", err)
+		_ = s.server.Close()
 	}
-	return nil
+	<-s.done
+	return err
 }
 
 // Module Server Module
@@ -0,0 +1,109 @@
+package server
+
+import (
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/kiramishima/receipt-processor/domain"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+func newTestServer(handler http.HandlerFunc, shutdownTimeout time.Duration) *Server {
+	var r = chi.NewRouter()
+	r.Get("/slow", handler)
+	var cfg = &domain.Configuration{HTTPServer: domain.HTTPServer{ServerAddress: "127.0.0.1", ShutdownTimeout: shutdownTimeout}}
+	logger, _ := zap.NewProduction()
+	return NewServer(cfg, logger.Sugar(), r)
+}
+
+func TestServer_StopCompletesInFlightRequests(t *testing.T) {
+	var started, release = make(chan struct{}), make(chan struct{})
+	var server = newTestServer(func(w http.ResponseWriter, req *http.Request) {
+		close(started)
+		<-release
+		_, _ = io.WriteString(w, "done")
+	}, 5*time.Second)
+	if !assert.NoError(t, server.Start()) {
+		return
+	}
+
+	type response struct {
+		body string
+		err  error
+	}
+	var responses = make(chan response, 1)
+	go func() {
+		resp, err := http.Get("http://" + server.Addr().String() + "/slow")
+		if err != nil {
+			responses <- response{err: err}
+			return
+		}
+		defer resp.Body.Close()
+		body, err := io.ReadAll(resp.Body)
+		responses <- response{body: string(body), err: err}
+	}()
+	<-started
+
+	var stopped = make(chan error, 1)
+	go func() {
+		stopped <- server.Stop(context.Background())
+	}()
+
+	// The server waits for the request and refuses new connections meanwhile
+	assert.Eventually(t, func() bool {
+		_, err := http.Get("http://" + server.Addr().String() + "/slow")
+		return err != nil
+	}, time.Second, 10*time.Millisecond)
+	select {
+	case <-stopped:
+		t.Fatal("server stopped before the in-flight request completed")
+	default:
+	}
+
+	close(release)
+	var resp = <-responses
+	assert.NoError(t, resp.err)
+	assert.Equal(t, "done", resp.body)
+	assert.NoError(t, <-stopped)
+}
+
+func TestServer_StopTimeout(t *testing.T) {
+	var started, release = make(chan struct{}), make(chan struct{})
+	defer close(release)
+	var server = newTestServer(func(w http.ResponseWriter, req *http.Request) {
+		close(started)
+		<-release
+	}, 50*time.Millisecond)
+	if !assert.NoError(t, server.Start()) {
+		return
+	}
+
+	go func() {
+		resp, err := http.Get("http://" + server.Addr().String() + "/slow")
+		if err == nil {
+			resp.Body.Close()
+		}
+	}()
+	<-started
+
+	assert.ErrorIs(t, server.Stop(context.Background()), context.DeadlineExceeded)
+}
+
+func TestServer_StartFailsOnPortInUse(t *testing.T) {
+	var first = newTestServer(nil, time.Second)
+	if !assert.NoError(t, first.Start()) {
+		return
+	}
+	defer first.Stop(context.Background())
+
+	var second = newTestServer(nil, time.Second)
+	second.cfg.Port = first.Addr().(*net.TCPAddr).Port
+	assert.Error(t, second.Start())
+	assert.NoError(t, second.Stop(context.Background()))
+}
@@ -0,0 +1,153 @@
+package server
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/kiramishima/receipt-processor/domain"
+	"go.uber.org/zap"
+)
+
+// fileStamp modification time and size of a file, a change means the file was replaced
+type fileStamp struct {
+	modTime time.Time
+	size    int64
+}
+
+// certificates keeps the server certificate and the client CAs loaded from the configured files, and reloads them
+// when the files change so the certificates can be renewed without restarting the server
+type certificates struct {
+	logger      *zap.SugaredLogger
+	certFile    string
+	keyFile     string
+	clientCA    string
+	mu          sync.RWMutex
+	certificate *tls.Certificate
+	clientCAs   *x509.CertPool
+	stamps      map[string]fileStamp
+}
+
+func newCertificates(cfg domain.HTTPServer, logger *zap.SugaredLogger) (*certificates, error) {
+	var c = &certificates{
+		logger:   logger,
+		certFile: cfg.TLSCertFile,
+		keyFile:  cfg.TLSKeyFile,
+		clientCA: cfg.TLSClientCAFile,
+	}
+	if err := c.load(); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+func (c *certificates) files() []string {
+	if c.clientCA == "" {
+		return []string{c.certFile, c.keyFile}
+	}
+	return []string{c.certFile, c.keyFile, c.clientCA}
+}
+
+// load reads the files, replacing the certificates only when every file is valid
+func (c *certificates) load() error {
+	var stamps = make(map[string]fileStamp)
+	for _, file := range c.files() {
+		info, err := os.Stat(file)
+		if err != nil {
+			return err
+		}
+		stamps[file] = fileStamp{modTime: info.ModTime(), size: info.Size()}
+	}
+
+	certificate, err := tls.LoadX509KeyPair(c.certFile, c.keyFile)
+	if err != nil {
+		return fmt.Errorf("loading certificate %s: %w", c.certFile, err)
+	}
+	var clientCAs *x509.CertPool
+	if c.clientCA != "" {
+		content, err := os.ReadFile(c.clientCA)
+		if err != nil {
+			return err
+		}
+		clientCAs = x509.NewCertPool()
+		if !clientCAs.AppendCertsFromPEM(content) {
+			return fmt.Errorf("no certificates in the client CA file %s", c.clientCA)
+		}
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.certificate, c.clientCAs, c.stamps = &certificate, clientCAs, stamps
+	return nil
+}
+
+// changed returns true if a file was modified since it was loaded
+func (c *certificates) changed() bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	for _, file := range c.files() {
+		info, err := os.Stat(file)
+		if err != nil {
+			// A file being replaced, it is checked again on the next tick
+			continue
+		}
+		if (fileStamp{modTime: info.ModTime(), size: info.Size()}) != c.stamps[file] {
+			return true
+		}
+	}
+	return false
+}
+
+// watch reloads the files when they change until the context is canceled. Invalid files are logged and the loaded
+// certificates are kept.
+func (c *certificates) watch(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		if !c.changed() {
+			continue
+		}
+		if err := c.load(); err != nil {
+			c.logger.Errorf("Keeping the current certificates, reload failed: %s", err)
+			continue
+		}
+		c.logger.Infof("Certificates reloaded from %s", c.certFile)
+	}
+}
+
+// config returns the TLS configuration serving the current certificates. With client CAs the clients must present a
+// certificate signed by one of them.
+func (c *certificates) config() *tls.Config {
+	var config = &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		GetCertificate: func(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+			c.mu.RLock()
+			defer c.mu.RUnlock()
+			return c.certificate, nil
+		},
+	}
+	if c.clientCA == "" {
+		return config
+	}
+
+	config.ClientAuth = tls.RequireAndVerifyClientCert
+	config.GetConfigForClient = func(*tls.ClientHelloInfo) (*tls.Config, error) {
+		c.mu.RLock()
+		defer c.mu.RUnlock()
+		var client = config.Clone()
+		client.GetConfigForClient = nil
+		client.ClientCAs = c.clientCAs
+		return client, nil
+	}
+	return config
+}
@@ -0,0 +1,169 @@
+package server
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io"
+	"math/big"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testCA signs the server and client certificates of the tests
+type testCA struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+	pem  []byte
+}
+
+func newTestCA(t *testing.T) *testCA {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	var template = &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "test CA"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	return &testCA{cert: cert, key: key, pem: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})}
+}
+
+// issue returns the PEM certificate and key of a leaf certificate with the serial number
+func (ca *testCA) issue(t *testing.T, serial int64, usage x509.ExtKeyUsage) ([]byte, []byte) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	var template = &x509.Certificate{
+		SerialNumber: big.NewInt(serial),
+		Subject:      pkix.Name{CommonName: "localhost"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{usage},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, ca.cert, &key.PublicKey, ca.key)
+	require.NoError(t, err)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+}
+
+func writeFile(t *testing.T, path string, content []byte) {
+	require.NoError(t, os.WriteFile(path, content, 0o600))
+}
+
+func newTLSServer(t *testing.T, dir string, clientCA bool) *Server {
+	var server = newTestServer(func(w http.ResponseWriter, req *http.Request) {
+		_, _ = io.WriteString(w, "ok")
+	}, time.Second)
+	server.cfg.TLS = true
+	server.cfg.TLSCertFile = filepath.Join(dir, "server.crt")
+	server.cfg.TLSKeyFile = filepath.Join(dir, "server.pem")
+	server.cfg.TLSReloadInterval = 10 * time.Millisecond
+	if clientCA {
+		server.cfg.TLSClientCAFile = filepath.Join(dir, "ca.crt")
+	}
+	return server
+}
+
+func tlsClient(ca *testCA, certificates ...tls.Certificate) *http.Client {
+	var roots = x509.NewCertPool()
+	roots.AddCert(ca.cert)
+	return &http.Client{Transport: &http.Transport{
+		TLSClientConfig:   &tls.Config{RootCAs: roots, Certificates: certificates},
+		DisableKeepAlives: true,
+	}}
+}
+
+func TestServer_TLSReload(t *testing.T) {
+	var ca = newTestCA(t)
+	var dir = t.TempDir()
+	cert, key := ca.issue(t, 10, x509.ExtKeyUsageServerAuth)
+	writeFile(t, filepath.Join(dir, "server.crt"), cert)
+	writeFile(t, filepath.Join(dir, "server.pem"), key)
+
+	var server = newTLSServer(t, dir, false)
+	require.NoError(t, server.Start())
+	defer server.Stop(context.Background())
+
+	var client = tlsClient(ca)
+	var serial = func() int64 {
+		resp, err := client.Get("https://" + server.Addr().String() + "/slow")
+		if err != nil {
+			return 0
+		}
+		defer resp.Body.Close()
+		return resp.TLS.PeerCertificates[0].SerialNumber.Int64()
+	}
+	assert.Equal(t, int64(10), serial())
+
+	// An invalid key keeps the loaded certificate
+	writeFile(t, filepath.Join(dir, "server.pem"), []byte("invalid"))
+	time.Sleep(50 * time.Millisecond)
+	assert.Equal(t, int64(10), serial())
+
+	cert, key = ca.issue(t, 11, x509.ExtKeyUsageServerAuth)
+	writeFile(t, filepath.Join(dir, "server.crt"), cert)
+	writeFile(t, filepath.Join(dir, "server.pem"), key)
+	assert.Eventually(t, func() bool { return serial() == 11 }, time.Second, 10*time.Millisecond)
+}
+
+func TestServer_MutualTLS(t *testing.T) {
+	var ca = newTestCA(t)
+	var dir = t.TempDir()
+	cert, key := ca.issue(t, 10, x509.ExtKeyUsageServerAuth)
+	writeFile(t, filepath.Join(dir, "server.crt"), cert)
+	writeFile(t, filepath.Join(dir, "server.pem"), key)
+	writeFile(t, filepath.Join(dir, "ca.crt"), ca.pem)
+
+	var server = newTLSServer(t, dir, true)
+	require.NoError(t, server.Start())
+	defer server.Stop(context.Background())
+	var url = "https://" + server.Addr().String() + "/slow"
+
+	_, err := tlsClient(ca).Get(url)
+	assert.Error(t, err)
+
+	clientCert, clientKey := ca.issue(t, 20, x509.ExtKeyUsageClientAuth)
+	certificate, err := tls.X509KeyPair(clientCert, clientKey)
+	require.NoError(t, err)
+	resp, err := tlsClient(ca, certificate).Get(url)
+	if assert.NoError(t, err) {
+		defer resp.Body.Close()
+		body, _ := io.ReadAll(resp.Body)
+		assert.Equal(t, "ok", string(body))
+	}
+
+	// A client certificate of another CA is rejected
+	otherCert, otherKey := newTestCA(t).issue(t, 30, x509.ExtKeyUsageClientAuth)
+	other, err := tls.X509KeyPair(otherCert, otherKey)
+	require.NoError(t, err)
+	_, err = tlsClient(ca, other).Get(url)
+	assert.Error(t, err)
+}
+
+func TestServer_TLSMissingCertificate(t *testing.T) {
+	var server = newTLSServer(t, t.TempDir(), false)
+	assert.Error(t, server.Start())
+	assert.NoError(t, server.Stop(context.Background()))
+}
@@ -0,0 +1,193 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"github.com/kiramishima/receipt-processor/domain"
+	appErrors "github.com/kiramishima/receipt-processor/pkg/errors"
+	"github.com/kiramishima/receipt-processor/pkg/logging"
+	ports "github.com/kiramishima/receipt-processor/ports/repository"
+	"go.uber.org/zap"
+	"strings"
+	"time"
+)
+
+const (
+	// apiKeyBytes random bytes of a key
+	apiKeyBytes = 32
+	// minBootstrapKeyLength shortest bootstrap key accepted
+	minBootstrapKeyLength = 32
+	// lastUsedResolution the last use of a key is only recorded again after this time, not on every request
+	lastUsedResolution = time.Minute
+	// bootstrapKeyID id of the bootstrap key
+	bootstrapKeyID = "bootstrap"
+)
+
+type APIKeyService struct {
+	logger     *zap.SugaredLogger
+	cfg        domain.AuthConfig
+	repository ports.IAPIKeyRepository
+}
+
+func NewAPIKeyService(cfg domain.AuthConfig, repository ports.IAPIKeyRepository, logger *zap.SugaredLogger) *APIKeyService {
+	return &APIKeyService{
+		logger:     logger,
+		cfg:        cfg,
+		repository: repository,
+	}
+}
+
+// IssueAPIKey creates a key with the scopes of the request, the key is returned once and only its hash is stored
+func (svc *APIKeyService) IssueAPIKey(ctx context.Context, req *domain.APIKeyRequest) (*domain.IssuedAPIKey, error) {
+	if err := validate.StructCtx(ctx, *req); err != nil {
+		logging.Logger(ctx, svc.logger).Error(err)
+		return nil, validationError(err)
+	}
+	var now = time.Now().UTC()
+	if req.ExpiresAt != nil && !req.ExpiresAt.After(now) {
+		return nil, fmt.Errorf("%w: expiresAt is in the past", appErrors.BadRequest)
+	}
+
+	issued, err := svc.issue(&domain.APIKey{Name: req.Name, Scopes: req.Scopes, ExpiresAt: req.ExpiresAt, DailyReceiptQuota: req.DailyReceiptQuota, CreatedAt: now})
+	if err != nil {
+		return nil, err
+	}
+	logging.Logger(ctx, svc.logger).Infow("API key issued", "keyId", issued.ID, "name", issued.Name, "scopes", issued.Scopes)
+	return issued, nil
+}
+
+// issue generates the key of the API key and stores it
+func (svc *APIKeyService) issue(key *domain.APIKey) (*domain.IssuedAPIKey, error) {
+	var secret = make([]byte, apiKeyBytes)
+	if _, err := rand.Read(secret); err != nil {
+		return nil, err
+	}
+	var plain = domain.APIKeyPrefix + base64.RawURLEncoding.EncodeToString(secret)
+	key.Prefix = plain[:len(domain.APIKeyPrefix)+8]
+	key.Hash = hashAPIKey(plain)
+
+	id, err := svc.repository.SaveAPIKey(key)
+	if err != nil {
+		return nil, err
+	}
+	stored, err := svc.repository.FindAPIKeyById(id)
+	if err != nil {
+		return nil, err
+	}
+	return &domain.IssuedAPIKey{APIKey: stored, Key: plain}, nil
+}
+
+// RetrieveAPIKey recover an API key by id
+func (svc *APIKeyService) RetrieveAPIKey(id string) (*domain.APIKey, error) {
+	return svc.repository.FindAPIKeyById(id)
+}
+
+// ListAPIKeys returns all the API keys, the revoked and expired ones included
+func (svc *APIKeyService) ListAPIKeys() ([]*domain.APIKey, error) {
+	return svc.repository.ListAPIKeys()
+}
+
+// RevokeAPIKey stops accepting a key at once, the key is kept to audit its use
+func (svc *APIKeyService) RevokeAPIKey(ctx context.Context, id string) error {
+	key, err := svc.repository.FindAPIKeyById(id)
+	if err != nil {
+		return err
+	}
+	if key.RevokedAt != nil {
+		return nil
+	}
+	var now = time.Now().UTC()
+	key.RevokedAt = &now
+	if err := svc.repository.UpdateAPIKey(key); err != nil {
+		return err
+	}
+	logging.Logger(ctx, svc.logger).Infow("API key revoked", "keyId", key.ID, "name", key.Name)
+	return nil
+}
+
+// RotateAPIKey issues a new key with the name, scopes and expiration of a key. The previous key keeps working for
+// the rotation grace time, so the client can switch to the new key without downtime.
+func (svc *APIKeyService) RotateAPIKey(ctx context.Context, id string) (*domain.IssuedAPIKey, error) {
+	key, err := svc.repository.FindAPIKeyById(id)
+	if err != nil {
+		return nil, err
+	}
+	var now = time.Now().UTC()
+	if !key.IsActive(now) {
+		return nil, fmt.Errorf("%w: API key %s is revoked or expired", appErrors.BadRequest, id)
+	}
+	if key.RotatedTo != "" {
+		return nil, fmt.Errorf("%w: API key %s was already rotated to %s", appErrors.BadRequest, id, key.RotatedTo)
+	}
+
+	issued, err := svc.issue(&domain.APIKey{Name: key.Name, Scopes: key.Scopes, ExpiresAt: key.ExpiresAt, DailyReceiptQuota: key.DailyReceiptQuota, CreatedAt: now})
+	if err != nil {
+		return nil, err
+	}
+	var graceEnd = now.Add(svc.cfg.APIKeyRotationGrace)
+	if key.ExpiresAt == nil || graceEnd.Before(*key.ExpiresAt) {
+		key.ExpiresAt = &graceEnd
+	}
+	key.RotatedTo = issued.ID
+	if err := svc.repository.UpdateAPIKey(key); err != nil {
+		return nil, err
+	}
+	logging.Logger(ctx, svc.logger).Infow("API key rotated", "keyId", key.ID, "rotatedTo", issued.ID, "expiresAt", key.ExpiresAt)
+	return issued, nil
+}
+
+// Authenticate returns the active API key of the key and records its use
+func (svc *APIKeyService) Authenticate(key string) (*domain.APIKey, error) {
+	if key == "" {
+		return nil, fmt.Errorf("%w: missing API key", appErrors.Unauthorized)
+	}
+	stored, err := svc.repository.FindAPIKeyByHash(hashAPIKey(key))
+	if errors.Is(err, appErrors.NotFound) {
+		return nil, fmt.Errorf("%w: invalid API key", appErrors.Unauthorized)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var now = time.Now().UTC()
+	if !stored.IsActive(now) {
+		return nil, fmt.Errorf("%w: API key is revoked or expired", appErrors.Unauthorized)
+	}
+	if stored.LastUsedAt == nil || now.Sub(*stored.LastUsedAt) >= lastUsedResolution {
+		if err := svc.repository.TouchAPIKey(stored.ID, now); err != nil {
+			svc.logger.Warnw("API key last use not recorded", "keyId", stored.ID, "error", err)
+		}
+	}
+	return stored, nil
+}
+
+// RegisterBootstrapKey registers the key with the admin scope, to issue the first keys of the clients. The key has
+// the prefix of the API keys, the bearer tokens without it are taken for JWTs
+func (svc *APIKeyService) RegisterBootstrapKey(key string) error {
+	if !strings.HasPrefix(key, domain.APIKeyPrefix) {
+		return fmt.Errorf("the bootstrap API key must start with %s", domain.APIKeyPrefix)
+	}
+	if len(key) < minBootstrapKeyLength {
+		return fmt.Errorf("the bootstrap API key must have at least %d characters", minBootstrapKeyLength)
+	}
+	_, err := svc.repository.SaveAPIKey(&domain.APIKey{
+		ID:        bootstrapKeyID,
+		Name:      bootstrapKeyID,
+		Prefix:    key[:len(domain.APIKeyPrefix)+8],
+		Hash:      hashAPIKey(key),
+		Scopes:    []string{domain.ScopeAdmin},
+		CreatedAt: time.Now().UTC(),
+	})
+	return err
+}
+
+// hashAPIKey the keys are random, a SHA-256 of them can't be reversed and finds them with a lookup
+func hashAPIKey(key string) string {
+	var sum = sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
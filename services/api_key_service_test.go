@@ -0,0 +1,128 @@
+package services
+
+import (
+	"context"
+	"github.com/kiramishima/receipt-processor/domain"
+	"github.com/kiramishima/receipt-processor/mocks"
+	appErrors "github.com/kiramishima/receipt-processor/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"go.uber.org/zap"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestAPIKeyService(t *testing.T) {
+	logger, _ := zap.NewProduction()
+	slogger := logger.Sugar()
+	mockCtrl := gomock.NewController(t)
+
+	defer mockCtrl.Finish()
+	repo := mocks.NewMockIAPIKeyRepository(mockCtrl)
+	svc := NewAPIKeyService(domain.AuthConfig{APIKeyRotationGrace: time.Hour}, repo, slogger)
+
+	// the mocked repository keeps the saved keys
+	var keys = map[string]*domain.APIKey{}
+	repo.EXPECT().SaveAPIKey(gomock.Any()).DoAndReturn(func(key *domain.APIKey) (string, error) {
+		if key.ID == "" {
+			key.ID = key.Prefix
+		}
+		var item = *key
+		keys[key.ID] = &item
+		return key.ID, nil
+	}).AnyTimes()
+	repo.EXPECT().FindAPIKeyById(gomock.Any()).DoAndReturn(func(id string) (*domain.APIKey, error) {
+		if key, ok := keys[id]; ok {
+			var item = *key
+			return &item, nil
+		}
+		return nil, appErrors.NotFound
+	}).AnyTimes()
+	repo.EXPECT().FindAPIKeyByHash(gomock.Any()).DoAndReturn(func(hash string) (*domain.APIKey, error) {
+		for _, key := range keys {
+			if key.Hash == hash {
+				var item = *key
+				return &item, nil
+			}
+		}
+		return nil, appErrors.NotFound
+	}).AnyTimes()
+	repo.EXPECT().UpdateAPIKey(gomock.Any()).DoAndReturn(func(key *domain.APIKey) error {
+		var item = *key
+		keys[key.ID] = &item
+		return nil
+	}).AnyTimes()
+
+	var issued *domain.IssuedAPIKey
+	t.Run("Issue", func(t *testing.T) {
+		var err error
+		issued, err = svc.IssueAPIKey(context.Background(), &domain.APIKeyRequest{Name: "pos", Scopes: []string{domain.ScopeReceiptsWrite}})
+		assert.NoError(t, err)
+		assert.True(t, strings.HasPrefix(issued.Key, "rp_"))
+		assert.True(t, strings.HasPrefix(issued.Key, issued.Prefix))
+		assert.Len(t, issued.Prefix, 11)
+		assert.Equal(t, hashAPIKey(issued.Key), keys[issued.ID].Hash)
+		assert.NotContains(t, keys[issued.ID].Hash, issued.Key)
+	})
+
+	t.Run("Issue invalid scope", func(t *testing.T) {
+		_, err := svc.IssueAPIKey(context.Background(), &domain.APIKeyRequest{Name: "pos", Scopes: []string{"receipts:delete"}})
+		assert.ErrorIs(t, err, appErrors.BadRequest)
+	})
+
+	t.Run("Authenticate", func(t *testing.T) {
+		repo.EXPECT().TouchAPIKey(gomock.Eq(issued.ID), gomock.Any()).Times(1).DoAndReturn(func(id string, usedAt time.Time) error {
+			keys[id].LastUsedAt = &usedAt
+			return nil
+		})
+		key, err := svc.Authenticate(issued.Key)
+		assert.NoError(t, err)
+		assert.Equal(t, issued.ID, key.ID)
+		// the last use was recorded less than a minute ago
+		_, err = svc.Authenticate(issued.Key)
+		assert.NoError(t, err)
+	})
+
+	t.Run("Authenticate invalid", func(t *testing.T) {
+		_, err := svc.Authenticate("")
+		assert.ErrorIs(t, err, appErrors.Unauthorized)
+		_, err = svc.Authenticate("rp_unknown")
+		assert.ErrorIs(t, err, appErrors.Unauthorized)
+	})
+
+	t.Run("Rotate", func(t *testing.T) {
+		rotated, err := svc.RotateAPIKey(context.Background(), issued.ID)
+		assert.NoError(t, err)
+		assert.NotEqual(t, issued.Key, rotated.Key)
+		assert.Equal(t, issued.Scopes, rotated.Scopes)
+
+		var previous = keys[issued.ID]
+		assert.Equal(t, rotated.ID, previous.RotatedTo)
+		assert.WithinDuration(t, time.Now().Add(time.Hour), *previous.ExpiresAt, time.Minute)
+		// the previous key works during the grace time
+		_, err = svc.Authenticate(issued.Key)
+		assert.NoError(t, err)
+
+		_, err = svc.RotateAPIKey(context.Background(), issued.ID)
+		assert.ErrorIs(t, err, appErrors.BadRequest)
+	})
+
+	t.Run("Revoke", func(t *testing.T) {
+		assert.NoError(t, svc.RevokeAPIKey(context.Background(), issued.ID))
+		_, err := svc.Authenticate(issued.Key)
+		assert.ErrorIs(t, err, appErrors.Unauthorized)
+		assert.ErrorIs(t, svc.RevokeAPIKey(context.Background(), "unknown"), appErrors.NotFound)
+	})
+
+	t.Run("Bootstrap key", func(t *testing.T) {
+		assert.Error(t, svc.RegisterBootstrapKey("rp_short"))
+		assert.Error(t, svc.RegisterBootstrapKey(strings.Repeat("b", 32)))
+		var bootstrapKey = domain.APIKeyPrefix + strings.Repeat("b", 32)
+		assert.NoError(t, svc.RegisterBootstrapKey(bootstrapKey))
+		repo.EXPECT().TouchAPIKey(gomock.Eq("bootstrap"), gomock.Any()).Times(1).Return(nil)
+		key, err := svc.Authenticate(bootstrapKey)
+		assert.NoError(t, err)
+		assert.True(t, key.HasScope(domain.ScopeReceiptsWrite))
+	})
+}
@@ -0,0 +1,88 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"github.com/kiramishima/receipt-processor/domain"
+	appErrors "github.com/kiramishima/receipt-processor/pkg/errors"
+	ports "github.com/kiramishima/receipt-processor/ports/repository"
+	"github.com/samber/lo"
+	"go.uber.org/zap"
+	"time"
+)
+
+type CampaignService struct {
+	logger     *zap.SugaredLogger
+	repository ports.ICampaignRepository
+}
+
+func NewCampaignService(repository ports.ICampaignRepository, logger *zap.SugaredLogger) *CampaignService {
+	return &CampaignService{
+		logger:     logger,
+		repository: repository,
+	}
+}
+
+// CreateCampaign validates and stores a new campaign
+func (svc *CampaignService) CreateCampaign(ctx context.Context, campaign *domain.Campaign) (*domain.Campaign, error) {
+	if err := svc.validate(ctx, campaign); err != nil {
+		return nil, err
+	}
+
+	id, err := svc.repository.SaveCampaign(campaign)
+	if err != nil {
+		return nil, err
+	}
+	return svc.repository.FindCampaignById(id)
+}
+
+// RetrieveCampaign recover a campaign by id
+func (svc *CampaignService) RetrieveCampaign(id string) (*domain.Campaign, error) {
+	return svc.repository.FindCampaignById(id)
+}
+
+// ListCampaigns returns all the campaigns
+func (svc *CampaignService) ListCampaigns() ([]*domain.Campaign, error) {
+	return svc.repository.ListCampaigns()
+}
+
+// UpdateCampaign validates and replaces a stored campaign
+func (svc *CampaignService) UpdateCampaign(ctx context.Context, campaign *domain.Campaign) (*domain.Campaign, error) {
+	if err := svc.validate(ctx, campaign); err != nil {
+		return nil, err
+	}
+
+	if err := svc.repository.UpdateCampaign(campaign); err != nil {
+		return nil, err
+	}
+	return svc.repository.FindCampaignById(campaign.ID)
+}
+
+// DeleteCampaign removes a campaign
+func (svc *CampaignService) DeleteCampaign(id string) error {
+	return svc.repository.DeleteCampaign(id)
+}
+
+// ActiveCampaigns returns the campaigns running at the given time
+func (svc *CampaignService) ActiveCampaigns(at time.Time) ([]*domain.Campaign, error) {
+	campaigns, err := svc.repository.ListCampaigns()
+	if err != nil {
+		return nil, err
+	}
+	return lo.Filter(campaigns, func(c *domain.Campaign, _ int) bool {
+		return c.IsActive(at)
+	}), nil
+}
+
+func (svc *CampaignService) validate(ctx context.Context, campaign *domain.Campaign) error {
+	err := validate.StructCtx(ctx, *campaign)
+	if err != nil {
+		svc.logger.Error(err)
+		return validationError(err)
+	}
+
+	if campaign.Multiplier == 0 && campaign.Bonus == 0 {
+		return fmt.Errorf("%w: a campaign requires a multiplier or a bonus", appErrors.BadRequest)
+	}
+	return nil
+}
@@ -0,0 +1,89 @@
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/kiramishima/receipt-processor/domain"
+	appErrors "github.com/kiramishima/receipt-processor/pkg/errors"
+	"go.uber.org/zap"
+	"os"
+)
+
+type CurrencyService struct {
+	logger *zap.SugaredLogger
+	cfg    domain.CurrencyConfig
+	rates  *domain.ExchangeRates
+}
+
+func NewCurrencyService(cfg domain.CurrencyConfig, logger *zap.SugaredLogger) *CurrencyService {
+	return &CurrencyService{
+		logger: logger,
+		cfg:    cfg,
+		rates:  &domain.ExchangeRates{Base: cfg.BaseCurrency},
+	}
+}
+
+// LoadExchangeRates loads the exchange rate table of a JSON file, its base must be the configured base currency
+func (svc *CurrencyService) LoadExchangeRates(path string) error {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	var rates domain.ExchangeRates
+	if err := json.Unmarshal(content, &rates); err != nil {
+		return err
+	}
+	if err := validate.Struct(rates); err != nil {
+		return validationError(err)
+	}
+	if rates.Base != svc.cfg.BaseCurrency {
+		return fmt.Errorf("%w: exchange rates base %s is not the base currency %s", appErrors.BadRequest, rates.Base, svc.cfg.BaseCurrency)
+	}
+
+	svc.rates = &rates
+	svc.logger.Infof("Exchange rates loaded: %d currencies, base %s", len(rates.Rates), rates.Base)
+	return nil
+}
+
+// BaseCurrency returns the currency of the receipts without currency
+func (svc *CurrencyService) BaseCurrency() string {
+	return svc.cfg.BaseCurrency
+}
+
+// ExchangeRate returns the units of the base currency worth one unit of the currency
+func (svc *CurrencyService) ExchangeRate(currency string) (float64, error) {
+	rate, ok := svc.rates.Rate(currency)
+	if !ok {
+		return 0, fmt.Errorf("%w: no exchange rate for %s", appErrors.BadRequest, currency)
+	}
+	return rate, nil
+}
+
+// ReceiptRate returns the exchange rate stored with the receipts in the currency. The rate is only required when the
+// rules are evaluated in the base currency, otherwise the receipts in a currency without rate are stored without one
+func (svc *CurrencyService) ReceiptRate(currency string) (float64, error) {
+	rate, err := svc.ExchangeRate(currency)
+	if err != nil && svc.cfg.RulesCurrency != domain.RulesCurrencyBase {
+		return 0, nil
+	}
+	return rate, err
+}
+
+// RulesReceipt returns the receipt in the currency the rules are evaluated in, the receipts are converted to the base
+// currency with the rate they were stored with when the rules are evaluated in the base currency. The receipts stored
+// without rate are converted with the current one, and kept in their currency when there is none.
+func (svc *CurrencyService) RulesReceipt(receipt *domain.Receipt) *domain.Receipt {
+	if svc.cfg.RulesCurrency != domain.RulesCurrencyBase || receipt.Currency == "" || receipt.Currency == svc.cfg.BaseCurrency {
+		return receipt
+	}
+	var rate = receipt.ExchangeRate
+	if rate == 0 {
+		var ok bool
+		if rate, ok = svc.rates.Rate(receipt.Currency); !ok {
+			svc.logger.Warnw("receipt without exchange rate evaluated in its currency", "currency", receipt.Currency)
+			return receipt
+		}
+	}
+	return receipt.InCurrency(svc.cfg.BaseCurrency, rate)
+}
@@ -0,0 +1,100 @@
+package services
+
+import (
+	"github.com/kiramishima/receipt-processor/domain"
+	appErrors "github.com/kiramishima/receipt-processor/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestCurrencyService_LoadExchangeRates(t *testing.T) {
+	logger, _ := zap.NewProduction()
+	slogger := logger.Sugar()
+	svc := NewCurrencyService(usd, slogger)
+
+	_, err := svc.ExchangeRate("CAD")
+	assert.ErrorIs(t, err, appErrors.BadRequest)
+
+	var write = func(content string) string {
+		var path = filepath.Join(t.TempDir(), "rates.json")
+		assert.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+		return path
+	}
+	assert.NoError(t, svc.LoadExchangeRates(write(`{"base": "USD", "rates": {"CAD": 0.74, "MXN": 0.058}}`)))
+	rate, err := svc.ExchangeRate("CAD")
+	assert.NoError(t, err)
+	assert.Equal(t, 0.74, rate)
+	rate, err = svc.ExchangeRate("USD")
+	assert.NoError(t, err)
+	assert.Equal(t, 1.0, rate)
+
+	t.Run("Invalid files", func(t *testing.T) {
+		assert.ErrorIs(t, svc.LoadExchangeRates(write(`{"base": "CAD", "rates": {"USD": 1.35}}`)), appErrors.BadRequest)
+		assert.ErrorIs(t, svc.LoadExchangeRates(write(`{"base": "USD", "rates": {"CAD": 0}}`)), appErrors.BadRequest)
+		assert.ErrorIs(t, svc.LoadExchangeRates(write(`{"base": "USD", "rates": {"XYZ": 1}}`)), appErrors.BadRequest)
+		assert.Error(t, svc.LoadExchangeRates(write(`{"base": "USD", "rates": [0.74]}`)))
+		assert.Error(t, svc.LoadExchangeRates(filepath.Join(t.TempDir(), "missing.json")))
+		// The rates loaded are kept
+		rate, err := svc.ExchangeRate("MXN")
+		assert.NoError(t, err)
+		assert.Equal(t, 0.058, rate)
+	})
+}
+
+func TestCurrencyService_ReceiptRate(t *testing.T) {
+	logger, _ := zap.NewProduction()
+	slogger := logger.Sugar()
+
+	t.Run("Receipt currency", func(t *testing.T) {
+		rate, err := NewCurrencyService(usd, slogger).ReceiptRate("CAD")
+		assert.NoError(t, err)
+		assert.Zero(t, rate)
+	})
+
+	t.Run("Base currency", func(t *testing.T) {
+		svc := NewCurrencyService(domain.CurrencyConfig{BaseCurrency: "USD", RulesCurrency: domain.RulesCurrencyBase}, slogger)
+		_, err := svc.ReceiptRate("CAD")
+		assert.ErrorIs(t, err, appErrors.BadRequest)
+		rate, err := svc.ReceiptRate("USD")
+		assert.NoError(t, err)
+		assert.Equal(t, 1.0, rate)
+	})
+}
+
+func TestCurrencyService_RulesReceipt(t *testing.T) {
+	logger, _ := zap.NewProduction()
+	slogger := logger.Sugar()
+	var receipt = &domain.Receipt{
+		Retailer:     "Tim Hortons",
+		PurchaseDT:   time.Date(2022, 1, 1, 13, 1, 0, 0, time.UTC),
+		Currency:     "CAD",
+		ExchangeRate: 0.75,
+		Total:        10,
+	}
+
+	t.Run("Receipt currency", func(t *testing.T) {
+		svc := NewCurrencyService(usd, slogger)
+		assert.Same(t, receipt, svc.RulesReceipt(receipt))
+	})
+
+	t.Run("Base currency", func(t *testing.T) {
+		svc := NewCurrencyService(domain.CurrencyConfig{BaseCurrency: "USD", RulesCurrency: domain.RulesCurrencyBase}, slogger)
+		var converted = svc.RulesReceipt(receipt)
+		assert.Equal(t, "USD", converted.Currency)
+		assert.Equal(t, float32(7.5), converted.Total)
+
+		// Receipts stored without currency are in the base currency
+		var stored = &domain.Receipt{Retailer: "Target", Total: 10}
+		assert.Same(t, stored, svc.RulesReceipt(stored))
+
+		// Receipts stored without rate keep their currency until there is one
+		var withoutRate = &domain.Receipt{Retailer: "Tim Hortons", Currency: "CAD", Total: 10}
+		assert.Same(t, withoutRate, svc.RulesReceipt(withoutRate))
+		svc.rates = &domain.ExchangeRates{Base: "USD", Rates: map[string]float64{"CAD": 0.8}}
+		assert.Equal(t, float32(8), svc.RulesReceipt(withoutRate).Total)
+	})
+}
@@ -0,0 +1,151 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"github.com/kiramishima/receipt-processor/domain"
+	"github.com/kiramishima/receipt-processor/pkg/email"
+	appErrors "github.com/kiramishima/receipt-processor/pkg/errors"
+	"github.com/kiramishima/receipt-processor/pkg/logging"
+	repositoryPorts "github.com/kiramishima/receipt-processor/ports/repository"
+	ports "github.com/kiramishima/receipt-processor/ports/services"
+	"go.uber.org/zap"
+	"io"
+	"strings"
+	"time"
+)
+
+// senderConfidence confidence of the purchase date, time and retailer taken from the message headers
+const senderConfidence = 0.5
+
+type EmailService struct {
+	logger    *zap.SugaredLogger
+	cfg       domain.EmailConfig
+	parser    ports.IParserService
+	retailers ports.IRetailerService
+	receipts  ports.IReceiptService
+	messages  repositoryPorts.IEmailMessageRepository
+}
+
+func NewEmailService(cfg domain.EmailConfig, parser ports.IParserService, retailers ports.IRetailerService, receipts ports.IReceiptService, messages repositoryPorts.IEmailMessageRepository, logger *zap.SugaredLogger) *EmailService {
+	return &EmailService{
+		logger:    logger,
+		cfg:       cfg,
+		parser:    parser,
+		retailers: retailers,
+		receipts:  receipts,
+		messages:  messages,
+	}
+}
+
+// IngestEmail parses the receipt of an email message and stores it. The sender is the retailer and the message date
+// the purchase date when the body has none, and the receipts with a field below the minimum confidence are rejected.
+// The repeats of a Message-ID, like the retries of the mail servers, are rejected with errors.Conflict.
+func (svc *EmailService) IngestEmail(ctx context.Context, message io.Reader, memberID string) (*domain.EmailIngestion, error) {
+	msg, err := email.Parse(message)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", appErrors.BadRequest, err)
+	}
+	// The messages without Message-ID can't be told apart
+	if msg.MessageID == "" {
+		return svc.ingest(ctx, msg, memberID)
+	}
+
+	if err := svc.messages.ReserveEmailMessage(msg.MessageID, time.Now().UTC()); err != nil {
+		return nil, err
+	}
+	ingestion, err := svc.ingest(ctx, msg, memberID)
+	if err != nil {
+		// The message can be sent again once its receipt is fixed
+		if err := svc.messages.ReleaseEmailMessage(msg.MessageID); err != nil {
+			logging.Logger(ctx, svc.logger).Errorw("email message not released", "messageId", msg.MessageID, "error", err)
+		}
+		return nil, err
+	}
+	if err := svc.messages.CompleteEmailMessage(msg.MessageID, ingestion.ID); err != nil {
+		logging.Logger(ctx, svc.logger).Errorw("email message not completed", "messageId", msg.MessageID, "error", err)
+	}
+	return ingestion, nil
+}
+
+// ingest parses and stores the receipt of the message
+func (svc *EmailService) ingest(ctx context.Context, msg *email.Message, memberID string) (*domain.EmailIngestion, error) {
+	parsed, err := svc.parser.ParseReceipt(ctx, &domain.ParseRequest{Text: msg.Body()})
+	if err != nil {
+		return nil, err
+	}
+	if err := svc.fromHeaders(msg, parsed); err != nil {
+		return nil, err
+	}
+	if err := svc.checkConfidence(parsed.Confidence); err != nil {
+		return nil, err
+	}
+
+	parsed.Receipt.MemberID = memberID
+	id, err := svc.receipts.StoreReceipt(ctx, parsed.Receipt)
+	if err != nil {
+		return nil, err
+	}
+
+	var ingestion = &domain.EmailIngestion{ID: id, MessageID: msg.MessageID, Subject: msg.Subject, Parsed: parsed}
+	if msg.From != nil {
+		ingestion.From = strings.TrimSpace(fmt.Sprintf("%s <%s>", msg.From.Name, msg.From.Address))
+	}
+	logging.Logger(ctx, svc.logger).Infow("email receipt stored", "receiptId", id, "messageId", msg.MessageID, "retailer", parsed.Receipt.Retailer)
+	return ingestion, nil
+}
+
+// fromHeaders completes the receipt with the sender and the date of the message
+func (svc *EmailService) fromHeaders(msg *email.Message, parsed *domain.ParsedReceipt) error {
+	var receipt, confidence = parsed.Receipt, parsed.Confidence
+
+	if msg.From != nil && msg.From.Name != "" && confidence.Retailer < catalogConfidence {
+		retailer, err := svc.retailers.ResolveRetailer(msg.From.Name)
+		if err != nil {
+			return err
+		}
+		switch {
+		case retailer != nil:
+			receipt.Retailer, confidence.Retailer = retailer.Name, catalogConfidence
+		case receipt.Retailer == "":
+			receipt.Retailer, confidence.Retailer = msg.From.Name, senderConfidence
+			parsed.Warnings = append(parsed.Warnings, "retailer taken from the sender")
+		}
+	}
+
+	if msg.Date.IsZero() {
+		return nil
+	}
+	if receipt.PurchaseDate == "" {
+		receipt.PurchaseDate, confidence.PurchaseDate = msg.Date.Format("2006-01-02"), senderConfidence
+		parsed.Warnings = append(parsed.Warnings, "purchase date taken from the message date")
+	}
+	if receipt.PurchaseTime == "" && receipt.PurchaseDate == msg.Date.Format("2006-01-02") {
+		receipt.PurchaseTime, confidence.PurchaseTime = msg.Date.Format("15:04"), senderConfidence
+		parsed.Warnings = append(parsed.Warnings, "purchase time taken from the message date")
+	}
+	return nil
+}
+
+// checkConfidence rejects the receipts with a field below the minimum confidence
+func (svc *EmailService) checkConfidence(confidence *domain.ParseConfidence) error {
+	var fields = []struct {
+		name       string
+		confidence float64
+	}{
+		{"retailer", confidence.Retailer},
+		{"purchaseDate", confidence.PurchaseDate},
+		{"purchaseTime", confidence.PurchaseTime},
+		{"total", confidence.Total},
+	}
+	var low []string
+	for _, field := range fields {
+		if field.confidence < svc.cfg.EmailMinConfidence {
+			low = append(low, fmt.Sprintf("%s confidence %.2f is below %.2f", field.name, field.confidence, svc.cfg.EmailMinConfidence))
+		}
+	}
+	if len(low) > 0 {
+		return fmt.Errorf("%w: receipt needs review: %s", appErrors.BadRequest, strings.Join(low, ", "))
+	}
+	return nil
+}
@@ -0,0 +1,115 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	repository "github.com/kiramishima/receipt-processor/adapter/db/in-memory"
+	"github.com/kiramishima/receipt-processor/domain"
+	"github.com/kiramishima/receipt-processor/mocks"
+	appErrors "github.com/kiramishima/receipt-processor/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"go.uber.org/zap"
+	"strings"
+	"testing"
+)
+
+func TestEmailService_IngestEmail(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	logger, _ := zap.NewProduction()
+	slogger := logger.Sugar()
+	retailers := mocks.NewMockIRetailerService(mockCtrl)
+	receipts := mocks.NewMockIReceiptService(mockCtrl)
+	parser := NewParserService(retailers, slogger)
+	messages := repository.NewEmailMessageRepository()
+	svc := NewEmailService(domain.EmailConfig{EmailMinConfidence: 0.5}, parser, retailers, receipts, messages, slogger)
+
+	var messageWithID = func(id string, body string) *strings.Reader {
+		return strings.NewReader("From: Target <receipts@target.com>\r\nDate: Fri, 18 Mar 2022 14:35:02 -0500\r\n" +
+			"Message-ID: <" + id + ">\r\nSubject: Your receipt\r\nContent-Type: text/html\r\n\r\n" + body)
+	}
+	var sequence = 1234
+	var message = func(body string) *strings.Reader {
+		sequence++
+		return messageWithID(fmt.Sprintf("%d@target.com", sequence), body)
+	}
+	const targetReceipt = "<p>TARGET T-1234</p><p>03/18/2022 2:33 PM</p>" +
+		"<table><tr><td>Pepsi 12PK</td><td>4.99</td></tr><tr><td>TOTAL</td><td>4.99</td></tr></table>"
+
+	t.Run("Body receipt", func(t *testing.T) {
+		retailers.EXPECT().ResolveRetailer(gomock.Eq("TARGET T-1234")).Times(1).Return(&domain.Retailer{ID: "target", Name: "Target"}, nil)
+		receipts.EXPECT().StoreReceipt(gomock.Any(), gomock.Any()).Times(1).DoAndReturn(func(_ any, receipt *domain.ReceiptBase) (string, error) {
+			assert.Equal(t, "member-1", receipt.MemberID)
+			assert.Equal(t, "Target", receipt.Retailer)
+			assert.Equal(t, "2022-03-18", receipt.PurchaseDate)
+			assert.Equal(t, "14:33", receipt.PurchaseTime)
+			assert.Equal(t, "4.99", receipt.Total)
+			return "receipt-1", nil
+		})
+
+		ingestion, err := svc.IngestEmail(context.Background(), messageWithID("1234@target.com", targetReceipt), "member-1")
+		if assert.NoError(t, err) {
+			assert.Equal(t, "receipt-1", ingestion.ID)
+			assert.Equal(t, "1234@target.com", ingestion.MessageID)
+			assert.Equal(t, "Target <receipts@target.com>", ingestion.From)
+			assert.Empty(t, ingestion.Parsed.Warnings)
+		}
+	})
+
+	t.Run("Repeated message", func(t *testing.T) {
+		receipts.EXPECT().StoreReceipt(gomock.Any(), gomock.Any()).Times(0)
+
+		_, err := svc.IngestEmail(context.Background(), messageWithID("1234@target.com", targetReceipt), "member-1")
+		assert.ErrorIs(t, err, appErrors.Conflict)
+		assert.ErrorContains(t, err, "was ingested as receipt receipt-1")
+	})
+
+	t.Run("Not stored", func(t *testing.T) {
+		retailers.EXPECT().ResolveRetailer(gomock.Eq("TARGET T-1234")).Times(2).Return(&domain.Retailer{ID: "target", Name: "Target"}, nil)
+		gomock.InOrder(
+			receipts.EXPECT().StoreReceipt(gomock.Any(), gomock.Any()).Times(1).Return("", fmt.Errorf("%w: 10 receipts per day", appErrors.QuotaExceeded)),
+			receipts.EXPECT().StoreReceipt(gomock.Any(), gomock.Any()).Times(1).Return("receipt-3", nil),
+		)
+
+		// The message is not recorded until its receipt is stored
+		_, err := svc.IngestEmail(context.Background(), messageWithID("9999@target.com", targetReceipt), "")
+		assert.ErrorIs(t, err, appErrors.QuotaExceeded)
+		ingestion, err := svc.IngestEmail(context.Background(), messageWithID("9999@target.com", targetReceipt), "")
+		if assert.NoError(t, err) {
+			assert.Equal(t, "receipt-3", ingestion.ID)
+		}
+	})
+
+	t.Run("Message headers", func(t *testing.T) {
+		retailers.EXPECT().ResolveRetailer(gomock.Eq("Target")).Times(1).Return(nil, nil)
+		receipts.EXPECT().StoreReceipt(gomock.Any(), gomock.Any()).Times(1).Return("receipt-2", nil)
+
+		ingestion, err := svc.IngestEmail(context.Background(), message("<p>Pepsi 12PK 4.99</p><p>TOTAL 4.99</p>"), "")
+		if assert.NoError(t, err) {
+			var receipt = ingestion.Parsed.Receipt
+			assert.Equal(t, "Target", receipt.Retailer)
+			assert.Equal(t, "2022-03-18", receipt.PurchaseDate)
+			assert.Equal(t, "14:35", receipt.PurchaseTime)
+			assert.Equal(t, senderConfidence, ingestion.Parsed.Confidence.PurchaseDate)
+			assert.Equal(t, []string{
+				"retailer not found", "purchase date not found", "purchase time not found",
+				"retailer taken from the sender", "purchase date taken from the message date", "purchase time taken from the message date",
+			}, ingestion.Parsed.Warnings)
+		}
+	})
+
+	t.Run("Needs review", func(t *testing.T) {
+		retailers.EXPECT().ResolveRetailer(gomock.Any()).Times(1).Return(nil, nil)
+		receipts.EXPECT().StoreReceipt(gomock.Any(), gomock.Any()).Times(0)
+
+		_, err := svc.IngestEmail(context.Background(), message("<p>Pepsi 12PK 4.99</p>"), "")
+		assert.ErrorIs(t, err, appErrors.BadRequest)
+		assert.ErrorContains(t, err, "total confidence 0.30 is below 0.50")
+	})
+
+	t.Run("Invalid message", func(t *testing.T) {
+		_, err := svc.IngestEmail(context.Background(), strings.NewReader("not a message"), "")
+		assert.ErrorIs(t, err, appErrors.BadRequest)
+	})
+}
@@ -0,0 +1,125 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/kiramishima/receipt-processor/domain"
+	appErrors "github.com/kiramishima/receipt-processor/pkg/errors"
+	ports "github.com/kiramishima/receipt-processor/ports/repository"
+	servicePorts "github.com/kiramishima/receipt-processor/ports/services"
+	"github.com/samber/lo"
+	"go.uber.org/zap"
+	"time"
+)
+
+type ExperimentService struct {
+	logger     *zap.SugaredLogger
+	repository ports.IExperimentRepository
+	receipts   ports.IReceiptRepository
+	ruleSets   servicePorts.IRuleSetService
+}
+
+func NewExperimentService(repository ports.IExperimentRepository, receipts ports.IReceiptRepository, ruleSets servicePorts.IRuleSetService, logger *zap.SugaredLogger) *ExperimentService {
+	return &ExperimentService{
+		logger:     logger,
+		repository: repository,
+		receipts:   receipts,
+		ruleSets:   ruleSets,
+	}
+}
+
+// CreateExperiment validates and stores a new experiment
+func (svc *ExperimentService) CreateExperiment(ctx context.Context, experiment *domain.Experiment) (*domain.Experiment, error) {
+	if err := svc.validate(ctx, experiment); err != nil {
+		return nil, err
+	}
+
+	id, err := svc.repository.SaveExperiment(experiment)
+	if err != nil {
+		return nil, err
+	}
+	return svc.repository.FindExperimentById(id)
+}
+
+// RetrieveExperiment recover an experiment by id
+func (svc *ExperimentService) RetrieveExperiment(id string) (*domain.Experiment, error) {
+	return svc.repository.FindExperimentById(id)
+}
+
+// ListExperiments returns all the experiments
+func (svc *ExperimentService) ListExperiments() ([]*domain.Experiment, error) {
+	return svc.repository.ListExperiments()
+}
+
+// UpdateExperiment validates and replaces a stored experiment
+func (svc *ExperimentService) UpdateExperiment(ctx context.Context, experiment *domain.Experiment) (*domain.Experiment, error) {
+	if err := svc.validate(ctx, experiment); err != nil {
+		return nil, err
+	}
+
+	if err := svc.repository.UpdateExperiment(experiment); err != nil {
+		return nil, err
+	}
+	return svc.repository.FindExperimentById(experiment.ID)
+}
+
+// DeleteExperiment removes an experiment
+func (svc *ExperimentService) DeleteExperiment(id string) error {
+	return svc.repository.DeleteExperiment(id)
+}
+
+// ActiveExperiment returns the experiment running at the given time, nil if there is none
+func (svc *ExperimentService) ActiveExperiment(at time.Time) (*domain.Experiment, error) {
+	experiments, err := svc.repository.ListExperiments()
+	if err != nil {
+		return nil, err
+	}
+	experiment, _ := lo.Find(experiments, func(e *domain.Experiment) bool {
+		return e.IsActive(at)
+	})
+	return experiment, nil
+}
+
+// ExperimentReport compares the points distributions of the receipts scored by every arm of the experiment
+func (svc *ExperimentService) ExperimentReport(id string) (*domain.ExperimentReport, error) {
+	experiment, err := svc.repository.FindExperimentById(id)
+	if err != nil {
+		return nil, err
+	}
+	results, err := svc.receipts.ListReceipts(&domain.ReceiptFilter{ExperimentID: id})
+	if err != nil {
+		return nil, err
+	}
+	return domain.NewExperimentReport(experiment, results), nil
+}
+
+func (svc *ExperimentService) validate(ctx context.Context, experiment *domain.Experiment) error {
+	err := validate.StructCtx(ctx, *experiment)
+	if err != nil {
+		svc.logger.Error(err)
+		return validationError(err)
+	}
+
+	for _, version := range []string{experiment.ControlVersion, experiment.TreatmentVersion} {
+		_, err := svc.ruleSets.RetrieveRuleSet(version)
+		if errors.Is(err, appErrors.NotFound) {
+			return fmt.Errorf("%w: unknown rule set version: %s", appErrors.BadRequest, version)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	// A receipt can only be scored by one experiment
+	experiments, err := svc.repository.ListExperiments()
+	if err != nil {
+		return err
+	}
+	for _, other := range experiments {
+		if other.ID != experiment.ID && other.Overlaps(experiment) {
+			return fmt.Errorf("%w: experiment overlaps with experiment %s", appErrors.BadRequest, other.ID)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,94 @@
+package services
+
+import (
+	"context"
+	"github.com/kiramishima/receipt-processor/domain"
+	"github.com/kiramishima/receipt-processor/mocks"
+	appErrors "github.com/kiramishima/receipt-processor/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"go.uber.org/zap"
+	"testing"
+	"time"
+)
+
+func TestExperimentService(t *testing.T) {
+	logger, _ := zap.NewProduction()
+	slogger := logger.Sugar()
+	mockCtrl := gomock.NewController(t)
+
+	defer mockCtrl.Finish()
+	repo := mocks.NewMockIExperimentRepository(mockCtrl)
+	receipts := mocks.NewMockIReceiptRepository(mockCtrl)
+	ruleSets := mocks.NewMockIRuleSetService(mockCtrl)
+	ruleSets.EXPECT().RetrieveRuleSet(gomock.Eq(domain.DefaultRuleSetVersion)).Return(domain.DefaultRuleSet(), nil).AnyTimes()
+	ruleSets.EXPECT().RetrieveRuleSet(gomock.Eq("v2")).Return(&domain.RuleSet{Version: "v2"}, nil).AnyTimes()
+	ruleSets.EXPECT().RetrieveRuleSet(gomock.Any()).Return(nil, appErrors.NotFound).AnyTimes()
+
+	var running = &domain.Experiment{
+		ID:               "exp-1",
+		Name:             "Odd days",
+		StartsAt:         time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC),
+		EndsAt:           time.Date(2022, 2, 1, 0, 0, 0, 0, time.UTC),
+		ControlVersion:   domain.DefaultRuleSetVersion,
+		TreatmentVersion: "v2",
+		TreatmentPercent: 50,
+	}
+	repo.EXPECT().ListExperiments().Return([]*domain.Experiment{running}, nil).AnyTimes()
+	svc := NewExperimentService(repo, receipts, ruleSets, slogger)
+
+	t.Run("Create", func(t *testing.T) {
+		var experiment = *running
+		experiment.ID = ""
+		experiment.StartsAt = running.EndsAt
+		experiment.EndsAt = running.EndsAt.AddDate(0, 1, 0)
+		repo.EXPECT().SaveExperiment(gomock.Any()).Times(1).Return("exp-2", nil)
+		repo.EXPECT().FindExperimentById(gomock.Eq("exp-2")).Times(1).Return(&experiment, nil)
+		_, err := svc.CreateExperiment(context.Background(), &experiment)
+		assert.NoError(t, err)
+	})
+
+	t.Run("Overlapping", func(t *testing.T) {
+		var experiment = *running
+		experiment.ID = ""
+		experiment.StartsAt = running.EndsAt.AddDate(0, 0, -1)
+		experiment.EndsAt = running.EndsAt.AddDate(0, 1, 0)
+		_, err := svc.CreateExperiment(context.Background(), &experiment)
+		assert.ErrorIs(t, err, appErrors.BadRequest)
+	})
+
+	t.Run("Unknown rule set", func(t *testing.T) {
+		var experiment = *running
+		experiment.TreatmentVersion = "v9"
+		_, err := svc.UpdateExperiment(context.Background(), &experiment)
+		assert.ErrorIs(t, err, appErrors.BadRequest)
+	})
+
+	t.Run("Same rule set in both arms", func(t *testing.T) {
+		var experiment = *running
+		experiment.TreatmentVersion = experiment.ControlVersion
+		_, err := svc.UpdateExperiment(context.Background(), &experiment)
+		assert.ErrorIs(t, err, appErrors.BadRequest)
+	})
+
+	t.Run("Active", func(t *testing.T) {
+		experiment, err := svc.ActiveExperiment(time.Date(2022, 1, 15, 0, 0, 0, 0, time.UTC))
+		assert.NoError(t, err)
+		assert.Equal(t, running, experiment)
+
+		experiment, err = svc.ActiveExperiment(running.EndsAt)
+		assert.NoError(t, err)
+		assert.Nil(t, experiment)
+	})
+
+	t.Run("Report", func(t *testing.T) {
+		repo.EXPECT().FindExperimentById(gomock.Eq("exp-1")).Times(1).Return(running, nil)
+		receipts.EXPECT().ListReceipts(gomock.Eq(&domain.ReceiptFilter{ExperimentID: "exp-1"})).Times(1).Return([]*domain.Result{
+			{Points: 10, Experiment: &domain.ExperimentAssignment{ExperimentID: "exp-1", Arm: domain.ExperimentArmControl}},
+			{Points: 15, Experiment: &domain.ExperimentAssignment{ExperimentID: "exp-1", Arm: domain.ExperimentArmTreatment}},
+		}, nil)
+		report, err := svc.ExperimentReport("exp-1")
+		assert.NoError(t, err)
+		assert.Equal(t, 5.0, report.MeanDelta)
+	})
+}
@@ -0,0 +1,123 @@
+package services
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"github.com/kiramishima/receipt-processor/domain"
+	appErrors "github.com/kiramishima/receipt-processor/pkg/errors"
+	"github.com/kiramishima/receipt-processor/pkg/logging"
+	ports "github.com/kiramishima/receipt-processor/ports/repository"
+	"go.uber.org/zap"
+	"io"
+	"strconv"
+	"time"
+)
+
+type ExportService struct {
+	logger     *zap.SugaredLogger
+	repository ports.IReceiptRepository
+}
+
+func NewExportService(repository ports.IReceiptRepository, logger *zap.SugaredLogger) *ExportService {
+	return &ExportService{
+		logger:     logger,
+		repository: repository,
+	}
+}
+
+// exportEncoder writes the exported receipts in a format
+type exportEncoder interface {
+	Encode(export *domain.ReceiptExport) error
+	Close() error
+}
+
+// ExportReceipts writes the stored receipts matching the filter to w in the format, one receipt at a time as they are
+// read from the repository. It returns the number of receipts written. The exports of a member JWT only have the
+// receipts of the member.
+func (svc *ExportService) ExportReceipts(ctx context.Context, filter *domain.ReceiptFilter, format string, w io.Writer) (int, error) {
+	if filter == nil {
+		filter = &domain.ReceiptFilter{}
+	}
+	if err := principalMember(ctx, &filter.MemberID); err != nil {
+		return 0, err
+	}
+	encoder, err := newExportEncoder(format, w)
+	if err != nil {
+		return 0, err
+	}
+
+	var count = 0
+	err = svc.repository.EachReceipt(filter, func(result *domain.Result) error {
+		if ctx.Err() != nil {
+			return appErrors.ErrTimeout
+		}
+		if err := encoder.Encode(domain.NewReceiptExport(result)); err != nil {
+			return err
+		}
+		count++
+		return nil
+	})
+	if err == nil {
+		err = encoder.Close()
+	}
+	if err != nil {
+		logging.Logger(ctx, svc.logger).Errorw("receipt export failed", "format", format, "receipts", count, "error", err)
+		return count, err
+	}
+
+	logging.Logger(ctx, svc.logger).Infow("receipts exported", "format", format, "receipts", count)
+	return count, nil
+}
+
+func newExportEncoder(format string, w io.Writer) (exportEncoder, error) {
+	switch format {
+	case domain.ExportFormatCSV:
+		var writer = csv.NewWriter(w)
+		if err := writer.Write(domain.ExportColumns); err != nil {
+			return nil, err
+		}
+		return &csvExportEncoder{writer: writer}, nil
+	case domain.ExportFormatNDJSON:
+		return &ndjsonExportEncoder{encoder: json.NewEncoder(w)}, nil
+	}
+	return nil, fmt.Errorf("%w: unknown export format %s", appErrors.BadRequest, format)
+}
+
+type csvExportEncoder struct {
+	writer *csv.Writer
+	record []string
+}
+
+func (e *csvExportEncoder) Encode(export *domain.ReceiptExport) error {
+	e.record = e.record[:0]
+	for _, value := range export.Row() {
+		switch v := value.(type) {
+		case time.Time:
+			e.record = append(e.record, v.Format(time.RFC3339))
+		case float64:
+			e.record = append(e.record, strconv.FormatFloat(v, 'f', -1, 64))
+		default:
+			e.record = append(e.record, fmt.Sprint(v))
+		}
+	}
+	return e.writer.Write(e.record)
+}
+
+func (e *csvExportEncoder) Close() error {
+	e.writer.Flush()
+	return e.writer.Error()
+}
+
+type ndjsonExportEncoder struct {
+	encoder *json.Encoder
+}
+
+func (e *ndjsonExportEncoder) Encode(export *domain.ReceiptExport) error {
+	return e.encoder.Encode(export)
+}
+
+func (e *ndjsonExportEncoder) Close() error {
+	return nil
+}
@@ -0,0 +1,96 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"github.com/kiramishima/receipt-processor/domain"
+	"github.com/kiramishima/receipt-processor/mocks"
+	"github.com/kiramishima/receipt-processor/pkg/auth"
+	appErrors "github.com/kiramishima/receipt-processor/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"go.uber.org/zap"
+	"testing"
+	"time"
+)
+
+func TestExportService_ExportReceipts(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	logger, _ := zap.NewProduction()
+	repo := mocks.NewMockIReceiptRepository(mockCtrl)
+	svc := NewExportService(repo, logger.Sugar())
+
+	var created = time.Date(2022, 3, 20, 10, 0, 0, 0, time.UTC)
+	var results = []*domain.Result{
+		{
+			ID: "id-1", MemberID: "m1", Retailer: "Target", RetailerID: "target", Points: 28, CreatedAt: created,
+			Breakdown: &domain.Breakdown{RuleSetVersion: "v1", BasePoints: 28, Rules: []*domain.RulePoints{}, TotalPoints: 28},
+			Receipt: &domain.Receipt{
+				PurchaseDT: time.Date(2022, 1, 1, 13, 1, 0, 0, time.UTC), Currency: "USD", Total: 35.35,
+				Items: []*domain.ReceiptItem{{}, {}},
+			},
+		},
+		{ID: "id-2", Retailer: "Walgreens", Points: 5, CreatedAt: created},
+	}
+	var each = func(_ *domain.ReceiptFilter, fn func(*domain.Result) error) error {
+		for _, result := range results {
+			if err := fn(result); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	var filter = &domain.ReceiptFilter{MemberID: "m1"}
+
+	t.Run("CSV", func(t *testing.T) {
+		repo.EXPECT().EachReceipt(gomock.Eq(filter), gomock.Any()).Times(1).DoAndReturn(each)
+
+		var out bytes.Buffer
+		count, err := svc.ExportReceipts(context.Background(), filter, domain.ExportFormatCSV, &out)
+		assert.NoError(t, err)
+		assert.Equal(t, 2, count)
+		assert.Equal(t, "id,createdAt,memberId,retailer,retailerId,purchaseDate,purchaseTime,currency,total,items,points,basePoints,ruleSetVersion,tier,experimentId,arm,breakdown\n"+
+			`id-1,2022-03-20T10:00:00Z,m1,Target,target,2022-01-01,13:01,USD,35.35,2,28,28,v1,,,,"{""ruleSetVersion"":""v1"",""rules"":[],""basePoints"":28,""totalPoints"":28}"`+"\n"+
+			"id-2,2022-03-20T10:00:00Z,,Walgreens,,0001-01-01,00:00,,0,0,5,0,,,,,\n", out.String())
+	})
+
+	t.Run("NDJSON", func(t *testing.T) {
+		repo.EXPECT().EachReceipt(gomock.Any(), gomock.Any()).Times(1).DoAndReturn(each)
+
+		var out bytes.Buffer
+		count, err := svc.ExportReceipts(context.Background(), nil, domain.ExportFormatNDJSON, &out)
+		assert.NoError(t, err)
+		assert.Equal(t, 2, count)
+		var lines = bytes.Split(bytes.TrimSpace(out.Bytes()), []byte("\n"))
+		assert.Len(t, lines, 2)
+		assert.JSONEq(t, `{"id":"id-2","createdAt":"2022-03-20T10:00:00Z","retailer":"Walgreens","purchaseTime":"0001-01-01T00:00:00Z","total":0,"items":0,"points":5}`, string(lines[1]))
+	})
+
+	t.Run("Unknown format", func(t *testing.T) {
+		repo.EXPECT().EachReceipt(gomock.Any(), gomock.Any()).Times(0)
+
+		_, err := svc.ExportReceipts(context.Background(), nil, "xml", &bytes.Buffer{})
+		assert.ErrorIs(t, err, appErrors.BadRequest)
+	})
+
+	t.Run("Member JWT", func(t *testing.T) {
+		var ctx = auth.WithPrincipal(context.Background(), domain.NewTokenPrincipal("user-1", "m1", []string{domain.RoleMember}))
+		repo.EXPECT().EachReceipt(gomock.Eq(filter), gomock.Any()).Times(1).Return(nil)
+
+		_, err := svc.ExportReceipts(ctx, nil, domain.ExportFormatNDJSON, &bytes.Buffer{})
+		assert.NoError(t, err)
+		_, err = svc.ExportReceipts(ctx, &domain.ReceiptFilter{MemberID: "m2"}, domain.ExportFormatNDJSON, &bytes.Buffer{})
+		assert.ErrorIs(t, err, appErrors.Forbidden)
+	})
+
+	t.Run("Canceled", func(t *testing.T) {
+		repo.EXPECT().EachReceipt(gomock.Any(), gomock.Any()).Times(1).DoAndReturn(each)
+
+		var ctx, cancel = context.WithCancel(context.Background())
+		cancel()
+		count, err := svc.ExportReceipts(ctx, nil, domain.ExportFormatNDJSON, &bytes.Buffer{})
+		assert.ErrorIs(t, err, appErrors.ErrTimeout)
+		assert.Equal(t, 0, count)
+	})
+}
@@ -0,0 +1,104 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"github.com/kiramishima/receipt-processor/domain"
+	"go.uber.org/zap"
+	"sync"
+	"sync/atomic"
+)
+
+// ErrShuttingDown readiness error once the shutdown began
+var ErrShuttingDown = errors.New("shutting down")
+
+// HealthService registry of the health checks contributed by the adapters and background workers
+type HealthService struct {
+	logger       *zap.SugaredLogger
+	cfg          domain.HealthConfig
+	mu           sync.RWMutex
+	checks       []domain.HealthCheck
+	shuttingDown atomic.Bool
+}
+
+func NewHealthService(cfg domain.HealthConfig, checks []domain.HealthCheck, logger *zap.SugaredLogger) *HealthService {
+	return &HealthService{
+		logger: logger,
+		cfg:    cfg,
+		checks: checks,
+	}
+}
+
+// Register adds a check, the checks run in the order they are registered
+func (svc *HealthService) Register(check domain.HealthCheck) {
+	svc.mu.Lock()
+	defer svc.mu.Unlock()
+	svc.checks = append(svc.checks, check)
+}
+
+// ShutdownStarted makes the readiness fail, so no new traffic is routed to the service while it drains
+func (svc *HealthService) ShutdownStarted() {
+	svc.shuttingDown.Store(true)
+}
+
+// Live runs the liveness checks
+func (svc *HealthService) Live(ctx context.Context) *domain.HealthReport {
+	return svc.run(ctx, domain.HealthLiveness)
+}
+
+// Ready runs the readiness checks, failing once the shutdown began
+func (svc *HealthService) Ready(ctx context.Context) *domain.HealthReport {
+	var report = svc.run(ctx, domain.HealthReadiness)
+	if svc.shuttingDown.Load() {
+		report.Add("shutdown", ErrShuttingDown)
+	}
+	return report
+}
+
+// run runs the checks of a kind concurrently, each with the check timeout
+func (svc *HealthService) run(ctx context.Context, kind domain.HealthCheckKind) *domain.HealthReport {
+	svc.mu.RLock()
+	var checks = make([]domain.HealthCheck, 0, len(svc.checks))
+	for _, check := range svc.checks {
+		if check.Kind == kind {
+			checks = append(checks, check)
+		}
+	}
+	svc.mu.RUnlock()
+
+	var errs = make([]error, len(checks))
+	var wg sync.WaitGroup
+	for i, check := range checks {
+		wg.Add(1)
+		go func(i int, check domain.HealthCheck) {
+			defer wg.Done()
+			ctx, cancel := context.WithTimeout(ctx, svc.cfg.HealthCheckTimeout)
+			defer cancel()
+			errs[i] = svc.check(ctx, check)
+		}(i, check)
+	}
+	wg.Wait()
+
+	var report = &domain.HealthReport{Status: domain.HealthOK, Checks: make([]*domain.HealthCheckResult, 0, len(checks))}
+	for i, check := range checks {
+		if errs[i] != nil {
+			svc.logger.Warnw("health check failing", "check", check.Name, "kind", kind, "error", errs[i])
+		}
+		report.Add(check.Name, errs[i])
+	}
+	return report
+}
+
+// check runs a check, failing when it doesn't return before the context ends
+func (svc *HealthService) check(ctx context.Context, check domain.HealthCheck) error {
+	var result = make(chan error, 1)
+	go func() {
+		result <- check.Check(ctx)
+	}()
+	select {
+	case err := <-result:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,61 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"github.com/kiramishima/receipt-processor/domain"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"testing"
+	"time"
+)
+
+func TestHealthService(t *testing.T) {
+	logger, _ := zap.NewProduction()
+	var ok = func(ctx context.Context) error { return nil }
+	svc := NewHealthService(domain.HealthConfig{HealthCheckTimeout: 50 * time.Millisecond}, []domain.HealthCheck{
+		{Name: "repository", Kind: domain.HealthReadiness, Check: ok},
+		{Name: "job", Kind: domain.HealthLiveness, Check: ok},
+	}, logger.Sugar())
+
+	t.Run("Healthy", func(t *testing.T) {
+		assert.Equal(t, &domain.HealthReport{Status: domain.HealthOK, Checks: []*domain.HealthCheckResult{
+			{Name: "repository", Status: domain.HealthOK},
+		}}, svc.Ready(context.Background()))
+		assert.Equal(t, &domain.HealthReport{Status: domain.HealthOK, Checks: []*domain.HealthCheckResult{
+			{Name: "job", Status: domain.HealthOK},
+		}}, svc.Live(context.Background()))
+	})
+
+	t.Run("Failing and slow checks", func(t *testing.T) {
+		svc.Register(domain.HealthCheck{Name: "cache", Kind: domain.HealthReadiness, Check: func(ctx context.Context) error {
+			return errors.New("unreachable")
+		}})
+		svc.Register(domain.HealthCheck{Name: "slow", Kind: domain.HealthReadiness, Check: func(ctx context.Context) error {
+			time.Sleep(time.Second)
+			return nil
+		}})
+
+		var started = time.Now()
+		var report = svc.Ready(context.Background())
+		assert.Less(t, time.Since(started), time.Second)
+		assert.Equal(t, domain.HealthFailing, report.Status)
+		assert.Equal(t, []*domain.HealthCheckResult{
+			{Name: "repository", Status: domain.HealthOK},
+			{Name: "cache", Status: domain.HealthFailing, Error: "unreachable"},
+			{Name: "slow", Status: domain.HealthFailing, Error: context.DeadlineExceeded.Error()},
+		}, report.Checks)
+		assert.Equal(t, domain.HealthOK, svc.Live(context.Background()).Status)
+	})
+
+	t.Run("Shutting down", func(t *testing.T) {
+		var svc = NewHealthService(domain.HealthConfig{HealthCheckTimeout: time.Second}, nil, logger.Sugar())
+		assert.Equal(t, domain.HealthOK, svc.Ready(context.Background()).Status)
+
+		svc.ShutdownStarted()
+		assert.Equal(t, &domain.HealthReport{Status: domain.HealthFailing, Checks: []*domain.HealthCheckResult{
+			{Name: "shutdown", Status: domain.HealthFailing, Error: "shutting down"},
+		}}, svc.Ready(context.Background()))
+		assert.Equal(t, domain.HealthOK, svc.Live(context.Background()).Status)
+	})
+}
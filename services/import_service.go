@@ -0,0 +1,234 @@
+package services
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"github.com/kiramishima/receipt-processor/domain"
+	appErrors "github.com/kiramishima/receipt-processor/pkg/errors"
+	"github.com/kiramishima/receipt-processor/pkg/logging"
+	ports "github.com/kiramishima/receipt-processor/ports/services"
+	"go.uber.org/zap"
+	"io"
+	"strings"
+)
+
+type ImportService struct {
+	logger   *zap.SugaredLogger
+	cfg      domain.ImportConfig
+	receipts ports.IReceiptService
+}
+
+func NewImportService(cfg domain.ImportConfig, receipts ports.IReceiptService, logger *zap.SugaredLogger) *ImportService {
+	return &ImportService{
+		logger:   logger,
+		cfg:      cfg,
+		receipts: receipts,
+	}
+}
+
+// importGroup rows of a receipt
+type importGroup struct {
+	receipt *domain.ReceiptBase
+	// rowOf the row setting every receipt field
+	rowOf map[string]int
+	rows  []*domain.ImportRow
+	err   error
+}
+
+// ImportReceipts reads a CSV with a row per item, groups the rows by receipt key and stores or scores every receipt.
+// The receipt fields can be in every row of the receipt or only in one, and rows with different values fail the
+// receipt. The errors of the rows and receipts are reported, the errors of the whole file are returned.
+func (svc *ImportService) ImportReceipts(ctx context.Context, r io.Reader, opts *domain.ImportOptions) (*domain.ImportReport, error) {
+	columns, err := domain.DefaultImportColumns().With(svc.cfg.ImportColumns)
+	if err == nil {
+		columns, err = columns.With(opts.Columns)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", appErrors.BadRequest, err)
+	}
+
+	var reader = csv.NewReader(r)
+	if opts.Delimiter != 0 {
+		reader.Comma = opts.Delimiter
+	}
+	reader.TrimLeadingSpace = true
+	header, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("%w: empty file", appErrors.BadRequest)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", appErrors.BadRequest, err)
+	}
+
+	index, err := columnIndex(header, columns)
+	if err != nil {
+		return nil, err
+	}
+
+	var report = &domain.ImportReport{Rows: make([]*domain.ImportRow, 0)}
+	var groups = make([]*importGroup, 0)
+	var byKey = make(map[string]*importGroup)
+	for count := 1; ; count++ {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if count > svc.cfg.ImportMaxRows {
+			return nil, fmt.Errorf("%w: more than %d rows", appErrors.BadRequest, svc.cfg.ImportMaxRows)
+		}
+		if err != nil && !errors.Is(err, csv.ErrFieldCount) {
+			return nil, fmt.Errorf("%w: %s", appErrors.BadRequest, err)
+		}
+		line, _ := reader.FieldPos(0)
+		if err != nil {
+			report.Rows = append(report.Rows, &domain.ImportRow{Row: line, Status: domain.ImportStatusFailed, Error: err.Error()})
+			continue
+		}
+
+		var value = func(field string) string {
+			if i, ok := index[field]; ok {
+				return strings.TrimSpace(record[i])
+			}
+			return ""
+		}
+		var row = &domain.ImportRow{Row: line, Receipt: value(domain.ImportReceipt)}
+		report.Rows = append(report.Rows, row)
+		if row.Receipt == "" {
+			row.Status, row.Error = domain.ImportStatusFailed, "missing receipt key"
+			continue
+		}
+
+		group, ok := byKey[row.Receipt]
+		if !ok {
+			group = &importGroup{receipt: &domain.ReceiptBase{}, rowOf: make(map[string]int)}
+			byKey[row.Receipt] = group
+			groups = append(groups, group)
+		}
+		group.add(row, value)
+	}
+
+	for _, group := range groups {
+		if ctx.Err() != nil {
+			return nil, appErrors.ErrTimeout
+		}
+		svc.importReceipt(ctx, group, opts.DryRun, report)
+	}
+	report.Receipts = len(groups)
+	report.SortRows()
+
+	logging.Logger(ctx, svc.logger).Infow("receipts imported", "receipts", report.Receipts, "stored", report.Stored, "scored", report.Scored, "failed", report.Failed)
+	return report, nil
+}
+
+// columnIndex returns the position of the column of every field in the header, the receipt key column is required
+func columnIndex(header []string, columns domain.ImportColumns) (map[string]int, error) {
+	var positions = make(map[string]int)
+	for i, name := range header {
+		name = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))
+		if _, ok := positions[name]; !ok {
+			positions[name] = i
+		}
+	}
+
+	var index = make(map[string]int)
+	for field, name := range columns {
+		if i, ok := positions[strings.ToLower(name)]; ok {
+			index[field] = i
+		}
+	}
+	if _, ok := index[domain.ImportReceipt]; !ok {
+		return nil, fmt.Errorf("%w: missing column %s", appErrors.BadRequest, columns[domain.ImportReceipt])
+	}
+	return index, nil
+}
+
+// add adds the receipt fields and the item of a row to the receipt
+func (g *importGroup) add(row *domain.ImportRow, value func(field string) string) {
+	g.rows = append(g.rows, row)
+
+	var fields = receiptFields(g.receipt)
+	for _, field := range domain.ImportReceiptFields {
+		var v = value(field)
+		switch target := fields[field]; {
+		case v == "":
+		case *target == "":
+			*target, g.rowOf[field] = v, row.Row
+		case *target != v && g.err == nil:
+			g.err = fmt.Errorf("row %d: %s %q differs from %q of row %d", row.Row, field, v, *target, g.rowOf[field])
+		}
+	}
+
+	var item = &domain.ReceiptItemBase{}
+	var empty = true
+	for field, target := range itemFields(item) {
+		if *target = value(field); *target != "" {
+			empty = false
+		}
+	}
+	if !empty {
+		g.receipt.Items = append(g.receipt.Items, item)
+	}
+}
+
+// importReceipt stores or scores the receipt of a group and reports the outcome in its rows
+func (svc *ImportService) importReceipt(ctx context.Context, group *importGroup, dryRun bool, report *domain.ImportReport) {
+	var status, id, points, err = domain.ImportStatusScored, "", (*int)(nil), group.err
+	switch {
+	case err != nil:
+	case dryRun:
+		var score *domain.ScoreResult
+		if score, err = svc.receipts.ScoreReceipt(ctx, group.receipt); err == nil {
+			points = &score.Points
+		}
+	default:
+		status = domain.ImportStatusStored
+		if id, err = svc.receipts.StoreReceipt(ctx, group.receipt); err == nil {
+			if result, err := svc.receipts.RetrieveReceipt(ctx, id); err == nil {
+				points = &result.Points
+			}
+		}
+	}
+
+	switch {
+	case err != nil:
+		report.Failed++
+	case status == domain.ImportStatusStored:
+		report.Stored++
+	default:
+		report.Scored++
+	}
+	for _, row := range group.rows {
+		if err != nil {
+			row.Status, row.Error = domain.ImportStatusFailed, strings.TrimSpace(err.Error())
+			continue
+		}
+		row.Status, row.ID, row.Points = status, id, points
+	}
+}
+
+func receiptFields(receipt *domain.ReceiptBase) map[string]*string {
+	return map[string]*string{
+		domain.ImportMemberID:     &receipt.MemberID,
+		domain.ImportLocale:       &receipt.Locale,
+		domain.ImportRetailer:     &receipt.Retailer,
+		domain.ImportPurchaseDate: &receipt.PurchaseDate,
+		domain.ImportPurchaseTime: &receipt.PurchaseTime,
+		domain.ImportCurrency:     &receipt.Currency,
+		domain.ImportTotal:        &receipt.Total,
+	}
+}
+
+func itemFields(item *domain.ReceiptItemBase) map[string]*string {
+	return map[string]*string{
+		domain.ImportItemType:         &item.Type,
+		domain.ImportShortDescription: &item.ShortDescription,
+		domain.ImportQuantity:         &item.Quantity,
+		domain.ImportUnitPrice:        &item.UnitPrice,
+		domain.ImportPrice:            &item.Price,
+		domain.ImportSKU:              &item.SKU,
+		domain.ImportUPC:              &item.UPC,
+		domain.ImportCategory:         &item.Category,
+	}
+}
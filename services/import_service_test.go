@@ -0,0 +1,100 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"github.com/kiramishima/receipt-processor/domain"
+	"github.com/kiramishima/receipt-processor/mocks"
+	appErrors "github.com/kiramishima/receipt-processor/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"go.uber.org/zap"
+	"strings"
+	"testing"
+)
+
+func TestImportService_ImportReceipts(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	logger, _ := zap.NewProduction()
+	receipts := mocks.NewMockIReceiptService(mockCtrl)
+	svc := NewImportService(domain.ImportConfig{ImportColumns: map[string]string{"retailer": "Store"}, ImportMaxRows: 5}, receipts, logger.Sugar())
+
+	var csv = "Order ID;Store;purchaseDate;purchaseTime;total;shortDescription;quantity;price\n" +
+		"A1;Target;2022-01-01;13:01;6.49;Mountain Dew 12PK;1;6.49\n" +
+		"B2;Walgreens;2022-01-02;08:13;2.65;Pepsi 12PK;;1.25\n" +
+		"B2;;;;;Dasani;2;1.40\n" +
+		"C3;Target;2022-01-01;13:01;1.00;Gum;;1.00\n" +
+		"C3;Walmart;;;;;;\n"
+	var opts = &domain.ImportOptions{Columns: map[string]string{"receipt": "Order ID"}, Delimiter: ';'}
+
+	t.Run("Store", func(t *testing.T) {
+		receipts.EXPECT().StoreReceipt(gomock.Any(), gomock.Any()).Times(2).DoAndReturn(func(_ any, receipt *domain.ReceiptBase) (string, error) {
+			if receipt.Retailer == "Walgreens" {
+				assert.Equal(t, &domain.ReceiptBase{Retailer: "Walgreens", PurchaseDate: "2022-01-02", PurchaseTime: "08:13", Total: "2.65", Items: []*domain.ReceiptItemBase{
+					{ShortDescription: "Pepsi 12PK", Price: "1.25"},
+					{ShortDescription: "Dasani", Quantity: "2", Price: "1.40"},
+				}}, receipt)
+				return "", errors.New("Field: Total, Error: required\n")
+			}
+			return "id-1", nil
+		})
+		receipts.EXPECT().RetrieveReceipt(gomock.Any(), gomock.Eq("id-1")).Times(1).Return(&domain.Result{Points: 12}, nil)
+
+		report, err := svc.ImportReceipts(context.Background(), strings.NewReader(csv), opts)
+		if !assert.NoError(t, err) {
+			return
+		}
+		assert.Equal(t, 3, report.Receipts)
+		assert.Equal(t, 1, report.Stored)
+		assert.Equal(t, 2, report.Failed)
+		var points = 12
+		assert.Equal(t, []*domain.ImportRow{
+			{Row: 2, Receipt: "A1", Status: domain.ImportStatusStored, ID: "id-1", Points: &points},
+			{Row: 3, Receipt: "B2", Status: domain.ImportStatusFailed, Error: "Field: Total, Error: required"},
+			{Row: 4, Receipt: "B2", Status: domain.ImportStatusFailed, Error: "Field: Total, Error: required"},
+			{Row: 5, Receipt: "C3", Status: domain.ImportStatusFailed, Error: `row 6: retailer "Walmart" differs from "Target" of row 5`},
+			{Row: 6, Receipt: "C3", Status: domain.ImportStatusFailed, Error: `row 6: retailer "Walmart" differs from "Target" of row 5`},
+		}, report.Rows)
+	})
+
+	t.Run("Dry run", func(t *testing.T) {
+		receipts.EXPECT().ScoreReceipt(gomock.Any(), gomock.Any()).Times(1).Return(&domain.ScoreResult{Points: 31}, nil)
+
+		report, err := svc.ImportReceipts(context.Background(), strings.NewReader(csv[:strings.Index(csv, "B2")]),
+			&domain.ImportOptions{Columns: opts.Columns, Delimiter: ';', DryRun: true})
+		if assert.NoError(t, err) && assert.Len(t, report.Rows, 1) {
+			assert.Equal(t, 1, report.Scored)
+			assert.Equal(t, domain.ImportStatusScored, report.Rows[0].Status)
+			assert.Equal(t, 31, *report.Rows[0].Points)
+		}
+	})
+
+	t.Run("Row errors", func(t *testing.T) {
+		report, err := svc.ImportReceipts(context.Background(), strings.NewReader("receiptId,total\n,1.00\nA1\n"), &domain.ImportOptions{})
+		if assert.NoError(t, err) {
+			assert.Equal(t, 0, report.Receipts)
+			assert.Equal(t, []*domain.ImportRow{
+				{Row: 2, Status: domain.ImportStatusFailed, Error: "missing receipt key"},
+				{Row: 3, Status: domain.ImportStatusFailed, Error: "record on line 3: wrong number of fields"},
+			}, report.Rows)
+		}
+	})
+
+	t.Run("Invalid files", func(t *testing.T) {
+		var testCases = map[string]struct {
+			csv  string
+			opts *domain.ImportOptions
+		}{
+			"Empty":          {"", &domain.ImportOptions{}},
+			"Missing key":    {"Order ID,total\nA1,1.00\n", &domain.ImportOptions{}},
+			"Unknown field":  {"receiptId\nA1\n", &domain.ImportOptions{Columns: map[string]string{"store": "Store"}}},
+			"Too many rows":  {"receiptId\n1\n2\n3\n4\n5\n6\n", &domain.ImportOptions{}},
+			"Malformed line": {"receiptId,total\n\"A1,1.00\n", &domain.ImportOptions{}},
+		}
+		for name, tc := range testCases {
+			_, err := svc.ImportReceipts(context.Background(), strings.NewReader(tc.csv), tc.opts)
+			assert.ErrorIs(t, err, appErrors.BadRequest, name)
+		}
+	})
+}
@@ -0,0 +1,120 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/kiramishima/receipt-processor/domain"
+	appErrors "github.com/kiramishima/receipt-processor/pkg/errors"
+	"github.com/kiramishima/receipt-processor/pkg/locale"
+	"github.com/kiramishima/receipt-processor/pkg/logging"
+	"github.com/kiramishima/receipt-processor/pkg/receipttext"
+	ports "github.com/kiramishima/receipt-processor/ports/services"
+	"go.uber.org/zap"
+	"math"
+	"os"
+)
+
+// catalogConfidence confidence of the retailers found in the retailer catalog
+const catalogConfidence = 0.9
+
+type ParserService struct {
+	logger    *zap.SugaredLogger
+	retailers ports.IRetailerService
+	parser    *receipttext.Parser
+}
+
+func NewParserService(retailers ports.IRetailerService, logger *zap.SugaredLogger) *ParserService {
+	parser, _ := receipttext.NewParser(nil)
+	return &ParserService{
+		logger:    logger,
+		retailers: retailers,
+		parser:    parser,
+	}
+}
+
+// LoadTemplates loads the retailer templates of a JSON file with an array of templates
+func (svc *ParserService) LoadTemplates(path string) error {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	var templates []*receipttext.Template
+	if err := json.Unmarshal(content, &templates); err != nil {
+		return err
+	}
+	for _, template := range templates {
+		if err := validate.Struct(template); err != nil {
+			return validationError(err)
+		}
+	}
+	parser, err := receipttext.NewParser(templates)
+	if err != nil {
+		return fmt.Errorf("%w: %s", appErrors.BadRequest, err)
+	}
+
+	svc.parser = parser
+	svc.logger.Infof("Receipt templates loaded: %d templates", len(templates))
+	return nil
+}
+
+// ParseReceipt extracts the receipt of the text, the retailer is replaced by its catalog name when it is in the
+// retailer catalog
+func (svc *ParserService) ParseReceipt(ctx context.Context, req *domain.ParseRequest) (*domain.ParsedReceipt, error) {
+	if err := validate.Struct(req); err != nil {
+		return nil, validationError(err)
+	}
+
+	result, err := svc.parser.Parse(req.Text, req.Locale)
+	if errors.Is(err, locale.ErrUnknownLocale) {
+		return nil, fmt.Errorf("%w: %s", appErrors.BadRequest, err)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if result.Retailer.Value != "" {
+		retailer, err := svc.retailers.ResolveRetailer(result.Retailer.Value)
+		if err != nil {
+			return nil, err
+		}
+		if retailer != nil {
+			result.Retailer.Value = retailer.Name
+			result.Retailer.Confidence = math.Max(result.Retailer.Confidence, catalogConfidence)
+		}
+	}
+
+	var parsed = &domain.ParsedReceipt{
+		Receipt: &domain.ReceiptBase{
+			Retailer:     result.Retailer.Value,
+			PurchaseDate: result.Date.Value,
+			PurchaseTime: result.Time.Value,
+			Total:        result.Total.Value,
+			Items:        make([]*domain.ReceiptItemBase, 0, len(result.Items)),
+		},
+		Confidence: &domain.ParseConfidence{
+			Retailer:     result.Retailer.Confidence,
+			PurchaseDate: result.Date.Confidence,
+			PurchaseTime: result.Time.Confidence,
+			Total:        result.Total.Confidence,
+			Items:        make([]float64, 0, len(result.Items)),
+		},
+		Template: result.Template,
+		Warnings: result.Warnings,
+	}
+	for _, item := range result.Items {
+		parsed.Receipt.Items = append(parsed.Receipt.Items, &domain.ReceiptItemBase{
+			Type:             item.Type,
+			ShortDescription: item.Description,
+			Quantity:         item.Quantity,
+			UnitPrice:        item.UnitPrice,
+			Price:            item.Price,
+		})
+		parsed.Confidence.Items = append(parsed.Confidence.Items, item.Confidence)
+	}
+
+	logging.Logger(ctx, svc.logger).Infow("receipt parsed", "template", result.Template, "locale", result.Locale, "items", len(result.Items), "warnings", len(result.Warnings))
+	return parsed, nil
+}
@@ -0,0 +1,95 @@
+package services
+
+import (
+	"context"
+	"github.com/kiramishima/receipt-processor/domain"
+	"github.com/kiramishima/receipt-processor/mocks"
+	appErrors "github.com/kiramishima/receipt-processor/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"go.uber.org/zap"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestParserService_ParseReceipt(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	logger, _ := zap.NewProduction()
+	slogger := logger.Sugar()
+	retailers := mocks.NewMockIRetailerService(mockCtrl)
+	svc := NewParserService(retailers, slogger)
+
+	var text = "TARGET STORE T-1234\n03/18/2022 2:33 PM\nPepsi 12PK 4.99 N\n2 x Gum 2.00\nTAX 0.35\nTOTAL 7.34\n"
+
+	t.Run("Catalog retailer", func(t *testing.T) {
+		retailers.EXPECT().ResolveRetailer(gomock.Eq("TARGET STORE T-1234")).Times(1).Return(&domain.Retailer{ID: "target", Name: "Target"}, nil)
+
+		parsed, err := svc.ParseReceipt(context.Background(), &domain.ParseRequest{Text: text})
+		if !assert.NoError(t, err) {
+			return
+		}
+		assert.Equal(t, &domain.ReceiptBase{
+			Retailer:     "Target",
+			PurchaseDate: "2022-03-18",
+			PurchaseTime: "14:33",
+			Total:        "7.34",
+			Items: []*domain.ReceiptItemBase{
+				{ShortDescription: "Pepsi 12PK", Price: "4.99"},
+				{ShortDescription: "Gum", Quantity: "2", Price: "2.00"},
+				{Type: domain.ItemLineTax, ShortDescription: "TAX", Price: "0.35"},
+			},
+		}, parsed.Receipt)
+		assert.Equal(t, catalogConfidence, parsed.Confidence.Retailer)
+		assert.Equal(t, 0.95, parsed.Confidence.Total)
+		assert.Len(t, parsed.Confidence.Items, 3)
+		assert.Empty(t, parsed.Warnings)
+		// The parsed receipt is a valid receipt
+		assert.NoError(t, validate.Struct(parsed.Receipt))
+	})
+
+	t.Run("Unknown retailer", func(t *testing.T) {
+		retailers.EXPECT().ResolveRetailer(gomock.Any()).Times(1).Return(nil, nil)
+
+		parsed, err := svc.ParseReceipt(context.Background(), &domain.ParseRequest{Text: text})
+		if assert.NoError(t, err) {
+			assert.Equal(t, "TARGET STORE T-1234", parsed.Receipt.Retailer)
+			assert.Less(t, parsed.Confidence.Retailer, catalogConfidence)
+		}
+	})
+
+	t.Run("Invalid requests", func(t *testing.T) {
+		_, err := svc.ParseReceipt(context.Background(), &domain.ParseRequest{})
+		assert.ErrorIs(t, err, appErrors.BadRequest)
+		_, err = svc.ParseReceipt(context.Background(), &domain.ParseRequest{Text: text, Locale: "xx-YY"})
+		assert.ErrorIs(t, err, appErrors.BadRequest)
+	})
+}
+
+func TestParserService_LoadTemplates(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	logger, _ := zap.NewProduction()
+	retailers := mocks.NewMockIRetailerService(mockCtrl)
+	svc := NewParserService(retailers, logger.Sugar())
+
+	var write = func(content string) string {
+		var path = filepath.Join(t.TempDir(), "templates.json")
+		assert.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+		return path
+	}
+	assert.NoError(t, svc.LoadTemplates(write(`[{"name": "corner", "retailer": "Corner Shop", "match": "CORNER", "total": "DUE (?P<total>\\S+)"}]`)))
+
+	retailers.EXPECT().ResolveRetailer(gomock.Eq("Corner Shop")).Times(1).Return(nil, nil)
+	parsed, err := svc.ParseReceipt(context.Background(), &domain.ParseRequest{Text: "CORNER\nMilk 1.20\nDUE 1.20\n"})
+	if assert.NoError(t, err) {
+		assert.Equal(t, "corner", parsed.Template)
+		assert.Equal(t, "Corner Shop", parsed.Receipt.Retailer)
+		assert.Equal(t, "1.20", parsed.Receipt.Total)
+	}
+
+	assert.ErrorIs(t, svc.LoadTemplates(write(`[{"name": "corner", "match": "CORNER"}]`)), appErrors.BadRequest)
+	assert.ErrorIs(t, svc.LoadTemplates(write(`[{"name": "corner", "retailer": "Corner Shop", "match": "CORNER", "total": "DUE (\\S+)"}]`)), appErrors.BadRequest)
+	assert.Error(t, svc.LoadTemplates(filepath.Join(t.TempDir(), "missing.json")))
+}
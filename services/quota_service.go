@@ -0,0 +1,87 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/kiramishima/receipt-processor/domain"
+	"github.com/kiramishima/receipt-processor/pkg/auth"
+	appErrors "github.com/kiramishima/receipt-processor/pkg/errors"
+	"github.com/kiramishima/receipt-processor/pkg/logging"
+	ports "github.com/kiramishima/receipt-processor/ports/repository"
+	"go.uber.org/zap"
+	"time"
+)
+
+type QuotaService struct {
+	logger     *zap.SugaredLogger
+	cfg        domain.RateLimitConfig
+	repository ports.IQuotaRepository
+}
+
+func NewQuotaService(cfg domain.RateLimitConfig, repository ports.IQuotaRepository, logger *zap.SugaredLogger) *QuotaService {
+	return &QuotaService{
+		logger:     logger,
+		cfg:        cfg,
+		repository: repository,
+	}
+}
+
+// quota returns the daily receipt quota of the principal, its own or the default
+func (svc *QuotaService) quota(principal *domain.Principal) int {
+	if principal.DailyReceiptQuota > 0 {
+		return principal.DailyReceiptQuota
+	}
+	return svc.cfg.QuotaDailyReceipts
+}
+
+// client returns the client of the request and its quota: the principal, or the IP of the anonymous requests with
+// the default quota. The receipts that are not of a request, like the emails, have no client.
+func (svc *QuotaService) client(ctx context.Context) (string, int) {
+	if principal := auth.Principal(ctx); principal != nil {
+		return principal.ClientKey(), svc.quota(principal)
+	}
+	if ip := auth.ClientIP(ctx); ip != "" {
+		return "ip:" + ip, svc.cfg.QuotaDailyReceipts
+	}
+	return "", 0
+}
+
+// ConsumeReceiptQuota counts a receipt in the daily quota of the client of the request. The returned refund gives it
+// back when the receipt can't be stored.
+func (svc *QuotaService) ConsumeReceiptQuota(ctx context.Context) (func(), error) {
+	var client, quota = svc.client(ctx)
+	if client == "" || quota <= 0 {
+		return func() {}, nil
+	}
+
+	day, resetsAt := domain.QuotaDay(time.Now())
+	_, err := svc.repository.IncrementQuotaUsage(client, day, quota)
+	if errors.Is(err, appErrors.QuotaExceeded) {
+		logging.Logger(ctx, svc.logger).Warnw("receipt quota exceeded", "client", client, "quota", quota)
+		return nil, fmt.Errorf("%w: %d receipts per day, resets at %s", appErrors.QuotaExceeded, quota, resetsAt.Format(time.RFC3339))
+	}
+	if err != nil {
+		return nil, err
+	}
+	return func() {
+		if err := svc.repository.DecrementQuotaUsage(client, day); err != nil {
+			logging.Logger(ctx, svc.logger).Error(err)
+		}
+	}, nil
+}
+
+// ReceiptQuota returns the daily receipt quota of the client of the request and its usage
+func (svc *QuotaService) ReceiptQuota(ctx context.Context) (*domain.QuotaStatus, error) {
+	var client, quota = svc.client(ctx)
+	if client == "" {
+		return nil, fmt.Errorf("%w: the quotas apply to the clients of the API", appErrors.Unauthorized)
+	}
+
+	day, resetsAt := domain.QuotaDay(time.Now())
+	usage, err := svc.repository.FindQuotaUsage(client, day)
+	if err != nil {
+		return nil, err
+	}
+	return &domain.QuotaStatus{QuotaUsage: *usage, Quota: quota, ResetsAt: resetsAt}, nil
+}
@@ -0,0 +1,84 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"github.com/kiramishima/receipt-processor/domain"
+	"github.com/kiramishima/receipt-processor/mocks"
+	"github.com/kiramishima/receipt-processor/pkg/auth"
+	appErrors "github.com/kiramishima/receipt-processor/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"go.uber.org/zap"
+	"testing"
+	"time"
+)
+
+func TestQuotaService(t *testing.T) {
+	logger, _ := zap.NewProduction()
+	slogger := logger.Sugar()
+	mockCtrl := gomock.NewController(t)
+
+	defer mockCtrl.Finish()
+	repo := mocks.NewMockIQuotaRepository(mockCtrl)
+	svc := NewQuotaService(domain.RateLimitConfig{QuotaDailyReceipts: 100}, repo, slogger)
+	day, resetsAt := domain.QuotaDay(time.Now())
+
+	var member = auth.WithPrincipal(context.Background(), domain.NewTokenPrincipal("user-1", "member-1", []string{domain.RoleMember}))
+	var partner = auth.WithPrincipal(context.Background(), (&domain.APIKey{ID: "key-1", DailyReceiptQuota: 2}).Principal())
+
+	t.Run("Default quota", func(t *testing.T) {
+		repo.EXPECT().IncrementQuotaUsage(gomock.Eq("member:member-1"), gomock.Eq(day), gomock.Eq(100)).Times(1).Return(&domain.QuotaUsage{Used: 1}, nil)
+		_, err := svc.ConsumeReceiptQuota(member)
+		assert.NoError(t, err)
+	})
+
+	t.Run("Refund", func(t *testing.T) {
+		repo.EXPECT().IncrementQuotaUsage(gomock.Eq("member:member-1"), gomock.Eq(day), gomock.Eq(100)).Times(1).Return(&domain.QuotaUsage{Used: 2}, nil)
+		repo.EXPECT().DecrementQuotaUsage(gomock.Eq("member:member-1"), gomock.Eq(day)).Times(1).Return(nil)
+		refund, err := svc.ConsumeReceiptQuota(member)
+		assert.NoError(t, err)
+		refund()
+	})
+
+	t.Run("Quota of the API key exceeded", func(t *testing.T) {
+		repo.EXPECT().IncrementQuotaUsage(gomock.Eq("apikey:key-1"), gomock.Eq(day), gomock.Eq(2)).Times(1).Return(&domain.QuotaUsage{Used: 2}, fmt.Errorf("%w: client apikey:key-1 used 2 of 2", appErrors.QuotaExceeded))
+		_, err := svc.ConsumeReceiptQuota(partner)
+		assert.ErrorIs(t, err, appErrors.QuotaExceeded)
+		assert.ErrorContains(t, err, "2 receipts per day")
+	})
+
+	t.Run("Anonymous client", func(t *testing.T) {
+		var anonymous = auth.WithClientIP(context.Background(), "10.0.0.1")
+		repo.EXPECT().IncrementQuotaUsage(gomock.Eq("ip:10.0.0.1"), gomock.Eq(day), gomock.Eq(100)).Times(1).Return(&domain.QuotaUsage{Used: 1}, nil)
+		_, err := svc.ConsumeReceiptQuota(anonymous)
+		assert.NoError(t, err)
+
+		repo.EXPECT().FindQuotaUsage(gomock.Eq("ip:10.0.0.1"), gomock.Eq(day)).Times(1).Return(&domain.QuotaUsage{Client: "ip:10.0.0.1", Day: day, Used: 1}, nil)
+		status, err := svc.ReceiptQuota(anonymous)
+		assert.NoError(t, err)
+		assert.Equal(t, 100, status.Quota)
+	})
+
+	t.Run("Without client", func(t *testing.T) {
+		refund, err := svc.ConsumeReceiptQuota(context.Background())
+		assert.NoError(t, err)
+		refund()
+		_, err = svc.ReceiptQuota(context.Background())
+		assert.ErrorIs(t, err, appErrors.Unauthorized)
+	})
+
+	t.Run("Unlimited", func(t *testing.T) {
+		_, err := NewQuotaService(domain.RateLimitConfig{}, repo, slogger).ConsumeReceiptQuota(member)
+		assert.NoError(t, err)
+	})
+
+	t.Run("Status", func(t *testing.T) {
+		repo.EXPECT().FindQuotaUsage(gomock.Eq("apikey:key-1"), gomock.Eq(day)).Times(1).Return(&domain.QuotaUsage{Client: "apikey:key-1", Day: day, Used: 2}, nil)
+		status, err := svc.ReceiptQuota(partner)
+		assert.NoError(t, err)
+		assert.Equal(t, 2, status.Quota)
+		assert.Equal(t, 2, status.Used)
+		assert.Equal(t, resetsAt, status.ResetsAt)
+	})
+}
@@ -6,9 +6,16 @@ import (
 	"fmt"
 	"github.com/go-playground/validator/v10"
 	"github.com/kiramishima/receipt-processor/domain"
+	"github.com/kiramishima/receipt-processor/pkg/auth"
 	appErrors "github.com/kiramishima/receipt-processor/pkg/errors"
+	"github.com/kiramishima/receipt-processor/pkg/locale"
+	"github.com/kiramishima/receipt-processor/pkg/logging"
+	"github.com/kiramishima/receipt-processor/pkg/tracing"
+	metricsPorts "github.com/kiramishima/receipt-processor/ports/metrics"
 	ports "github.com/kiramishima/receipt-processor/ports/repository"
+	servicePorts "github.com/kiramishima/receipt-processor/ports/services"
 	"go.uber.org/zap"
+	"math"
 	"strconv"
 	"time"
 )
@@ -16,80 +23,602 @@ import (
 var validate = validator.New(validator.WithRequiredStructEnabled())
 
 type ReceiptService struct {
-	logger     *zap.SugaredLogger
-	repository ports.IReceiptRepository
+	logger      *zap.SugaredLogger
+	cfg         domain.PointsConfig
+	repository  ports.IReceiptRepository
+	tiers       servicePorts.ITierService
+	campaigns   servicePorts.ICampaignService
+	retailers   servicePorts.IRetailerService
+	ruleSets    servicePorts.IRuleSetService
+	experiments servicePorts.IExperimentService
+	currencies  servicePorts.ICurrencyService
+	ledger      ports.ILedgerRepository
+	metrics     metricsPorts.IMetricsRecorder
+	quotas      servicePorts.IQuotaService
 }
 
-func NewReceiptService(repository ports.IReceiptRepository, logger *zap.SugaredLogger) *ReceiptService {
+// ReceiptServiceDeps the repositories and services the receipts are scored and stored with
+type ReceiptServiceDeps struct {
+	Repository  ports.IReceiptRepository
+	Ledger      ports.ILedgerRepository
+	Tiers       servicePorts.ITierService
+	Campaigns   servicePorts.ICampaignService
+	Retailers   servicePorts.IRetailerService
+	RuleSets    servicePorts.IRuleSetService
+	Experiments servicePorts.IExperimentService
+	Currencies  servicePorts.ICurrencyService
+	Metrics     metricsPorts.IMetricsRecorder
+	Quotas      servicePorts.IQuotaService
+}
+
+func NewReceiptService(cfg domain.PointsConfig, deps ReceiptServiceDeps, logger *zap.SugaredLogger) *ReceiptService {
 	return &ReceiptService{
-		logger:     logger,
-		repository: repository,
+		logger:      logger,
+		cfg:         cfg,
+		repository:  deps.Repository,
+		tiers:       deps.Tiers,
+		campaigns:   deps.Campaigns,
+		retailers:   deps.Retailers,
+		ruleSets:    deps.RuleSets,
+		experiments: deps.Experiments,
+		currencies:  deps.Currencies,
+		ledger:      deps.Ledger,
+		metrics:     deps.Metrics,
+		quotas:      deps.Quotas,
 	}
 }
 
 // StoreReceipt Save the points and return the id for consulting
 func (svc *ReceiptService) StoreReceipt(ctx context.Context, base *domain.ReceiptBase) (string, error) {
-	// Validate
-	err := validate.StructCtx(ctx, *base)
+	if err := principalMember(ctx, &base.MemberID); err != nil {
+		svc.metrics.ReceiptRejected(rejectionReason(err))
+		return "", err
+	}
+	ctx = memberContext(ctx, base)
+	score, err := svc.score(ctx, base)
+	if err != nil {
+		svc.metrics.ReceiptRejected(rejectionReason(err))
+		return "", err
+	}
+	// Only the valid receipts count in the quota of the client
+	refund, err := svc.quotas.ConsumeReceiptQuota(ctx)
+	if err != nil {
+		svc.metrics.ReceiptRejected(rejectionReason(err))
+		return "", err
+	}
+
+	var result = &domain.Result{
+		MemberID:   score.Receipt.MemberID,
+		Retailer:   score.Receipt.Retailer,
+		RetailerID: score.Receipt.RetailerID,
+		Points:     score.Points,
+		Breakdown:  score.Breakdown,
+		Receipt:    score.Receipt,
+		Experiment: score.Experiment,
+	}
+	id, err := svc.save(ctx, result)
 	if err != nil {
-		svc.logger.Error(err)
+		refund()
+		svc.metrics.ReceiptRejected(domain.RejectionStorage)
+		return "", err
+	}
+
+	// New members are only enrolled once their first receipt is stored. The receipt is kept when the enrollment fails,
+	// the member is enrolled with its next receipt.
+	if score.Receipt.MemberID != "" {
+		if _, err := svc.tiers.ResolveMember(score.Receipt.MemberID); err != nil {
+			logging.Logger(ctx, svc.logger).Error(err)
+		}
+	}
+
+	logging.Logger(logging.With(ctx, "receiptId", id), svc.logger).Infow("receipt stored", "points", result.Points, "ruleSetVersion", result.Breakdown.RuleSetVersion)
+	svc.metrics.ReceiptProcessed(result.Points)
+	for _, rule := range result.Breakdown.Rules {
+		if rule.Points != 0 {
+			svc.metrics.RuleFired(rule.Rule)
+		}
+	}
+	return id, nil
+}
+
+// save stores the receipt. The daily cap of the member is applied by the repository, in the same step as the insert.
+func (svc *ReceiptService) save(ctx context.Context, result *domain.Result) (string, error) {
+	if result.MemberID == "" || svc.cfg.MemberDailyCap <= 0 {
+		var done = traceRepository(ctx, "receipts", "save")
+		id, err := svc.repository.SaveReceiptPoints(result)
+		done(err)
+		return id, err
+	}
+
+	var done = traceRepository(ctx, "receipts", "save_capped")
+	id, err := svc.repository.SaveCappedReceiptPoints(result, svc.cfg.MemberDailyCap, startOfDay(time.Now()))
+	done(err)
+	return id, err
+}
+
+// startOfDay returns the start of the UTC day of t, the day of the member daily caps
+func startOfDay(t time.Time) time.Time {
+	var day = t.UTC()
+	return time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// rejectionReason classifies the errors of the rejected receipts for the metrics
+func rejectionReason(err error) string {
+	switch {
+	case errors.Is(err, appErrors.ErrTimeout):
+		return domain.RejectionTimeout
+	case errors.Is(err, appErrors.NotFound):
+		return domain.RejectionNotFound
+	case errors.Is(err, appErrors.QuotaExceeded):
+		return domain.RejectionQuota
+	default:
+		return domain.RejectionInvalid
+	}
+}
+
+// ScoreReceipt runs the same validation and scoring of StoreReceipt without persisting anything
+func (svc *ReceiptService) ScoreReceipt(ctx context.Context, base *domain.ReceiptBase) (*domain.ScoreResult, error) {
+	if err := principalMember(ctx, &base.MemberID); err != nil {
+		return nil, err
+	}
+	ctx = memberContext(ctx, base)
+	score, err := svc.score(ctx, base)
+	if err != nil {
+		return nil, err
+	}
+
+	// The daily cap is previewed with the points the member earned today, it is applied again when the receipt is stored
+	if score.Receipt.MemberID != "" && svc.cfg.MemberDailyCap > 0 {
+		var done = traceRepository(ctx, "receipts", "sum_member_points")
+		earnedToday, err := svc.repository.SumMemberPointsSince(score.Receipt.MemberID, startOfDay(time.Now()))
+		done(err)
+		if err != nil {
+			return nil, err
+		}
+		score.Breakdown.ApplyDailyCap(svc.cfg.MemberDailyCap, earnedToday)
+		score.Points = score.Breakdown.TotalPoints
+	}
+	for _, applied := range score.Breakdown.Caps {
+		score.Warnings = append(score.Warnings, fmt.Sprintf("points limited by %s from %d to %d", applied.Cap, applied.PointsBefore, applied.Limit))
+	}
+	return score, nil
+}
+
+// principalMember sets the member of the JWT that authenticated the request, so the receipts of the member app are
+// attributed to it. A member can't act for another member.
+func principalMember(ctx context.Context, memberID *string) error {
+	var principal = auth.Principal(ctx)
+	if principal == nil || principal.MemberID == "" {
+		return nil
+	}
+	if *memberID != "" && *memberID != principal.MemberID {
+		return fmt.Errorf("%w: member %s can't act for member %s", appErrors.Forbidden, principal.MemberID, *memberID)
+	}
+	*memberID = principal.MemberID
+	return nil
+}
+
+// memberContext scopes the logs of the receipt to its member
+func memberContext(ctx context.Context, base *domain.ReceiptBase) context.Context {
+	if base.MemberID == "" {
+		return ctx
+	}
+	return logging.With(ctx, "memberId", base.MemberID)
+}
+
+// score validates, parses and scores the receipt with the receipt limits, without the member daily cap. New members are
+// scored in the lowest tier without being enrolled.
+func (svc *ReceiptService) score(ctx context.Context, base *domain.ReceiptBase) (*domain.ScoreResult, error) {
+	if err := svc.validateReceipt(ctx, base); err != nil {
+		return nil, err
+	}
+
+	var warnings = make([]string, 0)
+
+	// Unknown retailers are scored with the default rule set
+	retailer, err := svc.retailers.ResolveRetailer(base.Retailer)
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case retailer == nil:
+		warnings = append(warnings, fmt.Sprintf("retailer %q is not in the catalog, default rules applied", base.Retailer))
+	case !retailer.MatchesName(base.Retailer):
+		warnings = append(warnings, fmt.Sprintf("retailer %q matched to catalog retailer %q", base.Retailer, retailer.Name))
+	}
+
+	receipt, total, parseWarnings, err := svc.parse(ctx, base, retailer)
+	if err != nil {
+		return nil, err
+	}
+	warnings = append(warnings, parseWarnings...)
+
+	active, assignment, err := svc.baseRuleSet(receipt)
+	if err != nil {
+		return nil, err
+	}
+	rules, err := svc.retailers.RuleSetFor(active, retailer)
+	if err != nil {
+		return nil, err
+	}
+	breakdown, err := svc.evaluate(receipt, rules, svc.observeRules(ctx))
+	if err != nil {
+		return nil, err
+	}
+	if converted := svc.currencies.RulesReceipt(receipt); converted != receipt {
+		warnings = append(warnings, fmt.Sprintf("total %s %s evaluated as %s %s", domain.FormatAmount(total, receipt.Currency), receipt.Currency, domain.FormatAmount(float64(converted.Total), converted.Currency), converted.Currency))
+	}
+
+	if receipt.MemberID != "" {
+		member, err := svc.tiers.FindMember(receipt.MemberID)
+		if err != nil {
+			return nil, err
+		}
+		breakdown.ApplyTierMultiplier(member.Tier, svc.tiers.MultiplierFor(member.Tier))
+	}
+
+	breakdown.ApplyReceiptLimits(svc.cfg.MinPerReceipt, svc.cfg.MaxPerReceipt)
+
+	return &domain.ScoreResult{
+		Points:     breakdown.TotalPoints,
+		Breakdown:  breakdown,
+		Warnings:   warnings,
+		Experiment: assignment,
+		Receipt:    receipt,
+	}, nil
+}
+
+// validateReceipt validates the fields of the receipt, the first invalid field is reported
+func (svc *ReceiptService) validateReceipt(ctx context.Context, base *domain.ReceiptBase) (err error) {
+	ctx, span := tracer.Start(ctx, "validate")
+	defer func() { tracing.End(span, err) }()
+
+	err = validate.StructCtx(ctx, *base)
+	if err != nil {
+		logging.Logger(ctx, svc.logger).Error(err)
 		select {
 		case <-ctx.Done():
-			return "", appErrors.ErrTimeout
+			return appErrors.ErrTimeout
 
 		default:
 
 			for _, err := range err.(validator.ValidationErrors) {
-				return "", errors.New(fmt.Sprintf("Field: %s, Error: %s\n", err.Field(), err.Tag()))
+				return errors.New(fmt.Sprintf("Field: %s, Error: %s\n", err.Field(), err.Tag()))
 			}
 		}
 	}
+	return nil
+}
 
-	// Parse to Receipt
+// parse parses the dates, times, amounts and items of the receipt and returns it with its total in its currency and
+// the warnings of the inputs it accepted
+func (svc *ReceiptService) parse(ctx context.Context, base *domain.ReceiptBase, retailer *domain.Retailer) (receipt *domain.Receipt, total float64, warnings []string, err error) {
+	_, span := tracer.Start(ctx, "parse")
+	defer func() { tracing.End(span, err) }()
+
+	// Parse to Receipt, the purchase time is local to the retailer
+	var location = time.UTC
+	if retailer != nil && retailer.TimeZone != "" {
+		if location, err = time.LoadLocation(retailer.TimeZone); err != nil {
+			return nil, 0, nil, err
+		}
+	}
+	// The dates, times and amounts are read with the formats of the declared locale, ambiguous inputs are rejected
+	profile, err := locale.Lookup(base.Locale)
+	if err != nil {
+		return nil, 0, nil, fmt.Errorf("%w: %s", appErrors.BadRequest, err)
+	}
 	timeString := fmt.Sprintf("%s %s", base.PurchaseDate, base.PurchaseTime)
-	purchaseDt, err := time.Parse("2006-01-02 15:04", timeString)
+	purchaseDt, err := profile.ParseDateTime(base.PurchaseDate, base.PurchaseTime, location)
+	if errors.Is(err, locale.ErrAmbiguous) {
+		return nil, 0, nil, fmt.Errorf("%w: %s", appErrors.BadRequest, err)
+	}
 	if err != nil {
-		return "", errors.New(fmt.Sprintf("error parsing timestring: %s", timeString))
+		return nil, 0, nil, errors.New(fmt.Sprintf("error parsing timestring: %s", timeString))
+	}
+	if purchaseDt.After(time.Now()) {
+		warnings = append(warnings, fmt.Sprintf("purchase time %s is in the future", purchaseDt.Format(time.RFC3339)))
 	}
 
-	total, err := strconv.ParseFloat(base.Total, 32)
+	// Amounts are parsed with the minor unit of the currency, the receipts without currency are in the base currency
+	var currency = base.Currency
+	if currency == "" {
+		currency = svc.currencies.BaseCurrency()
+	}
+	rate, err := svc.currencies.ReceiptRate(currency)
 	if err != nil {
-		return "", errors.New(fmt.Sprintf("error parsing currency string: %s", base.Total))
+		return nil, 0, nil, err
+	}
+	total, err = parseAmount(profile, base.Total, currency)
+	if err != nil {
+		return nil, 0, nil, err
 	}
 	// Items
 	var items []*domain.ReceiptItem
+	var itemsTotal = 0.0
 	for _, item := range base.Items {
-		// Parsing price
-		price, err := strconv.ParseFloat(item.Price, 32)
+		parsed, warning, err := parseItem(profile, item, currency)
 		if err != nil {
-			return "", errors.New(fmt.Sprintf("error parsing currency string: %s", item.Price))
+			return nil, 0, nil, err
+		}
+		if warning != "" {
+			warnings = append(warnings, warning)
 		}
-		items = append(items, &domain.ReceiptItem{ShortDescription: item.ShortDescription, Price: float32(price)})
+		itemsTotal += float64(parsed.Price)
+		items = append(items, parsed)
+	}
+	// Discount lines are subtracted and tax lines are added to the items
+	if math.Abs(itemsTotal-total) >= 0.5*math.Pow10(-domain.CurrencyExponent(currency)) {
+		warnings = append(warnings, fmt.Sprintf("items add up to %s but the total is %s", domain.FormatAmount(itemsTotal, currency), domain.FormatAmount(total, currency)))
 	}
 
-	receipt := &domain.Receipt{
-		Retailer:   base.Retailer,
-		PurchaseDT: purchaseDt,
-		Total:      float32(total),
-		Items:      items,
+	receipt = &domain.Receipt{
+		MemberID:     base.MemberID,
+		Retailer:     base.Retailer,
+		PurchaseDT:   purchaseDt,
+		Currency:     currency,
+		ExchangeRate: rate,
+		Total:        float32(total),
+		Items:        items,
+	}
+	if retailer != nil {
+		receipt.RetailerID = retailer.ID
+		receipt.RetailerName = retailer.Name
 	}
+	return receipt, total, warnings, nil
+}
 
-	var points = receipt.GetTotalPoints()
+// parseItem parses a receipt line. The price of the line is the quantity times the unit price when it is not given,
+// and discounts are stored as negative amounts. The warning reports prices that don't match the quantity.
+func parseItem(profile *locale.Profile, item *domain.ReceiptItemBase, currency string) (*domain.ReceiptItem, string, error) {
+	var parsed = &domain.ReceiptItem{
+		ShortDescription: item.ShortDescription,
+		SKU:              item.SKU,
+		UPC:              item.UPC,
+		Category:         item.Category,
+	}
+	if item.Type != domain.ItemLineItem {
+		parsed.Type = item.Type
+	}
 
-	id, err := svc.repository.SaveReceiptPoints(int16(points))
+	var quantity, unitPrice = 1.0, 0.0
+	var err error
+	if item.Quantity != "" {
+		canonical, err := profile.ParseAmount(item.Quantity)
+		if err == nil {
+			quantity, err = strconv.ParseFloat(canonical, 32)
+		}
+		if err != nil || quantity <= 0 || quantity > domain.MaxItemQuantity {
+			return nil, "", errors.New(fmt.Sprintf("error parsing quantity string: %s", item.Quantity))
+		}
+		parsed.Quantity = float32(quantity)
+	}
+	if item.UnitPrice != "" {
+		if unitPrice, err = parseAmount(profile, item.UnitPrice, currency); err != nil {
+			return nil, "", err
+		}
+		parsed.UnitPrice = float32(unitPrice)
+	}
+
+	// Parsing price
+	var expected = domain.RoundAmount(quantity*unitPrice, currency)
+	var price = expected
+	var warning string
+	if item.Price != "" {
+		if price, err = parseAmount(profile, item.Price, currency); err != nil {
+			return nil, "", err
+		}
+		if item.UnitPrice != "" && price != expected {
+			warning = fmt.Sprintf("item %q price %s is not %g x %s", item.ShortDescription, domain.FormatAmount(price, currency), quantity, domain.FormatAmount(unitPrice, currency))
+		}
+	}
+	if parsed.Type == domain.ItemLineDiscount {
+		price = -math.Abs(price)
+	}
+	parsed.Price = float32(price)
+	return parsed, warning, nil
+}
+
+// parseAmount parses an amount with the format of the locale and the minor unit of the currency
+func parseAmount(profile *locale.Profile, amount string, currency string) (float64, error) {
+	canonical, err := profile.ParseAmount(amount)
+	if errors.Is(err, locale.ErrAmbiguous) {
+		return 0, fmt.Errorf("%w: %s", appErrors.BadRequest, err)
+	}
 	if err != nil {
-		return "", err
+		return 0, errors.New(fmt.Sprintf("error parsing currency string: %s", amount))
 	}
-	return id, nil
+	return domain.ParseAmount(canonical, currency)
+}
+
+// baseRuleSet returns the active rule set, or the rule set of the arm the receipt is assigned to when an experiment
+// is running
+func (svc *ReceiptService) baseRuleSet(receipt *domain.Receipt) (*domain.RuleSet, *domain.ExperimentAssignment, error) {
+	experiment, err := svc.experiments.ActiveExperiment(time.Now())
+	if err != nil {
+		return nil, nil, err
+	}
+	if experiment == nil {
+		rs, err := svc.ruleSets.ActiveRuleSet()
+		return rs, nil, err
+	}
+
+	var assignment = experiment.Assign(receipt)
+	rs, err := svc.ruleSets.RetrieveRuleSet(assignment.RuleSetVersion)
+	if err != nil {
+		return nil, nil, err
+	}
+	return rs, assignment, nil
+}
+
+// evaluate scores the receipt with the base rules and the campaigns running when it was purchased, in the currency
+// the rules are evaluated in. The evaluation of every rule is reported to the observer, when there is one.
+func (svc *ReceiptService) evaluate(receipt *domain.Receipt, rules *domain.RuleSet, observe domain.RuleObserver) (*domain.Breakdown, error) {
+	receipt = svc.currencies.RulesReceipt(receipt)
+	var breakdown = receipt.ObserveBreakdown(rules, observe)
+
+	campaigns, err := svc.campaigns.ActiveCampaigns(receipt.PurchaseDT)
+	if err != nil {
+		return nil, err
+	}
+	for _, campaign := range campaigns {
+		if campaign.Matches(receipt) {
+			breakdown.ApplyCampaign(campaign)
+		}
+	}
+	return breakdown, nil
+}
+
+// observeRules traces the evaluation of every rule and logs it at debug level
+func (svc *ReceiptService) observeRules(ctx context.Context) domain.RuleObserver {
+	var trace = traceRules(ctx)
+	var logger = logging.Logger(ctx, svc.logger)
+	return func(rule string) func(points *domain.RulePoints) {
+		var done = trace(rule)
+		return func(points *domain.RulePoints) {
+			done(points)
+			logger.Debugw("rule evaluated", "rule", points.Rule, "points", points.Points, "reason", points.Reason)
+		}
+	}
+}
+
+// RescoreReceipts re-scores the stored receipts matching the filter with a rule set version and reports the
+// differences with the stored points. The tier multiplier the receipt got when it was stored is kept and the member
+// daily cap is applied again with the points of the member stored the same day before the receipt. When the request
+// commits, the new points are stored and every difference is recorded as a ledger adjustment.
+func (svc *ReceiptService) RescoreReceipts(ctx context.Context, req *domain.RescoreRequest) (*domain.RescoreReport, error) {
+	if err := validate.StructCtx(ctx, *req); err != nil {
+		logging.Logger(ctx, svc.logger).Error(err)
+		return nil, validationError(err)
+	}
+
+	target, err := svc.ruleSets.RetrieveRuleSet(req.RuleSetVersion)
+	if err != nil {
+		return nil, err
+	}
+	var done = traceRepository(ctx, "receipts", "list")
+	results, err := svc.repository.ListReceipts(req.Filter)
+	done(err)
+	if err != nil {
+		return nil, err
+	}
+
+	var report = &domain.RescoreReport{
+		RuleSetVersion: target.Version,
+		Committed:      req.Commit,
+		Summary:        &domain.RescoreSummary{},
+		Receipts:       make([]*domain.RescoreDiff, 0, len(results)),
+	}
+	// The new points of the receipts, the daily cap of the later receipts of the member is applied with them
+	var rescored = make(map[string]int, len(results))
+	for _, result := range results {
+		select {
+		case <-ctx.Done():
+			return nil, appErrors.ErrTimeout
+		default:
+		}
+
+		// Receipts stored without their content can't be re-scored
+		if result.Receipt == nil {
+			continue
+		}
+		breakdown, err := svc.rescore(ctx, result, target, rescored)
+		if err != nil {
+			return nil, err
+		}
+		rescored[result.ID] = breakdown.TotalPoints
+
+		var diff = report.Add(result.ID, result.Points, breakdown.TotalPoints)
+		if !req.Commit {
+			continue
+		}
+		var done = traceRepository(ctx, "receipts", "update_points")
+		err = svc.repository.UpdateReceiptPoints(result.ID, breakdown.TotalPoints, breakdown)
+		done(err)
+		if err != nil {
+			return nil, err
+		}
+		if diff.Delta == 0 {
+			continue
+		}
+		done = traceRepository(ctx, "ledger", "save")
+		_, err = svc.ledger.SaveLedgerEntry(&domain.LedgerEntry{
+			ReceiptID:      result.ID,
+			MemberID:       result.MemberID,
+			Points:         diff.Delta,
+			Reason:         "rescore",
+			RuleSetVersion: target.Version,
+		})
+		done(err)
+		if err != nil {
+			return nil, err
+		}
+	}
+	logging.Logger(ctx, svc.logger).Infow("receipts rescored", "ruleSetVersion", target.Version, "receipts", report.Summary.Receipts, "delta", report.Summary.Delta, "committed", req.Commit)
+	return report, nil
 }
 
-// RetrieveReceipt recover points by id
-func (svc *ReceiptService) RetrieveReceipt(id string) (*domain.Result, error) {
-	svc.logger.Info("ID -> ", id)
+func (svc *ReceiptService) rescore(ctx context.Context, result *domain.Result, target *domain.RuleSet, rescored map[string]int) (*domain.Breakdown, error) {
+	var retailer *domain.Retailer
+	if result.RetailerID != "" {
+		// Retailers removed from the catalog fall back to the rule set without overrides
+		retailer, _ = svc.retailers.RetrieveRetailer(result.RetailerID)
+	}
+	rules, err := svc.retailers.RuleSetFor(target, retailer)
+	if err != nil {
+		return nil, err
+	}
+
+	// The rules are not traced, a span per rule of every receipt would flood the trace
+	breakdown, err := svc.evaluate(result.Receipt, rules, nil)
+	if err != nil {
+		return nil, err
+	}
+	if result.Breakdown != nil && result.Breakdown.Tier != "" {
+		breakdown.ApplyTierMultiplier(result.Breakdown.Tier, result.Breakdown.TierMultiplier)
+	}
+	breakdown.ApplyReceiptLimits(svc.cfg.MinPerReceipt, svc.cfg.MaxPerReceipt)
+	if result.MemberID != "" && svc.cfg.MemberDailyCap > 0 {
+		earned, err := svc.earnedBefore(ctx, result, rescored)
+		if err != nil {
+			return nil, err
+		}
+		breakdown.ApplyDailyCap(svc.cfg.MemberDailyCap, earned)
+	}
+	return breakdown, nil
+}
+
+// earnedBefore sums the points of the receipts of the member stored the same day before the receipt, the day its daily
+// cap was applied. The receipts re-scored before count with their new points.
+func (svc *ReceiptService) earnedBefore(ctx context.Context, result *domain.Result, rescored map[string]int) (int, error) {
+	var done = traceRepository(ctx, "receipts", "list")
+	receipts, err := svc.repository.ListReceipts(&domain.ReceiptFilter{MemberID: result.MemberID, From: startOfDay(result.CreatedAt), To: result.CreatedAt})
+	done(err)
+	if err != nil {
+		return 0, err
+	}
+	var earned = 0
+	for _, item := range receipts {
+		if points, ok := rescored[item.ID]; ok {
+			earned = domain.AddPoints(earned, points)
+		} else if item.ID != result.ID {
+			earned = domain.AddPoints(earned, item.Points)
+		}
+	}
+	return earned, nil
+}
+
+// RetrieveReceipt recover points by id, the members only find their own receipts
+func (svc *ReceiptService) RetrieveReceipt(ctx context.Context, id string) (*domain.Result, error) {
+	logging.Logger(ctx, svc.logger).Debugw("receipt retrieved", "receiptId", id)
 
 	item, err := svc.repository.FindReceiptById(id)
 	if err != nil {
 		return nil, err
 	}
+	// A member only finds its own receipts, the receipts of others look like they don't exist
+	if principal := auth.Principal(ctx); principal != nil && principal.MemberID != "" && item.MemberID != principal.MemberID {
+		return nil, fmt.Errorf("receipt with id: %s %w", id, appErrors.NotFound)
+	}
 	return item, nil
 }
@@ -5,22 +5,91 @@ import (
 	"errors"
 	"fmt"
 	"github.com/google/uuid"
+	repository "github.com/kiramishima/receipt-processor/adapter/db/in-memory"
+	"github.com/kiramishima/receipt-processor/adapter/metrics"
 	"github.com/kiramishima/receipt-processor/domain"
 	"github.com/kiramishima/receipt-processor/mocks"
+	"github.com/kiramishima/receipt-processor/pkg/auth"
+	appErrors "github.com/kiramishima/receipt-processor/pkg/errors"
+	"github.com/kiramishima/receipt-processor/pkg/logging"
 	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
 	"go.uber.org/mock/gomock"
 	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 )
 
-func TestReceiptService_StoreReceipt(t *testing.T) {
+var usd = domain.CurrencyConfig{BaseCurrency: "USD", RulesCurrency: domain.RulesCurrencyReceipt}
+
+// receiptServiceSetup the config, collaborators and logger of the receipt service of a test
+type receiptServiceSetup struct {
+	cfg    domain.PointsConfig
+	deps   ReceiptServiceDeps
+	logger *zap.SugaredLogger
+}
+
+// receiptServiceMocks the mocked repositories and tier service of the receipt service of a test, without expectations
+type receiptServiceMocks struct {
+	repo   *mocks.MockIReceiptRepository
+	ledger *mocks.MockILedgerRepository
+	tiers  *mocks.MockITierService
+}
+
+// newTestReceiptService creates a receipt service scoring with the active default rule set, without experiments,
+// campaigns nor catalog retailers, in dollars, without quotas nor metrics. The overrides replace its config and
+// collaborators.
+func newTestReceiptService(t *testing.T, overrides ...func(s *receiptServiceSetup)) (*ReceiptService, *receiptServiceMocks) {
+	t.Helper()
 	logger, _ := zap.NewProduction()
 	slogger := logger.Sugar()
 	mockCtrl := gomock.NewController(t)
 
-	defer mockCtrl.Finish()
-	repo := mocks.NewMockIReceiptRepository(mockCtrl)
+	var m = &receiptServiceMocks{
+		repo:   mocks.NewMockIReceiptRepository(mockCtrl),
+		ledger: mocks.NewMockILedgerRepository(mockCtrl),
+		tiers:  mocks.NewMockITierService(mockCtrl),
+	}
+	campaigns := mocks.NewMockICampaignService(mockCtrl)
+	campaigns.EXPECT().ActiveCampaigns(gomock.Any()).Return(nil, nil).AnyTimes()
+	retailers := mocks.NewMockIRetailerService(mockCtrl)
+	retailers.EXPECT().ResolveRetailer(gomock.Any()).Return(nil, nil).AnyTimes()
+	retailers.EXPECT().RuleSetFor(gomock.Any(), gomock.Any()).Return(domain.DefaultRuleSet(), nil).AnyTimes()
+	ruleSets := mocks.NewMockIRuleSetService(mockCtrl)
+	ruleSets.EXPECT().ActiveRuleSet().Return(domain.DefaultRuleSet(), nil).AnyTimes()
+	experiments := mocks.NewMockIExperimentService(mockCtrl)
+	experiments.EXPECT().ActiveExperiment(gomock.Any()).Return(nil, nil).AnyTimes()
+
+	var setup = &receiptServiceSetup{
+		deps: ReceiptServiceDeps{
+			Repository:  m.repo,
+			Ledger:      m.ledger,
+			Tiers:       m.tiers,
+			Campaigns:   campaigns,
+			Retailers:   retailers,
+			RuleSets:    ruleSets,
+			Experiments: experiments,
+			Currencies:  NewCurrencyService(usd, slogger),
+			Metrics:     metrics.NopRecorder{},
+			// Without a default quota the requests without principal never use the quota repository
+			Quotas: NewQuotaService(domain.RateLimitConfig{}, mocks.NewMockIQuotaRepository(mockCtrl), slogger),
+		},
+		logger: slogger,
+	}
+	for _, override := range overrides {
+		override(setup)
+	}
+	return NewReceiptService(setup.cfg, setup.deps, setup.logger), m
+}
 
+func TestReceiptService_StoreReceipt(t *testing.T) {
 	var data = []*domain.ReceiptBase{
 		{
 			Retailer:     "Target",
@@ -323,11 +392,11 @@ func TestReceiptService_StoreReceipt(t *testing.T) {
 
 	// uids
 	var uids = []string{uuid.New().String(), uuid.New().String()}
+	svc, m := newTestReceiptService(t)
 	gomock.InOrder(
-		repo.EXPECT().SaveReceiptPoints(gomock.Any()).Times(1).Return(uids[0], nil),
-		repo.EXPECT().SaveReceiptPoints(gomock.Any()).Return("", nil).AnyTimes(),
+		m.repo.EXPECT().SaveReceiptPoints(gomock.Any()).Times(1).Return(uids[0], nil),
+		m.repo.EXPECT().SaveReceiptPoints(gomock.Any()).Return("", nil).AnyTimes(),
 	)
-	svc := NewReceiptService(repo, slogger)
 
 	t.Run("OK", func(t *testing.T) {
 		ctx := context.Background()
@@ -481,26 +550,19 @@ func TestReceiptService_StoreReceipt(t *testing.T) {
 }
 
 func TestReceiptService_StoreReceipt2(t *testing.T) {
-	logger, _ := zap.NewProduction()
-	slogger := logger.Sugar()
-	mockCtrl := gomock.NewController(t)
-
-	defer mockCtrl.Finish()
-	repo := mocks.NewMockIReceiptRepository(mockCtrl)
-
 	var uids = []string{uuid.New().String(), uuid.New().String()}
+	svc, m := newTestReceiptService(t)
 	gomock.InOrder(
-		repo.EXPECT().FindReceiptById(gomock.Eq(uids[0])).Times(1).Return(&domain.Result{
+		m.repo.EXPECT().FindReceiptById(gomock.Eq(uids[0])).Times(1).Return(&domain.Result{
 			ID:     uids[0],
 			Points: 10,
 		}, nil),
-		repo.EXPECT().FindReceiptById(gomock.Eq(uids[1])).Return(nil, errors.New(fmt.Sprintf("element with id: %s don't found", uids[1]))).AnyTimes(),
+		m.repo.EXPECT().FindReceiptById(gomock.Eq(uids[1])).Return(nil, errors.New(fmt.Sprintf("element with id: %s don't found", uids[1]))).AnyTimes(),
 	)
-	svc := NewReceiptService(repo, slogger)
 
 	t.Run("OK", func(t *testing.T) {
 		id := uids[0]
-		b, err := svc.RetrieveReceipt(id)
+		b, err := svc.RetrieveReceipt(context.Background(), id)
 		// t.Log("B", b)
 		assert.NoError(t, err)
 		assert.Equal(t, id, b.ID)
@@ -508,8 +570,872 @@ func TestReceiptService_StoreReceipt2(t *testing.T) {
 
 	t.Run("Not Found", func(t *testing.T) {
 		id := uids[1]
-		_, err := svc.RetrieveReceipt(id)
+		_, err := svc.RetrieveReceipt(context.Background(), id)
 		// t.Log("B", b)
 		assert.Error(t, err)
 	})
 }
+
+func TestReceiptService_RetrieveReceiptOfMember(t *testing.T) {
+	svc, m := newTestReceiptService(t)
+	m.repo.EXPECT().FindReceiptById(gomock.Eq("receipt-1")).AnyTimes().Return(&domain.Result{ID: "receipt-1", MemberID: "member-1", Points: 10}, nil)
+	m.repo.EXPECT().FindReceiptById(gomock.Eq("receipt-2")).AnyTimes().Return(&domain.Result{ID: "receipt-2", Points: 20}, nil)
+	var member = func(id string) context.Context {
+		return auth.WithPrincipal(context.Background(), domain.NewTokenPrincipal("user-1", id, []string{domain.RoleMember}))
+	}
+
+	t.Run("Own receipt", func(t *testing.T) {
+		result, err := svc.RetrieveReceipt(member("member-1"), "receipt-1")
+		assert.NoError(t, err)
+		assert.Equal(t, 10, result.Points)
+	})
+
+	t.Run("Other member", func(t *testing.T) {
+		_, err := svc.RetrieveReceipt(member("member-2"), "receipt-1")
+		assert.ErrorIs(t, err, appErrors.NotFound)
+	})
+
+	t.Run("Without member", func(t *testing.T) {
+		_, err := svc.RetrieveReceipt(member("member-1"), "receipt-2")
+		assert.ErrorIs(t, err, appErrors.NotFound)
+	})
+
+	t.Run("API key", func(t *testing.T) {
+		var ctx = auth.WithPrincipal(context.Background(), (&domain.APIKey{ID: "key-1", Scopes: []string{domain.ScopeReceiptsRead}}).Principal())
+		result, err := svc.RetrieveReceipt(ctx, "receipt-1")
+		assert.NoError(t, err)
+		assert.Equal(t, "receipt-1", result.ID)
+	})
+}
+
+func TestReceiptService_StoreReceiptWithMember(t *testing.T) {
+	var data = &domain.ReceiptBase{
+		MemberID:     "member-1",
+		Retailer:     "M&M Corner Market",
+		PurchaseDate: "2022-03-20",
+		PurchaseTime: "14:33",
+		Items: []*domain.ReceiptItemBase{
+			{ShortDescription: "Gatorade", Price: "2.25"},
+			{ShortDescription: "Gatorade", Price: "2.25"},
+			{ShortDescription: "Gatorade", Price: "2.25"},
+			{ShortDescription: "Gatorade", Price: "2.25"},
+		},
+		Total: "9.00",
+	}
+
+	var uid = uuid.New().String()
+	campaigns := mocks.NewMockICampaignService(gomock.NewController(t))
+	campaigns.EXPECT().ActiveCampaigns(gomock.Any()).Times(1).Return([]*domain.Campaign{
+		{ID: "c1", Name: "Gatorade bonus", ItemMatcher: "gatorade", Bonus: 100, StartsAt: time.Date(2022, 3, 19, 0, 0, 0, 0, time.UTC), EndsAt: time.Date(2022, 3, 21, 0, 0, 0, 0, time.UTC)},
+		{ID: "c2", Name: "Double points at Target", RetailerMatcher: "Target", Multiplier: 2, StartsAt: time.Date(2022, 3, 19, 0, 0, 0, 0, time.UTC), EndsAt: time.Date(2022, 3, 21, 0, 0, 0, 0, time.UTC)},
+	}, nil)
+	svc, m := newTestReceiptService(t, func(s *receiptServiceSetup) { s.deps.Campaigns = campaigns })
+	m.tiers.EXPECT().FindMember(gomock.Eq("member-1")).Times(1).Return(&domain.Member{ID: "member-1", Tier: domain.TierGold}, nil)
+	m.tiers.EXPECT().MultiplierFor(gomock.Eq(domain.TierGold)).Times(1).Return(1.5)
+	save := m.repo.EXPECT().SaveReceiptPoints(gomock.Any()).Times(1).DoAndReturn(func(result *domain.Result) (string, error) {
+		assert.Equal(t, "member-1", result.MemberID)
+		assert.Equal(t, 314, result.Points)
+		assert.Equal(t, 109, result.Breakdown.BasePoints)
+		assert.Equal(t, []*domain.CampaignPoints{{CampaignID: "c1", Name: "Gatorade bonus", Points: 100}}, result.Breakdown.Campaigns)
+		assert.Equal(t, domain.TierGold, result.Breakdown.Tier)
+		assert.Equal(t, 1.5, result.Breakdown.TierMultiplier)
+		return uid, nil
+	})
+	// The member is enrolled once its receipt is stored
+	m.tiers.EXPECT().ResolveMember(gomock.Eq("member-1")).Times(1).After(save).Return(&domain.Member{ID: "member-1", Tier: domain.TierGold}, nil)
+
+	id, err := svc.StoreReceipt(context.Background(), data)
+	assert.NoError(t, err)
+	assert.Equal(t, uid, id)
+}
+
+func TestReceiptService_StoreReceiptEnrollment(t *testing.T) {
+	var data = &domain.ReceiptBase{
+		MemberID:     "member-1",
+		Retailer:     "Target",
+		PurchaseDate: "2022-01-01",
+		PurchaseTime: "13:01",
+		Items:        []*domain.ReceiptItemBase{{ShortDescription: "Pepsi", Price: "1.25"}},
+		Total:        "1.25",
+	}
+
+	t.Run("Not stored", func(t *testing.T) {
+		svc, m := newTestReceiptService(t)
+		m.tiers.EXPECT().FindMember(gomock.Eq("member-1")).Return(&domain.Member{ID: "member-1", Tier: domain.TierBronze}, nil)
+		m.tiers.EXPECT().MultiplierFor(gomock.Any()).Return(1.0)
+		m.repo.EXPECT().SaveReceiptPoints(gomock.Any()).Times(1).Return("", errors.New("unavailable"))
+		m.tiers.EXPECT().ResolveMember(gomock.Any()).Times(0)
+
+		_, err := svc.StoreReceipt(context.Background(), data)
+		assert.EqualError(t, err, "unavailable")
+	})
+
+	t.Run("Enrollment error", func(t *testing.T) {
+		svc, m := newTestReceiptService(t)
+		m.tiers.EXPECT().FindMember(gomock.Eq("member-1")).Return(&domain.Member{ID: "member-1", Tier: domain.TierBronze}, nil)
+		m.tiers.EXPECT().MultiplierFor(gomock.Any()).Return(1.0)
+		m.repo.EXPECT().SaveReceiptPoints(gomock.Any()).Times(1).Return("id-1", nil)
+		m.tiers.EXPECT().ResolveMember(gomock.Eq("member-1")).Times(1).Return(nil, errors.New("unavailable"))
+
+		// The stored receipt is not rejected, the member is enrolled with its next receipt
+		id, err := svc.StoreReceipt(context.Background(), data)
+		assert.NoError(t, err)
+		assert.Equal(t, "id-1", id)
+	})
+
+	t.Run("Member error", func(t *testing.T) {
+		svc, m := newTestReceiptService(t)
+		m.tiers.EXPECT().FindMember(gomock.Eq("member-1")).Return(nil, errors.New("unavailable"))
+		m.repo.EXPECT().SaveReceiptPoints(gomock.Any()).Times(0)
+
+		_, err := svc.StoreReceipt(context.Background(), data)
+		assert.EqualError(t, err, "unavailable")
+	})
+}
+
+func TestReceiptService_StoreReceiptWithRetailer(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+
+	var disabled = false
+	var retailer = &domain.Retailer{
+		ID:            "mm-corner-market",
+		Name:          "M&M Corner Market",
+		TimeZone:      "America/Chicago",
+		RuleOverrides: map[string]*domain.RuleOverride{domain.RuleRoundDollar: {Enabled: &disabled}},
+	}
+	var data = &domain.ReceiptBase{
+		Retailer:     "M & M CORNER MKT",
+		PurchaseDate: "2022-03-20",
+		PurchaseTime: "14:33",
+		Items: []*domain.ReceiptItemBase{
+			{ShortDescription: "Gatorade", Price: "2.25"},
+			{ShortDescription: "Gatorade", Price: "2.25"},
+			{ShortDescription: "Gatorade", Price: "2.25"},
+			{ShortDescription: "Gatorade", Price: "2.25"},
+		},
+		Total: "9.00",
+	}
+
+	var uid = uuid.New().String()
+	retailers := mocks.NewMockIRetailerService(mockCtrl)
+	retailers.EXPECT().ResolveRetailer(gomock.Eq("M & M CORNER MKT")).Times(1).Return(retailer, nil)
+	retailers.EXPECT().RuleSetFor(gomock.Any(), gomock.Eq(retailer)).Times(1).Return(domain.DefaultRuleSet().WithOverrides(retailer.RuleOverrides), nil)
+	campaigns := mocks.NewMockICampaignService(mockCtrl)
+	campaigns.EXPECT().ActiveCampaigns(gomock.Any()).Times(1).DoAndReturn(func(at time.Time) ([]*domain.Campaign, error) {
+		assert.Equal(t, "America/Chicago", at.Location().String())
+		return nil, nil
+	})
+	svc, m := newTestReceiptService(t, func(s *receiptServiceSetup) {
+		s.deps.Retailers = retailers
+		s.deps.Campaigns = campaigns
+	})
+	m.repo.EXPECT().SaveReceiptPoints(gomock.Any()).Times(1).DoAndReturn(func(result *domain.Result) (string, error) {
+		assert.Equal(t, "M & M CORNER MKT", result.Retailer)
+		assert.Equal(t, "mm-corner-market", result.RetailerID)
+		// 109 points of the example - 50 of the round dollar rule disabled for the retailer
+		assert.Equal(t, 59, result.Points)
+		return uid, nil
+	})
+
+	id, err := svc.StoreReceipt(context.Background(), data)
+	assert.NoError(t, err)
+	assert.Equal(t, uid, id)
+}
+
+func TestReceiptService_StoreReceiptWithCaps(t *testing.T) {
+	var data = &domain.ReceiptBase{
+		MemberID:     "member-1",
+		Retailer:     "M&M Corner Market",
+		PurchaseDate: "2022-03-20",
+		PurchaseTime: "14:33",
+		Items: []*domain.ReceiptItemBase{
+			{ShortDescription: "Gatorade", Price: "2.25"},
+			{ShortDescription: "Gatorade", Price: "2.25"},
+			{ShortDescription: "Gatorade", Price: "2.25"},
+			{ShortDescription: "Gatorade", Price: "2.25"},
+		},
+		Total: "9.00",
+	}
+
+	// The member earned 50 points today
+	var receipts = repository.NewReceiptRepository()
+	_, _ = receipts.SaveReceiptPoints(&domain.Result{MemberID: "member-1", Points: 50})
+	svc, m := newTestReceiptService(t, func(s *receiptServiceSetup) {
+		s.cfg = domain.PointsConfig{MaxPerReceipt: 100, MinPerReceipt: 5, MemberDailyCap: 120}
+		s.deps.Repository = receipts
+	})
+	m.tiers.EXPECT().FindMember(gomock.Eq("member-1")).Return(&domain.Member{ID: "member-1", Tier: domain.TierBronze}, nil).AnyTimes()
+	m.tiers.EXPECT().MultiplierFor(gomock.Eq(domain.TierBronze)).Return(1.0).AnyTimes()
+	m.tiers.EXPECT().ResolveMember(gomock.Eq("member-1")).Return(&domain.Member{ID: "member-1", Tier: domain.TierBronze}, nil).AnyTimes()
+	var caps = []*domain.AppliedCap{
+		{Cap: domain.CapReceiptMax, Limit: 100, PointsBefore: 109},
+		{Cap: domain.CapMemberDailyCap, Limit: 70, PointsBefore: 100},
+	}
+
+	t.Run("Preview", func(t *testing.T) {
+		result, err := svc.ScoreReceipt(context.Background(), data)
+		assert.NoError(t, err)
+		assert.Equal(t, 70, result.Points)
+		assert.Equal(t, caps, result.Breakdown.Caps)
+		assert.Contains(t, result.Warnings, "points limited by memberDailyCap from 100 to 70")
+	})
+
+	t.Run("Stored", func(t *testing.T) {
+		id, err := svc.StoreReceipt(context.Background(), data)
+		assert.NoError(t, err)
+		stored, err := receipts.FindReceiptById(id)
+		assert.NoError(t, err)
+		assert.Equal(t, 70, stored.Points)
+		assert.Equal(t, caps, stored.Breakdown.Caps)
+	})
+
+	t.Run("Cap reached", func(t *testing.T) {
+		// The daily cap goes below the receipt minimum
+		id, err := svc.StoreReceipt(context.Background(), data)
+		assert.NoError(t, err)
+		stored, err := receipts.FindReceiptById(id)
+		assert.NoError(t, err)
+		assert.Equal(t, 0, stored.Points)
+		assert.Equal(t, &domain.AppliedCap{Cap: domain.CapMemberDailyCap, Limit: 0, PointsBefore: 100}, stored.Breakdown.Caps[len(stored.Breakdown.Caps)-1])
+	})
+}
+
+func TestReceiptService_RescoreCappedReceipts(t *testing.T) {
+	var data = &domain.ReceiptBase{
+		MemberID:     "member-1",
+		Retailer:     "M&M Corner Market",
+		PurchaseDate: "2022-03-20",
+		PurchaseTime: "14:33",
+		Items: []*domain.ReceiptItemBase{
+			{ShortDescription: "Gatorade", Price: "2.25"},
+			{ShortDescription: "Gatorade", Price: "2.25"},
+			{ShortDescription: "Gatorade", Price: "2.25"},
+			{ShortDescription: "Gatorade", Price: "2.25"},
+		},
+		Total: "9.00",
+	}
+
+	var receipts = repository.NewReceiptRepository()
+	ruleSets := mocks.NewMockIRuleSetService(gomock.NewController(t))
+	ruleSets.EXPECT().ActiveRuleSet().Return(domain.DefaultRuleSet(), nil).AnyTimes()
+	ruleSets.EXPECT().RetrieveRuleSet(gomock.Eq(domain.DefaultRuleSetVersion)).Return(domain.DefaultRuleSet(), nil).AnyTimes()
+	svc, m := newTestReceiptService(t, func(s *receiptServiceSetup) {
+		s.cfg = domain.PointsConfig{MaxPerReceipt: 100, MemberDailyCap: 120}
+		s.deps.Repository = receipts
+		s.deps.RuleSets = ruleSets
+	})
+	m.tiers.EXPECT().FindMember(gomock.Eq("member-1")).Return(&domain.Member{ID: "member-1", Tier: domain.TierBronze}, nil).AnyTimes()
+	m.tiers.EXPECT().MultiplierFor(gomock.Eq(domain.TierBronze)).Return(1.0).AnyTimes()
+	m.tiers.EXPECT().ResolveMember(gomock.Eq("member-1")).Return(&domain.Member{ID: "member-1", Tier: domain.TierBronze}, nil).AnyTimes()
+
+	// 100 points, then 20 to reach the cap and 0
+	for i := 0; i < 3; i++ {
+		_, err := svc.StoreReceipt(context.Background(), data)
+		assert.NoError(t, err)
+	}
+	earned, _ := receipts.SumMemberPointsSince("member-1", startOfDay(time.Now()))
+	assert.Equal(t, 120, earned)
+
+	// The same rule set gives the same points, the daily cap included
+	m.ledger.EXPECT().SaveLedgerEntry(gomock.Any()).Times(0)
+	report, err := svc.RescoreReceipts(context.Background(), &domain.RescoreRequest{RuleSetVersion: domain.DefaultRuleSetVersion, Commit: true})
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Len(t, report.Receipts, 3)
+	assert.Equal(t, 0, report.Summary.Delta)
+	for _, diff := range report.Receipts {
+		assert.Equal(t, 0, diff.Delta)
+	}
+	earned, _ = receipts.SumMemberPointsSince("member-1", startOfDay(time.Now()))
+	assert.Equal(t, 120, earned)
+}
+
+func TestReceiptService_StoreReceiptConcurrentCap(t *testing.T) {
+	var receipts = repository.NewReceiptRepository()
+	svc, m := newTestReceiptService(t, func(s *receiptServiceSetup) {
+		s.cfg = domain.PointsConfig{MemberDailyCap: 120}
+		s.deps.Repository = receipts
+	})
+	m.tiers.EXPECT().FindMember(gomock.Eq("member-1")).Return(&domain.Member{ID: "member-1", Tier: domain.TierBronze}, nil).AnyTimes()
+	m.tiers.EXPECT().MultiplierFor(gomock.Any()).Return(1.0).AnyTimes()
+	m.tiers.EXPECT().ResolveMember(gomock.Eq("member-1")).Return(&domain.Member{ID: "member-1", Tier: domain.TierBronze}, nil).AnyTimes()
+
+	// 10 receipts of 37 points stored at the same time can't exceed the cap
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := svc.StoreReceipt(context.Background(), &domain.ReceiptBase{
+				MemberID:     "member-1",
+				Retailer:     "Target",
+				PurchaseDate: "2022-01-01",
+				PurchaseTime: "13:01",
+				Items:        []*domain.ReceiptItemBase{{ShortDescription: "Pepsi", Price: "1.25"}},
+				Total:        "1.25",
+			})
+			assert.NoError(t, err)
+		}()
+	}
+	wg.Wait()
+
+	points, err := receipts.SumMemberPointsSince("member-1", startOfDay(time.Now()))
+	assert.NoError(t, err)
+	assert.Equal(t, 120, points)
+}
+
+func TestReceiptService_ScoreReceipt(t *testing.T) {
+	var data = &domain.ReceiptBase{
+		MemberID:     "member-1",
+		Retailer:     "Target",
+		PurchaseDate: "2022-01-01",
+		PurchaseTime: "13:01",
+		Items: []*domain.ReceiptItemBase{
+			{ShortDescription: "Mountain Dew 12PK", Price: "6.49"},
+			{ShortDescription: "Emils Cheese Pizza", Price: "12.25"},
+		},
+		Total: "35.35",
+	}
+
+	svc, m := newTestReceiptService(t)
+	// Dry runs never enroll members nor store receipts
+	m.tiers.EXPECT().ResolveMember(gomock.Any()).Times(0)
+	m.tiers.EXPECT().FindMember(gomock.Eq("member-1")).Return(&domain.Member{ID: "member-1", Tier: domain.TierBronze}, nil)
+	m.tiers.EXPECT().MultiplierFor(gomock.Any()).Return(1.0)
+	m.repo.EXPECT().SaveReceiptPoints(gomock.Any()).Times(0)
+
+	result, err := svc.ScoreReceipt(context.Background(), data)
+	assert.NoError(t, err)
+	// 6 retailer name + 5 one pair + 3 pizza description + 6 odd day
+	assert.Equal(t, 20, result.Points)
+	assert.Len(t, result.Breakdown.Rules, len(domain.RuleNames))
+	assert.Equal(t, "purchase day 1 is odd", result.Breakdown.Rules[5].Reason)
+	assert.Equal(t, []string{
+		`retailer "Target" is not in the catalog, default rules applied`,
+		"items add up to 18.74 but the total is 35.35",
+	}, result.Warnings)
+}
+
+func TestReceiptService_ScoreReceiptItemLines(t *testing.T) {
+	svc, _ := newTestReceiptService(t)
+
+	var data = &domain.ReceiptBase{
+		Retailer:     "Walgreens",
+		PurchaseDate: "2022-01-02",
+		PurchaseTime: "08:13",
+		Items: []*domain.ReceiptItemBase{
+			{ShortDescription: "Gatorade", Quantity: "3", UnitPrice: "2.25", SKU: "GT-500", Category: "drinks"},
+			{ShortDescription: "Bananas", Quantity: "1.25", UnitPrice: "0.60", Price: "0.80"},
+			{Type: domain.ItemLineDiscount, ShortDescription: "Coupon", Price: "0.50"},
+			{Type: domain.ItemLineTax, ShortDescription: "Tax", Price: "0.45"},
+		},
+		Total: "7.50",
+	}
+
+	t.Run("Lines", func(t *testing.T) {
+		result, err := svc.ScoreReceipt(context.Background(), data)
+		assert.NoError(t, err)
+		assert.Equal(t, []*domain.ReceiptItem{
+			{ShortDescription: "Gatorade", Quantity: 3, UnitPrice: 2.25, Price: 6.75, SKU: "GT-500", Category: "drinks"},
+			{ShortDescription: "Bananas", Quantity: 1.25, UnitPrice: 0.6, Price: 0.8},
+			{Type: domain.ItemLineDiscount, ShortDescription: "Coupon", Price: -0.5},
+			{Type: domain.ItemLineTax, ShortDescription: "Tax", Price: 0.45},
+		}, result.Receipt.Items)
+		// 4 units make 2 pairs
+		assert.Equal(t, "4 items (2 pairs @ 5 points each)", result.Breakdown.Rules[3].Reason)
+		// 9 retailer name + 25 multiple of 0.25 + 10 two pairs
+		assert.Equal(t, 44, result.Points)
+		assert.Equal(t, []string{
+			`retailer "Walgreens" is not in the catalog, default rules applied`,
+			`item "Bananas" price 0.80 is not 1.25 x 0.60`,
+		}, result.Warnings)
+	})
+
+	t.Run("Invalid quantity", func(t *testing.T) {
+		for _, quantity := range []string{"0", "-1", "10000", "three"} {
+			var invalid = *data
+			invalid.Items = []*domain.ReceiptItemBase{{ShortDescription: "Gatorade", Quantity: quantity, UnitPrice: "2.25"}}
+			_, err := svc.ScoreReceipt(context.Background(), &invalid)
+			assert.EqualError(t, err, "error parsing quantity string: "+quantity)
+		}
+	})
+
+	t.Run("Missing price", func(t *testing.T) {
+		var invalid = *data
+		invalid.Items = []*domain.ReceiptItemBase{{ShortDescription: "Gatorade", Quantity: "3"}}
+		_, err := svc.ScoreReceipt(context.Background(), &invalid)
+		assert.Error(t, err)
+	})
+}
+
+func TestReceiptService_ScoreReceiptCurrency(t *testing.T) {
+	currencies := mocks.NewMockICurrencyService(gomock.NewController(t))
+	currencies.EXPECT().BaseCurrency().Return("USD").AnyTimes()
+	currencies.EXPECT().ReceiptRate(gomock.Eq("USD")).Return(1.0, nil).AnyTimes()
+	currencies.EXPECT().ReceiptRate(gomock.Eq("MXN")).Return(0.05, nil).AnyTimes()
+	currencies.EXPECT().ReceiptRate(gomock.Eq("JPY")).Return(0.007, nil).AnyTimes()
+	currencies.EXPECT().ReceiptRate(gomock.Any()).Return(0.0, appErrors.BadRequest).AnyTimes()
+	// The rules are evaluated in the base currency
+	currencies.EXPECT().RulesReceipt(gomock.Any()).DoAndReturn(func(receipt *domain.Receipt) *domain.Receipt {
+		if receipt.Currency == "USD" {
+			return receipt
+		}
+		return receipt.InCurrency("USD", receipt.ExchangeRate)
+	}).AnyTimes()
+	svc, _ := newTestReceiptService(t, func(s *receiptServiceSetup) { s.deps.Currencies = currencies })
+
+	var data = &domain.ReceiptBase{
+		Retailer:     "Oxxo",
+		PurchaseDate: "2022-01-02",
+		PurchaseTime: "08:13",
+		Currency:     "MXN",
+		Items: []*domain.ReceiptItemBase{
+			{ShortDescription: "Coca-Cola 600ml", Quantity: "3", UnitPrice: "25"},
+			{ShortDescription: "Sabritas", Price: "30.00"},
+		},
+		Total: "105.00",
+	}
+
+	t.Run("Converted", func(t *testing.T) {
+		result, err := svc.ScoreReceipt(context.Background(), data)
+		assert.NoError(t, err)
+		assert.Equal(t, "MXN", result.Receipt.Currency)
+		assert.Equal(t, 0.05, result.Receipt.ExchangeRate)
+		assert.Equal(t, float32(105), result.Receipt.Total)
+		// 4 retailer name + 25 multiple of 0.25 of 5.25 USD + 10 two pairs + 1 coca-cola description of 3.75 USD
+		assert.Equal(t, 40, result.Points)
+		assert.Equal(t, []string{
+			`retailer "Oxxo" is not in the catalog, default rules applied`,
+			"total 105.00 MXN evaluated as 5.25 USD",
+		}, result.Warnings)
+	})
+
+	t.Run("Without currency", func(t *testing.T) {
+		var dollars = *data
+		dollars.Currency = ""
+		dollars.Items = []*domain.ReceiptItemBase{{ShortDescription: "Sabritas", Price: "105.00"}}
+		result, err := svc.ScoreReceipt(context.Background(), &dollars)
+		assert.NoError(t, err)
+		assert.Equal(t, "USD", result.Receipt.Currency)
+		assert.Len(t, result.Warnings, 1)
+	})
+
+	t.Run("Minor units", func(t *testing.T) {
+		var yen = *data
+		yen.Currency = "JPY"
+		yen.Total = "105.50"
+		_, err := svc.ScoreReceipt(context.Background(), &yen)
+		assert.EqualError(t, err, "error parsing currency string: 105.50 has more than 0 decimals for JPY")
+	})
+
+	t.Run("Without exchange rate", func(t *testing.T) {
+		var euros = *data
+		euros.Currency = "EUR"
+		_, err := svc.ScoreReceipt(context.Background(), &euros)
+		assert.ErrorIs(t, err, appErrors.BadRequest)
+	})
+
+	t.Run("Invalid currency", func(t *testing.T) {
+		var invalid = *data
+		invalid.Currency = "PESOS"
+		_, err := svc.ScoreReceipt(context.Background(), &invalid)
+		assert.EqualError(t, err, "Field: Currency, Error: iso4217\n")
+	})
+}
+
+func TestReceiptService_ScoreReceiptCurrencyWithoutRates(t *testing.T) {
+	// The rules are evaluated in the receipt currency and no rates file is loaded
+	svc, _ := newTestReceiptService(t)
+	var data = &domain.ReceiptBase{
+		Retailer:     "Tim Hortons",
+		PurchaseDate: "2022-01-02",
+		PurchaseTime: "08:13",
+		Currency:     "CAD",
+		Items:        []*domain.ReceiptItemBase{{ShortDescription: "Double Double", Price: "2.00"}},
+		Total:        "2.00",
+	}
+
+	result, err := svc.ScoreReceipt(context.Background(), data)
+	if assert.NoError(t, err) {
+		assert.Equal(t, "CAD", result.Receipt.Currency)
+		assert.Zero(t, result.Receipt.ExchangeRate)
+		assert.Equal(t, float32(2), result.Receipt.Total)
+	}
+}
+
+func TestReceiptService_ScoreReceiptLocale(t *testing.T) {
+	svc, _ := newTestReceiptService(t)
+
+	var data = &domain.ReceiptBase{
+		Locale:       "de-DE",
+		Retailer:     "M&M Corner Market",
+		PurchaseDate: "20.03.2022",
+		PurchaseTime: "14.33",
+		Items: []*domain.ReceiptItemBase{
+			{ShortDescription: "Gatorade", Quantity: "4", UnitPrice: "2,25"},
+			{ShortDescription: "Bananen", Quantity: "1,5", UnitPrice: "1,00", Price: "1,50"},
+			{Type: domain.ItemLineDiscount, ShortDescription: "Rabatt", Price: "1,50"},
+		},
+		Total: "9,00",
+	}
+
+	t.Run("Declared locale", func(t *testing.T) {
+		result, err := svc.ScoreReceipt(context.Background(), data)
+		assert.NoError(t, err)
+		assert.Equal(t, time.Date(2022, 3, 20, 14, 33, 0, 0, time.UTC), result.Receipt.PurchaseDT)
+		assert.Equal(t, float32(9), result.Receipt.Total)
+		assert.Equal(t, float32(1.5), result.Receipt.Items[1].Quantity)
+		assert.Equal(t, float32(-1.5), result.Receipt.Items[2].Price)
+		// The receipt of the examples: 14 retailer name + 50 round dollar + 25 multiple of 0.25 + 10 two pairs + 10 afternoon
+		assert.Equal(t, 109, result.Points)
+		assert.Equal(t, []string{`retailer "M&M Corner Market" is not in the catalog, default rules applied`}, result.Warnings)
+	})
+
+	t.Run("Ambiguous date", func(t *testing.T) {
+		var auto = *data
+		auto.Locale = "auto"
+		auto.PurchaseDate = "03/04/2022"
+		_, err := svc.ScoreReceipt(context.Background(), &auto)
+		assert.ErrorIs(t, err, appErrors.BadRequest)
+		assert.ErrorContains(t, err, "date 03/04/2022 can be 2022-04-03 or 2022-03-04")
+	})
+
+	t.Run("Ambiguous amount", func(t *testing.T) {
+		var auto = *data
+		auto.Locale = "auto"
+		auto.Total = "1.125"
+		_, err := svc.ScoreReceipt(context.Background(), &auto)
+		assert.ErrorIs(t, err, appErrors.BadRequest)
+	})
+
+	t.Run("Wrong format", func(t *testing.T) {
+		var dotted = *data
+		dotted.Total = "9.00"
+		_, err := svc.ScoreReceipt(context.Background(), &dotted)
+		assert.EqualError(t, err, "error parsing currency string: 9.00")
+	})
+
+	t.Run("Unknown locale", func(t *testing.T) {
+		var unknown = *data
+		unknown.Locale = "tlh"
+		_, err := svc.ScoreReceipt(context.Background(), &unknown)
+		assert.ErrorIs(t, err, appErrors.BadRequest)
+	})
+}
+
+func TestReceiptService_RescoreReceipts(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+
+	// v2 doubles the odd day points
+	var v2 = domain.DefaultRuleSet()
+	v2.Version = "v2"
+	v2.Rules[domain.RuleOddDay] = domain.RuleParams{Enabled: true, Points: 12}
+
+	var receipt = &domain.Receipt{
+		MemberID:   "member-1",
+		Retailer:   "Target",
+		PurchaseDT: time.Date(2022, 1, 1, 13, 1, 0, 0, time.UTC),
+		Total:      35.35,
+	}
+	// 6 retailer name + 6 odd day, doubled by the gold tier
+	var stored = []*domain.Result{
+		{ID: "r1", MemberID: "member-1", Points: 24, Receipt: receipt, Breakdown: &domain.Breakdown{Tier: domain.TierGold, TierMultiplier: 2}},
+		{ID: "r2", Points: 10},
+	}
+
+	ruleSets := mocks.NewMockIRuleSetService(mockCtrl)
+	ruleSets.EXPECT().RetrieveRuleSet(gomock.Eq("v2")).Return(v2, nil).AnyTimes()
+	ruleSets.EXPECT().RetrieveRuleSet(gomock.Any()).Return(nil, appErrors.NotFound).AnyTimes()
+	retailers := mocks.NewMockIRetailerService(mockCtrl)
+	retailers.EXPECT().RuleSetFor(gomock.Eq(v2), gomock.Nil()).Return(v2, nil).AnyTimes()
+	svc, m := newTestReceiptService(t, func(s *receiptServiceSetup) {
+		s.deps.RuleSets = ruleSets
+		s.deps.Retailers = retailers
+	})
+	m.repo.EXPECT().ListReceipts(gomock.Any()).Return(stored, nil).AnyTimes()
+
+	t.Run("Dry run", func(t *testing.T) {
+		m.repo.EXPECT().UpdateReceiptPoints(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+		report, err := svc.RescoreReceipts(context.Background(), &domain.RescoreRequest{RuleSetVersion: "v2"})
+		assert.NoError(t, err)
+		assert.False(t, report.Committed)
+		// Receipts stored without content are skipped
+		assert.Len(t, report.Receipts, 1)
+		assert.Equal(t, &domain.RescoreDiff{ReceiptID: "r1", OldPoints: 24, NewPoints: 36, Delta: 12}, report.Receipts[0])
+	})
+
+	t.Run("Commit", func(t *testing.T) {
+		m.repo.EXPECT().UpdateReceiptPoints(gomock.Eq("r1"), gomock.Eq(36), gomock.Any()).Times(1).DoAndReturn(func(_ string, _ int, breakdown *domain.Breakdown) error {
+			assert.Equal(t, "v2", breakdown.RuleSetVersion)
+			return nil
+		})
+		m.ledger.EXPECT().SaveLedgerEntry(gomock.Any()).Times(1).DoAndReturn(func(entry *domain.LedgerEntry) (string, error) {
+			assert.Equal(t, 12, entry.Points)
+			assert.Equal(t, "member-1", entry.MemberID)
+			assert.Equal(t, "v2", entry.RuleSetVersion)
+			return uuid.New().String(), nil
+		})
+		report, err := svc.RescoreReceipts(context.Background(), &domain.RescoreRequest{RuleSetVersion: "v2", Commit: true})
+		assert.NoError(t, err)
+		assert.True(t, report.Committed)
+		assert.Equal(t, 12, report.Summary.Delta)
+	})
+
+	t.Run("Unknown version", func(t *testing.T) {
+		_, err := svc.RescoreReceipts(context.Background(), &domain.RescoreRequest{RuleSetVersion: "v9"})
+		assert.ErrorIs(t, err, appErrors.NotFound)
+	})
+
+	t.Run("Missing version", func(t *testing.T) {
+		_, err := svc.RescoreReceipts(context.Background(), &domain.RescoreRequest{})
+		assert.ErrorIs(t, err, appErrors.BadRequest)
+	})
+}
+
+func TestReceiptService_StoreReceiptExperiment(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+
+	// Every receipt is in the treatment arm, which doesn't award points for the retailer name
+	var experiment = &domain.Experiment{ID: "exp-1", ControlVersion: domain.DefaultRuleSetVersion, TreatmentVersion: "v2", TreatmentPercent: 100}
+	var v2 = domain.DefaultRuleSet()
+	v2.Version = "v2"
+	delete(v2.Rules, domain.RuleRetailerName)
+
+	experiments := mocks.NewMockIExperimentService(mockCtrl)
+	experiments.EXPECT().ActiveExperiment(gomock.Any()).Return(experiment, nil)
+	ruleSets := mocks.NewMockIRuleSetService(mockCtrl)
+	ruleSets.EXPECT().ActiveRuleSet().Times(0)
+	ruleSets.EXPECT().RetrieveRuleSet(gomock.Eq("v2")).Return(v2, nil)
+	retailers := mocks.NewMockIRetailerService(mockCtrl)
+	retailers.EXPECT().ResolveRetailer(gomock.Any()).Return(nil, nil)
+	retailers.EXPECT().RuleSetFor(gomock.Eq(v2), gomock.Nil()).Return(v2, nil)
+	svc, m := newTestReceiptService(t, func(s *receiptServiceSetup) {
+		s.deps.Experiments = experiments
+		s.deps.RuleSets = ruleSets
+		s.deps.Retailers = retailers
+	})
+	m.repo.EXPECT().SaveReceiptPoints(gomock.Any()).Times(1).DoAndReturn(func(result *domain.Result) (string, error) {
+		assert.Equal(t, domain.ExperimentArmTreatment, result.Experiment.Arm)
+		assert.Equal(t, "v2", result.Breakdown.RuleSetVersion)
+		// 25 multiple of 0.25 + 6 odd day
+		assert.Equal(t, 31, result.Points)
+		return uuid.New().String(), nil
+	})
+
+	_, err := svc.StoreReceipt(context.Background(), &domain.ReceiptBase{
+		Retailer:     "Target",
+		PurchaseDate: "2022-01-01",
+		PurchaseTime: "13:01",
+		Items:        []*domain.ReceiptItemBase{{ShortDescription: "Pepsi", Price: "1.25"}},
+		Total:        "1.25",
+	})
+	assert.NoError(t, err)
+}
+
+func TestReceiptService_StoreReceiptMetrics(t *testing.T) {
+	recorder := mocks.NewMockIMetricsRecorder(gomock.NewController(t))
+	svc, m := newTestReceiptService(t, func(s *receiptServiceSetup) { s.deps.Metrics = recorder })
+
+	var receipt = &domain.ReceiptBase{
+		Retailer:     "Target",
+		PurchaseDate: "2022-01-01",
+		PurchaseTime: "13:01",
+		Items:        []*domain.ReceiptItemBase{{ShortDescription: "Pepsi", Price: "1.25"}},
+		Total:        "1.25",
+	}
+
+	t.Run("Processed", func(t *testing.T) {
+		m.repo.EXPECT().SaveReceiptPoints(gomock.Any()).Times(1).Return("id-1", nil)
+		recorder.EXPECT().ReceiptProcessed(37).Times(1)
+		recorder.EXPECT().RuleFired("retailerName").Times(1)
+		recorder.EXPECT().RuleFired("multipleOf25Cents").Times(1)
+		recorder.EXPECT().RuleFired("oddDay").Times(1)
+
+		_, err := svc.StoreReceipt(context.Background(), receipt)
+		assert.NoError(t, err)
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		recorder.EXPECT().ReceiptRejected(domain.RejectionInvalid).Times(1)
+
+		_, err := svc.StoreReceipt(context.Background(), &domain.ReceiptBase{})
+		assert.Error(t, err)
+	})
+
+	t.Run("Storage", func(t *testing.T) {
+		m.repo.EXPECT().SaveReceiptPoints(gomock.Any()).Times(1).Return("", errors.New("unavailable"))
+		recorder.EXPECT().ReceiptRejected(domain.RejectionStorage).Times(1)
+
+		_, err := svc.StoreReceipt(context.Background(), receipt)
+		assert.Error(t, err)
+	})
+}
+
+func TestReceiptService_StoreReceiptTracing(t *testing.T) {
+	var exporter = tracetest.NewInMemoryExporter()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter)))
+
+	svc, m := newTestReceiptService(t)
+
+	var receipt = &domain.ReceiptBase{
+		Retailer:     "Target",
+		PurchaseDate: "2022-01-01",
+		PurchaseTime: "13:01",
+		Items:        []*domain.ReceiptItemBase{{ShortDescription: "Pepsi", Price: "1.25"}},
+		Total:        "1.25",
+	}
+
+	t.Run("Stored", func(t *testing.T) {
+		exporter.Reset()
+		m.repo.EXPECT().SaveReceiptPoints(gomock.Any()).Times(1).Return("id-1", nil)
+		ctx, parent := otel.Tracer("test").Start(context.Background(), "request")
+		_, err := svc.StoreReceipt(ctx, receipt)
+		parent.End()
+		assert.NoError(t, err)
+
+		var names = make([]string, 0)
+		var rules = 0
+		for _, span := range exporter.GetSpans() {
+			assert.Equal(t, parent.SpanContext().TraceID(), span.SpanContext.TraceID())
+			if span.Name == "request" {
+				continue
+			}
+			assert.Equal(t, parent.SpanContext().SpanID(), span.Parent.SpanID(), span.Name)
+			if strings.HasPrefix(span.Name, "rule ") {
+				rules++
+				continue
+			}
+			names = append(names, span.Name)
+		}
+		assert.Equal(t, []string{"validate", "parse", "repository receipts.save"}, names)
+		assert.Equal(t, len(domain.RuleNames), rules)
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		exporter.Reset()
+		_, err := svc.StoreReceipt(context.Background(), &domain.ReceiptBase{})
+		assert.Error(t, err)
+
+		var spans = exporter.GetSpans()
+		if assert.Len(t, spans, 1) {
+			assert.Equal(t, "validate", spans[0].Name)
+			assert.Equal(t, codes.Error, spans[0].Status.Code)
+		}
+	})
+
+	t.Run("Storage", func(t *testing.T) {
+		exporter.Reset()
+		m.repo.EXPECT().SaveReceiptPoints(gomock.Any()).Times(1).Return("", errors.New("unavailable"))
+		_, err := svc.StoreReceipt(context.Background(), receipt)
+		assert.Error(t, err)
+
+		var spans = exporter.GetSpans()
+		var save = spans[len(spans)-1]
+		assert.Equal(t, "repository receipts.save", save.Name)
+		assert.Equal(t, codes.Error, save.Status.Code)
+		assert.Equal(t, "unavailable", save.Status.Description)
+	})
+}
+
+func TestReceiptService_StoreReceiptLogs(t *testing.T) {
+	core, logs := observer.New(zap.DebugLevel)
+	svc, m := newTestReceiptService(t, func(s *receiptServiceSetup) { s.logger = zap.New(core).Sugar() })
+	m.repo.EXPECT().SaveReceiptPoints(gomock.Any()).Times(1).Return("id-1", nil)
+	m.tiers.EXPECT().FindMember("member-1").Return(&domain.Member{ID: "member-1", Tier: domain.TierBronze}, nil)
+	m.tiers.EXPECT().MultiplierFor(domain.TierBronze).Return(1.0)
+	m.tiers.EXPECT().ResolveMember("member-1").Return(&domain.Member{ID: "member-1", Tier: domain.TierBronze}, nil)
+
+	var ctx = logging.With(context.Background(), "requestId", "req-1")
+	_, err := svc.StoreReceipt(ctx, &domain.ReceiptBase{
+		MemberID:     "member-1",
+		Retailer:     "Target",
+		PurchaseDate: "2022-01-01",
+		PurchaseTime: "13:01",
+		Items:        []*domain.ReceiptItemBase{{ShortDescription: "Pepsi", Price: "1.25"}},
+		Total:        "1.25",
+	})
+	assert.NoError(t, err)
+
+	var rules = logs.FilterMessage("rule evaluated").AllUntimed()
+	if assert.Len(t, rules, len(domain.RuleNames)) {
+		assert.Equal(t, zapcore.DebugLevel, rules[0].Level)
+		assert.Equal(t, map[string]interface{}{
+			"requestId": "req-1",
+			"memberId":  "member-1",
+			"rule":      domain.RuleRetailerName,
+			"points":    int64(6),
+			"reason":    "retailer name (Target) has 6 alphanumeric characters",
+		}, rules[0].ContextMap())
+	}
+
+	var stored = logs.FilterMessage("receipt stored").AllUntimed()
+	if assert.Len(t, stored, 1) {
+		assert.Equal(t, map[string]interface{}{
+			"requestId":      "req-1",
+			"memberId":       "member-1",
+			"receiptId":      "id-1",
+			"points":         int64(37),
+			"ruleSetVersion": domain.DefaultRuleSet().Version,
+		}, stored[0].ContextMap())
+	}
+}
+
+func TestReceiptService_ScoreReceiptPrincipal(t *testing.T) {
+	svc, m := newTestReceiptService(t)
+
+	var ctx = auth.WithPrincipal(context.Background(), domain.NewTokenPrincipal("user-1", "member-1", []string{domain.RoleMember}))
+	var receipt = func(memberID string) *domain.ReceiptBase {
+		return &domain.ReceiptBase{
+			MemberID:     memberID,
+			Retailer:     "Target",
+			PurchaseDate: "2022-01-01",
+			PurchaseTime: "13:01",
+			Items:        []*domain.ReceiptItemBase{{ShortDescription: "Pepsi", Price: "1.25"}},
+			Total:        "1.25",
+		}
+	}
+
+	t.Run("Attributed to the member", func(t *testing.T) {
+		m.tiers.EXPECT().FindMember(gomock.Eq("member-1")).Return(&domain.Member{ID: "member-1", Tier: domain.TierBronze}, nil)
+		m.tiers.EXPECT().MultiplierFor(gomock.Any()).Return(1.0)
+		result, err := svc.ScoreReceipt(ctx, receipt(""))
+		assert.NoError(t, err)
+		assert.Equal(t, "member-1", result.Receipt.MemberID)
+	})
+
+	t.Run("Quota exceeded", func(t *testing.T) {
+		quotas := mocks.NewMockIQuotaService(gomock.NewController(t))
+		quotas.EXPECT().ConsumeReceiptQuota(gomock.Any()).Times(1).Return(nil, fmt.Errorf("%w: 10 receipts per day", appErrors.QuotaExceeded))
+		svc, m := newTestReceiptService(t, func(s *receiptServiceSetup) { s.deps.Quotas = quotas })
+		m.tiers.EXPECT().FindMember(gomock.Eq("member-1")).Return(&domain.Member{ID: "member-1", Tier: domain.TierBronze}, nil)
+		m.tiers.EXPECT().MultiplierFor(gomock.Any()).Return(1.0)
+		m.tiers.EXPECT().ResolveMember(gomock.Any()).Times(0)
+		m.repo.EXPECT().SaveReceiptPoints(gomock.Any()).Times(0)
+
+		_, err := svc.StoreReceipt(ctx, receipt(""))
+		assert.ErrorIs(t, err, appErrors.QuotaExceeded)
+	})
+
+	t.Run("Quota refunded", func(t *testing.T) {
+		var refunded = 0
+		quotas := mocks.NewMockIQuotaService(gomock.NewController(t))
+		quotas.EXPECT().ConsumeReceiptQuota(gomock.Any()).Times(1).Return(func() { refunded++ }, nil)
+		svc, m := newTestReceiptService(t, func(s *receiptServiceSetup) { s.deps.Quotas = quotas })
+		m.tiers.EXPECT().FindMember(gomock.Eq("member-1")).Return(&domain.Member{ID: "member-1", Tier: domain.TierBronze}, nil)
+		m.tiers.EXPECT().MultiplierFor(gomock.Any()).Return(1.0)
+		m.repo.EXPECT().SaveReceiptPoints(gomock.Any()).Times(1).Return("", errors.New("unavailable"))
+
+		// The receipts that are not stored don't use the quota
+		_, err := svc.StoreReceipt(ctx, receipt(""))
+		assert.EqualError(t, err, "unavailable")
+		assert.Equal(t, 1, refunded)
+	})
+
+	t.Run("Other member", func(t *testing.T) {
+		_, err := svc.ScoreReceipt(ctx, receipt("member-2"))
+		assert.ErrorIs(t, err, appErrors.Forbidden)
+		_, err = svc.StoreReceipt(ctx, receipt("member-2"))
+		assert.ErrorIs(t, err, appErrors.Forbidden)
+	})
+}
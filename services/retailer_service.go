@@ -0,0 +1,169 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/kiramishima/receipt-processor/domain"
+	appErrors "github.com/kiramishima/receipt-processor/pkg/errors"
+	"github.com/kiramishima/receipt-processor/pkg/normalize"
+	ports "github.com/kiramishima/receipt-processor/ports/repository"
+	"go.uber.org/zap"
+	"os"
+)
+
+type RetailerService struct {
+	logger     *zap.SugaredLogger
+	cfg        domain.CatalogConfig
+	normalizer *normalize.Normalizer
+	repository ports.IRetailerRepository
+}
+
+func NewRetailerService(cfg domain.CatalogConfig, repository ports.IRetailerRepository, logger *zap.SugaredLogger) *RetailerService {
+	return &RetailerService{
+		logger:     logger,
+		cfg:        cfg,
+		normalizer: normalize.NewNormalizer(cfg.RetailerAbbreviations),
+		repository: repository,
+	}
+}
+
+// ImportCatalog loads the retailers and categories of a JSON catalog file
+func (svc *RetailerService) ImportCatalog(path string) error {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	var catalog domain.RetailerCatalog
+	if err := json.Unmarshal(content, &catalog); err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	for _, category := range catalog.Categories {
+		if _, err := svc.SaveCategory(ctx, category); err != nil {
+			return err
+		}
+	}
+	for _, retailer := range catalog.Retailers {
+		if _, err := svc.CreateRetailer(ctx, retailer); err != nil {
+			return err
+		}
+	}
+	svc.logger.Infof("Retailer catalog loaded: %d retailers, %d categories", len(catalog.Retailers), len(catalog.Categories))
+	return nil
+}
+
+// CreateRetailer validates and stores a new retailer
+func (svc *RetailerService) CreateRetailer(ctx context.Context, retailer *domain.Retailer) (*domain.Retailer, error) {
+	if err := validate.StructCtx(ctx, *retailer); err != nil {
+		svc.logger.Error(err)
+		return nil, validationError(err)
+	}
+
+	id, err := svc.repository.SaveRetailer(retailer)
+	if err != nil {
+		return nil, err
+	}
+	return svc.repository.FindRetailerById(id)
+}
+
+// RetrieveRetailer recover a retailer by id
+func (svc *RetailerService) RetrieveRetailer(id string) (*domain.Retailer, error) {
+	return svc.repository.FindRetailerById(id)
+}
+
+// ListRetailers returns the retailer catalog
+func (svc *RetailerService) ListRetailers() ([]*domain.Retailer, error) {
+	return svc.repository.ListRetailers()
+}
+
+// UpdateRetailer validates and replaces a retailer of the catalog
+func (svc *RetailerService) UpdateRetailer(ctx context.Context, retailer *domain.Retailer) (*domain.Retailer, error) {
+	if err := validate.StructCtx(ctx, *retailer); err != nil {
+		svc.logger.Error(err)
+		return nil, validationError(err)
+	}
+
+	if err := svc.repository.UpdateRetailer(retailer); err != nil {
+		return nil, err
+	}
+	return svc.repository.FindRetailerById(retailer.ID)
+}
+
+// DeleteRetailer removes a retailer from the catalog
+func (svc *RetailerService) DeleteRetailer(id string) error {
+	return svc.repository.DeleteRetailer(id)
+}
+
+// SaveCategory creates or replaces the rule overrides of a category
+func (svc *RetailerService) SaveCategory(ctx context.Context, category *domain.RetailerCategory) (*domain.RetailerCategory, error) {
+	if err := validate.StructCtx(ctx, *category); err != nil {
+		svc.logger.Error(err)
+		return nil, validationError(err)
+	}
+
+	if err := svc.repository.SaveCategory(category); err != nil {
+		return nil, err
+	}
+	return svc.repository.FindCategoryByName(category.Name)
+}
+
+// ListCategories returns the categories with rule overrides
+func (svc *RetailerService) ListCategories() ([]*domain.RetailerCategory, error) {
+	return svc.repository.ListCategories()
+}
+
+// ResolveRetailer returns the catalog entry of the free-text retailer name, nil if the retailer is unknown.
+// The normalized name is compared with the normalized names and aliases of the catalog, and when there is no exact
+// match the most similar retailer above the match threshold is returned.
+func (svc *RetailerService) ResolveRetailer(name string) (*domain.Retailer, error) {
+	retailers, err := svc.repository.ListRetailers()
+	if err != nil {
+		return nil, err
+	}
+
+	var normalized = svc.normalizer.Normalize(name)
+	if normalized == "" {
+		return nil, nil
+	}
+
+	var best *domain.Retailer
+	var bestScore = svc.cfg.RetailerMatchThreshold
+	for _, retailer := range retailers {
+		for _, candidate := range retailer.Names() {
+			var score = normalize.Similarity(normalized, svc.normalizer.Normalize(candidate))
+			if score == 1 {
+				return retailer, nil
+			}
+			if score >= bestScore {
+				best, bestScore = retailer, score
+			}
+		}
+	}
+
+	if best != nil {
+		svc.logger.Infow("retailer fuzzy matched", "retailer", name, "retailerId", best.ID, "score", bestScore)
+	}
+	return best, nil
+}
+
+// RuleSetFor returns the base rule set with the overrides of the retailer category and then the retailer applied
+func (svc *RetailerService) RuleSetFor(base *domain.RuleSet, retailer *domain.Retailer) (*domain.RuleSet, error) {
+	var rs = base
+	if retailer == nil {
+		return rs, nil
+	}
+
+	if retailer.Category != "" {
+		category, err := svc.repository.FindCategoryByName(retailer.Category)
+		if err != nil && !errors.Is(err, appErrors.NotFound) {
+			return nil, err
+		}
+		if category != nil {
+			rs = rs.WithOverrides(category.RuleOverrides)
+		}
+	}
+	return rs.WithOverrides(retailer.RuleOverrides), nil
+}
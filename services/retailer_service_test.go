@@ -0,0 +1,139 @@
+package services
+
+import (
+	"errors"
+	"github.com/kiramishima/receipt-processor/domain"
+	"github.com/kiramishima/receipt-processor/mocks"
+	appErrors "github.com/kiramishima/receipt-processor/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"go.uber.org/zap"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+var catalogConfig = domain.CatalogConfig{RetailerMatchThreshold: 0.85}
+
+func TestRetailerService_ResolveRetailer(t *testing.T) {
+	logger, _ := zap.NewProduction()
+	slogger := logger.Sugar()
+	mockCtrl := gomock.NewController(t)
+
+	defer mockCtrl.Finish()
+	repo := mocks.NewMockIRetailerRepository(mockCtrl)
+	repo.EXPECT().ListRetailers().Return([]*domain.Retailer{
+		{ID: "target", Name: "Target", Aliases: []string{"Target Store"}},
+		{ID: "walgreens", Name: "Walgreens"},
+		{ID: "mm-corner-market", Name: "M&M Corner Market"},
+	}, nil).AnyTimes()
+	svc := NewRetailerService(catalogConfig, repo, slogger)
+
+	t.Run("Canonical name", func(t *testing.T) {
+		retailer, err := svc.ResolveRetailer("walgreens ")
+		assert.NoError(t, err)
+		assert.Equal(t, "walgreens", retailer.ID)
+	})
+
+	t.Run("Alias", func(t *testing.T) {
+		retailer, err := svc.ResolveRetailer("TARGET STORE")
+		assert.NoError(t, err)
+		assert.Equal(t, "target", retailer.ID)
+	})
+
+	t.Run("Normalized name", func(t *testing.T) {
+		for _, name := range []string{"M & M CORNER MKT", "m&m corner market ", "M&M Córner Market"} {
+			retailer, err := svc.ResolveRetailer(name)
+			assert.NoError(t, err)
+			assert.Equal(t, "mm-corner-market", retailer.ID, name)
+		}
+	})
+
+	t.Run("Fuzzy match", func(t *testing.T) {
+		retailer, err := svc.ResolveRetailer("Walgreen")
+		assert.NoError(t, err)
+		assert.Equal(t, "walgreens", retailer.ID)
+	})
+
+	t.Run("Unknown", func(t *testing.T) {
+		retailer, err := svc.ResolveRetailer("Kroger")
+		assert.NoError(t, err)
+		assert.Nil(t, retailer)
+	})
+}
+
+func TestRetailerService_RuleSetFor(t *testing.T) {
+	logger, _ := zap.NewProduction()
+	slogger := logger.Sugar()
+	mockCtrl := gomock.NewController(t)
+
+	defer mockCtrl.Finish()
+	repo := mocks.NewMockIRetailerRepository(mockCtrl)
+	var disabled = false
+	var categoryPoints = 20
+	var retailerPoints = 30
+	repo.EXPECT().FindCategoryByName(gomock.Eq("grocery")).Return(&domain.RetailerCategory{
+		Name: "grocery",
+		RuleOverrides: map[string]*domain.RuleOverride{
+			domain.RuleRetailerName: {Enabled: &disabled},
+			domain.RuleOddDay:       {Points: &categoryPoints},
+		},
+	}, nil).AnyTimes()
+	repo.EXPECT().FindCategoryByName(gomock.Any()).Return(nil, appErrors.NotFound).AnyTimes()
+	svc := NewRetailerService(catalogConfig, repo, slogger)
+
+	t.Run("Unknown retailer", func(t *testing.T) {
+		rs, err := svc.RuleSetFor(domain.DefaultRuleSet(), nil)
+		assert.NoError(t, err)
+		assert.Equal(t, domain.DefaultRuleSet(), rs)
+	})
+
+	t.Run("Retailer overrides category", func(t *testing.T) {
+		rs, err := svc.RuleSetFor(domain.DefaultRuleSet(), &domain.Retailer{
+			Name:          "M&M Corner Market",
+			Category:      "grocery",
+			RuleOverrides: map[string]*domain.RuleOverride{domain.RuleOddDay: {Points: &retailerPoints}},
+		})
+		assert.NoError(t, err)
+		assert.False(t, rs.Rules[domain.RuleRetailerName].Enabled)
+		assert.Equal(t, 30, rs.Rules[domain.RuleOddDay].Points)
+	})
+
+	t.Run("Category without overrides", func(t *testing.T) {
+		rs, err := svc.RuleSetFor(domain.DefaultRuleSet(), &domain.Retailer{Name: "Target", Category: "general"})
+		assert.NoError(t, err)
+		assert.Equal(t, domain.DefaultRuleSet(), rs)
+	})
+
+	t.Run("Repository error", func(t *testing.T) {
+		repo := mocks.NewMockIRetailerRepository(mockCtrl)
+		repo.EXPECT().FindCategoryByName(gomock.Any()).Return(nil, errors.New("unavailable"))
+		_, err := NewRetailerService(catalogConfig, repo, slogger).RuleSetFor(domain.DefaultRuleSet(), &domain.Retailer{Name: "Target", Category: "general"})
+		assert.Error(t, err)
+	})
+}
+
+func TestRetailerService_ImportCatalog(t *testing.T) {
+	logger, _ := zap.NewProduction()
+	slogger := logger.Sugar()
+	mockCtrl := gomock.NewController(t)
+
+	defer mockCtrl.Finish()
+	repo := mocks.NewMockIRetailerRepository(mockCtrl)
+
+	var path = filepath.Join(t.TempDir(), "catalog.json")
+	var content = `{
+		"retailers": [{"id": "target", "name": "Target", "timeZone": "America/Chicago", "category": "general"}],
+		"categories": [{"name": "general", "ruleOverrides": {"retailerName": {"enabled": false}}}]
+	}`
+	assert.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+
+	repo.EXPECT().SaveCategory(gomock.Any()).Times(1).Return(nil)
+	repo.EXPECT().FindCategoryByName(gomock.Eq("general")).Times(1).Return(&domain.RetailerCategory{Name: "general"}, nil)
+	repo.EXPECT().SaveRetailer(gomock.Any()).Times(1).Return("target", nil)
+	repo.EXPECT().FindRetailerById(gomock.Eq("target")).Times(1).Return(&domain.Retailer{ID: "target", Name: "Target"}, nil)
+	svc := NewRetailerService(catalogConfig, repo, slogger)
+
+	assert.NoError(t, svc.ImportCatalog(path))
+	assert.Error(t, svc.ImportCatalog(filepath.Join(t.TempDir(), "missing.json")))
+}
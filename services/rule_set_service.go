@@ -0,0 +1,91 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"github.com/kiramishima/receipt-processor/domain"
+	appErrors "github.com/kiramishima/receipt-processor/pkg/errors"
+	"github.com/kiramishima/receipt-processor/pkg/expr"
+	ports "github.com/kiramishima/receipt-processor/ports/repository"
+	"github.com/samber/lo"
+	"go.uber.org/zap"
+	"sync"
+)
+
+type RuleSetService struct {
+	logger        *zap.SugaredLogger
+	cfg           domain.RulesConfig
+	repository    ports.IRuleSetRepository
+	mu            sync.RWMutex
+	activeVersion string
+}
+
+func NewRuleSetService(cfg domain.RulesConfig, repository ports.IRuleSetRepository, logger *zap.SugaredLogger) *RuleSetService {
+	return &RuleSetService{
+		logger:        logger,
+		cfg:           cfg,
+		repository:    repository,
+		activeVersion: cfg.ActiveRuleSetVersion,
+	}
+}
+
+// CreateRuleSet validates, compiles the custom rules and stores a new rule set version. The base rules not listed don't
+// award points.
+func (svc *RuleSetService) CreateRuleSet(ctx context.Context, rs *domain.RuleSet) (*domain.RuleSet, error) {
+	if err := validate.StructCtx(ctx, *rs); err != nil {
+		svc.logger.Error(err)
+		return nil, validationError(err)
+	}
+	for name := range rs.Rules {
+		if !lo.Contains(domain.RuleNames, name) {
+			return nil, fmt.Errorf("%w: unknown rule: %s", appErrors.BadRequest, name)
+		}
+	}
+
+	if err := rs.Compile(expr.WithMaxSteps(svc.cfg.ExpressionMaxSteps), expr.WithTimeout(svc.cfg.ExpressionTimeout)); err != nil {
+		return nil, fmt.Errorf("%w: %s", appErrors.BadRequest, err)
+	}
+
+	if err := svc.repository.SaveRuleSet(rs); err != nil {
+		return nil, err
+	}
+	return svc.repository.FindRuleSetByVersion(rs.Version)
+}
+
+// RetrieveRuleSet recover a rule set by version
+func (svc *RuleSetService) RetrieveRuleSet(version string) (*domain.RuleSet, error) {
+	return svc.repository.FindRuleSetByVersion(version)
+}
+
+// ListRuleSets returns all the rule set versions
+func (svc *RuleSetService) ListRuleSets() ([]*domain.RuleSet, error) {
+	return svc.repository.ListRuleSets()
+}
+
+// ActiveRuleSet returns the rule set used to score the new receipts
+func (svc *RuleSetService) ActiveRuleSet() (*domain.RuleSet, error) {
+	svc.mu.RLock()
+	defer svc.mu.RUnlock()
+	return svc.repository.FindRuleSetByVersion(svc.activeVersion)
+}
+
+// CheckActiveRuleSet fails when the active rule set can't be loaded, the receipts can't be scored without it
+func (svc *RuleSetService) CheckActiveRuleSet(ctx context.Context) error {
+	if _, err := svc.ActiveRuleSet(); err != nil {
+		return fmt.Errorf("active rule set: %w", err)
+	}
+	return nil
+}
+
+// ActivateRuleSet makes the version the one used to score the new receipts
+func (svc *RuleSetService) ActivateRuleSet(version string) error {
+	if _, err := svc.repository.FindRuleSetByVersion(version); err != nil {
+		return err
+	}
+
+	svc.mu.Lock()
+	defer svc.mu.Unlock()
+	svc.logger.Infof("Rule set %s activated, previous: %s", version, svc.activeVersion)
+	svc.activeVersion = version
+	return nil
+}
@@ -0,0 +1,68 @@
+package services
+
+import (
+	"context"
+	"github.com/kiramishima/receipt-processor/domain"
+	"github.com/kiramishima/receipt-processor/mocks"
+	appErrors "github.com/kiramishima/receipt-processor/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"go.uber.org/zap"
+	"testing"
+)
+
+func TestRuleSetService(t *testing.T) {
+	logger, _ := zap.NewProduction()
+	slogger := logger.Sugar()
+	mockCtrl := gomock.NewController(t)
+
+	defer mockCtrl.Finish()
+	repo := mocks.NewMockIRuleSetRepository(mockCtrl)
+	var v2 = &domain.RuleSet{Version: "v2", Rules: map[string]domain.RuleParams{domain.RuleOddDay: {Enabled: true, Points: 12}}}
+	repo.EXPECT().FindRuleSetByVersion(gomock.Eq(domain.DefaultRuleSetVersion)).Return(domain.DefaultRuleSet(), nil).AnyTimes()
+	repo.EXPECT().FindRuleSetByVersion(gomock.Eq("v2")).Return(v2, nil).AnyTimes()
+	repo.EXPECT().FindRuleSetByVersion(gomock.Any()).Return(nil, appErrors.NotFound).AnyTimes()
+	svc := NewRuleSetService(domain.RulesConfig{ActiveRuleSetVersion: domain.DefaultRuleSetVersion}, repo, slogger)
+
+	t.Run("Create", func(t *testing.T) {
+		repo.EXPECT().SaveRuleSet(gomock.Eq(v2)).Times(1).Return(nil)
+		rs, err := svc.CreateRuleSet(context.Background(), v2)
+		assert.NoError(t, err)
+		assert.Equal(t, v2, rs)
+	})
+
+	t.Run("Unknown rule", func(t *testing.T) {
+		_, err := svc.CreateRuleSet(context.Background(), &domain.RuleSet{Version: "v3", Rules: map[string]domain.RuleParams{"weekend": {}}})
+		assert.ErrorIs(t, err, appErrors.BadRequest)
+	})
+
+	t.Run("Invalid custom rule", func(t *testing.T) {
+		_, err := svc.CreateRuleSet(context.Background(), &domain.RuleSet{Version: "v3", Rules: v2.Rules, CustomRules: []domain.CustomRule{
+			{Name: "weekend", Expression: `purchase.weekday == 0 ? "5" : "0"`},
+		}})
+		assert.ErrorIs(t, err, appErrors.BadRequest)
+		assert.ErrorContains(t, err, "custom rule weekend: expression must evaluate to int points, got string")
+	})
+
+	t.Run("Missing version", func(t *testing.T) {
+		_, err := svc.CreateRuleSet(context.Background(), &domain.RuleSet{})
+		assert.ErrorIs(t, err, appErrors.BadRequest)
+	})
+
+	t.Run("Activate", func(t *testing.T) {
+		rs, err := svc.ActiveRuleSet()
+		assert.NoError(t, err)
+		assert.Equal(t, domain.DefaultRuleSetVersion, rs.Version)
+
+		assert.NoError(t, svc.ActivateRuleSet("v2"))
+		rs, err = svc.ActiveRuleSet()
+		assert.NoError(t, err)
+		assert.Equal(t, "v2", rs.Version)
+	})
+
+	t.Run("Activate unknown version", func(t *testing.T) {
+		assert.ErrorIs(t, svc.ActivateRuleSet("v9"), appErrors.NotFound)
+		rs, _ := svc.ActiveRuleSet()
+		assert.Equal(t, "v2", rs.Version)
+	})
+}
@@ -1,13 +1,115 @@
 package services
 
 import (
+	"fmt"
 	repository "github.com/kiramishima/receipt-processor/adapter/db/in-memory"
+	events "github.com/kiramishima/receipt-processor/adapter/events/in-memory"
+	"github.com/kiramishima/receipt-processor/domain"
+	metricsPorts "github.com/kiramishima/receipt-processor/ports/metrics"
+	repositoryPorts "github.com/kiramishima/receipt-processor/ports/repository"
 	"go.uber.org/fx"
 	"go.uber.org/zap"
 )
 
 var Module = fx.Module("services",
-	fx.Provide(func(logger *zap.SugaredLogger, receiptRepository *repository.ReceiptRepository) *ReceiptService {
-		return NewReceiptService(receiptRepository, logger)
+	fx.Provide(func(cfg *domain.Configuration, logger *zap.SugaredLogger, memberRepository *repository.MemberRepository, receiptRepository repositoryPorts.IReceiptRepository, bus *events.EventBus) *TierService {
+		return NewTierService(cfg.TierConfig, memberRepository, receiptRepository, bus, logger)
 	}),
+	fx.Provide(func(logger *zap.SugaredLogger, campaignRepository *repository.CampaignRepository) *CampaignService {
+		return NewCampaignService(campaignRepository, logger)
+	}),
+	fx.Provide(func(cfg *domain.Configuration, logger *zap.SugaredLogger, retailerRepository *repository.RetailerRepository) (*RetailerService, error) {
+		svc := NewRetailerService(cfg.CatalogConfig, retailerRepository, logger)
+		if cfg.RetailerCatalogFile != "" {
+			if err := svc.ImportCatalog(cfg.RetailerCatalogFile); err != nil {
+				return nil, err
+			}
+		}
+		return svc, nil
+	}),
+	fx.Provide(func(cfg *domain.Configuration, logger *zap.SugaredLogger, ruleSetRepository *repository.RuleSetRepository) (*RuleSetService, error) {
+		svc := NewRuleSetService(cfg.RulesConfig, ruleSetRepository, logger)
+		if _, err := svc.ActiveRuleSet(); err != nil {
+			return nil, err
+		}
+		return svc, nil
+	}),
+	fx.Provide(func(logger *zap.SugaredLogger, experimentRepository *repository.ExperimentRepository, receiptRepository repositoryPorts.IReceiptRepository, ruleSetService *RuleSetService) *ExperimentService {
+		return NewExperimentService(experimentRepository, receiptRepository, ruleSetService, logger)
+	}),
+	fx.Provide(func(cfg *domain.Configuration, logger *zap.SugaredLogger) (*CurrencyService, error) {
+		if cfg.RulesCurrency != domain.RulesCurrencyReceipt && cfg.RulesCurrency != domain.RulesCurrencyBase {
+			return nil, fmt.Errorf("invalid rules currency: %s", cfg.RulesCurrency)
+		}
+		svc := NewCurrencyService(cfg.CurrencyConfig, logger)
+		if cfg.ExchangeRatesFile != "" {
+			if err := svc.LoadExchangeRates(cfg.ExchangeRatesFile); err != nil {
+				return nil, err
+			}
+		}
+		return svc, nil
+	}),
+	fx.Provide(func(cfg *domain.Configuration, logger *zap.SugaredLogger, retailerService *RetailerService) (*ParserService, error) {
+		svc := NewParserService(retailerService, logger)
+		if cfg.ParserTemplatesFile != "" {
+			if err := svc.LoadTemplates(cfg.ParserTemplatesFile); err != nil {
+				return nil, err
+			}
+		}
+		return svc, nil
+	}),
+	fx.Provide(func(cfg *domain.Configuration, logger *zap.SugaredLogger, quotaRepository *repository.QuotaRepository) *QuotaService {
+		return NewQuotaService(cfg.RateLimitConfig, quotaRepository, logger)
+	}),
+	fx.Provide(func(cfg *domain.Configuration, logger *zap.SugaredLogger, receiptRepository repositoryPorts.IReceiptRepository, ledgerRepository repositoryPorts.ILedgerRepository, tierService *TierService, campaignService *CampaignService, retailerService *RetailerService, ruleSetService *RuleSetService, experimentService *ExperimentService, currencyService *CurrencyService, recorder metricsPorts.IMetricsRecorder, quotaService *QuotaService) *ReceiptService {
+		return NewReceiptService(cfg.PointsConfig, ReceiptServiceDeps{
+			Repository:  receiptRepository,
+			Ledger:      ledgerRepository,
+			Tiers:       tierService,
+			Campaigns:   campaignService,
+			Retailers:   retailerService,
+			RuleSets:    ruleSetService,
+			Experiments: experimentService,
+			Currencies:  currencyService,
+			Metrics:     recorder,
+			Quotas:      quotaService,
+		}, logger)
+	}),
+	fx.Provide(func(cfg *domain.Configuration, logger *zap.SugaredLogger, parserService *ParserService, retailerService *RetailerService, receiptService *ReceiptService, emailMessageRepository *repository.EmailMessageRepository) *EmailService {
+		return NewEmailService(cfg.EmailConfig, parserService, retailerService, receiptService, emailMessageRepository, logger)
+	}),
+	fx.Provide(func(cfg *domain.Configuration, logger *zap.SugaredLogger, receiptService *ReceiptService) *ImportService {
+		return NewImportService(cfg.ImportConfig, receiptService, logger)
+	}),
+	fx.Provide(func(logger *zap.SugaredLogger, receiptRepository repositoryPorts.IReceiptRepository) *ExportService {
+		return NewExportService(receiptRepository, logger)
+	}),
+	fx.Provide(func(cfg *domain.Configuration, logger *zap.SugaredLogger, apiKeyRepository *repository.APIKeyRepository) (*APIKeyService, error) {
+		svc := NewAPIKeyService(cfg.AuthConfig, apiKeyRepository, logger)
+		if cfg.AuthBootstrapKey != "" {
+			if err := svc.RegisterBootstrapKey(cfg.AuthBootstrapKey); err != nil {
+				return nil, err
+			}
+		} else if cfg.AuthEnabled && cfg.JWTJWKSPath == "" {
+			return nil, fmt.Errorf("auth is enabled without AUTH_BOOTSTRAP_KEY nor JWT_JWKS_PATH, no request could be authenticated")
+		} else if cfg.AuthEnabled {
+			logger.Warn("Auth is enabled without a bootstrap API key, no API key can be issued")
+		}
+		return svc, nil
+	}),
+	fx.Provide(func(cfg *domain.Configuration, logger *zap.SugaredLogger) (*TokenService, error) {
+		svc := NewTokenService(cfg.AuthConfig, logger)
+		if cfg.JWTJWKSPath != "" {
+			if err := svc.LoadJWKS(cfg.JWTJWKSPath); err != nil {
+				return nil, err
+			}
+		}
+		return svc, nil
+	}),
+	fx.Provide(fx.Annotate(func(ruleSetService *RuleSetService) domain.HealthCheck {
+		return domain.HealthCheck{Name: "rule-set", Kind: domain.HealthReadiness, Check: ruleSetService.CheckActiveRuleSet}
+	}, fx.ResultTags(`group:"health"`))),
+	fx.Provide(fx.Annotate(func(cfg *domain.Configuration, logger *zap.SugaredLogger, checks []domain.HealthCheck) *HealthService {
+		return NewHealthService(cfg.HealthConfig, checks, logger)
+	}, fx.ParamTags(``, ``, `group:"health"`))),
 )
@@ -0,0 +1,102 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"github.com/kiramishima/receipt-processor/domain"
+	appErrors "github.com/kiramishima/receipt-processor/pkg/errors"
+	events "github.com/kiramishima/receipt-processor/ports/events"
+	ports "github.com/kiramishima/receipt-processor/ports/repository"
+	"go.uber.org/zap"
+	"time"
+)
+
+type TierService struct {
+	logger    *zap.SugaredLogger
+	cfg       domain.TierConfig
+	members   ports.IMemberRepository
+	receipts  ports.IReceiptRepository
+	publisher events.IEventPublisher
+}
+
+func NewTierService(cfg domain.TierConfig, members ports.IMemberRepository, receipts ports.IReceiptRepository, publisher events.IEventPublisher, logger *zap.SugaredLogger) *TierService {
+	return &TierService{
+		logger:    logger,
+		cfg:       cfg,
+		members:   members,
+		receipts:  receipts,
+		publisher: publisher,
+	}
+}
+
+// ResolveMember returns the member, enrolling it in the lowest tier the first time it is seen
+func (svc *TierService) ResolveMember(memberID string) (*domain.Member, error) {
+	member, err := svc.members.FindMemberById(memberID)
+	if !errors.Is(err, appErrors.NotFound) {
+		return member, err
+	}
+
+	member = &domain.Member{ID: memberID, Tier: domain.TierBronze, UpdatedAt: time.Now().UTC()}
+	if err := svc.members.SaveMember(member); err != nil {
+		return nil, err
+	}
+	return member, nil
+}
+
+// FindMember returns the member, or a member of the lowest tier that is not stored when it has never been seen
+func (svc *TierService) FindMember(memberID string) (*domain.Member, error) {
+	member, err := svc.members.FindMemberById(memberID)
+	if errors.Is(err, appErrors.NotFound) {
+		return &domain.Member{ID: memberID, Tier: domain.TierBronze}, nil
+	}
+	return member, err
+}
+
+// MultiplierFor returns the configured earning multiplier of the tier
+func (svc *TierService) MultiplierFor(tier domain.Tier) float64 {
+	return svc.cfg.MultiplierFor(tier)
+}
+
+// RecomputeTiers moves every member to the tier matching its rolling points and emits the tier changes
+func (svc *TierService) RecomputeTiers(ctx context.Context) ([]*domain.TierChangeEvent, error) {
+	members, err := svc.members.ListMembers()
+	if err != nil {
+		return nil, err
+	}
+
+	var now = time.Now().UTC()
+	var since = now.Add(-svc.cfg.Window)
+	var changes = make([]*domain.TierChangeEvent, 0)
+	for _, member := range members {
+		select {
+		case <-ctx.Done():
+			return changes, ctx.Err()
+		default:
+		}
+
+		points, err := svc.receipts.SumMemberPointsSince(member.ID, since)
+		if err != nil {
+			return changes, err
+		}
+
+		var tier = svc.cfg.TierFor(points)
+		var previous = member.Tier
+		member.RollingPoints = points
+		member.Tier = tier
+		member.UpdatedAt = now
+		if err := svc.members.SaveMember(member); err != nil {
+			return changes, err
+		}
+
+		if tier == previous {
+			continue
+		}
+		event := &domain.TierChangeEvent{MemberID: member.ID, From: previous, To: tier, OccurredAt: now}
+		if err := svc.publisher.Publish(ctx, event); err != nil {
+			svc.logger.Error(err)
+		}
+		changes = append(changes, event)
+	}
+	svc.logger.Infow("tiers recomputed", "members", len(members), "changes", len(changes))
+	return changes, nil
+}
@@ -0,0 +1,119 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/kiramishima/receipt-processor/domain"
+	"github.com/kiramishima/receipt-processor/mocks"
+	appErrors "github.com/kiramishima/receipt-processor/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"go.uber.org/zap"
+	"testing"
+	"time"
+)
+
+var tierConfig = domain.TierConfig{
+	SilverThreshold:  1000,
+	GoldThreshold:    5000,
+	BronzeMultiplier: 1,
+	SilverMultiplier: 1.25,
+	GoldMultiplier:   1.5,
+	Window:           365 * 24 * time.Hour,
+}
+
+func TestTierService_ResolveMember(t *testing.T) {
+	logger, _ := zap.NewProduction()
+	slogger := logger.Sugar()
+	mockCtrl := gomock.NewController(t)
+
+	defer mockCtrl.Finish()
+	members := mocks.NewMockIMemberRepository(mockCtrl)
+	receipts := mocks.NewMockIReceiptRepository(mockCtrl)
+	publisher := mocks.NewMockIEventPublisher(mockCtrl)
+	svc := NewTierService(tierConfig, members, receipts, publisher, slogger)
+
+	t.Run("Existing member", func(t *testing.T) {
+		members.EXPECT().FindMemberById(gomock.Eq("m1")).Times(1).Return(&domain.Member{ID: "m1", Tier: domain.TierSilver}, nil)
+		member, err := svc.ResolveMember("m1")
+		assert.NoError(t, err)
+		assert.Equal(t, domain.TierSilver, member.Tier)
+	})
+
+	t.Run("New member", func(t *testing.T) {
+		members.EXPECT().FindMemberById(gomock.Eq("m2")).Times(1).Return(nil, fmt.Errorf("member with id: m2 %w", appErrors.NotFound))
+		members.EXPECT().SaveMember(gomock.Any()).Times(1).Return(nil)
+		member, err := svc.ResolveMember("m2")
+		assert.NoError(t, err)
+		assert.Equal(t, domain.TierBronze, member.Tier)
+	})
+
+	t.Run("Repository error", func(t *testing.T) {
+		// A stored member is never enrolled again when it can't be read
+		members.EXPECT().FindMemberById(gomock.Eq("m1")).Times(1).Return(nil, errors.New("unavailable"))
+		members.EXPECT().SaveMember(gomock.Any()).Times(0)
+		_, err := svc.ResolveMember("m1")
+		assert.EqualError(t, err, "unavailable")
+	})
+}
+
+func TestTierService_FindMember(t *testing.T) {
+	logger, _ := zap.NewProduction()
+	slogger := logger.Sugar()
+	mockCtrl := gomock.NewController(t)
+
+	defer mockCtrl.Finish()
+	members := mocks.NewMockIMemberRepository(mockCtrl)
+	members.EXPECT().SaveMember(gomock.Any()).Times(0)
+	svc := NewTierService(tierConfig, members, mocks.NewMockIReceiptRepository(mockCtrl), mocks.NewMockIEventPublisher(mockCtrl), slogger)
+
+	t.Run("Existing member", func(t *testing.T) {
+		members.EXPECT().FindMemberById(gomock.Eq("m1")).Times(1).Return(&domain.Member{ID: "m1", Tier: domain.TierGold}, nil)
+		member, err := svc.FindMember("m1")
+		assert.NoError(t, err)
+		assert.Equal(t, domain.TierGold, member.Tier)
+	})
+
+	t.Run("New member", func(t *testing.T) {
+		members.EXPECT().FindMemberById(gomock.Eq("m2")).Times(1).Return(nil, fmt.Errorf("member with id: m2 %w", appErrors.NotFound))
+		member, err := svc.FindMember("m2")
+		assert.NoError(t, err)
+		assert.Equal(t, &domain.Member{ID: "m2", Tier: domain.TierBronze}, member)
+	})
+
+	t.Run("Repository error", func(t *testing.T) {
+		members.EXPECT().FindMemberById(gomock.Eq("m1")).Times(1).Return(nil, errors.New("unavailable"))
+		_, err := svc.FindMember("m1")
+		assert.EqualError(t, err, "unavailable")
+	})
+}
+
+func TestTierService_RecomputeTiers(t *testing.T) {
+	logger, _ := zap.NewProduction()
+	slogger := logger.Sugar()
+	mockCtrl := gomock.NewController(t)
+
+	defer mockCtrl.Finish()
+	members := mocks.NewMockIMemberRepository(mockCtrl)
+	receipts := mocks.NewMockIReceiptRepository(mockCtrl)
+	publisher := mocks.NewMockIEventPublisher(mockCtrl)
+
+	members.EXPECT().ListMembers().Times(1).Return([]*domain.Member{
+		{ID: "m1", Tier: domain.TierBronze},
+		{ID: "m2", Tier: domain.TierGold},
+		{ID: "m3", Tier: domain.TierSilver},
+	}, nil)
+	receipts.EXPECT().SumMemberPointsSince(gomock.Eq("m1"), gomock.Any()).Return(1200, nil)
+	receipts.EXPECT().SumMemberPointsSince(gomock.Eq("m2"), gomock.Any()).Return(300, nil)
+	receipts.EXPECT().SumMemberPointsSince(gomock.Eq("m3"), gomock.Any()).Return(1500, nil)
+	members.EXPECT().SaveMember(gomock.Any()).Times(3).Return(nil)
+	publisher.EXPECT().Publish(gomock.Any(), gomock.Any()).Times(2).Return(nil)
+	svc := NewTierService(tierConfig, members, receipts, publisher, slogger)
+
+	changes, err := svc.RecomputeTiers(context.Background())
+	assert.NoError(t, err)
+	assert.Len(t, changes, 2)
+	assert.Equal(t, &domain.TierChangeEvent{MemberID: "m1", From: domain.TierBronze, To: domain.TierSilver, OccurredAt: changes[0].OccurredAt}, changes[0])
+	assert.Equal(t, &domain.TierChangeEvent{MemberID: "m2", From: domain.TierGold, To: domain.TierBronze, OccurredAt: changes[1].OccurredAt}, changes[1])
+}
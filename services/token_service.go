@@ -0,0 +1,98 @@
+package services
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/kiramishima/receipt-processor/domain"
+	appErrors "github.com/kiramishima/receipt-processor/pkg/errors"
+	"github.com/kiramishima/receipt-processor/pkg/jwt"
+	"github.com/samber/lo"
+	"go.uber.org/zap"
+)
+
+type TokenService struct {
+	logger    *zap.SugaredLogger
+	cfg       domain.AuthConfig
+	keys      *jwt.KeySet
+	validator jwt.Validator
+}
+
+func NewTokenService(cfg domain.AuthConfig, logger *zap.SugaredLogger) *TokenService {
+	return &TokenService{
+		logger: logger,
+		cfg:    cfg,
+		keys:   &jwt.KeySet{},
+		validator: jwt.Validator{
+			Issuer:   cfg.JWTIssuer,
+			Audience: cfg.JWTAudience,
+			Leeway:   cfg.JWTClockSkew,
+		},
+	}
+}
+
+// LoadJWKS loads the keys of the JWKS file, or of every .json file of the directory
+func (svc *TokenService) LoadJWKS(path string) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+	var files = []string{path}
+	if info.IsDir() {
+		if files, err = filepath.Glob(filepath.Join(path, "*.json")); err != nil {
+			return err
+		}
+		sort.Strings(files)
+	}
+
+	var keys = &jwt.KeySet{}
+	for _, file := range files {
+		content, err := os.ReadFile(file)
+		if err != nil {
+			return err
+		}
+		set, err := jwt.ParseJWKS(content)
+		if err != nil {
+			return fmt.Errorf("%s: %w", file, err)
+		}
+		keys.Add(set)
+	}
+	if keys.Len() == 0 {
+		return fmt.Errorf("no JWT verification keys in %s", path)
+	}
+
+	svc.keys = keys
+	svc.logger.Infof("JWKS loaded: %d keys from %d files", keys.Len(), len(files))
+	return nil
+}
+
+// Authenticate verifies the signature and claims of the JWT and returns its principal, with the member and roles of
+// its claims
+func (svc *TokenService) Authenticate(token string) (*domain.Principal, error) {
+	if svc.keys.Len() == 0 {
+		return nil, fmt.Errorf("%w: JWT authentication is not configured", appErrors.InvalidJWTToken)
+	}
+	claims, err := svc.keys.Verify(token)
+	if err != nil {
+		return nil, err
+	}
+	if err := svc.validator.Validate(claims, time.Now()); err != nil {
+		return nil, err
+	}
+
+	var roles = claims.Strings(svc.cfg.JWTRolesClaim)
+	if len(roles) == 0 && svc.cfg.JWTDefaultRole != "" {
+		roles = []string{svc.cfg.JWTDefaultRole}
+	}
+	var principal = domain.NewTokenPrincipal(claims.String("sub"), claims.String(svc.cfg.JWTMemberClaim), roles)
+	if principal.Subject == "" {
+		return nil, fmt.Errorf("%w: missing sub", appErrors.InvalidJWTClaims)
+	}
+	if principal.MemberID == "" && lo.Contains(roles, domain.RoleMember) {
+		return nil, fmt.Errorf("%w: missing %s", appErrors.InvalidJWTClaims, svc.cfg.JWTMemberClaim)
+	}
+	return principal, nil
+}
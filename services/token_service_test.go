@@ -0,0 +1,111 @@
+package services
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"github.com/kiramishima/receipt-processor/domain"
+	appErrors "github.com/kiramishima/receipt-processor/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// signHS256 serializes a token signed with the secret
+func signHS256(secret []byte, claims map[string]any) string {
+	var b64 = base64.RawURLEncoding.EncodeToString
+	headerJSON, _ := json.Marshal(map[string]string{"alg": "HS256", "kid": "app"})
+	claimsJSON, _ := json.Marshal(claims)
+	var signed = b64(headerJSON) + "." + b64(claimsJSON)
+	var mac = hmac.New(sha256.New, secret)
+	mac.Write([]byte(signed))
+	return signed + "." + b64(mac.Sum(nil))
+}
+
+func TestTokenService(t *testing.T) {
+	logger, _ := zap.NewProduction()
+	slogger := logger.Sugar()
+	var cfg = domain.AuthConfig{
+		JWTIssuer:      "https://id.example.com",
+		JWTAudience:    "receipt-processor",
+		JWTClockSkew:   time.Minute,
+		JWTMemberClaim: "member_id",
+		JWTRolesClaim:  "roles",
+		JWTDefaultRole: domain.RoleMember,
+	}
+	var secret = []byte("0123456789abcdef0123456789abcdef")
+	var dir = t.TempDir()
+	var jwks = fmt.Sprintf(`{"keys": [{"kty": "oct", "kid": "app", "k": %q}]}`, base64.RawURLEncoding.EncodeToString(secret))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "app.json"), []byte(jwks), 0o600))
+
+	var claims = func(extra map[string]any) map[string]any {
+		var c = map[string]any{
+			"iss":       "https://id.example.com",
+			"aud":       "receipt-processor",
+			"sub":       "user-1",
+			"member_id": "member-1",
+			"exp":       time.Now().Add(time.Hour).Unix(),
+		}
+		for k, v := range extra {
+			c[k] = v
+		}
+		return c
+	}
+
+	t.Run("Not configured", func(t *testing.T) {
+		svc := NewTokenService(cfg, slogger)
+		_, err := svc.Authenticate(signHS256(secret, claims(nil)))
+		assert.ErrorIs(t, err, appErrors.InvalidJWTToken)
+	})
+
+	svc := NewTokenService(cfg, slogger)
+	assert.NoError(t, svc.LoadJWKS(dir))
+
+	t.Run("Member", func(t *testing.T) {
+		principal, err := svc.Authenticate(signHS256(secret, claims(nil)))
+		assert.NoError(t, err)
+		assert.Equal(t, "user-1", principal.Subject)
+		assert.Equal(t, "member-1", principal.MemberID)
+		assert.Equal(t, []string{domain.RoleMember}, principal.Roles)
+		assert.True(t, principal.HasScope(domain.ScopeReceiptsWrite))
+		assert.False(t, principal.HasScope(domain.ScopeAdmin))
+	})
+
+	t.Run("Admin", func(t *testing.T) {
+		principal, err := svc.Authenticate(signHS256(secret, claims(map[string]any{"roles": []string{"admin"}, "member_id": nil})))
+		assert.NoError(t, err)
+		assert.Empty(t, principal.MemberID)
+		assert.True(t, principal.HasScope(domain.ScopeAdmin))
+	})
+
+	t.Run("Member without member id", func(t *testing.T) {
+		_, err := svc.Authenticate(signHS256(secret, claims(map[string]any{"member_id": nil})))
+		assert.ErrorIs(t, err, appErrors.InvalidJWTClaims)
+	})
+
+	t.Run("Expired", func(t *testing.T) {
+		_, err := svc.Authenticate(signHS256(secret, claims(map[string]any{"exp": time.Now().Add(-time.Hour).Unix()})))
+		assert.ErrorIs(t, err, appErrors.InvalidJWTClaims)
+	})
+
+	t.Run("Other audience", func(t *testing.T) {
+		_, err := svc.Authenticate(signHS256(secret, claims(map[string]any{"aud": "other"})))
+		assert.ErrorIs(t, err, appErrors.InvalidJWTClaims)
+	})
+
+	t.Run("Other secret", func(t *testing.T) {
+		_, err := svc.Authenticate(signHS256([]byte("fedcba9876543210fedcba9876543210"), claims(nil)))
+		assert.ErrorIs(t, err, appErrors.InvalidJWTToken)
+	})
+
+	t.Run("Invalid JWKS", func(t *testing.T) {
+		var file = filepath.Join(t.TempDir(), "jwks.json")
+		assert.NoError(t, os.WriteFile(file, []byte(`{"keys": []}`), 0o600))
+		assert.Error(t, NewTokenService(cfg, slogger).LoadJWKS(file))
+	})
+}
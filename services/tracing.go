@@ -0,0 +1,36 @@
+package services
+
+import (
+	"context"
+
+	"github.com/kiramishima/receipt-processor/domain"
+	"github.com/kiramishima/receipt-processor/pkg/tracing"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// tracer of the services, it sends the spans to the global tracer provider
+var tracer = otel.Tracer("github.com/kiramishima/receipt-processor/services")
+
+// traceRepository starts the span of a repository call, the returned function ends it with the error of the call
+func traceRepository(ctx context.Context, repository string, operation string) func(err error) {
+	_, span := tracer.Start(ctx, "repository "+repository+"."+operation,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attribute.String("repository", repository), attribute.String("operation", operation)),
+	)
+	return func(err error) {
+		tracing.End(span, err)
+	}
+}
+
+// traceRules returns the rule observer recording the evaluation of every rule as a span with its points and reason
+func traceRules(ctx context.Context) domain.RuleObserver {
+	return func(rule string) func(points *domain.RulePoints) {
+		_, span := tracer.Start(ctx, "rule "+rule, trace.WithAttributes(attribute.String("rule", rule)))
+		return func(points *domain.RulePoints) {
+			span.SetAttributes(attribute.Int("points", points.Points), attribute.String("reason", points.Reason))
+			span.End()
+		}
+	}
+}
@@ -0,0 +1,17 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"github.com/go-playground/validator/v10"
+	appErrors "github.com/kiramishima/receipt-processor/pkg/errors"
+)
+
+// validationError wraps the first validation error as a bad request
+func validationError(err error) error {
+	var validationErrors validator.ValidationErrors
+	if errors.As(err, &validationErrors) {
+		return fmt.Errorf("%w: Field: %s, Error: %s", appErrors.BadRequest, validationErrors[0].Field(), validationErrors[0].Tag())
+	}
+	return err
+}